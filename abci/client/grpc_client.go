@@ -214,6 +214,16 @@ func (cli *grpcClient) CheckTxAsync(ctx context.Context, params types.RequestChe
 	return cli.finishAsyncCall(ctx, req, &types.Response{Value: &types.Response_CheckTx{CheckTx: res}})
 }
 
+// NOTE: call is synchronous, use ctx to break early if needed
+func (cli *grpcClient) CheckTxBatchAsync(ctx context.Context, params types.RequestCheckTxBatch) (*ReqRes, error) {
+	req := types.ToRequestCheckTxBatch(params)
+	res, err := cli.client.CheckTxBatch(ctx, req.GetCheckTxBatch(), grpc.WaitForReady(true))
+	if err != nil {
+		return nil, err
+	}
+	return cli.finishAsyncCall(ctx, req, &types.Response{Value: &types.Response_CheckTxBatch{CheckTxBatch: res}})
+}
+
 // NOTE: call is synchronous, use ctx to break early if needed
 func (cli *grpcClient) QueryAsync(ctx context.Context, params types.RequestQuery) (*ReqRes, error) {
 	req := types.ToRequestQuery(params)
@@ -404,6 +414,18 @@ func (cli *grpcClient) CheckTxSync(
 	return cli.finishSyncCall(reqres).GetCheckTx(), cli.Error()
 }
 
+func (cli *grpcClient) CheckTxBatchSync(
+	ctx context.Context,
+	params types.RequestCheckTxBatch,
+) (*types.ResponseCheckTxBatch, error) {
+
+	reqres, err := cli.CheckTxBatchAsync(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return cli.finishSyncCall(reqres).GetCheckTxBatch(), cli.Error()
+}
+
 func (cli *grpcClient) QuerySync(
 	ctx context.Context,
 	req types.RequestQuery,
@@ -99,6 +99,17 @@ func (app *localClient) CheckTxAsync(ctx context.Context, req types.RequestCheck
 	), nil
 }
 
+func (app *localClient) CheckTxBatchAsync(ctx context.Context, req types.RequestCheckTxBatch) (*ReqRes, error) {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+
+	res := types.CheckTxBatch(app.Application, req)
+	return app.callback(
+		types.ToRequestCheckTxBatch(req),
+		types.ToResponseCheckTxBatch(res),
+	), nil
+}
+
 func (app *localClient) QueryAsync(ctx context.Context, req types.RequestQuery) (*ReqRes, error) {
 	app.mtx.RLock()
 	defer app.mtx.RUnlock()
@@ -245,6 +256,17 @@ func (app *localClient) CheckTxSync(
 	return &res, nil
 }
 
+func (app *localClient) CheckTxBatchSync(
+	ctx context.Context,
+	req types.RequestCheckTxBatch,
+) (*types.ResponseCheckTxBatch, error) {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+
+	res := types.CheckTxBatch(app.Application, req)
+	return &res, nil
+}
+
 func (app *localClient) QuerySync(
 	ctx context.Context,
 	req types.RequestQuery,
@@ -349,7 +371,9 @@ func (app *localClient) ApplySnapshotChunkSync(
 //-------------------------------------------------------
 
 func (app *localClient) callback(req *types.Request, res *types.Response) *ReqRes {
-	app.Callback(req, res)
+	if app.Callback != nil {
+		app.Callback(req, res)
+	}
 	return newLocalReqRes(req, res)
 }
 
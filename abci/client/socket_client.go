@@ -253,6 +253,10 @@ func (cli *socketClient) CheckTxAsync(ctx context.Context, req types.RequestChec
 	return cli.queueRequestAsync(ctx, types.ToRequestCheckTx(req))
 }
 
+func (cli *socketClient) CheckTxBatchAsync(ctx context.Context, req types.RequestCheckTxBatch) (*ReqRes, error) {
+	return cli.queueRequestAsync(ctx, types.ToRequestCheckTxBatch(req))
+}
+
 func (cli *socketClient) QueryAsync(ctx context.Context, req types.RequestQuery) (*ReqRes, error) {
 	return cli.queueRequestAsync(ctx, types.ToRequestQuery(req))
 }
@@ -364,6 +368,17 @@ func (cli *socketClient) CheckTxSync(
 	return reqres.Response.GetCheckTx(), nil
 }
 
+func (cli *socketClient) CheckTxBatchSync(
+	ctx context.Context,
+	req types.RequestCheckTxBatch,
+) (*types.ResponseCheckTxBatch, error) {
+	reqres, err := cli.queueRequestAndFlushSync(ctx, types.ToRequestCheckTxBatch(req))
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetCheckTxBatch(), nil
+}
+
 func (cli *socketClient) QuerySync(
 	ctx context.Context,
 	req types.RequestQuery,
@@ -573,6 +588,8 @@ func resMatchesReq(req *types.Request, res *types.Response) (ok bool) {
 		_, ok = res.Value.(*types.Response_DeliverTx)
 	case *types.Request_CheckTx:
 		_, ok = res.Value.(*types.Response_CheckTx)
+	case *types.Request_CheckTxBatch:
+		_, ok = res.Value.(*types.Response_CheckTxBatch)
 	case *types.Request_Commit:
 		_, ok = res.Value.(*types.Response_Commit)
 	case *types.Request_Query:
@@ -206,6 +206,9 @@ func (s *SocketServer) handleRequest(req *types.Request, responses chan<- *types
 	case *types.Request_CheckTx:
 		res := s.app.CheckTx(*r.CheckTx)
 		responses <- types.ToResponseCheckTx(res)
+	case *types.Request_CheckTxBatch:
+		res := types.CheckTxBatch(s.app, *r.CheckTxBatch)
+		responses <- types.ToResponseCheckTxBatch(res)
 	case *types.Request_Commit:
 		res := s.app.Commit()
 		responses <- types.ToResponseCommit(res)
@@ -92,6 +92,28 @@ func (BaseApplication) ApplySnapshotChunk(req RequestApplySnapshotChunk) Respons
 
 //-------------------------------------------------------
 
+// CheckTxBatcher is implemented by the applications validating a batch of txs
+// for the mempool at once, e.g. to verify their signatures in parallel.
+type CheckTxBatcher interface {
+	CheckTxBatch(RequestCheckTxBatch) ResponseCheckTxBatch // Validate a batch of txs for the mempool
+}
+
+// CheckTxBatch validates a batch of txs with the application: with its own
+// CheckTxBatch if it implements CheckTxBatcher, or else with CheckTx for each
+// tx of the batch.
+func CheckTxBatch(app Application, req RequestCheckTxBatch) ResponseCheckTxBatch {
+	if batcher, ok := app.(CheckTxBatcher); ok {
+		return batcher.CheckTxBatch(req)
+	}
+	responses := make([]ResponseCheckTx, len(req.Requests))
+	for i := range req.Requests {
+		responses[i] = app.CheckTx(req.Requests[i])
+	}
+	return ResponseCheckTxBatch{Responses: responses}
+}
+
+//-------------------------------------------------------
+
 // GRPCApplication is a GRPC wrapper for Application
 type GRPCApplication struct {
 	app Application
@@ -124,6 +146,12 @@ func (app *GRPCApplication) CheckTx(ctx context.Context, req *RequestCheckTx) (*
 	return &res, nil
 }
 
+func (app *GRPCApplication) CheckTxBatch(
+	ctx context.Context, req *RequestCheckTxBatch) (*ResponseCheckTxBatch, error) {
+	res := CheckTxBatch(app.app, *req)
+	return &res, nil
+}
+
 func (app *GRPCApplication) Query(ctx context.Context, req *RequestQuery) (*ResponseQuery, error) {
 	res := app.app.Query(*req)
 	return &res, nil
@@ -110,6 +110,12 @@ func ToRequestApplySnapshotChunk(req RequestApplySnapshotChunk) *Request {
 	}
 }
 
+func ToRequestCheckTxBatch(req RequestCheckTxBatch) *Request {
+	return &Request{
+		Value: &Request_CheckTxBatch{&req},
+	}
+}
+
 //----------------------------------------
 
 func ToResponseException(errStr string) *Response {
@@ -200,3 +206,9 @@ func ToResponseApplySnapshotChunk(res ResponseApplySnapshotChunk) *Response {
 		Value: &Response_ApplySnapshotChunk{&res},
 	}
 }
+
+func ToResponseCheckTxBatch(res ResponseCheckTxBatch) *Response {
+	return &Response{
+		Value: &Response_CheckTxBatch{&res},
+	}
+}
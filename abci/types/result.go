@@ -59,7 +59,14 @@ func (r *ResponseCheckTx) MarshalJSON() ([]byte, error) {
 
 func (r *ResponseCheckTx) UnmarshalJSON(b []byte) error {
 	reader := bytes.NewBuffer(b)
-	return jsonpbUnmarshaller.Unmarshal(reader, r)
+	if err := jsonpbUnmarshaller.Unmarshal(reader, r); err != nil {
+		return err
+	}
+	// the keys are emitted as an empty list when nil
+	if len(r.RecheckKeys) == 0 {
+		r.RecheckKeys = nil
+	}
+	return nil
 }
 
 func (r *ResponseDeliverTx) MarshalJSON() ([]byte, error) {
@@ -69,7 +76,14 @@ func (r *ResponseDeliverTx) MarshalJSON() ([]byte, error) {
 
 func (r *ResponseDeliverTx) UnmarshalJSON(b []byte) error {
 	reader := bytes.NewBuffer(b)
-	return jsonpbUnmarshaller.Unmarshal(reader, r)
+	if err := jsonpbUnmarshaller.Unmarshal(reader, r); err != nil {
+		return err
+	}
+	// the keys are emitted as an empty list when nil
+	if len(r.UpdatedKeys) == 0 {
+		r.UpdatedKeys = nil
+	}
+	return nil
 }
 
 func (r *ResponseQuery) MarshalJSON() ([]byte, error) {
@@ -120,7 +120,7 @@ func (x ResponseOfferSnapshot_Result) String() string {
 }
 
 func (ResponseOfferSnapshot_Result) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{28, 0}
+	return fileDescriptor_252557cfdd89a31a, []int{29, 0}
 }
 
 type ResponseApplySnapshotChunk_Result int32
@@ -157,7 +157,7 @@ func (x ResponseApplySnapshotChunk_Result) String() string {
 }
 
 func (ResponseApplySnapshotChunk_Result) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{30, 0}
+	return fileDescriptor_252557cfdd89a31a, []int{31, 0}
 }
 
 type Request struct {
@@ -176,6 +176,7 @@ type Request struct {
 	//	*Request_OfferSnapshot
 	//	*Request_LoadSnapshotChunk
 	//	*Request_ApplySnapshotChunk
+	//	*Request_CheckTxBatch
 	Value isRequest_Value `protobuf_oneof:"value"`
 }
 
@@ -260,6 +261,9 @@ type Request_LoadSnapshotChunk struct {
 type Request_ApplySnapshotChunk struct {
 	ApplySnapshotChunk *RequestApplySnapshotChunk `protobuf:"bytes,14,opt,name=apply_snapshot_chunk,json=applySnapshotChunk,proto3,oneof" json:"apply_snapshot_chunk,omitempty"`
 }
+type Request_CheckTxBatch struct {
+	CheckTxBatch *RequestCheckTxBatch `protobuf:"bytes,15,opt,name=check_tx_batch,json=checkTxBatch,proto3,oneof" json:"check_tx_batch,omitempty"`
+}
 
 func (*Request_Echo) isRequest_Value()               {}
 func (*Request_Flush) isRequest_Value()              {}
@@ -275,6 +279,7 @@ func (*Request_ListSnapshots) isRequest_Value()      {}
 func (*Request_OfferSnapshot) isRequest_Value()      {}
 func (*Request_LoadSnapshotChunk) isRequest_Value()  {}
 func (*Request_ApplySnapshotChunk) isRequest_Value() {}
+func (*Request_CheckTxBatch) isRequest_Value()       {}
 
 func (m *Request) GetValue() isRequest_Value {
 	if m != nil {
@@ -381,6 +386,13 @@ func (m *Request) GetApplySnapshotChunk() *RequestApplySnapshotChunk {
 	return nil
 }
 
+func (m *Request) GetCheckTxBatch() *RequestCheckTxBatch {
+	if x, ok := m.GetValue().(*Request_CheckTxBatch); ok {
+		return x.CheckTxBatch
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Request) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -398,6 +410,7 @@ func (*Request) XXX_OneofWrappers() []interface{} {
 		(*Request_OfferSnapshot)(nil),
 		(*Request_LoadSnapshotChunk)(nil),
 		(*Request_ApplySnapshotChunk)(nil),
+		(*Request_CheckTxBatch)(nil),
 	}
 }
 
@@ -1157,6 +1170,51 @@ func (m *RequestApplySnapshotChunk) GetSender() string {
 	return ""
 }
 
+// checks a batch of txs, e.g. to verify their signatures in parallel
+type RequestCheckTxBatch struct {
+	Requests []RequestCheckTx `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests"`
+}
+
+func (m *RequestCheckTxBatch) Reset()         { *m = RequestCheckTxBatch{} }
+func (m *RequestCheckTxBatch) String() string { return proto.CompactTextString(m) }
+func (*RequestCheckTxBatch) ProtoMessage()    {}
+func (*RequestCheckTxBatch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{15}
+}
+func (m *RequestCheckTxBatch) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestCheckTxBatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestCheckTxBatch.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestCheckTxBatch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestCheckTxBatch.Merge(m, src)
+}
+func (m *RequestCheckTxBatch) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestCheckTxBatch) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestCheckTxBatch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestCheckTxBatch proto.InternalMessageInfo
+
+func (m *RequestCheckTxBatch) GetRequests() []RequestCheckTx {
+	if m != nil {
+		return m.Requests
+	}
+	return nil
+}
+
 type Response struct {
 	// Types that are valid to be assigned to Value:
 	//	*Response_Exception
@@ -1174,6 +1232,7 @@ type Response struct {
 	//	*Response_OfferSnapshot
 	//	*Response_LoadSnapshotChunk
 	//	*Response_ApplySnapshotChunk
+	//	*Response_CheckTxBatch
 	Value isResponse_Value `protobuf_oneof:"value"`
 }
 
@@ -1181,7 +1240,7 @@ func (m *Response) Reset()         { *m = Response{} }
 func (m *Response) String() string { return proto.CompactTextString(m) }
 func (*Response) ProtoMessage()    {}
 func (*Response) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{15}
+	return fileDescriptor_252557cfdd89a31a, []int{16}
 }
 func (m *Response) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1261,6 +1320,9 @@ type Response_LoadSnapshotChunk struct {
 type Response_ApplySnapshotChunk struct {
 	ApplySnapshotChunk *ResponseApplySnapshotChunk `protobuf:"bytes,15,opt,name=apply_snapshot_chunk,json=applySnapshotChunk,proto3,oneof" json:"apply_snapshot_chunk,omitempty"`
 }
+type Response_CheckTxBatch struct {
+	CheckTxBatch *ResponseCheckTxBatch `protobuf:"bytes,16,opt,name=check_tx_batch,json=checkTxBatch,proto3,oneof" json:"check_tx_batch,omitempty"`
+}
 
 func (*Response_Exception) isResponse_Value()          {}
 func (*Response_Echo) isResponse_Value()               {}
@@ -1277,6 +1339,7 @@ func (*Response_ListSnapshots) isResponse_Value()      {}
 func (*Response_OfferSnapshot) isResponse_Value()      {}
 func (*Response_LoadSnapshotChunk) isResponse_Value()  {}
 func (*Response_ApplySnapshotChunk) isResponse_Value() {}
+func (*Response_CheckTxBatch) isResponse_Value()       {}
 
 func (m *Response) GetValue() isResponse_Value {
 	if m != nil {
@@ -1390,6 +1453,13 @@ func (m *Response) GetApplySnapshotChunk() *ResponseApplySnapshotChunk {
 	return nil
 }
 
+func (m *Response) GetCheckTxBatch() *ResponseCheckTxBatch {
+	if x, ok := m.GetValue().(*Response_CheckTxBatch); ok {
+		return x.CheckTxBatch
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Response) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -1408,6 +1478,7 @@ func (*Response) XXX_OneofWrappers() []interface{} {
 		(*Response_OfferSnapshot)(nil),
 		(*Response_LoadSnapshotChunk)(nil),
 		(*Response_ApplySnapshotChunk)(nil),
+		(*Response_CheckTxBatch)(nil),
 	}
 }
 
@@ -1420,7 +1491,7 @@ func (m *ResponseException) Reset()         { *m = ResponseException{} }
 func (m *ResponseException) String() string { return proto.CompactTextString(m) }
 func (*ResponseException) ProtoMessage()    {}
 func (*ResponseException) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{16}
+	return fileDescriptor_252557cfdd89a31a, []int{17}
 }
 func (m *ResponseException) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1464,7 +1535,7 @@ func (m *ResponseEcho) Reset()         { *m = ResponseEcho{} }
 func (m *ResponseEcho) String() string { return proto.CompactTextString(m) }
 func (*ResponseEcho) ProtoMessage()    {}
 func (*ResponseEcho) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{17}
+	return fileDescriptor_252557cfdd89a31a, []int{18}
 }
 func (m *ResponseEcho) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1507,7 +1578,7 @@ func (m *ResponseFlush) Reset()         { *m = ResponseFlush{} }
 func (m *ResponseFlush) String() string { return proto.CompactTextString(m) }
 func (*ResponseFlush) ProtoMessage()    {}
 func (*ResponseFlush) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{18}
+	return fileDescriptor_252557cfdd89a31a, []int{19}
 }
 func (m *ResponseFlush) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1549,7 +1620,7 @@ func (m *ResponseInfo) Reset()         { *m = ResponseInfo{} }
 func (m *ResponseInfo) String() string { return proto.CompactTextString(m) }
 func (*ResponseInfo) ProtoMessage()    {}
 func (*ResponseInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{19}
+	return fileDescriptor_252557cfdd89a31a, []int{20}
 }
 func (m *ResponseInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1623,7 +1694,7 @@ func (m *ResponseInitChain) Reset()         { *m = ResponseInitChain{} }
 func (m *ResponseInitChain) String() string { return proto.CompactTextString(m) }
 func (*ResponseInitChain) ProtoMessage()    {}
 func (*ResponseInitChain) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{20}
+	return fileDescriptor_252557cfdd89a31a, []int{21}
 }
 func (m *ResponseInitChain) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1690,7 +1761,7 @@ func (m *ResponseQuery) Reset()         { *m = ResponseQuery{} }
 func (m *ResponseQuery) String() string { return proto.CompactTextString(m) }
 func (*ResponseQuery) ProtoMessage()    {}
 func (*ResponseQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{21}
+	return fileDescriptor_252557cfdd89a31a, []int{22}
 }
 func (m *ResponseQuery) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1790,7 +1861,7 @@ func (m *ResponseBeginBlock) Reset()         { *m = ResponseBeginBlock{} }
 func (m *ResponseBeginBlock) String() string { return proto.CompactTextString(m) }
 func (*ResponseBeginBlock) ProtoMessage()    {}
 func (*ResponseBeginBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{22}
+	return fileDescriptor_252557cfdd89a31a, []int{23}
 }
 func (m *ResponseBeginBlock) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1840,13 +1911,28 @@ type ResponseCheckTx struct {
 	// mempool_error is set by Tendermint.
 	// ABCI applictions creating a ResponseCheckTX should not set mempool_error.
 	MempoolError string `protobuf:"bytes,11,opt,name=mempool_error,json=mempoolError,proto3" json:"mempool_error,omitempty"`
+	// nonce orders the transactions of the same sender in the mempool, lowest
+	// first. It is only used if sender is set.
+	Nonce uint64 `protobuf:"varint,12,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	// lane assigns the transaction to a lane of the mempool, with its own size
+	// limits, share of the block space and gossip priority. An empty or unknown
+	// lane assigns it to the default lane.
+	Lane string `protobuf:"bytes,13,opt,name=lane,proto3" json:"lane,omitempty"`
+	// recheck_keys are the keys of the application state the validity of the
+	// transaction depends on. If set, the v1 mempool only rechecks the
+	// transaction after a block which updated any of these keys, as reported in
+	// the updated_keys of ResponseDeliverTx. An application setting them must
+	// report the keys updated by every transaction it delivers. The state
+	// updated by BeginBlock and EndBlock is not reported, so the transactions
+	// depending on it are not rechecked when it changes.
+	RecheckKeys [][]byte `protobuf:"bytes,14,rep,name=recheck_keys,json=recheckKeys,proto3" json:"recheck_keys,omitempty"`
 }
 
 func (m *ResponseCheckTx) Reset()         { *m = ResponseCheckTx{} }
 func (m *ResponseCheckTx) String() string { return proto.CompactTextString(m) }
 func (*ResponseCheckTx) ProtoMessage()    {}
 func (*ResponseCheckTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{23}
+	return fileDescriptor_252557cfdd89a31a, []int{24}
 }
 func (m *ResponseCheckTx) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1952,6 +2038,27 @@ func (m *ResponseCheckTx) GetMempoolError() string {
 	return ""
 }
 
+func (m *ResponseCheckTx) GetNonce() uint64 {
+	if m != nil {
+		return m.Nonce
+	}
+	return 0
+}
+
+func (m *ResponseCheckTx) GetLane() string {
+	if m != nil {
+		return m.Lane
+	}
+	return ""
+}
+
+func (m *ResponseCheckTx) GetRecheckKeys() [][]byte {
+	if m != nil {
+		return m.RecheckKeys
+	}
+	return nil
+}
+
 type ResponseDeliverTx struct {
 	Code      uint32  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
 	Data      []byte  `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
@@ -1961,13 +2068,17 @@ type ResponseDeliverTx struct {
 	GasUsed   int64   `protobuf:"varint,6,opt,name=gas_used,proto3" json:"gas_used,omitempty"`
 	Events    []Event `protobuf:"bytes,7,rep,name=events,proto3" json:"events,omitempty"`
 	Codespace string  `protobuf:"bytes,8,opt,name=codespace,proto3" json:"codespace,omitempty"`
+	// updated_keys are the keys of the application state updated by the
+	// transaction, used by the mempool to skip rechecking the transactions
+	// which do not depend on them. See recheck_keys of ResponseCheckTx.
+	UpdatedKeys [][]byte `protobuf:"bytes,9,rep,name=updated_keys,json=updatedKeys,proto3" json:"updated_keys,omitempty"`
 }
 
 func (m *ResponseDeliverTx) Reset()         { *m = ResponseDeliverTx{} }
 func (m *ResponseDeliverTx) String() string { return proto.CompactTextString(m) }
 func (*ResponseDeliverTx) ProtoMessage()    {}
 func (*ResponseDeliverTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{24}
+	return fileDescriptor_252557cfdd89a31a, []int{25}
 }
 func (m *ResponseDeliverTx) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2052,6 +2163,13 @@ func (m *ResponseDeliverTx) GetCodespace() string {
 	return ""
 }
 
+func (m *ResponseDeliverTx) GetUpdatedKeys() [][]byte {
+	if m != nil {
+		return m.UpdatedKeys
+	}
+	return nil
+}
+
 type ResponseEndBlock struct {
 	ValidatorUpdates      []ValidatorUpdate       `protobuf:"bytes,1,rep,name=validator_updates,json=validatorUpdates,proto3" json:"validator_updates"`
 	ConsensusParamUpdates *types1.ConsensusParams `protobuf:"bytes,2,opt,name=consensus_param_updates,json=consensusParamUpdates,proto3" json:"consensus_param_updates,omitempty"`
@@ -2062,7 +2180,7 @@ func (m *ResponseEndBlock) Reset()         { *m = ResponseEndBlock{} }
 func (m *ResponseEndBlock) String() string { return proto.CompactTextString(m) }
 func (*ResponseEndBlock) ProtoMessage()    {}
 func (*ResponseEndBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{25}
+	return fileDescriptor_252557cfdd89a31a, []int{26}
 }
 func (m *ResponseEndBlock) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2122,7 +2240,7 @@ func (m *ResponseCommit) Reset()         { *m = ResponseCommit{} }
 func (m *ResponseCommit) String() string { return proto.CompactTextString(m) }
 func (*ResponseCommit) ProtoMessage()    {}
 func (*ResponseCommit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{26}
+	return fileDescriptor_252557cfdd89a31a, []int{27}
 }
 func (m *ResponseCommit) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2173,7 +2291,7 @@ func (m *ResponseListSnapshots) Reset()         { *m = ResponseListSnapshots{} }
 func (m *ResponseListSnapshots) String() string { return proto.CompactTextString(m) }
 func (*ResponseListSnapshots) ProtoMessage()    {}
 func (*ResponseListSnapshots) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{27}
+	return fileDescriptor_252557cfdd89a31a, []int{28}
 }
 func (m *ResponseListSnapshots) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2217,7 +2335,7 @@ func (m *ResponseOfferSnapshot) Reset()         { *m = ResponseOfferSnapshot{} }
 func (m *ResponseOfferSnapshot) String() string { return proto.CompactTextString(m) }
 func (*ResponseOfferSnapshot) ProtoMessage()    {}
 func (*ResponseOfferSnapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{28}
+	return fileDescriptor_252557cfdd89a31a, []int{29}
 }
 func (m *ResponseOfferSnapshot) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2261,7 +2379,7 @@ func (m *ResponseLoadSnapshotChunk) Reset()         { *m = ResponseLoadSnapshotC
 func (m *ResponseLoadSnapshotChunk) String() string { return proto.CompactTextString(m) }
 func (*ResponseLoadSnapshotChunk) ProtoMessage()    {}
 func (*ResponseLoadSnapshotChunk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{29}
+	return fileDescriptor_252557cfdd89a31a, []int{30}
 }
 func (m *ResponseLoadSnapshotChunk) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2307,7 +2425,7 @@ func (m *ResponseApplySnapshotChunk) Reset()         { *m = ResponseApplySnapsho
 func (m *ResponseApplySnapshotChunk) String() string { return proto.CompactTextString(m) }
 func (*ResponseApplySnapshotChunk) ProtoMessage()    {}
 func (*ResponseApplySnapshotChunk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{30}
+	return fileDescriptor_252557cfdd89a31a, []int{31}
 }
 func (m *ResponseApplySnapshotChunk) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2357,6 +2475,51 @@ func (m *ResponseApplySnapshotChunk) GetRejectSenders() []string {
 	return nil
 }
 
+type ResponseCheckTxBatch struct {
+	// responses to the requests of the batch, in the same order
+	Responses []ResponseCheckTx `protobuf:"bytes,1,rep,name=responses,proto3" json:"responses"`
+}
+
+func (m *ResponseCheckTxBatch) Reset()         { *m = ResponseCheckTxBatch{} }
+func (m *ResponseCheckTxBatch) String() string { return proto.CompactTextString(m) }
+func (*ResponseCheckTxBatch) ProtoMessage()    {}
+func (*ResponseCheckTxBatch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{32}
+}
+func (m *ResponseCheckTxBatch) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResponseCheckTxBatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponseCheckTxBatch.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ResponseCheckTxBatch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseCheckTxBatch.Merge(m, src)
+}
+func (m *ResponseCheckTxBatch) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponseCheckTxBatch) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseCheckTxBatch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponseCheckTxBatch proto.InternalMessageInfo
+
+func (m *ResponseCheckTxBatch) GetResponses() []ResponseCheckTx {
+	if m != nil {
+		return m.Responses
+	}
+	return nil
+}
+
 type LastCommitInfo struct {
 	Round int32      `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
 	Votes []VoteInfo `protobuf:"bytes,2,rep,name=votes,proto3" json:"votes"`
@@ -2366,7 +2529,7 @@ func (m *LastCommitInfo) Reset()         { *m = LastCommitInfo{} }
 func (m *LastCommitInfo) String() string { return proto.CompactTextString(m) }
 func (*LastCommitInfo) ProtoMessage()    {}
 func (*LastCommitInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{31}
+	return fileDescriptor_252557cfdd89a31a, []int{33}
 }
 func (m *LastCommitInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2421,7 +2584,7 @@ func (m *Event) Reset()         { *m = Event{} }
 func (m *Event) String() string { return proto.CompactTextString(m) }
 func (*Event) ProtoMessage()    {}
 func (*Event) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{32}
+	return fileDescriptor_252557cfdd89a31a, []int{34}
 }
 func (m *Event) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2475,7 +2638,7 @@ func (m *EventAttribute) Reset()         { *m = EventAttribute{} }
 func (m *EventAttribute) String() string { return proto.CompactTextString(m) }
 func (*EventAttribute) ProtoMessage()    {}
 func (*EventAttribute) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{33}
+	return fileDescriptor_252557cfdd89a31a, []int{35}
 }
 func (m *EventAttribute) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2539,7 +2702,7 @@ func (m *TxResult) Reset()         { *m = TxResult{} }
 func (m *TxResult) String() string { return proto.CompactTextString(m) }
 func (*TxResult) ProtoMessage()    {}
 func (*TxResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{34}
+	return fileDescriptor_252557cfdd89a31a, []int{36}
 }
 func (m *TxResult) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2607,7 +2770,7 @@ func (m *Validator) Reset()         { *m = Validator{} }
 func (m *Validator) String() string { return proto.CompactTextString(m) }
 func (*Validator) ProtoMessage()    {}
 func (*Validator) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{35}
+	return fileDescriptor_252557cfdd89a31a, []int{37}
 }
 func (m *Validator) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2660,7 +2823,7 @@ func (m *ValidatorUpdate) Reset()         { *m = ValidatorUpdate{} }
 func (m *ValidatorUpdate) String() string { return proto.CompactTextString(m) }
 func (*ValidatorUpdate) ProtoMessage()    {}
 func (*ValidatorUpdate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{36}
+	return fileDescriptor_252557cfdd89a31a, []int{38}
 }
 func (m *ValidatorUpdate) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2713,7 +2876,7 @@ func (m *VoteInfo) Reset()         { *m = VoteInfo{} }
 func (m *VoteInfo) String() string { return proto.CompactTextString(m) }
 func (*VoteInfo) ProtoMessage()    {}
 func (*VoteInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{37}
+	return fileDescriptor_252557cfdd89a31a, []int{39}
 }
 func (m *VoteInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2774,7 +2937,7 @@ func (m *Evidence) Reset()         { *m = Evidence{} }
 func (m *Evidence) String() string { return proto.CompactTextString(m) }
 func (*Evidence) ProtoMessage()    {}
 func (*Evidence) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{38}
+	return fileDescriptor_252557cfdd89a31a, []int{40}
 }
 func (m *Evidence) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2850,7 +3013,7 @@ func (m *Snapshot) Reset()         { *m = Snapshot{} }
 func (m *Snapshot) String() string { return proto.CompactTextString(m) }
 func (*Snapshot) ProtoMessage()    {}
 func (*Snapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{39}
+	return fileDescriptor_252557cfdd89a31a, []int{41}
 }
 func (m *Snapshot) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2934,6 +3097,7 @@ func init() {
 	proto.RegisterType((*RequestOfferSnapshot)(nil), "tendermint.abci.RequestOfferSnapshot")
 	proto.RegisterType((*RequestLoadSnapshotChunk)(nil), "tendermint.abci.RequestLoadSnapshotChunk")
 	proto.RegisterType((*RequestApplySnapshotChunk)(nil), "tendermint.abci.RequestApplySnapshotChunk")
+	proto.RegisterType((*RequestCheckTxBatch)(nil), "tendermint.abci.RequestCheckTxBatch")
 	proto.RegisterType((*Response)(nil), "tendermint.abci.Response")
 	proto.RegisterType((*ResponseException)(nil), "tendermint.abci.ResponseException")
 	proto.RegisterType((*ResponseEcho)(nil), "tendermint.abci.ResponseEcho")
@@ -2950,6 +3114,7 @@ func init() {
 	proto.RegisterType((*ResponseOfferSnapshot)(nil), "tendermint.abci.ResponseOfferSnapshot")
 	proto.RegisterType((*ResponseLoadSnapshotChunk)(nil), "tendermint.abci.ResponseLoadSnapshotChunk")
 	proto.RegisterType((*ResponseApplySnapshotChunk)(nil), "tendermint.abci.ResponseApplySnapshotChunk")
+	proto.RegisterType((*ResponseCheckTxBatch)(nil), "tendermint.abci.ResponseCheckTxBatch")
 	proto.RegisterType((*LastCommitInfo)(nil), "tendermint.abci.LastCommitInfo")
 	proto.RegisterType((*Event)(nil), "tendermint.abci.Event")
 	proto.RegisterType((*EventAttribute)(nil), "tendermint.abci.EventAttribute")
@@ -2964,172 +3129,181 @@ func init() {
 func init() { proto.RegisterFile("tendermint/abci/types.proto", fileDescriptor_252557cfdd89a31a) }
 
 var fileDescriptor_252557cfdd89a31a = []byte{
-	// 2627 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x5a, 0xcd, 0x73, 0xdb, 0xc6,
-	0x15, 0xe7, 0x37, 0x89, 0x47, 0x91, 0xa2, 0xd6, 0x8a, 0x43, 0x33, 0xb6, 0xe4, 0xc0, 0xe3, 0x34,
-	0x76, 0x12, 0xa9, 0x91, 0xc7, 0xae, 0x33, 0xe9, 0x47, 0x44, 0x9a, 0x2e, 0x15, 0xab, 0x92, 0xba,
-	0xa2, 0x9d, 0x49, 0xdb, 0x18, 0x01, 0x89, 0x15, 0x89, 0x98, 0x04, 0x10, 0x60, 0x29, 0x4b, 0x39,
-	0x76, 0xda, 0x8b, 0xa7, 0x07, 0x1f, 0x7b, 0xc9, 0x4c, 0xff, 0x83, 0x5e, 0x7b, 0xea, 0xa9, 0x87,
-	0x1c, 0xda, 0x99, 0x1c, 0x7b, 0xe8, 0xa4, 0x1d, 0xfb, 0xd6, 0x7f, 0xa0, 0xa7, 0xce, 0x74, 0xf6,
-	0x03, 0x20, 0x40, 0x12, 0x22, 0xd5, 0xf4, 0xd6, 0xdb, 0xee, 0xc3, 0x7b, 0x8f, 0xbb, 0x6f, 0xf7,
-	0xfd, 0xf6, 0xb7, 0x6f, 0x09, 0xaf, 0x51, 0x62, 0x19, 0xc4, 0x1d, 0x9a, 0x16, 0xdd, 0xd4, 0x3b,
-	0x5d, 0x73, 0x93, 0x9e, 0x3a, 0xc4, 0xdb, 0x70, 0x5c, 0x9b, 0xda, 0x68, 0x79, 0xfc, 0x71, 0x83,
-	0x7d, 0xac, 0x5d, 0x09, 0x69, 0x77, 0xdd, 0x53, 0x87, 0xda, 0x9b, 0x8e, 0x6b, 0xdb, 0x47, 0x42,
-	0xbf, 0x76, 0x39, 0xf4, 0x99, 0xfb, 0x09, 0x7b, 0x8b, 0x7c, 0x95, 0xc6, 0x4f, 0xc8, 0xa9, 0xff,
-	0xf5, 0xca, 0x94, 0xad, 0xa3, 0xbb, 0xfa, 0xd0, 0xff, 0xbc, 0xde, 0xb3, 0xed, 0xde, 0x80, 0x6c,
-	0xf2, 0x5e, 0x67, 0x74, 0xb4, 0x49, 0xcd, 0x21, 0xf1, 0xa8, 0x3e, 0x74, 0xa4, 0xc2, 0x6a, 0xcf,
-	0xee, 0xd9, 0xbc, 0xb9, 0xc9, 0x5a, 0x42, 0xaa, 0xfe, 0x25, 0x0f, 0x79, 0x4c, 0x3e, 0x1f, 0x11,
-	0x8f, 0xa2, 0x2d, 0xc8, 0x90, 0x6e, 0xdf, 0xae, 0x26, 0xaf, 0x26, 0xdf, 0x2c, 0x6e, 0x5d, 0xde,
-	0x98, 0x98, 0xdc, 0x86, 0xd4, 0x6b, 0x76, 0xfb, 0x76, 0x2b, 0x81, 0xb9, 0x2e, 0xba, 0x0d, 0xd9,
-	0xa3, 0xc1, 0xc8, 0xeb, 0x57, 0x53, 0xdc, 0xe8, 0x4a, 0x9c, 0xd1, 0x7d, 0xa6, 0xd4, 0x4a, 0x60,
-	0xa1, 0xcd, 0x7e, 0xca, 0xb4, 0x8e, 0xec, 0x6a, 0xfa, 0xec, 0x9f, 0xda, 0xb1, 0x8e, 0xf8, 0x4f,
-	0x31, 0x5d, 0x54, 0x07, 0x30, 0x2d, 0x93, 0x6a, 0xdd, 0xbe, 0x6e, 0x5a, 0xd5, 0x0c, 0xb7, 0x7c,
-	0x3d, 0xde, 0xd2, 0xa4, 0x0d, 0xa6, 0xd8, 0x4a, 0x60, 0xc5, 0xf4, 0x3b, 0x6c, 0xb8, 0x9f, 0x8f,
-	0x88, 0x7b, 0x5a, 0xcd, 0x9e, 0x3d, 0xdc, 0x9f, 0x32, 0x25, 0x36, 0x5c, 0xae, 0x8d, 0x9a, 0x50,
-	0xec, 0x90, 0x9e, 0x69, 0x69, 0x9d, 0x81, 0xdd, 0x7d, 0x52, 0xcd, 0x71, 0x63, 0x35, 0xce, 0xb8,
-	0xce, 0x54, 0xeb, 0x4c, 0xb3, 0x95, 0xc0, 0xd0, 0x09, 0x7a, 0xe8, 0xfb, 0x50, 0xe8, 0xf6, 0x49,
-	0xf7, 0x89, 0x46, 0x4f, 0xaa, 0x79, 0xee, 0x63, 0x3d, 0xce, 0x47, 0x83, 0xe9, 0xb5, 0x4f, 0x5a,
-	0x09, 0x9c, 0xef, 0x8a, 0x26, 0x9b, 0xbf, 0x41, 0x06, 0xe6, 0x31, 0x71, 0x99, 0x7d, 0xe1, 0xec,
-	0xf9, 0xdf, 0x13, 0x9a, 0xdc, 0x83, 0x62, 0xf8, 0x1d, 0xf4, 0x23, 0x50, 0x88, 0x65, 0xc8, 0x69,
-	0x28, 0xdc, 0xc5, 0xd5, 0xd8, 0x75, 0xb6, 0x0c, 0x7f, 0x12, 0x05, 0x22, 0xdb, 0xe8, 0x2e, 0xe4,
-	0xba, 0xf6, 0x70, 0x68, 0xd2, 0x2a, 0x70, 0xeb, 0xb5, 0xd8, 0x09, 0x70, 0xad, 0x56, 0x02, 0x4b,
-	0x7d, 0xb4, 0x07, 0xe5, 0x81, 0xe9, 0x51, 0xcd, 0xb3, 0x74, 0xc7, 0xeb, 0xdb, 0xd4, 0xab, 0x16,
-	0xb9, 0x87, 0xeb, 0x71, 0x1e, 0x76, 0x4d, 0x8f, 0x1e, 0xfa, 0xca, 0xad, 0x04, 0x2e, 0x0d, 0xc2,
-	0x02, 0xe6, 0xcf, 0x3e, 0x3a, 0x22, 0x6e, 0xe0, 0xb0, 0xba, 0x74, 0xb6, 0xbf, 0x7d, 0xa6, 0xed,
-	0xdb, 0x33, 0x7f, 0x76, 0x58, 0x80, 0x7e, 0x0e, 0x17, 0x06, 0xb6, 0x6e, 0x04, 0xee, 0xb4, 0x6e,
-	0x7f, 0x64, 0x3d, 0xa9, 0x96, 0xb8, 0xd3, 0x1b, 0xb1, 0x83, 0xb4, 0x75, 0xc3, 0x77, 0xd1, 0x60,
-	0x06, 0xad, 0x04, 0x5e, 0x19, 0x4c, 0x0a, 0xd1, 0x63, 0x58, 0xd5, 0x1d, 0x67, 0x70, 0x3a, 0xe9,
-	0xbd, 0xcc, 0xbd, 0xdf, 0x8c, 0xf3, 0xbe, 0xcd, 0x6c, 0x26, 0xdd, 0x23, 0x7d, 0x4a, 0x5a, 0xcf,
-	0x43, 0xf6, 0x58, 0x1f, 0x8c, 0x88, 0xfa, 0x1d, 0x28, 0x86, 0xd2, 0x14, 0x55, 0x21, 0x3f, 0x24,
-	0x9e, 0xa7, 0xf7, 0x08, 0xcf, 0x6a, 0x05, 0xfb, 0x5d, 0xb5, 0x0c, 0x4b, 0xe1, 0xd4, 0x54, 0x9f,
-	0x27, 0x03, 0x4b, 0x96, 0x75, 0xcc, 0xf2, 0x98, 0xb8, 0x9e, 0x69, 0x5b, 0xbe, 0xa5, 0xec, 0xa2,
-	0x6b, 0x50, 0xe2, 0xfb, 0x47, 0xf3, 0xbf, 0xb3, 0xd4, 0xcf, 0xe0, 0x25, 0x2e, 0x7c, 0x24, 0x95,
-	0xd6, 0xa1, 0xe8, 0x6c, 0x39, 0x81, 0x4a, 0x9a, 0xab, 0x80, 0xb3, 0xe5, 0xf8, 0x0a, 0xaf, 0xc3,
-	0x12, 0x9b, 0x69, 0xa0, 0x91, 0xe1, 0x3f, 0x52, 0x64, 0x32, 0xa9, 0xa2, 0xfe, 0x39, 0x05, 0x95,
-	0xc9, 0x74, 0x46, 0x77, 0x21, 0xc3, 0x90, 0x4d, 0x82, 0x54, 0x6d, 0x43, 0xc0, 0xde, 0x86, 0x0f,
-	0x7b, 0x1b, 0x6d, 0x1f, 0xf6, 0xea, 0x85, 0xaf, 0xbe, 0x59, 0x4f, 0x3c, 0xff, 0xfb, 0x7a, 0x12,
-	0x73, 0x0b, 0x74, 0x89, 0x65, 0x9f, 0x6e, 0x5a, 0x9a, 0x69, 0xf0, 0x21, 0x2b, 0x2c, 0xb5, 0x74,
-	0xd3, 0xda, 0x31, 0xd0, 0x2e, 0x54, 0xba, 0xb6, 0xe5, 0x11, 0xcb, 0x1b, 0x79, 0x9a, 0x80, 0x55,
-	0x09, 0x4d, 0x91, 0x04, 0x13, 0x60, 0xdd, 0xf0, 0x35, 0x0f, 0xb8, 0x22, 0x5e, 0xee, 0x46, 0x05,
-	0xe8, 0x3e, 0xc0, 0xb1, 0x3e, 0x30, 0x0d, 0x9d, 0xda, 0xae, 0x57, 0xcd, 0x5c, 0x4d, 0xcf, 0xcc,
-	0xb2, 0x47, 0xbe, 0xca, 0x43, 0xc7, 0xd0, 0x29, 0xa9, 0x67, 0xd8, 0x70, 0x71, 0xc8, 0x12, 0xbd,
-	0x01, 0xcb, 0xba, 0xe3, 0x68, 0x1e, 0xd5, 0x29, 0xd1, 0x3a, 0xa7, 0x94, 0x78, 0x1c, 0xb6, 0x96,
-	0x70, 0x49, 0x77, 0x9c, 0x43, 0x26, 0xad, 0x33, 0x21, 0xba, 0x0e, 0x65, 0x86, 0x70, 0xa6, 0x3e,
-	0xd0, 0xfa, 0xc4, 0xec, 0xf5, 0x29, 0x07, 0xa8, 0x34, 0x2e, 0x49, 0x69, 0x8b, 0x0b, 0x55, 0x23,
-	0x58, 0x71, 0x8e, 0x6e, 0x08, 0x41, 0xc6, 0xd0, 0xa9, 0xce, 0x23, 0xb9, 0x84, 0x79, 0x9b, 0xc9,
-	0x1c, 0x9d, 0xf6, 0x65, 0x7c, 0x78, 0x1b, 0x5d, 0x84, 0x9c, 0x74, 0x9b, 0xe6, 0x6e, 0x65, 0x0f,
-	0xad, 0x42, 0xd6, 0x71, 0xed, 0x63, 0xc2, 0x97, 0xae, 0x80, 0x45, 0x47, 0xfd, 0x55, 0x0a, 0x56,
-	0xa6, 0x70, 0x90, 0xf9, 0xed, 0xeb, 0x5e, 0xdf, 0xff, 0x2d, 0xd6, 0x46, 0x77, 0x98, 0x5f, 0xdd,
-	0x20, 0xae, 0x3c, 0x3b, 0xaa, 0xd3, 0xa1, 0x6e, 0xf1, 0xef, 0x32, 0x34, 0x52, 0x1b, 0xed, 0x43,
-	0x65, 0xa0, 0x7b, 0x54, 0x13, 0xb8, 0xa2, 0x85, 0xce, 0x91, 0x69, 0x34, 0xdd, 0xd5, 0x7d, 0x24,
-	0x62, 0x9b, 0x5a, 0x3a, 0x2a, 0x0f, 0x22, 0x52, 0x84, 0x61, 0xb5, 0x73, 0xfa, 0x85, 0x6e, 0x51,
-	0xd3, 0x22, 0xda, 0xd4, 0xca, 0x5d, 0x9a, 0x72, 0xda, 0x3c, 0x36, 0x0d, 0x62, 0x75, 0xfd, 0x25,
-	0xbb, 0x10, 0x18, 0x07, 0x4b, 0xea, 0xa9, 0x18, 0xca, 0x51, 0x24, 0x47, 0x65, 0x48, 0xd1, 0x13,
-	0x19, 0x80, 0x14, 0x3d, 0x41, 0xdf, 0x85, 0x0c, 0x9b, 0x24, 0x9f, 0x7c, 0x79, 0xc6, 0x11, 0x28,
-	0xed, 0xda, 0xa7, 0x0e, 0xc1, 0x5c, 0x53, 0x55, 0x83, 0x74, 0x08, 0xd0, 0x7d, 0xd2, 0xab, 0x7a,
-	0x03, 0x96, 0x27, 0xe0, 0x3b, 0xb4, 0x7e, 0xc9, 0xf0, 0xfa, 0xa9, 0xcb, 0x50, 0x8a, 0x60, 0xb5,
-	0x7a, 0x11, 0x56, 0x67, 0x41, 0xaf, 0xda, 0x0f, 0xe4, 0x11, 0x08, 0x45, 0xb7, 0xa1, 0x10, 0x60,
-	0xaf, 0x48, 0xc7, 0xe9, 0x58, 0xf9, 0xca, 0x38, 0x50, 0x65, 0x79, 0xc8, 0xb6, 0x35, 0xdf, 0x0f,
-	0x29, 0x3e, 0xf0, 0xbc, 0xee, 0x38, 0x2d, 0xdd, 0xeb, 0xab, 0x9f, 0x42, 0x35, 0x0e, 0x57, 0x27,
-	0xa6, 0x91, 0x09, 0xb6, 0xe1, 0x45, 0xc8, 0x1d, 0xd9, 0xee, 0x50, 0xa7, 0xdc, 0x59, 0x09, 0xcb,
-	0x1e, 0xdb, 0x9e, 0x02, 0x63, 0xd3, 0x5c, 0x2c, 0x3a, 0xaa, 0x06, 0x97, 0x62, 0xb1, 0x95, 0x99,
-	0x98, 0x96, 0x41, 0x44, 0x3c, 0x4b, 0x58, 0x74, 0xc6, 0x8e, 0xc4, 0x60, 0x45, 0x87, 0xfd, 0xac,
-	0xc7, 0xe7, 0xca, 0xfd, 0x2b, 0x58, 0xf6, 0xd4, 0xdf, 0x15, 0xa0, 0x80, 0x89, 0xe7, 0x30, 0x4c,
-	0x40, 0x75, 0x50, 0xc8, 0x49, 0x97, 0x38, 0xd4, 0x87, 0xd1, 0xd9, 0xac, 0x41, 0x68, 0x37, 0x7d,
-	0x4d, 0x76, 0x64, 0x07, 0x66, 0xe8, 0x96, 0x64, 0x65, 0xf1, 0x04, 0x4b, 0x9a, 0x87, 0x69, 0xd9,
-	0x1d, 0x9f, 0x96, 0xa5, 0x63, 0x4f, 0x69, 0x61, 0x35, 0xc1, 0xcb, 0x6e, 0x49, 0x5e, 0x96, 0x99,
-	0xf3, 0x63, 0x11, 0x62, 0xd6, 0x88, 0x10, 0xb3, 0xec, 0x9c, 0x69, 0xc6, 0x30, 0xb3, 0x3b, 0x3e,
-	0x33, 0xcb, 0xcd, 0x19, 0xf1, 0x04, 0x35, 0xbb, 0x1f, 0xa5, 0x66, 0x82, 0x56, 0x5d, 0x8b, 0xb5,
-	0x8e, 0xe5, 0x66, 0x3f, 0x08, 0x71, 0xb3, 0x42, 0x2c, 0x31, 0x12, 0x4e, 0x66, 0x90, 0xb3, 0x46,
-	0x84, 0x9c, 0x29, 0x73, 0x62, 0x10, 0xc3, 0xce, 0x3e, 0x08, 0xb3, 0x33, 0x88, 0x25, 0x78, 0x72,
-	0xbd, 0x67, 0xd1, 0xb3, 0xf7, 0x02, 0x7a, 0x56, 0x8c, 0xe5, 0x97, 0x72, 0x0e, 0x93, 0xfc, 0x6c,
-	0x7f, 0x8a, 0x9f, 0x09, 0x3e, 0xf5, 0x46, 0xac, 0x8b, 0x39, 0x04, 0x6d, 0x7f, 0x8a, 0xa0, 0x95,
-	0xe6, 0x38, 0x9c, 0xc3, 0xd0, 0x7e, 0x31, 0x9b, 0xa1, 0xc5, 0x73, 0x28, 0x39, 0xcc, 0xc5, 0x28,
-	0x9a, 0x16, 0x43, 0xd1, 0x96, 0xb9, 0xfb, 0xb7, 0x62, 0xdd, 0x9f, 0x9f, 0xa3, 0xdd, 0x60, 0x27,
-	0xe4, 0x44, 0xce, 0x33, 0x94, 0x21, 0xae, 0x6b, 0xbb, 0x92, 0x6d, 0x89, 0x8e, 0xfa, 0x26, 0x3b,
-	0xb3, 0xc7, 0xf9, 0x7d, 0x06, 0x9f, 0xe3, 0x68, 0x1e, 0xca, 0x69, 0xf5, 0x0f, 0xc9, 0xb1, 0x2d,
-	0x3f, 0xe6, 0xc2, 0xe7, 0xbd, 0x22, 0xcf, 0xfb, 0x10, 0xcb, 0x4b, 0x45, 0x59, 0xde, 0x3a, 0x14,
-	0x19, 0x4a, 0x4f, 0x10, 0x38, 0xdd, 0x09, 0x08, 0xdc, 0x4d, 0x58, 0xe1, 0xc7, 0xb0, 0xe0, 0x82,
-	0x12, 0x9a, 0x33, 0xfc, 0x84, 0x59, 0x66, 0x1f, 0xc4, 0xe6, 0x14, 0x18, 0xfd, 0x0e, 0x5c, 0x08,
-	0xe9, 0x06, 0xe8, 0x2f, 0xd8, 0x4c, 0x25, 0xd0, 0xde, 0x96, 0xc7, 0xc0, 0x9f, 0x92, 0xe3, 0x08,
-	0x8d, 0x99, 0xdf, 0x2c, 0x92, 0x96, 0xfc, 0x1f, 0x91, 0xb4, 0xd4, 0x7f, 0x4d, 0xd2, 0xc2, 0xa7,
-	0x59, 0x3a, 0x7a, 0x9a, 0xfd, 0x2b, 0x39, 0x5e, 0x93, 0x80, 0x72, 0x75, 0x6d, 0x83, 0xc8, 0xf3,
-	0x85, 0xb7, 0x51, 0x05, 0xd2, 0x03, 0xbb, 0x27, 0x4f, 0x11, 0xd6, 0x64, 0x5a, 0x01, 0x08, 0x2b,
-	0x12, 0x63, 0x83, 0xa3, 0x29, 0xcb, 0x23, 0x2c, 0x8f, 0xa6, 0x0a, 0xa4, 0x9f, 0x10, 0x01, 0x99,
-	0x4b, 0x98, 0x35, 0x99, 0x1e, 0xdf, 0x64, 0x1c, 0x08, 0x97, 0xb0, 0xe8, 0xa0, 0xbb, 0xa0, 0xf0,
-	0x32, 0x84, 0x66, 0x3b, 0x9e, 0x44, 0xb7, 0xd7, 0xc2, 0x73, 0x15, 0xd5, 0x86, 0x8d, 0x03, 0xa6,
-	0xb3, 0xef, 0x78, 0xb8, 0xe0, 0xc8, 0x56, 0xe8, 0xd4, 0x55, 0x22, 0xe4, 0xef, 0x32, 0x28, 0x6c,
-	0xf4, 0x9e, 0xa3, 0x77, 0x09, 0x87, 0x2a, 0x05, 0x8f, 0x05, 0xea, 0x63, 0x40, 0xd3, 0x80, 0x8b,
-	0x5a, 0x90, 0x23, 0xc7, 0xc4, 0xa2, 0x6c, 0xd9, 0x58, 0xb8, 0x2f, 0xce, 0x60, 0x56, 0xc4, 0xa2,
-	0xf5, 0x2a, 0x0b, 0xf2, 0x3f, 0xbf, 0x59, 0xaf, 0x08, 0xed, 0xb7, 0xed, 0xa1, 0x49, 0xc9, 0xd0,
-	0xa1, 0xa7, 0x58, 0xda, 0xab, 0x7f, 0x4b, 0x31, 0x9a, 0x13, 0x01, 0xe3, 0x99, 0xb1, 0xf5, 0xb7,
-	0x7c, 0x2a, 0x44, 0x71, 0x17, 0x8b, 0xf7, 0x1a, 0x40, 0x4f, 0xf7, 0xb4, 0xa7, 0xba, 0x45, 0x89,
-	0x21, 0x83, 0x1e, 0x92, 0xa0, 0x1a, 0x14, 0x58, 0x6f, 0xe4, 0x11, 0x43, 0xb2, 0xed, 0xa0, 0x1f,
-	0x9a, 0x67, 0xfe, 0xdb, 0xcd, 0x33, 0x1a, 0xe5, 0xc2, 0x44, 0x94, 0x43, 0x14, 0x44, 0x09, 0x53,
-	0x10, 0x36, 0x36, 0xc7, 0x35, 0x6d, 0xd7, 0xa4, 0xa7, 0x7c, 0x69, 0xd2, 0x38, 0xe8, 0xb3, 0xcb,
-	0xdb, 0x90, 0x0c, 0x1d, 0xdb, 0x1e, 0x68, 0x02, 0x6e, 0x8a, 0xdc, 0x74, 0x49, 0x0a, 0x9b, 0x1c,
-	0x75, 0x7e, 0x9d, 0x1a, 0xe7, 0xdf, 0x98, 0x6a, 0xfe, 0xdf, 0x05, 0x58, 0xfd, 0x0d, 0xbf, 0x80,
-	0x46, 0x8f, 0x5b, 0x74, 0x08, 0x2b, 0x41, 0xfa, 0x6b, 0x23, 0x0e, 0x0b, 0xfe, 0x86, 0x5e, 0x14,
-	0x3f, 0x2a, 0xc7, 0x51, 0xb1, 0x87, 0x3e, 0x86, 0x57, 0x27, 0xb0, 0x2d, 0x70, 0x9d, 0x5a, 0x14,
-	0xe2, 0x5e, 0x89, 0x42, 0x9c, 0xef, 0x7a, 0x1c, 0xac, 0xf4, 0xb7, 0xcc, 0xba, 0x1d, 0x76, 0xa7,
-	0x09, 0xb3, 0x87, 0x99, 0xcb, 0x7f, 0x0d, 0x4a, 0x2e, 0xa1, 0xec, 0x9e, 0x1d, 0xb9, 0x35, 0x2e,
-	0x09, 0xa1, 0xbc, 0x8b, 0x1e, 0xc0, 0x2b, 0x33, 0x59, 0x04, 0xfa, 0x1e, 0x28, 0x63, 0x02, 0x92,
-	0x8c, 0xb9, 0x80, 0x05, 0x97, 0x8a, 0xb1, 0xae, 0xfa, 0xc7, 0xe4, 0xd8, 0x65, 0xf4, 0x9a, 0xd2,
-	0x84, 0x9c, 0x4b, 0xbc, 0xd1, 0x40, 0x5c, 0x1c, 0xca, 0x5b, 0xef, 0x2c, 0xc6, 0x3f, 0x98, 0x74,
-	0x34, 0xa0, 0x58, 0x1a, 0xab, 0x8f, 0x21, 0x27, 0x24, 0xa8, 0x08, 0xf9, 0x87, 0x7b, 0x0f, 0xf6,
-	0xf6, 0x3f, 0xda, 0xab, 0x24, 0x10, 0x40, 0x6e, 0xbb, 0xd1, 0x68, 0x1e, 0xb4, 0x2b, 0x49, 0xa4,
-	0x40, 0x76, 0xbb, 0xbe, 0x8f, 0xdb, 0x95, 0x14, 0x13, 0xe3, 0xe6, 0x87, 0xcd, 0x46, 0xbb, 0x92,
-	0x46, 0x2b, 0x50, 0x12, 0x6d, 0xed, 0xfe, 0x3e, 0xfe, 0xc9, 0x76, 0xbb, 0x92, 0x09, 0x89, 0x0e,
-	0x9b, 0x7b, 0xf7, 0x9a, 0xb8, 0x92, 0x55, 0xdf, 0x65, 0x37, 0x93, 0x18, 0xc6, 0x32, 0xbe, 0x83,
-	0x24, 0x43, 0x77, 0x10, 0xf5, 0xb7, 0x29, 0xa8, 0xc5, 0xd3, 0x10, 0xf4, 0xe1, 0xc4, 0xc4, 0xb7,
-	0xce, 0xc1, 0x61, 0x26, 0x66, 0x8f, 0xae, 0x43, 0xd9, 0x25, 0x47, 0x84, 0x76, 0xfb, 0x82, 0x16,
-	0x89, 0x23, 0xb3, 0x84, 0x4b, 0x52, 0xca, 0x8d, 0x3c, 0xa1, 0xf6, 0x19, 0xe9, 0x52, 0x4d, 0x60,
-	0x91, 0xd8, 0x74, 0x0a, 0x53, 0x63, 0xd2, 0x43, 0x21, 0x54, 0x3f, 0x3d, 0x57, 0x2c, 0x15, 0xc8,
-	0xe2, 0x66, 0x1b, 0x7f, 0x5c, 0x49, 0x23, 0x04, 0x65, 0xde, 0xd4, 0x0e, 0xf7, 0xb6, 0x0f, 0x0e,
-	0x5b, 0xfb, 0x2c, 0x96, 0x17, 0x60, 0xd9, 0x8f, 0xa5, 0x2f, 0xcc, 0xaa, 0x9f, 0x40, 0x39, 0x7a,
-	0xf7, 0x67, 0x21, 0x74, 0xed, 0x91, 0x65, 0xf0, 0x60, 0x64, 0xb1, 0xe8, 0xa0, 0xdb, 0x90, 0x3d,
-	0xb6, 0x45, 0x9a, 0xcd, 0xde, 0x6b, 0x8f, 0x6c, 0x4a, 0x42, 0xb5, 0x03, 0xa1, 0xad, 0x7e, 0x01,
-	0x59, 0x9e, 0x35, 0x2c, 0x03, 0xf8, 0x2d, 0x5e, 0x92, 0x2a, 0xd6, 0x46, 0x9f, 0x00, 0xe8, 0x94,
-	0xba, 0x66, 0x67, 0x34, 0x76, 0xbc, 0x3e, 0x3b, 0xeb, 0xb6, 0x7d, 0xbd, 0xfa, 0x65, 0x99, 0x7e,
-	0xab, 0x63, 0xd3, 0x50, 0x0a, 0x86, 0x1c, 0xaa, 0x7b, 0x50, 0x8e, 0xda, 0xfa, 0x34, 0x40, 0x8c,
-	0x21, 0x4a, 0x03, 0x04, 0xab, 0x93, 0x34, 0x20, 0x20, 0x11, 0x69, 0x51, 0xb1, 0xe1, 0x1d, 0xf5,
-	0x59, 0x12, 0x0a, 0xed, 0x13, 0xb9, 0x1e, 0x31, 0xc5, 0x82, 0xb1, 0x69, 0x2a, 0x7c, 0x35, 0x16,
-	0xd5, 0x87, 0x74, 0x50, 0xd3, 0xf8, 0x20, 0xd8, 0x71, 0x99, 0x45, 0x6f, 0x40, 0x7e, 0x71, 0x47,
-	0x66, 0xd9, 0xfb, 0xa0, 0x04, 0x98, 0xc9, 0xd8, 0xa9, 0x6e, 0x18, 0x2e, 0xf1, 0x3c, 0xb9, 0xef,
-	0xfd, 0x2e, 0xaf, 0x3d, 0xd9, 0x4f, 0xe5, 0xe5, 0x3b, 0x8d, 0x45, 0x47, 0x35, 0x60, 0x79, 0x02,
-	0x70, 0xd1, 0xfb, 0x90, 0x77, 0x46, 0x1d, 0xcd, 0x0f, 0xcf, 0xc4, 0x5b, 0x83, 0xcf, 0x7b, 0x46,
-	0x9d, 0x81, 0xd9, 0x7d, 0x40, 0x4e, 0xfd, 0xc1, 0x38, 0xa3, 0xce, 0x03, 0x11, 0x45, 0xf1, 0x2b,
-	0xa9, 0xf0, 0xaf, 0x1c, 0x43, 0xc1, 0xdf, 0x14, 0xe8, 0x87, 0xa0, 0x04, 0x58, 0x1e, 0x94, 0x24,
-	0x63, 0x0f, 0x01, 0xe9, 0x7e, 0x6c, 0xc2, 0x48, 0xb4, 0x67, 0xf6, 0x2c, 0x62, 0x68, 0x63, 0x7e,
-	0xcc, 0x7f, 0xad, 0x80, 0x97, 0xc5, 0x87, 0x5d, 0x9f, 0x1c, 0xab, 0xff, 0x4e, 0x42, 0xc1, 0x2f,
-	0x3d, 0xa1, 0x77, 0x43, 0xfb, 0xae, 0x3c, 0xe3, 0xa2, 0xee, 0x2b, 0x8e, 0xcb, 0x47, 0xd1, 0xb1,
-	0xa6, 0xce, 0x3f, 0xd6, 0xb8, 0x3a, 0xa0, 0x5f, 0x91, 0xcd, 0x9c, 0xbb, 0x22, 0xfb, 0x36, 0x20,
-	0x6a, 0x53, 0x7d, 0xa0, 0x1d, 0xdb, 0xd4, 0xb4, 0x7a, 0x9a, 0x08, 0xb6, 0xe0, 0x02, 0x15, 0xfe,
-	0xe5, 0x11, 0xff, 0x70, 0xc0, 0xe3, 0xfe, 0xcb, 0x24, 0x14, 0x02, 0x50, 0x3f, 0x6f, 0x35, 0xe8,
-	0x22, 0xe4, 0x24, 0x6e, 0x89, 0x72, 0x90, 0xec, 0x05, 0x85, 0xc9, 0x4c, 0xa8, 0x30, 0x59, 0x83,
-	0xc2, 0x90, 0x50, 0x9d, 0x9f, 0x6c, 0xe2, 0x8a, 0x12, 0xf4, 0x6f, 0xbe, 0x07, 0xc5, 0x50, 0x61,
-	0x8e, 0x65, 0xde, 0x5e, 0xf3, 0xa3, 0x4a, 0xa2, 0x96, 0x7f, 0xf6, 0xe5, 0xd5, 0xf4, 0x1e, 0x79,
-	0xca, 0xf6, 0x2c, 0x6e, 0x36, 0x5a, 0xcd, 0xc6, 0x83, 0x4a, 0xb2, 0x56, 0x7c, 0xf6, 0xe5, 0xd5,
-	0x3c, 0x26, 0xbc, 0x48, 0x70, 0xb3, 0x05, 0x4b, 0xe1, 0x55, 0x89, 0x42, 0x1f, 0x82, 0xf2, 0xbd,
-	0x87, 0x07, 0xbb, 0x3b, 0x8d, 0xed, 0x76, 0x53, 0x7b, 0xb4, 0xdf, 0x6e, 0x56, 0x92, 0xe8, 0x55,
-	0xb8, 0xb0, 0xbb, 0xf3, 0xe3, 0x56, 0x5b, 0x6b, 0xec, 0xee, 0x34, 0xf7, 0xda, 0xda, 0x76, 0xbb,
-	0xbd, 0xdd, 0x78, 0x50, 0x49, 0x6d, 0xfd, 0x5e, 0x81, 0xe5, 0xed, 0x7a, 0x63, 0x87, 0xc1, 0xb6,
-	0xd9, 0xd5, 0xf9, 0xfd, 0xb1, 0x01, 0x19, 0x7e, 0x43, 0x3c, 0xf3, 0xd9, 0xae, 0x76, 0x76, 0xf9,
-	0x08, 0xdd, 0x87, 0x2c, 0xbf, 0x3c, 0xa2, 0xb3, 0xdf, 0xf1, 0x6a, 0x73, 0xea, 0x49, 0x6c, 0x30,
-	0x3c, 0x3d, 0xce, 0x7c, 0xd8, 0xab, 0x9d, 0x5d, 0x5e, 0x42, 0x18, 0x94, 0x31, 0xf9, 0x9c, 0xff,
-	0xd0, 0x55, 0x5b, 0x00, 0x6c, 0xd0, 0x2e, 0xe4, 0xfd, 0xfb, 0xc2, 0xbc, 0xa7, 0xb7, 0xda, 0xdc,
-	0xfa, 0x0f, 0x0b, 0x97, 0xb8, 0xd7, 0x9d, 0xfd, 0x8e, 0x58, 0x9b, 0x53, 0xcc, 0x42, 0x3b, 0x90,
-	0x93, 0x84, 0x6a, 0xce, 0x73, 0x5a, 0x6d, 0x5e, 0x3d, 0x87, 0x05, 0x6d, 0x7c, 0x63, 0x9e, 0xff,
-	0x3a, 0x5a, 0x5b, 0xa0, 0x4e, 0x87, 0x1e, 0x02, 0x84, 0x6e, 0x71, 0x0b, 0x3c, 0x7b, 0xd6, 0x16,
-	0xa9, 0xbf, 0xa1, 0x7d, 0x28, 0x04, 0xa4, 0x7a, 0xee, 0x23, 0x64, 0x6d, 0x7e, 0x21, 0x0c, 0x3d,
-	0x86, 0x52, 0x94, 0x4c, 0x2e, 0xf6, 0xb4, 0x58, 0x5b, 0xb0, 0xc2, 0xc5, 0xfc, 0x47, 0x99, 0xe5,
-	0x62, 0x4f, 0x8d, 0xb5, 0x05, 0x0b, 0x5e, 0xe8, 0x33, 0x58, 0x99, 0x66, 0x7e, 0x8b, 0xbf, 0x3c,
-	0xd6, 0xce, 0x51, 0x02, 0x43, 0x43, 0x40, 0x33, 0x18, 0xe3, 0x39, 0x1e, 0x22, 0x6b, 0xe7, 0xa9,
-	0x88, 0xd5, 0x9b, 0x5f, 0xbd, 0x58, 0x4b, 0x7e, 0xfd, 0x62, 0x2d, 0xf9, 0x8f, 0x17, 0x6b, 0xc9,
-	0xe7, 0x2f, 0xd7, 0x12, 0x5f, 0xbf, 0x5c, 0x4b, 0xfc, 0xf5, 0xe5, 0x5a, 0xe2, 0x67, 0x6f, 0xf5,
-	0x4c, 0xda, 0x1f, 0x75, 0x36, 0xba, 0xf6, 0x70, 0x33, 0xfc, 0x0f, 0x87, 0x59, 0xff, 0xba, 0xe8,
-	0xe4, 0xf8, 0xa1, 0x72, 0xeb, 0x3f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x92, 0xa5, 0x39, 0xcc, 0x95,
-	0x21, 0x00, 0x00,
+	// 2784 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x5a, 0xcf, 0x73, 0x23, 0xc5,
+	0xf5, 0xd7, 0x4f, 0x5b, 0xf3, 0xf4, 0xc3, 0x72, 0xaf, 0x59, 0xb4, 0xc3, 0x62, 0x9b, 0xe1, 0x0b,
+	0x5f, 0x58, 0xc0, 0x0e, 0xa6, 0x20, 0x50, 0x84, 0x04, 0x4b, 0xab, 0x8d, 0xcc, 0x1a, 0xdb, 0x69,
+	0x6b, 0x97, 0x10, 0x60, 0x87, 0xd1, 0x4c, 0xdb, 0x1a, 0x56, 0x9a, 0x19, 0x66, 0x46, 0xc6, 0xe6,
+	0x98, 0xca, 0x89, 0xe2, 0xc0, 0x31, 0x55, 0x29, 0xfe, 0x8f, 0x9c, 0x72, 0x22, 0x55, 0x1c, 0x72,
+	0xe0, 0x98, 0x13, 0x49, 0xc1, 0x8d, 0x4b, 0x8e, 0x39, 0xa5, 0x2a, 0xd5, 0xbf, 0x46, 0x33, 0x92,
+	0xc6, 0x92, 0x43, 0x6e, 0xb9, 0x75, 0x3f, 0xbd, 0xf7, 0xa6, 0xfb, 0x75, 0xf7, 0xe7, 0x7d, 0xfa,
+	0xb5, 0xe0, 0xb1, 0x90, 0x38, 0x16, 0xf1, 0x87, 0xb6, 0x13, 0x6e, 0x1b, 0x3d, 0xd3, 0xde, 0x0e,
+	0x2f, 0x3c, 0x12, 0x6c, 0x79, 0xbe, 0x1b, 0xba, 0x68, 0x65, 0xfc, 0xe3, 0x16, 0xfd, 0x51, 0x7d,
+	0x3c, 0xa6, 0x6d, 0xfa, 0x17, 0x5e, 0xe8, 0x6e, 0x7b, 0xbe, 0xeb, 0x9e, 0x70, 0x7d, 0xf5, 0x66,
+	0xec, 0x67, 0xe6, 0x27, 0xee, 0x4d, 0xbd, 0x39, 0x6d, 0xfc, 0x90, 0x5c, 0xc8, 0x5f, 0x1f, 0x9f,
+	0xb2, 0xf5, 0x0c, 0xdf, 0x18, 0xca, 0x9f, 0x37, 0x4e, 0x5d, 0xf7, 0x74, 0x40, 0xb6, 0x59, 0xaf,
+	0x37, 0x3a, 0xd9, 0x0e, 0xed, 0x21, 0x09, 0x42, 0x63, 0xe8, 0x09, 0x85, 0xb5, 0x53, 0xf7, 0xd4,
+	0x65, 0xcd, 0x6d, 0xda, 0xe2, 0x52, 0xed, 0x0f, 0x25, 0x58, 0xc6, 0xe4, 0xe3, 0x11, 0x09, 0x42,
+	0xb4, 0x03, 0x05, 0x62, 0xf6, 0xdd, 0x46, 0x76, 0x33, 0xfb, 0x4c, 0x79, 0xe7, 0xe6, 0xd6, 0xc4,
+	0xe4, 0xb6, 0x84, 0x5e, 0xdb, 0xec, 0xbb, 0x9d, 0x0c, 0x66, 0xba, 0xe8, 0x65, 0x28, 0x9e, 0x0c,
+	0x46, 0x41, 0xbf, 0x91, 0x63, 0x46, 0x8f, 0xa7, 0x19, 0xdd, 0xa1, 0x4a, 0x9d, 0x0c, 0xe6, 0xda,
+	0xf4, 0x53, 0xb6, 0x73, 0xe2, 0x36, 0xf2, 0x97, 0x7f, 0x6a, 0xcf, 0x39, 0x61, 0x9f, 0xa2, 0xba,
+	0xa8, 0x09, 0x60, 0x3b, 0x76, 0xa8, 0x9b, 0x7d, 0xc3, 0x76, 0x1a, 0x05, 0x66, 0xf9, 0x44, 0xba,
+	0xa5, 0x1d, 0xb6, 0xa8, 0x62, 0x27, 0x83, 0x15, 0x5b, 0x76, 0xe8, 0x70, 0x3f, 0x1e, 0x11, 0xff,
+	0xa2, 0x51, 0xbc, 0x7c, 0xb8, 0xbf, 0xa2, 0x4a, 0x74, 0xb8, 0x4c, 0x1b, 0xb5, 0xa1, 0xdc, 0x23,
+	0xa7, 0xb6, 0xa3, 0xf7, 0x06, 0xae, 0xf9, 0xb0, 0xb1, 0xc4, 0x8c, 0xb5, 0x34, 0xe3, 0x26, 0x55,
+	0x6d, 0x52, 0xcd, 0x4e, 0x06, 0x43, 0x2f, 0xea, 0xa1, 0x9f, 0x41, 0xc9, 0xec, 0x13, 0xf3, 0xa1,
+	0x1e, 0x9e, 0x37, 0x96, 0x99, 0x8f, 0x8d, 0x34, 0x1f, 0x2d, 0xaa, 0xd7, 0x3d, 0xef, 0x64, 0xf0,
+	0xb2, 0xc9, 0x9b, 0x74, 0xfe, 0x16, 0x19, 0xd8, 0x67, 0xc4, 0xa7, 0xf6, 0xa5, 0xcb, 0xe7, 0x7f,
+	0x9b, 0x6b, 0x32, 0x0f, 0x8a, 0x25, 0x3b, 0xe8, 0x17, 0xa0, 0x10, 0xc7, 0x12, 0xd3, 0x50, 0x98,
+	0x8b, 0xcd, 0xd4, 0x75, 0x76, 0x2c, 0x39, 0x89, 0x12, 0x11, 0x6d, 0xf4, 0x2a, 0x2c, 0x99, 0xee,
+	0x70, 0x68, 0x87, 0x0d, 0x60, 0xd6, 0xeb, 0xa9, 0x13, 0x60, 0x5a, 0x9d, 0x0c, 0x16, 0xfa, 0xe8,
+	0x00, 0x6a, 0x03, 0x3b, 0x08, 0xf5, 0xc0, 0x31, 0xbc, 0xa0, 0xef, 0x86, 0x41, 0xa3, 0xcc, 0x3c,
+	0x3c, 0x95, 0xe6, 0x61, 0xdf, 0x0e, 0xc2, 0x63, 0xa9, 0xdc, 0xc9, 0xe0, 0xea, 0x20, 0x2e, 0xa0,
+	0xfe, 0xdc, 0x93, 0x13, 0xe2, 0x47, 0x0e, 0x1b, 0x95, 0xcb, 0xfd, 0x1d, 0x52, 0x6d, 0x69, 0x4f,
+	0xfd, 0xb9, 0x71, 0x01, 0x7a, 0x0f, 0xae, 0x0d, 0x5c, 0xc3, 0x8a, 0xdc, 0xe9, 0x66, 0x7f, 0xe4,
+	0x3c, 0x6c, 0x54, 0x99, 0xd3, 0x67, 0x53, 0x07, 0xe9, 0x1a, 0x96, 0x74, 0xd1, 0xa2, 0x06, 0x9d,
+	0x0c, 0x5e, 0x1d, 0x4c, 0x0a, 0xd1, 0x03, 0x58, 0x33, 0x3c, 0x6f, 0x70, 0x31, 0xe9, 0xbd, 0xc6,
+	0xbc, 0xdf, 0x4a, 0xf3, 0xbe, 0x4b, 0x6d, 0x26, 0xdd, 0x23, 0x63, 0x4a, 0x8a, 0xf6, 0xa1, 0x26,
+	0x77, 0x96, 0xde, 0x33, 0x42, 0xb3, 0xdf, 0x58, 0x61, 0x9e, 0xff, 0x6f, 0xce, 0xfe, 0x6a, 0x52,
+	0xdd, 0x4e, 0x06, 0x57, 0xcc, 0x58, 0xbf, 0xb9, 0x0c, 0xc5, 0x33, 0x63, 0x30, 0x22, 0xda, 0xff,
+	0x43, 0x39, 0x76, 0xe8, 0x51, 0x03, 0x96, 0x87, 0x24, 0x08, 0x8c, 0x53, 0xc2, 0x30, 0x42, 0xc1,
+	0xb2, 0xab, 0xd5, 0xa0, 0x12, 0x3f, 0xe8, 0xda, 0x17, 0x59, 0x28, 0xc7, 0xce, 0x30, 0xb5, 0x3c,
+	0x23, 0x7e, 0x60, 0xbb, 0x8e, 0xb4, 0x14, 0x5d, 0xf4, 0x24, 0x54, 0xd9, 0x6e, 0xd4, 0xe5, 0xef,
+	0x14, 0x48, 0x0a, 0xb8, 0xc2, 0x84, 0xf7, 0x85, 0xd2, 0x06, 0x94, 0xbd, 0x1d, 0x2f, 0x52, 0xc9,
+	0x33, 0x15, 0xf0, 0x76, 0x3c, 0xa9, 0xf0, 0x04, 0x54, 0xe8, 0xec, 0x22, 0x8d, 0x02, 0xfb, 0x48,
+	0x99, 0xca, 0x84, 0x8a, 0xf6, 0x97, 0x1c, 0xd4, 0x27, 0xc1, 0x01, 0xbd, 0x0a, 0x05, 0x8a, 0x93,
+	0x02, 0xf2, 0xd4, 0x2d, 0x0e, 0xa2, 0x5b, 0x12, 0x44, 0xb7, 0xba, 0x12, 0x44, 0x9b, 0xa5, 0xaf,
+	0xbf, 0xdd, 0xc8, 0x7c, 0xf1, 0xb7, 0x8d, 0x2c, 0x66, 0x16, 0xe8, 0x06, 0x3d, 0xcb, 0x86, 0xed,
+	0xe8, 0xb6, 0xc5, 0x86, 0xac, 0xd0, 0x83, 0x6a, 0xd8, 0xce, 0x9e, 0x85, 0xf6, 0xa1, 0x6e, 0xba,
+	0x4e, 0x40, 0x9c, 0x60, 0x14, 0xe8, 0x1c, 0xa4, 0x1b, 0xf9, 0xe9, 0xe3, 0xca, 0xa1, 0xbf, 0x25,
+	0x35, 0x8f, 0x98, 0x22, 0x5e, 0x31, 0x93, 0x02, 0x74, 0x07, 0xe0, 0xcc, 0x18, 0xd8, 0x96, 0x11,
+	0xba, 0x7e, 0xd0, 0x28, 0x6c, 0xe6, 0x67, 0x9e, 0xd9, 0xfb, 0x52, 0xe5, 0x9e, 0x67, 0x19, 0x21,
+	0x69, 0x16, 0xe8, 0x70, 0x71, 0xcc, 0x12, 0x3d, 0x0d, 0x2b, 0x86, 0xe7, 0xe9, 0x41, 0x68, 0x84,
+	0x44, 0xef, 0x5d, 0x84, 0x24, 0x60, 0x20, 0x58, 0xc1, 0x55, 0xc3, 0xf3, 0x8e, 0xa9, 0xb4, 0x49,
+	0x85, 0xe8, 0x29, 0xa8, 0x51, 0xbc, 0xb4, 0x8d, 0x81, 0xde, 0x27, 0xf6, 0x69, 0x3f, 0x64, 0x70,
+	0x97, 0xc7, 0x55, 0x21, 0xed, 0x30, 0xa1, 0x66, 0x41, 0x25, 0x8e, 0x95, 0x08, 0x41, 0xc1, 0x32,
+	0x42, 0x83, 0x45, 0xb2, 0x82, 0x59, 0x9b, 0xca, 0x3c, 0x23, 0xec, 0x8b, 0xf8, 0xb0, 0x36, 0xba,
+	0x0e, 0x4b, 0xc2, 0x6d, 0x9e, 0xb9, 0x15, 0x3d, 0xb4, 0x06, 0x45, 0xcf, 0x77, 0xcf, 0x08, 0x5b,
+	0xba, 0x12, 0xe6, 0x1d, 0xed, 0x77, 0x39, 0x58, 0x9d, 0x42, 0x55, 0xea, 0xb7, 0x6f, 0x04, 0x7d,
+	0xf9, 0x2d, 0xda, 0x46, 0xaf, 0x50, 0xbf, 0x86, 0x45, 0x7c, 0x91, 0x89, 0x1a, 0xd3, 0xa1, 0xee,
+	0xb0, 0xdf, 0x45, 0x68, 0x84, 0x36, 0x3a, 0x84, 0xfa, 0xc0, 0x08, 0x42, 0x9d, 0xa3, 0x94, 0x1e,
+	0xcb, 0x4a, 0xd3, 0xd8, 0xbc, 0x6f, 0x48, 0x5c, 0xa3, 0x9b, 0x5a, 0x38, 0xaa, 0x0d, 0x12, 0x52,
+	0x84, 0x61, 0xad, 0x77, 0xf1, 0xa9, 0xe1, 0x84, 0xb6, 0x43, 0xf4, 0xa9, 0x95, 0xbb, 0x31, 0xe5,
+	0xb4, 0x7d, 0x66, 0x5b, 0xc4, 0x31, 0xe5, 0x92, 0x5d, 0x8b, 0x8c, 0xa3, 0x25, 0x0d, 0x34, 0x0c,
+	0xb5, 0xe4, 0xb9, 0x45, 0x35, 0xc8, 0x85, 0xe7, 0x22, 0x00, 0xb9, 0xf0, 0x1c, 0xfd, 0x04, 0x0a,
+	0x74, 0x92, 0x6c, 0xf2, 0xb5, 0x19, 0x09, 0x55, 0xd8, 0x75, 0x2f, 0x3c, 0x82, 0x99, 0xa6, 0xa6,
+	0x41, 0x7d, 0x32, 0x57, 0x4c, 0x7a, 0xd5, 0x9e, 0x85, 0x95, 0x89, 0x64, 0x10, 0x5b, 0xbf, 0x6c,
+	0x7c, 0xfd, 0xb4, 0x15, 0xa8, 0x26, 0x90, 0x5f, 0xbb, 0x0e, 0x6b, 0xb3, 0x80, 0x5c, 0xeb, 0xc3,
+	0xda, 0x2c, 0x40, 0x46, 0x2f, 0x43, 0x29, 0x42, 0x72, 0x7e, 0x1c, 0xa7, 0x63, 0x25, 0x95, 0x71,
+	0xa4, 0x4a, 0xcf, 0x21, 0xdd, 0xd6, 0x6c, 0x3f, 0xe4, 0xd8, 0xc0, 0x97, 0x0d, 0xcf, 0xeb, 0x18,
+	0x41, 0x5f, 0xfb, 0x10, 0x1a, 0x69, 0x28, 0x3d, 0x31, 0x8d, 0x42, 0xb4, 0x0d, 0xaf, 0xc3, 0xd2,
+	0x89, 0xeb, 0x0f, 0x8d, 0x90, 0x39, 0xab, 0x62, 0xd1, 0xa3, 0xdb, 0x93, 0x23, 0x76, 0x9e, 0x89,
+	0x79, 0x47, 0xd3, 0xe1, 0x46, 0x2a, 0x52, 0x53, 0x13, 0xdb, 0xb1, 0x08, 0x8f, 0x67, 0x15, 0xf3,
+	0xce, 0xd8, 0x11, 0x1f, 0x2c, 0xef, 0xd0, 0xcf, 0x06, 0x6c, 0xae, 0xcc, 0xbf, 0x82, 0x45, 0x4f,
+	0xfb, 0x35, 0x5c, 0x9b, 0x01, 0xd8, 0x68, 0x17, 0x4a, 0x3e, 0x17, 0x07, 0x8d, 0xec, 0x66, 0x7e,
+	0xe6, 0x66, 0x9d, 0xb0, 0xe3, 0xbb, 0x2b, 0x32, 0xd3, 0xfe, 0x51, 0x82, 0x12, 0x26, 0x81, 0x47,
+	0xd1, 0x06, 0x35, 0x41, 0x21, 0xe7, 0x26, 0xf1, 0x42, 0x09, 0xd0, 0xb3, 0xd9, 0x0d, 0xd7, 0x6e,
+	0x4b, 0x4d, 0x4a, 0x2d, 0x22, 0x33, 0xf4, 0x92, 0x60, 0x8f, 0xe9, 0x44, 0x50, 0x98, 0xc7, 0xe9,
+	0xe3, 0x2b, 0x92, 0x3e, 0xe6, 0x53, 0xd9, 0x04, 0xb7, 0x9a, 0xe0, 0x8f, 0x2f, 0x09, 0xfe, 0x58,
+	0x98, 0xf3, 0xb1, 0x04, 0x81, 0x6c, 0x25, 0x08, 0x64, 0x71, 0xce, 0x34, 0x53, 0x18, 0xe4, 0x2b,
+	0x92, 0x41, 0x2e, 0xcd, 0x19, 0xf1, 0x04, 0x85, 0xbc, 0x93, 0xa4, 0x90, 0x9c, 0xfe, 0x3d, 0x99,
+	0x6a, 0x9d, 0xca, 0x21, 0xdf, 0x88, 0x71, 0xc8, 0x52, 0x2a, 0x81, 0xe3, 0x4e, 0x66, 0x90, 0xc8,
+	0x56, 0x82, 0x44, 0x2a, 0x73, 0x62, 0x90, 0xc2, 0x22, 0xdf, 0x8c, 0xb3, 0x48, 0x48, 0x25, 0xa2,
+	0x62, 0xbd, 0x67, 0xd1, 0xc8, 0xd7, 0x22, 0x1a, 0x59, 0x4e, 0xe5, 0xc1, 0x62, 0x0e, 0x93, 0x3c,
+	0xf2, 0x70, 0x8a, 0x47, 0x72, 0xde, 0xf7, 0x74, 0xaa, 0x8b, 0x39, 0x44, 0xf2, 0x70, 0x8a, 0x48,
+	0x56, 0xe7, 0x38, 0x9c, 0xc3, 0x24, 0xdf, 0x9f, 0xcd, 0x24, 0xd3, 0xb9, 0x9e, 0x18, 0xe6, 0x62,
+	0x54, 0x52, 0x4f, 0xa1, 0x92, 0x9c, 0xf0, 0x3d, 0x97, 0xea, 0x7e, 0x61, 0x2e, 0xf9, 0xf6, 0x14,
+	0x97, 0xac, 0xa7, 0x12, 0xeb, 0xc4, 0x3e, 0x9b, 0x43, 0x26, 0x9f, 0x85, 0x55, 0x69, 0x10, 0x41,
+	0x08, 0x85, 0x43, 0xe2, 0xfb, 0xae, 0x2f, 0x68, 0x21, 0xef, 0x68, 0xcf, 0x40, 0x25, 0x52, 0xbd,
+	0x9c, 0x78, 0xb2, 0xb4, 0x13, 0x83, 0x08, 0xed, 0x8f, 0x59, 0xa8, 0xc4, 0x4f, 0x7f, 0x82, 0x98,
+	0x28, 0x82, 0x98, 0xc4, 0xe8, 0x68, 0x2e, 0x49, 0x47, 0x37, 0xa0, 0x4c, 0xd3, 0xc9, 0x04, 0xd3,
+	0x34, 0xbc, 0x88, 0x69, 0xde, 0x82, 0x55, 0xc6, 0x17, 0x38, 0x69, 0x15, 0x39, 0xa4, 0xc0, 0x52,
+	0xe1, 0x0a, 0xfd, 0x81, 0xef, 0x75, 0x26, 0x46, 0x2f, 0xc0, 0xb5, 0x98, 0x6e, 0x94, 0xa6, 0x38,
+	0xed, 0xaa, 0x47, 0xda, 0xbb, 0x22, 0x5f, 0x7d, 0x95, 0x85, 0xd5, 0x29, 0xf4, 0x99, 0xc9, 0x26,
+	0xb3, 0xff, 0x25, 0x36, 0x99, 0xfb, 0x8f, 0xd9, 0x64, 0x3c, 0xed, 0xe6, 0x93, 0x69, 0xf7, 0x9f,
+	0x59, 0xa8, 0x26, 0x40, 0x90, 0x2e, 0x81, 0xe9, 0x5a, 0x44, 0x24, 0x42, 0xd6, 0x46, 0x75, 0xc8,
+	0x0f, 0xdc, 0x53, 0x91, 0xee, 0x68, 0x93, 0x6a, 0x45, 0x98, 0xae, 0x08, 0xc8, 0x8e, 0x72, 0x68,
+	0x91, 0x45, 0x98, 0x77, 0xa8, 0xed, 0x43, 0xc2, 0x11, 0xb8, 0x82, 0x69, 0x13, 0xad, 0x89, 0x4d,
+	0xc6, 0x70, 0xb5, 0x82, 0x79, 0x07, 0xbd, 0x0a, 0x0a, 0xab, 0xbe, 0xe8, 0xae, 0x17, 0x08, 0xb0,
+	0x7c, 0x2c, 0x3e, 0x57, 0x5e, 0x64, 0xd9, 0x3a, 0xa2, 0x3a, 0x87, 0x5e, 0x80, 0x4b, 0x9e, 0x68,
+	0xc5, 0xe8, 0x81, 0x92, 0x60, 0xa9, 0x37, 0x41, 0xa1, 0xa3, 0x0f, 0x3c, 0xc3, 0x24, 0x0c, 0xf9,
+	0x14, 0x3c, 0x16, 0x68, 0x0f, 0x00, 0x4d, 0xe3, 0x37, 0xea, 0xc0, 0x12, 0x39, 0x23, 0x4e, 0x94,
+	0xaa, 0xaf, 0xcf, 0xa0, 0x80, 0xc4, 0x09, 0x9b, 0x0d, 0x1a, 0xe4, 0x1f, 0xbe, 0xdd, 0xa8, 0x73,
+	0xed, 0xe7, 0xdd, 0xa1, 0x1d, 0x92, 0xa1, 0x17, 0x5e, 0x60, 0x61, 0xaf, 0xfd, 0x39, 0x0f, 0x2b,
+	0xf2, 0x03, 0x92, 0x08, 0xce, 0x8a, 0xad, 0xdc, 0xf2, 0xb9, 0x18, 0x17, 0x5f, 0x2c, 0xde, 0xeb,
+	0x00, 0xa7, 0x46, 0xa0, 0x7f, 0x62, 0x38, 0x21, 0xb1, 0x44, 0xd0, 0x63, 0x12, 0xa4, 0x42, 0x89,
+	0xf6, 0x46, 0x01, 0xb1, 0xc4, 0xb5, 0x20, 0xea, 0xc7, 0xe6, 0xb9, 0xfc, 0xe3, 0xe6, 0x99, 0x8c,
+	0x72, 0x69, 0x22, 0xca, 0x31, 0xae, 0xa4, 0xc4, 0xb9, 0x12, 0x1d, 0x9b, 0xe7, 0xdb, 0xae, 0x6f,
+	0x87, 0x17, 0x6c, 0x69, 0xf2, 0x38, 0xea, 0xd3, 0x5b, 0xe6, 0x90, 0x0c, 0x3d, 0xd7, 0x1d, 0xe8,
+	0x1c, 0x6e, 0xca, 0xcc, 0xb4, 0x22, 0x84, 0x6d, 0x2a, 0xa3, 0x9b, 0xc8, 0x71, 0x1d, 0x93, 0xb0,
+	0x84, 0x52, 0xc0, 0xbc, 0x43, 0xc3, 0x34, 0x30, 0x1c, 0xc2, 0x92, 0x82, 0x82, 0x59, 0x1b, 0xbd,
+	0x01, 0x15, 0x9f, 0x70, 0x90, 0xa4, 0x15, 0xba, 0x46, 0x6d, 0x33, 0xff, 0x4c, 0xa5, 0xa9, 0xfe,
+	0xf0, 0xed, 0xc6, 0xf5, 0xb8, 0x3c, 0x36, 0xb5, 0xb2, 0x90, 0xdf, 0x25, 0x17, 0x81, 0xf6, 0x55,
+	0x0e, 0x56, 0xa7, 0x52, 0xec, 0xff, 0xe0, 0x4a, 0xbe, 0x01, 0x95, 0x11, 0x03, 0x18, 0x8b, 0x87,
+	0x51, 0x19, 0x87, 0x31, 0x2e, 0x8f, 0x87, 0x51, 0xc8, 0x59, 0x18, 0x3f, 0x67, 0x37, 0xfa, 0x24,
+	0xcb, 0x40, 0xc7, 0xb0, 0x1a, 0xc1, 0x94, 0xce, 0xb5, 0xe5, 0xc1, 0x5b, 0x14, 0xe7, 0xea, 0x67,
+	0x49, 0x71, 0x80, 0xde, 0x85, 0x47, 0x27, 0x30, 0x38, 0x72, 0x9d, 0x5b, 0x14, 0x8a, 0x1f, 0x49,
+	0x42, 0xb1, 0x74, 0x3d, 0x8e, 0x75, 0xfe, 0x47, 0xa2, 0xc3, 0x1e, 0xd4, 0x64, 0x34, 0x38, 0x69,
+	0x9a, 0xb9, 0x7b, 0x9e, 0x84, 0xaa, 0x4f, 0x42, 0x5a, 0xb8, 0x48, 0x5c, 0xc3, 0x2b, 0x5c, 0x28,
+	0x2e, 0xf7, 0x47, 0xf0, 0xc8, 0x4c, 0xf2, 0x84, 0x7e, 0x0a, 0xca, 0x98, 0x77, 0x65, 0x53, 0x6e,
+	0xb4, 0x52, 0x1d, 0x8f, 0x75, 0xb5, 0x3f, 0x65, 0xe1, 0x91, 0x99, 0xf4, 0x09, 0xb5, 0x61, 0xc9,
+	0x27, 0xc1, 0x68, 0xc0, 0x6f, 0x62, 0xb5, 0x9d, 0x17, 0x16, 0xa3, 0x5d, 0x54, 0x3a, 0x1a, 0x84,
+	0x58, 0x18, 0x6b, 0x0f, 0x60, 0x89, 0x4b, 0x50, 0x19, 0x96, 0xef, 0x1d, 0xdc, 0x3d, 0x38, 0x7c,
+	0xe7, 0xa0, 0x9e, 0x41, 0x00, 0x4b, 0xbb, 0xad, 0x56, 0xfb, 0xa8, 0x5b, 0xcf, 0x22, 0x05, 0x8a,
+	0xbb, 0xcd, 0x43, 0xdc, 0xad, 0xe7, 0xa8, 0x18, 0xb7, 0xdf, 0x6a, 0xb7, 0xba, 0xf5, 0x3c, 0x5a,
+	0x85, 0x2a, 0x6f, 0xeb, 0x77, 0x0e, 0xf1, 0xdb, 0xbb, 0xdd, 0x7a, 0x21, 0x26, 0x3a, 0x6e, 0x1f,
+	0xdc, 0x6e, 0xe3, 0x7a, 0x51, 0x7b, 0x11, 0x6e, 0xc8, 0x71, 0x4c, 0xdf, 0x26, 0xa3, 0x4b, 0x5d,
+	0x36, 0x76, 0xa9, 0xd3, 0x7e, 0x9f, 0x03, 0x35, 0x9d, 0x7d, 0xa1, 0xb7, 0x26, 0x26, 0xbe, 0x73,
+	0x05, 0xea, 0x36, 0x31, 0x7b, 0x5a, 0xb4, 0xf1, 0xc9, 0x09, 0x09, 0xcd, 0x3e, 0x67, 0x83, 0x3c,
+	0xb5, 0x57, 0x71, 0x55, 0x48, 0x99, 0x51, 0xc0, 0xd5, 0x3e, 0x22, 0x66, 0xa8, 0x73, 0xcc, 0xe4,
+	0x9b, 0x4e, 0xc1, 0x55, 0x2e, 0x3d, 0xe6, 0x42, 0xed, 0xc3, 0x2b, 0xc5, 0x52, 0x81, 0x22, 0x6e,
+	0x77, 0xf1, 0xbb, 0xf5, 0x3c, 0x42, 0x50, 0x63, 0x4d, 0xfd, 0xf8, 0x60, 0xf7, 0xe8, 0xb8, 0x73,
+	0x48, 0x63, 0x79, 0x0d, 0x56, 0x64, 0x2c, 0xa5, 0xb0, 0xa8, 0xbd, 0x0f, 0x6b, 0x72, 0x72, 0x89,
+	0x8b, 0xed, 0x6d, 0x50, 0x7c, 0x21, 0x4f, 0x3f, 0xb5, 0x93, 0x96, 0xfc, 0xd4, 0x8e, 0x0d, 0xb5,
+	0x0f, 0xa0, 0x96, 0x2c, 0xd5, 0xd0, 0x05, 0xf2, 0xdd, 0x91, 0x63, 0xb1, 0x50, 0x17, 0x31, 0xef,
+	0xd0, 0xd7, 0x80, 0x33, 0x97, 0x1f, 0xe2, 0xd9, 0x3b, 0xf9, 0xbe, 0x1b, 0x92, 0x58, 0xa9, 0x87,
+	0x6b, 0x6b, 0x9f, 0x42, 0x91, 0x9d, 0x49, 0x7a, 0xbe, 0x58, 0xd1, 0x45, 0x50, 0x4b, 0xda, 0x46,
+	0x1f, 0x00, 0x18, 0x61, 0xe8, 0xdb, 0xbd, 0xd1, 0xd8, 0xf1, 0xc6, 0xec, 0x33, 0xbd, 0x2b, 0xf5,
+	0x9a, 0x37, 0xc5, 0xe1, 0x5e, 0x1b, 0x9b, 0xc6, 0x0e, 0x78, 0xcc, 0xa1, 0x76, 0x00, 0xb5, 0xa4,
+	0xad, 0x24, 0x43, 0x7c, 0x0c, 0x49, 0x32, 0xc4, 0xb9, 0x2d, 0xef, 0x8c, 0xa9, 0x54, 0x9e, 0x17,
+	0xd8, 0x58, 0x47, 0xfb, 0x2c, 0x0b, 0xa5, 0xee, 0xb9, 0x58, 0xed, 0x94, 0xda, 0xce, 0xd8, 0x34,
+	0x17, 0xaf, 0x64, 0xf0, 0x62, 0x51, 0x3e, 0x2a, 0x41, 0xbd, 0x19, 0xed, 0xe7, 0xc2, 0xa2, 0xd7,
+	0x4a, 0x59, 0x8b, 0x13, 0x67, 0xf8, 0x75, 0x50, 0x22, 0x44, 0xa6, 0x1c, 0xdd, 0xb0, 0x2c, 0x9f,
+	0x04, 0x81, 0x38, 0x55, 0xb2, 0x4b, 0x87, 0xe3, 0xb9, 0x9f, 0x88, 0x5a, 0x49, 0x1e, 0xf3, 0x8e,
+	0x66, 0xc1, 0xca, 0x04, 0x9c, 0xa3, 0xd7, 0x61, 0xd9, 0x1b, 0xf5, 0x74, 0x19, 0x9e, 0x89, 0x87,
+	0x26, 0xc9, 0xfe, 0x46, 0xbd, 0x81, 0x6d, 0xde, 0x25, 0x17, 0x72, 0x30, 0xde, 0xa8, 0x77, 0x97,
+	0x47, 0x91, 0x7f, 0x25, 0x17, 0xff, 0xca, 0x19, 0x94, 0xe4, 0xa6, 0x40, 0x3f, 0x07, 0x25, 0xca,
+	0x14, 0x51, 0x05, 0x39, 0x35, 0xc5, 0xc8, 0x6d, 0x1a, 0x99, 0xd0, 0xab, 0x44, 0x60, 0x9f, 0x3a,
+	0xc4, 0xd2, 0xc7, 0xb7, 0x04, 0xf6, 0xb5, 0x12, 0x5e, 0xe1, 0x3f, 0xec, 0xcb, 0x2b, 0x82, 0xf6,
+	0xaf, 0x2c, 0x94, 0x64, 0xa5, 0x10, 0xbd, 0x18, 0xdb, 0x77, 0xb5, 0x19, 0xd5, 0x0f, 0xa9, 0x38,
+	0xae, 0xf6, 0x25, 0xc7, 0x9a, 0xbb, 0xfa, 0x58, 0xd3, 0xca, 0xb6, 0xb2, 0x80, 0x5e, 0xb8, 0x72,
+	0x01, 0xfd, 0x79, 0x40, 0xa1, 0x1b, 0x1a, 0x03, 0xfd, 0xcc, 0x0d, 0x6d, 0xe7, 0x54, 0xe7, 0xc1,
+	0xe6, 0x44, 0xa5, 0xce, 0x7e, 0xb9, 0xcf, 0x7e, 0x38, 0x62, 0x71, 0xff, 0x6d, 0x16, 0x4a, 0x51,
+	0xca, 0xb8, 0x6a, 0xf1, 0xee, 0x3a, 0x2c, 0x09, 0x54, 0xe4, 0xd5, 0x3b, 0xd1, 0x8b, 0xea, 0xc8,
+	0x85, 0x58, 0x1d, 0x59, 0x85, 0xd2, 0x90, 0x84, 0x06, 0xcb, 0x9b, 0xfc, 0xa2, 0x16, 0xf5, 0x6f,
+	0xbd, 0x06, 0xe5, 0x58, 0x1d, 0x95, 0x9e, 0xbc, 0x83, 0xf6, 0x3b, 0xf5, 0x8c, 0xba, 0xfc, 0xd9,
+	0x97, 0x9b, 0xf9, 0x03, 0xf2, 0x09, 0xdd, 0xb3, 0xb8, 0xdd, 0xea, 0xb4, 0x5b, 0x77, 0xeb, 0x59,
+	0xb5, 0xfc, 0xd9, 0x97, 0x9b, 0xcb, 0x98, 0xd3, 0xbe, 0x5b, 0x1d, 0xa8, 0xc4, 0x57, 0x25, 0x09,
+	0xac, 0x08, 0x6a, 0xb7, 0xef, 0x1d, 0xed, 0xef, 0xb5, 0x76, 0xbb, 0x6d, 0xfd, 0xfe, 0x61, 0xb7,
+	0x5d, 0xcf, 0xa2, 0x47, 0xe1, 0xda, 0xfe, 0xde, 0x2f, 0x3b, 0x5d, 0xbd, 0xb5, 0xbf, 0xd7, 0x3e,
+	0xe8, 0xea, 0xbb, 0xdd, 0xee, 0x6e, 0xeb, 0x6e, 0x3d, 0xb7, 0xf3, 0x39, 0xc0, 0xca, 0x6e, 0xb3,
+	0xb5, 0x47, 0x93, 0x82, 0x6d, 0x1a, 0xec, 0x16, 0xdd, 0x82, 0x02, 0xbb, 0x27, 0x5f, 0xfa, 0x66,
+	0xab, 0x5e, 0x5e, 0x93, 0x43, 0x77, 0xa0, 0xc8, 0xae, 0xd0, 0xe8, 0xf2, 0x47, 0x5c, 0x75, 0x4e,
+	0x91, 0x8e, 0x0e, 0x86, 0x1d, 0x8f, 0x4b, 0x5f, 0x75, 0xd5, 0xcb, 0x6b, 0x76, 0x08, 0x83, 0x32,
+	0x66, 0xc6, 0xf3, 0x5f, 0x39, 0xd5, 0x05, 0xc0, 0x06, 0xed, 0xc3, 0xb2, 0xbc, 0x35, 0xcd, 0x2b,
+	0x97, 0xaa, 0x73, 0xb3, 0x0e, 0x0d, 0x17, 0xbf, 0xdd, 0x5e, 0xfe, 0x88, 0xac, 0xce, 0xa9, 0x10,
+	0xa2, 0x3d, 0x58, 0x12, 0x74, 0x6d, 0xce, 0x5b, 0xaa, 0x3a, 0xaf, 0x48, 0x46, 0x83, 0x36, 0xae,
+	0x1b, 0xcc, 0x7f, 0x1a, 0x57, 0x17, 0x28, 0x7e, 0xa2, 0x7b, 0x00, 0xb1, 0xbb, 0xec, 0x02, 0x6f,
+	0xde, 0xea, 0x22, 0x45, 0x4d, 0x74, 0x08, 0xa5, 0x88, 0xb2, 0xcf, 0x7d, 0x81, 0x56, 0xe7, 0x57,
+	0x17, 0xd1, 0x03, 0xa8, 0x26, 0xa9, 0xea, 0x62, 0xef, 0xca, 0xea, 0x82, 0x65, 0x43, 0xea, 0x3f,
+	0xc9, 0x5b, 0x17, 0x7b, 0x67, 0x56, 0x17, 0xac, 0x22, 0xa2, 0x8f, 0x60, 0x75, 0x9a, 0x57, 0x2e,
+	0xfe, 0xec, 0xac, 0x5e, 0xa1, 0xae, 0x88, 0x86, 0x80, 0x66, 0xf0, 0xd1, 0x2b, 0xbc, 0x42, 0xab,
+	0x57, 0x29, 0x33, 0xa2, 0xf7, 0xa0, 0x92, 0x20, 0x79, 0x0b, 0x3d, 0x4a, 0xab, 0x8b, 0x95, 0x1b,
+	0x9b, 0xed, 0xaf, 0xbf, 0x5b, 0xcf, 0x7e, 0xf3, 0xdd, 0x7a, 0xf6, 0xef, 0xdf, 0xad, 0x67, 0xbf,
+	0xf8, 0x7e, 0x3d, 0xf3, 0xcd, 0xf7, 0xeb, 0x99, 0xbf, 0x7e, 0xbf, 0x9e, 0xf9, 0xcd, 0x73, 0xa7,
+	0x76, 0xd8, 0x1f, 0xf5, 0xb6, 0x4c, 0x77, 0xb8, 0x1d, 0xff, 0xef, 0xcc, 0xac, 0xff, 0xf3, 0xf4,
+	0x96, 0x58, 0xc6, 0x7a, 0xe9, 0xdf, 0x03, 0x00, 0x8e, 0x9a, 0xe5, 0x6a, 0xef, 0x23, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -3158,6 +3332,7 @@ type ABCIApplicationClient interface {
 	OfferSnapshot(ctx context.Context, in *RequestOfferSnapshot, opts ...grpc.CallOption) (*ResponseOfferSnapshot, error)
 	LoadSnapshotChunk(ctx context.Context, in *RequestLoadSnapshotChunk, opts ...grpc.CallOption) (*ResponseLoadSnapshotChunk, error)
 	ApplySnapshotChunk(ctx context.Context, in *RequestApplySnapshotChunk, opts ...grpc.CallOption) (*ResponseApplySnapshotChunk, error)
+	CheckTxBatch(ctx context.Context, in *RequestCheckTxBatch, opts ...grpc.CallOption) (*ResponseCheckTxBatch, error)
 }
 
 type aBCIApplicationClient struct {
@@ -3294,6 +3469,15 @@ func (c *aBCIApplicationClient) ApplySnapshotChunk(ctx context.Context, in *Requ
 	return out, nil
 }
 
+func (c *aBCIApplicationClient) CheckTxBatch(ctx context.Context, in *RequestCheckTxBatch, opts ...grpc.CallOption) (*ResponseCheckTxBatch, error) {
+	out := new(ResponseCheckTxBatch)
+	err := c.cc.Invoke(ctx, "/tendermint.abci.ABCIApplication/CheckTxBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ABCIApplicationServer is the server API for ABCIApplication service.
 type ABCIApplicationServer interface {
 	Echo(context.Context, *RequestEcho) (*ResponseEcho, error)
@@ -3310,6 +3494,7 @@ type ABCIApplicationServer interface {
 	OfferSnapshot(context.Context, *RequestOfferSnapshot) (*ResponseOfferSnapshot, error)
 	LoadSnapshotChunk(context.Context, *RequestLoadSnapshotChunk) (*ResponseLoadSnapshotChunk, error)
 	ApplySnapshotChunk(context.Context, *RequestApplySnapshotChunk) (*ResponseApplySnapshotChunk, error)
+	CheckTxBatch(context.Context, *RequestCheckTxBatch) (*ResponseCheckTxBatch, error)
 }
 
 // UnimplementedABCIApplicationServer can be embedded to have forward compatible implementations.
@@ -3358,6 +3543,9 @@ func (*UnimplementedABCIApplicationServer) LoadSnapshotChunk(ctx context.Context
 func (*UnimplementedABCIApplicationServer) ApplySnapshotChunk(ctx context.Context, req *RequestApplySnapshotChunk) (*ResponseApplySnapshotChunk, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ApplySnapshotChunk not implemented")
 }
+func (*UnimplementedABCIApplicationServer) CheckTxBatch(ctx context.Context, req *RequestCheckTxBatch) (*ResponseCheckTxBatch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckTxBatch not implemented")
+}
 
 func RegisterABCIApplicationServer(s *grpc.Server, srv ABCIApplicationServer) {
 	s.RegisterService(&_ABCIApplication_serviceDesc, srv)
@@ -3615,6 +3803,24 @@ func _ABCIApplication_ApplySnapshotChunk_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ABCIApplication_CheckTxBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestCheckTxBatch)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).CheckTxBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.abci.ABCIApplication/CheckTxBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).CheckTxBatch(ctx, req.(*RequestCheckTxBatch))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ABCIApplication_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "tendermint.abci.ABCIApplication",
 	HandlerType: (*ABCIApplicationServer)(nil),
@@ -3675,6 +3881,10 @@ var _ABCIApplication_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ApplySnapshotChunk",
 			Handler:    _ABCIApplication_ApplySnapshotChunk_Handler,
 		},
+		{
+			MethodName: "CheckTxBatch",
+			Handler:    _ABCIApplication_CheckTxBatch_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "tendermint/abci/types.proto",
@@ -4006,6 +4216,27 @@ func (m *Request_ApplySnapshotChunk) MarshalToSizedBuffer(dAtA []byte) (int, err
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Request_CheckTxBatch) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Request_CheckTxBatch) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.CheckTxBatch != nil {
+		{
+			size, err := m.CheckTxBatch.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x7a
+	}
+	return len(dAtA) - i, nil
+}
 func (m *RequestEcho) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -4171,12 +4402,12 @@ func (m *RequestInitChain) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x12
 	}
-	n16, err16 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
-	if err16 != nil {
-		return 0, err16
+	n17, err17 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
+	if err17 != nil {
+		return 0, err17
 	}
-	i -= n16
-	i = encodeVarintTypes(dAtA, i, uint64(n16))
+	i -= n17
+	i = encodeVarintTypes(dAtA, i, uint64(n17))
 	i--
 	dAtA[i] = 0xa
 	return len(dAtA) - i, nil
@@ -4559,6 +4790,43 @@ func (m *RequestApplySnapshotChunk) MarshalToSizedBuffer(dAtA []byte) (int, erro
 	return len(dAtA) - i, nil
 }
 
+func (m *RequestCheckTxBatch) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestCheckTxBatch) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequestCheckTxBatch) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Requests) > 0 {
+		for iNdEx := len(m.Requests) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Requests[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *Response) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -4906,6 +5174,29 @@ func (m *Response_ApplySnapshotChunk) MarshalToSizedBuffer(dAtA []byte) (int, er
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Response_CheckTxBatch) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Response_CheckTxBatch) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.CheckTxBatch != nil {
+		{
+			size, err := m.CheckTxBatch.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x82
+	}
+	return len(dAtA) - i, nil
+}
 func (m *ResponseException) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -5241,6 +5532,27 @@ func (m *ResponseCheckTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.RecheckKeys) > 0 {
+		for iNdEx := len(m.RecheckKeys) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.RecheckKeys[iNdEx])
+			copy(dAtA[i:], m.RecheckKeys[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.RecheckKeys[iNdEx])))
+			i--
+			dAtA[i] = 0x72
+		}
+	}
+	if len(m.Lane) > 0 {
+		i -= len(m.Lane)
+		copy(dAtA[i:], m.Lane)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Lane)))
+		i--
+		dAtA[i] = 0x6a
+	}
+	if m.Nonce != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Nonce))
+		i--
+		dAtA[i] = 0x60
+	}
 	if len(m.MempoolError) > 0 {
 		i -= len(m.MempoolError)
 		copy(dAtA[i:], m.MempoolError)
@@ -5340,6 +5652,15 @@ func (m *ResponseDeliverTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.UpdatedKeys) > 0 {
+		for iNdEx := len(m.UpdatedKeys) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.UpdatedKeys[iNdEx])
+			copy(dAtA[i:], m.UpdatedKeys[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.UpdatedKeys[iNdEx])))
+			i--
+			dAtA[i] = 0x4a
+		}
+	}
 	if len(m.Codespace) > 0 {
 		i -= len(m.Codespace)
 		copy(dAtA[i:], m.Codespace)
@@ -5623,20 +5944,20 @@ func (m *ResponseApplySnapshotChunk) MarshalToSizedBuffer(dAtA []byte) (int, err
 		}
 	}
 	if len(m.RefetchChunks) > 0 {
-		dAtA39 := make([]byte, len(m.RefetchChunks)*10)
-		var j38 int
+		dAtA41 := make([]byte, len(m.RefetchChunks)*10)
+		var j40 int
 		for _, num := range m.RefetchChunks {
 			for num >= 1<<7 {
-				dAtA39[j38] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA41[j40] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j38++
+				j40++
 			}
-			dAtA39[j38] = uint8(num)
-			j38++
+			dAtA41[j40] = uint8(num)
+			j40++
 		}
-		i -= j38
-		copy(dAtA[i:], dAtA39[:j38])
-		i = encodeVarintTypes(dAtA, i, uint64(j38))
+		i -= j40
+		copy(dAtA[i:], dAtA41[:j40])
+		i = encodeVarintTypes(dAtA, i, uint64(j40))
 		i--
 		dAtA[i] = 0x12
 	}
@@ -5648,7 +5969,7 @@ func (m *ResponseApplySnapshotChunk) MarshalToSizedBuffer(dAtA []byte) (int, err
 	return len(dAtA) - i, nil
 }
 
-func (m *LastCommitInfo) Marshal() (dAtA []byte, err error) {
+func (m *ResponseCheckTxBatch) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5658,20 +5979,20 @@ func (m *LastCommitInfo) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *LastCommitInfo) MarshalTo(dAtA []byte) (int, error) {
+func (m *ResponseCheckTxBatch) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *LastCommitInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ResponseCheckTxBatch) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Votes) > 0 {
-		for iNdEx := len(m.Votes) - 1; iNdEx >= 0; iNdEx-- {
+	if len(m.Responses) > 0 {
+		for iNdEx := len(m.Responses) - 1; iNdEx >= 0; iNdEx-- {
 			{
-				size, err := m.Votes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				size, err := m.Responses[iNdEx].MarshalToSizedBuffer(dAtA[:i])
 				if err != nil {
 					return 0, err
 				}
@@ -5679,18 +6000,13 @@ func (m *LastCommitInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 				i = encodeVarintTypes(dAtA, i, uint64(size))
 			}
 			i--
-			dAtA[i] = 0x12
+			dAtA[i] = 0xa
 		}
 	}
-	if m.Round != 0 {
-		i = encodeVarintTypes(dAtA, i, uint64(m.Round))
-		i--
-		dAtA[i] = 0x8
-	}
 	return len(dAtA) - i, nil
 }
 
-func (m *Event) Marshal() (dAtA []byte, err error) {
+func (m *LastCommitInfo) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5700,20 +6016,20 @@ func (m *Event) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Event) MarshalTo(dAtA []byte) (int, error) {
+func (m *LastCommitInfo) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Event) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *LastCommitInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Attributes) > 0 {
-		for iNdEx := len(m.Attributes) - 1; iNdEx >= 0; iNdEx-- {
+	if len(m.Votes) > 0 {
+		for iNdEx := len(m.Votes) - 1; iNdEx >= 0; iNdEx-- {
 			{
-				size, err := m.Attributes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				size, err := m.Votes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
 				if err != nil {
 					return 0, err
 				}
@@ -5724,7 +6040,49 @@ func (m *Event) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			dAtA[i] = 0x12
 		}
 	}
-	if len(m.Type) > 0 {
+	if m.Round != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Event) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Event) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Event) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Attributes) > 0 {
+		for iNdEx := len(m.Attributes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Attributes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Type) > 0 {
 		i -= len(m.Type)
 		copy(dAtA[i:], m.Type)
 		i = encodeVarintTypes(dAtA, i, uint64(len(m.Type)))
@@ -5972,12 +6330,12 @@ func (m *Evidence) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x28
 	}
-	n43, err43 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
-	if err43 != nil {
-		return 0, err43
+	n45, err45 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
+	if err45 != nil {
+		return 0, err45
 	}
-	i -= n43
-	i = encodeVarintTypes(dAtA, i, uint64(n43))
+	i -= n45
+	i = encodeVarintTypes(dAtA, i, uint64(n45))
 	i--
 	dAtA[i] = 0x22
 	if m.Height != 0 {
@@ -6246,6 +6604,18 @@ func (m *Request_ApplySnapshotChunk) Size() (n int) {
 	}
 	return n
 }
+func (m *Request_CheckTxBatch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CheckTxBatch != nil {
+		l = m.CheckTxBatch.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
 func (m *RequestEcho) Size() (n int) {
 	if m == nil {
 		return 0
@@ -6483,6 +6853,21 @@ func (m *RequestApplySnapshotChunk) Size() (n int) {
 	return n
 }
 
+func (m *RequestCheckTxBatch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Requests) > 0 {
+		for _, e := range m.Requests {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
 func (m *Response) Size() (n int) {
 	if m == nil {
 		return 0
@@ -6675,6 +7060,18 @@ func (m *Response_ApplySnapshotChunk) Size() (n int) {
 	}
 	return n
 }
+func (m *Response_CheckTxBatch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CheckTxBatch != nil {
+		l = m.CheckTxBatch.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
 func (m *ResponseException) Size() (n int) {
 	if m == nil {
 		return 0
@@ -6865,6 +7262,19 @@ func (m *ResponseCheckTx) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.Nonce != 0 {
+		n += 1 + sovTypes(uint64(m.Nonce))
+	}
+	l = len(m.Lane)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if len(m.RecheckKeys) > 0 {
+		for _, b := range m.RecheckKeys {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -6905,6 +7315,12 @@ func (m *ResponseDeliverTx) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if len(m.UpdatedKeys) > 0 {
+		for _, b := range m.UpdatedKeys {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -7014,6 +7430,21 @@ func (m *ResponseApplySnapshotChunk) Size() (n int) {
 	return n
 }
 
+func (m *ResponseCheckTxBatch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Responses) > 0 {
+		for _, e := range m.Responses {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
 func (m *LastCommitInfo) Size() (n int) {
 	if m == nil {
 		return 0
@@ -7709,6 +8140,41 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			}
 			m.Value = &Request_ApplySnapshotChunk{v}
 			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CheckTxBatch", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RequestCheckTxBatch{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Request_CheckTxBatch{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -9309,6 +9775,90 @@ func (m *RequestApplySnapshotChunk) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *RequestCheckTxBatch) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestCheckTxBatch: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestCheckTxBatch: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Requests", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Requests = append(m.Requests, RequestCheckTx{})
+			if err := m.Requests[len(m.Requests)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *Response) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -9863,6 +10413,41 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			}
 			m.Value = &Response_ApplySnapshotChunk{v}
 			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CheckTxBatch", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseCheckTxBatch{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_CheckTxBatch{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -11162,6 +11747,89 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 			}
 			m.MempoolError = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Nonce", wireType)
+			}
+			m.Nonce = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Nonce |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Lane", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Lane = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RecheckKeys", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RecheckKeys = append(m.RecheckKeys, make([]byte, postIndex-iNdEx))
+			copy(m.RecheckKeys[len(m.RecheckKeys)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -11433,6 +12101,38 @@ func (m *ResponseDeliverTx) Unmarshal(dAtA []byte) error {
 			}
 			m.Codespace = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UpdatedKeys", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UpdatedKeys = append(m.UpdatedKeys, make([]byte, postIndex-iNdEx))
+			copy(m.UpdatedKeys[len(m.UpdatedKeys)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -12125,6 +12825,90 @@ func (m *ResponseApplySnapshotChunk) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ResponseCheckTxBatch) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseCheckTxBatch: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseCheckTxBatch: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Responses", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Responses = append(m.Responses, ResponseCheckTx{})
+			if err := m.Responses[len(m.Responses)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *LastCommitInfo) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
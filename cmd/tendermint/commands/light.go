@@ -8,20 +8,30 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	dbm "github.com/tendermint/tm-db"
 
+	"github.com/tendermint/tendermint/internal/evidence"
+	"github.com/tendermint/tendermint/internal/p2p"
+	"github.com/tendermint/tendermint/internal/statesync"
 	"github.com/tendermint/tendermint/libs/log"
 	tmmath "github.com/tendermint/tendermint/libs/math"
 	tmos "github.com/tendermint/tendermint/libs/os"
 	"github.com/tendermint/tendermint/light"
+	"github.com/tendermint/tendermint/light/provider"
+	lightp2p "github.com/tendermint/tendermint/light/provider/p2p"
 	lproxy "github.com/tendermint/tendermint/light/proxy"
 	lrpc "github.com/tendermint/tendermint/light/rpc"
+	"github.com/tendermint/tendermint/light/store"
 	dbs "github.com/tendermint/tendermint/light/store/db"
+	lfile "github.com/tendermint/tendermint/light/store/file"
 	rpcserver "github.com/tendermint/tendermint/rpc/jsonrpc/server"
+	"github.com/tendermint/tendermint/types"
+	"github.com/tendermint/tendermint/version"
 )
 
 // LightCmd represents the base command when called without any subcommands
@@ -39,10 +49,31 @@ need a primary RPC address, a trusted hash and height and witness RPC addresses
 (if not using sequential verification). To restart the node, thereafter
 only the chainID is required.
 
+Instead of a trusted hash, a quorum of providers can be required to agree on
+the latest header, or on the header at the given height, with --quorum. The
+primary and the witnesses which agreed on it are recorded in the light store.
+
+When the primary and a witness are found to diverge, the light client halts and
+records the divergence, with the traces of both, in the light store. Recorded
+divergences are served by /divergences, and can be posted to a webhook with
+--divergence-webhook or passed to a program with --divergence-exec, which is
+given each --divergence-exec-arg as an argument.
+
+With --use-p2p, the light blocks are requested from the primary and the witnesses
+over the p2p network instead of their RPC, so that the light client doesn't
+depend on public RPC endpoints: -p and -w are then node addresses
+(id@host:port), and the proxy forwards the queries it verifies to the RPC
+address given with --primary-rpc.
+
 When /abci_query is called, the Merkle key path format is:
 
 	/{store name}/{key}
 
+The trusted light blocks are kept in the light client db, or with --store=file
+in an append-only file, which suits embedded deployments. A range of them can be
+exported with "tendermint light export", and imported by another light client
+with "tendermint light import".
+
 Please verify with your application that this Merkle key format is used (true
 for applications built w/ Cosmos SDK).
 `,
@@ -65,6 +96,18 @@ var (
 	trustedHeight  int64
 	trustedHash    []byte
 	trustLevelStr  string
+	quorum         int
+
+	divergenceWebhook  string
+	divergenceExec     string
+	divergenceExecArgs []string
+
+	useP2P         bool
+	primaryRPCAddr string
+
+	prefetchDepth uint16
+
+	storeBackend string
 
 	logLevel  string
 	logFormat string
@@ -91,6 +134,24 @@ func init() {
 		"trusting period that headers can be verified within. Should be significantly less than the unbonding period")
 	LightCmd.Flags().Int64Var(&trustedHeight, "height", 1, "Trusted header's height")
 	LightCmd.Flags().BytesHexVar(&trustedHash, "hash", []byte{}, "Trusted header's hash")
+	LightCmd.Flags().IntVar(&quorum, "quorum", 0,
+		"trust the latest header, or the header at --height, once this number of providers agree on it, "+
+			"instead of a trusted hash")
+	LightCmd.Flags().StringVar(&divergenceWebhook, "divergence-webhook", "",
+		"post each divergence detected between the primary and a witness, JSON-encoded, to this URL")
+	LightCmd.Flags().StringVar(&divergenceExec, "divergence-exec", "",
+		"run this program with each divergence detected between the primary and a witness, JSON-encoded, on its stdin")
+	LightCmd.Flags().StringArrayVar(&divergenceExecArgs, "divergence-exec-arg", nil,
+		"pass this argument to the --divergence-exec program; repeat the flag for each argument")
+	LightCmd.Flags().BoolVar(&useP2P, "use-p2p", false,
+		"request light blocks from the primary and witnesses over the p2p network; "+
+			"-p and -w are then node addresses (id@host:port)")
+	LightCmd.Flags().StringVar(&primaryRPCAddr, "primary-rpc", "",
+		"with --use-p2p, forward the queries to a Tendermint node at this RPC address")
+	LightCmd.Flags().StringVar(&storeBackend, "store", "db",
+		"store the trusted light blocks in the light client db (db), or in an append-only file (file)")
+	LightCmd.Flags().Uint16Var(&prefetchDepth, "prefetch-depth", 3,
+		"number of light blocks requested from the primary ahead of verification, 0 to disable prefetching")
 	LightCmd.Flags().StringVar(&logLevel, "log-level", log.LogLevelInfo, "The logging level (debug|info|warn|error|fatal)")
 	LightCmd.Flags().StringVar(&logFormat, "log-format", log.LogFormatPlain, "The logging format (text|json)")
 	LightCmd.Flags().StringVar(&trustLevelStr, "trust-level", "1/3",
@@ -115,12 +176,20 @@ func runProxy(cmd *cobra.Command, args []string) error {
 		witnessesAddrs = strings.Split(witnessAddrsJoined, ",")
 	}
 
-	lightDB, err := dbm.NewGoLevelDB("light-client-db", dir)
+	db, trustedStore, closeStores, err := openLightStores()
 	if err != nil {
-		return fmt.Errorf("can't create a db: %w", err)
+		return err
 	}
-	// create a prefixed db on the chainID
-	db := dbm.NewPrefixDB(lightDB, []byte(chainID))
+	// the stores are closed on return, or before exiting upon a signal
+	var closeOnce sync.Once
+	closeAll := func() {
+		closeOnce.Do(func() {
+			if err := closeStores(); err != nil {
+				logger.Error("failed to close the light client stores", "err", err)
+			}
+		})
+	}
+	defer closeAll()
 
 	if primaryAddr == "" { // check to see if we can start from an existing state
 		var err error
@@ -139,12 +208,21 @@ func runProxy(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	rpcAddr := primaryAddr
+	if useP2P {
+		if primaryRPCAddr == "" {
+			return errors.New("no primary RPC address was provided. Please provide one (using --primary-rpc)" +
+				" to forward the queries to")
+		}
+		rpcAddr = primaryRPCAddr
+	}
+
 	trustLevel, err := tmmath.ParseFraction(trustLevelStr)
 	if err != nil {
 		return fmt.Errorf("can't parse trust level: %w", err)
 	}
 
-	options := []light.Option{light.Logger(logger)}
+	options := []light.Option{light.Logger(logger), light.PrefetchDepth(prefetchDepth)}
 
 	if sequential {
 		options = append(options, light.SequentialVerification())
@@ -152,23 +230,61 @@ func runProxy(cmd *cobra.Command, args []string) error {
 		options = append(options, light.SkippingVerification(trustLevel))
 	}
 
+	if divergenceWebhook != "" {
+		options = append(options, light.OnDivergence(light.WebhookDivergenceHook(divergenceWebhook)))
+	}
+	if divergenceExec != "" {
+		options = append(options, light.OnDivergence(light.ExecDivergenceHook(divergenceExec, divergenceExecArgs...)))
+	} else if len(divergenceExecArgs) > 0 {
+		return errors.New("--divergence-exec-arg requires --divergence-exec")
+	}
+
+	trustOptions := light.TrustOptions{
+		Period: trustingPeriod,
+		Height: trustedHeight,
+		Hash:   trustedHash,
+		Quorum: quorum,
+	}
+	// With a quorum, the latest header is trusted unless a height is given.
+	if quorum > 0 && !cmd.Flags().Changed("height") {
+		trustOptions.Height = 0
+	}
+
 	// Initiate the light client. If the trusted store already has blocks in it, this
 	// will be used else we use the trusted options.
-	c, err := light.NewHTTPClient(
-		context.Background(),
-		chainID,
-		light.TrustOptions{
-			Period: trustingPeriod,
-			Height: trustedHeight,
-			Hash:   trustedHash,
-		},
-		primaryAddr,
-		witnessesAddrs,
-		dbs.New(db),
-		options...,
-	)
-	if err != nil {
-		return err
+	var c *light.Client
+	if useP2P {
+		primary, witnesses, stop, err := newP2PProviders(logger, primaryAddr, witnessesAddrs)
+		if err != nil {
+			return err
+		}
+		defer stop()
+
+		c, err = light.NewClient(
+			context.Background(),
+			chainID,
+			trustOptions,
+			primary,
+			witnesses,
+			trustedStore,
+			options...,
+		)
+		if err != nil {
+			return err
+		}
+	} else {
+		c, err = light.NewHTTPClient(
+			context.Background(),
+			chainID,
+			trustOptions,
+			primaryAddr,
+			witnessesAddrs,
+			trustedStore,
+			options...,
+		)
+		if err != nil {
+			return err
+		}
 	}
 
 	cfg := rpcserver.DefaultConfig()
@@ -182,7 +298,7 @@ func runProxy(cmd *cobra.Command, args []string) error {
 		cfg.WriteTimeout = config.RPC.TimeoutBroadcastTxCommit + 1*time.Second
 	}
 
-	p, err := lproxy.NewProxy(c, listenAddr, primaryAddr, cfg, logger, lrpc.KeyPathFn(lrpc.DefaultMerkleKeyPathFn()))
+	p, err := lproxy.NewProxy(c, listenAddr, rpcAddr, cfg, logger, lrpc.KeyPathFn(lrpc.DefaultMerkleKeyPathFn()))
 	if err != nil {
 		return err
 	}
@@ -190,6 +306,7 @@ func runProxy(cmd *cobra.Command, args []string) error {
 	// Stop upon receiving SIGTERM or CTRL-C.
 	tmos.TrapSignal(logger, func() {
 		p.Listener.Close()
+		closeAll()
 	})
 
 	logger.Info("Starting proxy...", "laddr", listenAddr)
@@ -201,6 +318,168 @@ func runProxy(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// newP2PProviders connects to the primary and the witnesses, given by their node
+// addresses, over the p2p network and returns a provider for each of them. The
+// returned function disconnects from them.
+func newP2PProviders(
+	logger log.Logger,
+	primaryAddr string,
+	witnessesAddrs []string,
+) (provider.Provider, []provider.Provider, func(), error) {
+	nodeKey, err := types.LoadOrGenNodeKey(filepath.Join(dir, "node_key.json"))
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("can't load or generate the node key: %w", err)
+	}
+
+	// the light client only dials its providers, so it listens on a random
+	// local port
+	p2pListenAddr := "tcp://127.0.0.1:0"
+	nodeInfo := types.NodeInfo{
+		ProtocolVersion: types.ProtocolVersion{
+			P2P:   version.P2PProtocol,
+			Block: version.BlockProtocol,
+		},
+		NodeID:     nodeKey.ID,
+		ListenAddr: p2pListenAddr,
+		Network:    chainID,
+		Version:    version.TMVersion,
+		Channels:   []byte{byte(statesync.LightBlockChannel), byte(evidence.EvidenceChannel)},
+		Moniker:    "light",
+	}
+	if err := nodeInfo.Validate(); err != nil {
+		return nil, nil, nil, err
+	}
+
+	var (
+		p2pLogger    = logger.With("module", "p2p")
+		blockShim    = statesync.ChannelShims[statesync.LightBlockChannel]
+		evidenceShim = evidence.ChannelShims[evidence.EvidenceChannel]
+	)
+	transport := p2p.NewMConnTransport(p2pLogger, p2p.MConnConfig(config.P2P),
+		[]*p2p.ChannelDescriptor{blockShim.Descriptor, evidenceShim.Descriptor}, p2p.MConnTransportOptions{})
+	addr, err := types.NewNetAddressString(nodeKey.ID.AddressString(p2pListenAddr))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if err := transport.Listen(p2p.NewEndpoint(addr)); err != nil {
+		return nil, nil, nil, err
+	}
+
+	// the primary and the witnesses are the only peers of the light client
+	var (
+		peers     []types.NodeID
+		peerAddrs []p2p.NodeAddress
+	)
+	for _, a := range append([]string{primaryAddr}, witnessesAddrs...) {
+		address, err := p2p.ParseNodeAddress(a)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("invalid node address %q: %w", a, err)
+		}
+		peers = append(peers, address.NodeID)
+		peerAddrs = append(peerAddrs, address)
+	}
+	peerManager, err := p2p.NewPeerManager(nodeKey.ID, dbm.NewMemDB(), p2p.PeerManagerOptions{
+		PersistentPeers:        peers,
+		MaxConnected:           uint16(len(peers)),
+		MinRetryTime:           100 * time.Millisecond,
+		MaxRetryTime:           time.Minute,
+		MaxRetryTimePersistent: time.Minute,
+		RetryTimeJitter:        time.Second,
+	})
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to create peer manager: %w", err)
+	}
+	for _, address := range peerAddrs {
+		if _, err := peerManager.Add(address); err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to add peer %v: %w", address, err)
+		}
+	}
+
+	router, err := p2p.NewRouter(p2pLogger, p2p.NopMetrics(), nodeInfo, nodeKey.PrivKey, peerManager,
+		[]p2p.Transport{transport}, p2p.RouterOptions{})
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to create router: %w", err)
+	}
+	blockCh, err := router.OpenChannel(*blockShim.Descriptor, blockShim.MsgType,
+		blockShim.Descriptor.RecvBufferCapacity)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	evidenceCh, err := router.OpenChannel(*evidenceShim.Descriptor, evidenceShim.MsgType,
+		evidenceShim.Descriptor.RecvBufferCapacity)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	reactor := lightp2p.NewReactor(logger.With("module", "light"), chainID, blockCh, evidenceCh,
+		peerManager.Subscribe(), 10*time.Second)
+	if err := router.Start(); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to start router: %w", err)
+	}
+	if err := reactor.Start(); err != nil {
+		_ = router.Stop()
+		return nil, nil, nil, fmt.Errorf("failed to start light p2p reactor: %w", err)
+	}
+	stop := func() {
+		if err := reactor.Stop(); err != nil {
+			logger.Error("failed to stop light p2p reactor", "err", err)
+		}
+		if err := router.Stop(); err != nil {
+			logger.Error("failed to stop router", "err", err)
+		}
+	}
+
+	// the primary must be connected for the light client to initialize
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if err := reactor.WaitForPeer(ctx, peers[0]); err != nil {
+		stop()
+		return nil, nil, nil, fmt.Errorf("failed to connect to the primary: %w", err)
+	}
+
+	witnesses := make([]provider.Provider, 0, len(peers)-1)
+	for _, peer := range peers[1:] {
+		witnesses = append(witnesses, reactor.Provider(peer))
+	}
+	return reactor.Provider(peers[0]), witnesses, stop, nil
+}
+
+// openLightStores opens the light client db of the chain, keeping the
+// addresses of the providers, and the store of its trusted light blocks, which
+// is either kept in the db or, with --store=file, in files of its own.
+func openLightStores() (dbm.DB, store.Store, func() error, error) {
+	lightDB, err := dbm.NewGoLevelDB("light-client-db", dir)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("can't create a db: %w", err)
+	}
+	// create a prefixed db on the chainID
+	db := dbm.NewPrefixDB(lightDB, []byte(chainID))
+
+	switch storeBackend {
+	case "db":
+		return db, dbs.New(db), lightDB.Close, nil
+
+	case "file":
+		fileStore, err := lfile.New(filepath.Join(dir, chainID))
+		if err != nil {
+			lightDB.Close()
+			return nil, nil, nil, fmt.Errorf("can't open the light store: %w", err)
+		}
+		closeStores := func() error {
+			if err := fileStore.Close(); err != nil {
+				lightDB.Close()
+				return err
+			}
+			return lightDB.Close()
+		}
+		return db, fileStore, closeStores, nil
+
+	default:
+		lightDB.Close()
+		return nil, nil, nil, fmt.Errorf("unknown store %q, expected db or file", storeBackend)
+	}
+}
+
 func checkForExistingProviders(db dbm.DB) (string, []string, error) {
 	primaryBytes, err := db.Get(primaryKey)
 	if err != nil {
@@ -0,0 +1,166 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	tmmath "github.com/tendermint/tendermint/libs/math"
+	"github.com/tendermint/tendermint/light"
+	lfile "github.com/tendermint/tendermint/light/store/file"
+	"github.com/tendermint/tendermint/types"
+)
+
+var lightExportCmd = &cobra.Command{
+	Use:   "export [chainID]",
+	Short: "Export a range of the trusted light blocks of the light client (the light client must be stopped)",
+	Long: `Export a range of the trusted light blocks of the light client to a file, which
+can be imported by another light client with "tendermint light import".
+The hash of the first light block exported is printed: it must be passed to the
+import, which verifies the other light blocks from it.`,
+	Args: cobra.ExactArgs(1),
+	RunE: exportLightBlocks,
+}
+
+var lightImportCmd = &cobra.Command{
+	Use:   "import [chainID]",
+	Short: "Import the light blocks exported by another light client (the light client must be stopped)",
+	Long: `Import the light blocks exported by another light client with "tendermint light export".
+The first light block must match the trusted hash given with --hash, and each
+following light block is verified from the previous one before being saved.
+The import stops at the first light block failing verification.`,
+	Args: cobra.ExactArgs(1),
+	RunE: importLightBlocks,
+}
+
+var (
+	exportFromHeight int64
+	exportToHeight   int64
+	exportOutFile    string
+	importInFile     string
+)
+
+func init() {
+	for _, cmd := range []*cobra.Command{lightExportCmd, lightImportCmd} {
+		cmd.Flags().StringVarP(&dir, "dir", "d", os.ExpandEnv(filepath.Join("$HOME", ".tendermint-light")),
+			"specify the directory")
+		cmd.Flags().StringVar(&storeBackend, "store", "db",
+			"store of the trusted light blocks: the light client db (db), or the append-only file (file)")
+	}
+
+	lightExportCmd.Flags().Int64Var(&exportFromHeight, "from", 1, "first height to export")
+	lightExportCmd.Flags().Int64Var(&exportToHeight, "to", 0, "last height to export, 0 for the last light block")
+	lightExportCmd.Flags().StringVarP(&exportOutFile, "out", "o", "", "file to export the light blocks to")
+
+	lightImportCmd.Flags().StringVarP(&importInFile, "in", "i", "", "file to import the light blocks from")
+	lightImportCmd.Flags().BytesHexVar(&trustedHash, "hash", []byte{},
+		"trusted hash of the first light block imported")
+	lightImportCmd.Flags().DurationVar(&trustingPeriod, "trusting-period", 168*time.Hour,
+		"trusting period that headers can be verified within. Should be significantly less than the unbonding period")
+	lightImportCmd.Flags().StringVar(&trustLevelStr, "trust-level", "1/3",
+		"trust level. Must be between 1/3 and 3/3")
+
+	LightCmd.AddCommand(lightExportCmd)
+	LightCmd.AddCommand(lightImportCmd)
+}
+
+func exportLightBlocks(cmd *cobra.Command, args []string) error {
+	chainID = args[0]
+	if exportOutFile == "" {
+		return errors.New("no file to export to was provided. Please provide one (using --out)")
+	}
+
+	_, trustedStore, closeStores, err := openLightStores()
+	if err != nil {
+		return err
+	}
+	defer closeStores()
+
+	to := exportToHeight
+	if to == 0 {
+		if to, err = trustedStore.LastLightBlockHeight(); err != nil {
+			return err
+		}
+		if to == -1 {
+			return errors.New("the light client has no trusted light blocks")
+		}
+	}
+
+	f, err := os.Create(exportOutFile)
+	if err != nil {
+		return err
+	}
+	exported, err := lfile.Export(f, trustedStore, exportFromHeight, to)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil && len(exported) == 0 {
+		err = fmt.Errorf("no light blocks between heights %d and %d", exportFromHeight, to)
+	}
+	if err != nil {
+		os.Remove(exportOutFile)
+		return err
+	}
+
+	first, last := exported[0], exported[len(exported)-1]
+	fmt.Fprintf(cmd.OutOrStdout(), "exported %d light blocks from height %d to %d\nfirst light block hash: %X\n",
+		len(exported), first.Height, last.Height, first.Hash())
+	return nil
+}
+
+func importLightBlocks(cmd *cobra.Command, args []string) error {
+	chainID = args[0]
+	if importInFile == "" {
+		return errors.New("no file to import from was provided. Please provide one (using --in)")
+	}
+	if len(trustedHash) == 0 {
+		return errors.New("no trusted hash was provided. Please provide the hash of the first light block (using --hash)")
+	}
+	trustLevel, err := tmmath.ParseFraction(trustLevelStr)
+	if err != nil {
+		return fmt.Errorf("can't parse trust level: %w", err)
+	}
+	if err := light.ValidateTrustLevel(trustLevel); err != nil {
+		return err
+	}
+
+	f, err := os.Open(importInFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, trustedStore, closeStores, err := openLightStores()
+	if err != nil {
+		return err
+	}
+	defer closeStores()
+
+	var prev *types.LightBlock
+	verify := func(lb *types.LightBlock) error {
+		if err := lb.ValidateBasic(chainID); err != nil {
+			return err
+		}
+		if prev == nil {
+			if !bytes.Equal(lb.Hash(), trustedHash) {
+				return fmt.Errorf("expected the first light block hash to be %X, got %X", trustedHash, lb.Hash())
+			}
+		} else if lb.Height <= prev.Height {
+			return fmt.Errorf("light block height %d is not above the previous one %d", lb.Height, prev.Height)
+		} else if err := light.Verify(prev.SignedHeader, prev.ValidatorSet, lb.SignedHeader, lb.ValidatorSet,
+			trustingPeriod, lb.Time, 10*time.Second, trustLevel); err != nil {
+			return err
+		}
+		prev = lb
+		return nil
+	}
+
+	imported, err := lfile.Import(f, trustedStore, verify)
+	fmt.Fprintf(cmd.OutOrStdout(), "imported %d light blocks\n", imported)
+	return err
+}
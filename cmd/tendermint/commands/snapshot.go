@@ -0,0 +1,288 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	tmdb "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/internal/p2p"
+	"github.com/tendermint/tendermint/internal/statesync"
+	tmos "github.com/tendermint/tendermint/libs/os"
+	tmstrings "github.com/tendermint/tendermint/libs/strings"
+	"github.com/tendermint/tendermint/proxy"
+	"github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
+	"github.com/tendermint/tendermint/types"
+	"github.com/tendermint/tendermint/version"
+)
+
+// SnapshotCmd groups the tools managing the snapshot store of the node: the
+// snapshots exported from the app, which the node serves to its peers
+// independently of the app. The node must be stopped while they are used.
+var SnapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Export state sync snapshots from the app, list and serve them (the node must be stopped)",
+	Long: `Export state sync snapshots from the app, list and serve them. The node must be stopped.
+
+Snapshots are exported to the directory set by snapshot-dir in the [statesync] section
+of the config, along with the checksums of their chunks, and the light blocks and the
+consensus parameters needed to restore them with "tendermint statesync restore". The
+node serves them to its peers along with the snapshots of the app, even once the app
+has pruned them.`,
+}
+
+var snapshotExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export a snapshot from the app to the snapshot store",
+	Long: `Export a snapshot from the app to the snapshot store: the latest snapshot of the
+app, or its snapshot at the given height, in the highest format. The app must be
+reachable at proxy-app, and the node must hold the two blocks following the
+snapshot height.`,
+	RunE: exportSnapshot,
+}
+
+var snapshotListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the snapshots of the snapshot store",
+	RunE:  listSnapshots,
+}
+
+var snapshotServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the snapshots of the snapshot store over p2p, without the app",
+	Long: `Serve the snapshots of the snapshot store to the peers state syncing from the
+node, along with the light blocks of the node, without running the node or the
+app. It listens on the p2p listen address, and dials the persistent peers of
+the config.`,
+	RunE: serveSnapshots,
+}
+
+var snapshotExportHeight uint64
+
+func init() {
+	snapshotExportCmd.Flags().Uint64Var(&snapshotExportHeight, "height", 0,
+		"height of the snapshot to export (0 exports the latest snapshot)")
+
+	SnapshotCmd.AddCommand(snapshotExportCmd)
+	SnapshotCmd.AddCommand(snapshotListCmd)
+	SnapshotCmd.AddCommand(snapshotServeCmd)
+}
+
+func exportSnapshot(cmd *cobra.Command, args []string) error {
+	blockStore, stateStore, closeStores, err := openNodeStores()
+	if err != nil {
+		return err
+	}
+	defer closeStores()
+
+	clientCreator, closer := proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir())
+	defer closer.Close()
+	proxyApp := proxy.NewAppConns(clientCreator)
+	proxyApp.SetLogger(logger.With("module", "proxy"))
+	if err := proxyApp.Start(); err != nil {
+		return fmt.Errorf("error starting proxy app connections: %w", err)
+	}
+	defer func() {
+		if err := proxyApp.Stop(); err != nil {
+			logger.Error("failed to stop proxy app connections", "err", err)
+		}
+	}()
+
+	snapshots, err := statesync.NewSnapshotStore(config.StateSync.SnapshotDir())
+	if err != nil {
+		return fmt.Errorf("failed to open the snapshot store: %w", err)
+	}
+	s, err := snapshots.Export(cmd.Context(), proxyApp.Snapshot(), stateStore, blockStore, snapshotExportHeight)
+	if err != nil {
+		return fmt.Errorf("failed to export snapshot: %w", err)
+	}
+
+	fmt.Printf("Exported snapshot at height %d in format %d (%d chunks) to %s\n",
+		s.Height, s.Format, s.Chunks, config.StateSync.SnapshotDir())
+	fmt.Printf("Header hash at height %d: %X\n", s.Height, s.LightBlocks[0].Hash())
+	return nil
+}
+
+func listSnapshots(cmd *cobra.Command, args []string) error {
+	store, err := statesync.NewSnapshotStore(config.StateSync.SnapshotDir())
+	if err != nil {
+		return fmt.Errorf("failed to open the snapshot store: %w", err)
+	}
+	snapshots, err := store.List()
+	if err != nil {
+		return err
+	}
+	if len(snapshots) == 0 {
+		fmt.Printf("No snapshots in %s\n", config.StateSync.SnapshotDir())
+		return nil
+	}
+
+	fmt.Printf("%-12s %-8s %-8s %-64s %s\n", "HEIGHT", "FORMAT", "CHUNKS", "HASH", "HEADER HASH")
+	for _, s := range snapshots {
+		var headerHash []byte
+		if len(s.LightBlocks) > 0 && s.LightBlocks[0] != nil {
+			headerHash = s.LightBlocks[0].Hash()
+		}
+		fmt.Printf("%-12d %-8d %-8d %-64X %X\n", s.Height, s.Format, s.Chunks, s.Hash, headerHash)
+	}
+	return nil
+}
+
+func serveSnapshots(cmd *cobra.Command, args []string) error {
+	genDoc, err := types.GenesisDocFromFile(config.GenesisFile())
+	if err != nil {
+		return err
+	}
+	blockStore, stateStore, closeStores, err := openNodeStores()
+	if err != nil {
+		return err
+	}
+	defer closeStores()
+
+	nodeKey, err := types.LoadOrGenNodeKey(config.NodeKeyFile())
+	if err != nil {
+		return fmt.Errorf("can't load or generate the node key: %w", err)
+	}
+	shims := []*p2p.ChannelDescriptorShim{
+		statesync.ChannelShims[statesync.SnapshotChannel],
+		statesync.ChannelShims[statesync.ChunkChannel],
+		statesync.ChannelShims[statesync.LightBlockChannel],
+	}
+	nodeInfo := types.NodeInfo{
+		ProtocolVersion: types.ProtocolVersion{
+			P2P:   version.P2PProtocol,
+			Block: version.BlockProtocol,
+		},
+		NodeID:     nodeKey.ID,
+		ListenAddr: config.P2P.ListenAddress,
+		Network:    genDoc.ChainID,
+		Version:    version.TMVersion,
+		Moniker:    config.Moniker,
+	}
+	if config.P2P.ExternalAddress != "" {
+		nodeInfo.ListenAddr = config.P2P.ExternalAddress
+	}
+	descriptors := make([]*p2p.ChannelDescriptor, 0, len(shims))
+	for _, shim := range shims {
+		nodeInfo.Channels = append(nodeInfo.Channels, shim.Descriptor.ID)
+		descriptors = append(descriptors, shim.Descriptor)
+	}
+	if err := nodeInfo.Validate(); err != nil {
+		return err
+	}
+
+	p2pLogger := logger.With("module", "p2p")
+	transport := p2p.NewMConnTransport(p2pLogger, p2p.MConnConfig(config.P2P), descriptors,
+		p2p.MConnTransportOptions{})
+	addr, err := types.NewNetAddressString(nodeKey.ID.AddressString(config.P2P.ListenAddress))
+	if err != nil {
+		return err
+	}
+	if err := transport.Listen(p2p.NewEndpoint(addr)); err != nil {
+		return err
+	}
+
+	options := p2p.PeerManagerOptions{
+		MaxConnected:           config.P2P.MaxConnections,
+		MinRetryTime:           100 * time.Millisecond,
+		MaxRetryTime:           time.Hour,
+		MaxRetryTimePersistent: 5 * time.Minute,
+		RetryTimeJitter:        3 * time.Second,
+	}
+	if options.MaxConnected == 0 {
+		options.MaxConnected = 64
+	}
+	var peers []p2p.NodeAddress
+	for _, a := range tmstrings.SplitAndTrimEmpty(config.P2P.PersistentPeers, ",", " ") {
+		address, err := p2p.ParseNodeAddress(a)
+		if err != nil {
+			return fmt.Errorf("invalid peer address %q: %w", a, err)
+		}
+		peers = append(peers, address)
+		options.PersistentPeers = append(options.PersistentPeers, address.NodeID)
+	}
+	peerManager, err := p2p.NewPeerManager(nodeKey.ID, tmdb.NewMemDB(), options)
+	if err != nil {
+		return fmt.Errorf("failed to create peer manager: %w", err)
+	}
+	for _, address := range peers {
+		if _, err := peerManager.Add(address); err != nil {
+			return fmt.Errorf("failed to add peer %v: %w", address, err)
+		}
+	}
+
+	router, err := p2p.NewRouter(p2pLogger, p2p.NopMetrics(), nodeInfo, nodeKey.PrivKey, peerManager,
+		[]p2p.Transport{transport}, p2p.RouterOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create router: %w", err)
+	}
+	channels := make(map[p2p.ChannelID]*p2p.Channel, len(shims))
+	for _, shim := range shims {
+		ch, err := router.OpenChannel(*shim.Descriptor, shim.MsgType, shim.Descriptor.RecvBufferCapacity)
+		if err != nil {
+			return err
+		}
+		channels[p2p.ChannelID(shim.Descriptor.ID)] = ch
+	}
+
+	snapshots, err := statesync.NewSnapshotStore(config.StateSync.SnapshotDir())
+	if err != nil {
+		return fmt.Errorf("failed to open the snapshot store: %w", err)
+	}
+
+	// without connections to the app, the reactor only serves the snapshot
+	// store and the light blocks of the node
+	reactor := statesync.NewReactor(*config.StateSync, logger.With("module", "statesync"), nil, nil,
+		channels[statesync.SnapshotChannel], channels[statesync.ChunkChannel],
+		channels[statesync.LightBlockChannel], peerManager.Subscribe(), stateStore, blockStore,
+		snapshots, config.StateSync.TempDir, statesync.NopMetrics())
+	if err := router.Start(); err != nil {
+		return fmt.Errorf("failed to start router: %w", err)
+	}
+	if err := reactor.Start(); err != nil {
+		_ = router.Stop()
+		return fmt.Errorf("failed to start state sync reactor: %w", err)
+	}
+	logger.Info("serving snapshots", "dir", config.StateSync.SnapshotDir(), "node_id", nodeKey.ID,
+		"addr", config.P2P.ListenAddress)
+
+	// Stop upon receiving SIGTERM or CTRL-C.
+	tmos.TrapSignal(logger, func() {
+		if err := reactor.Stop(); err != nil {
+			logger.Error("failed to stop state sync reactor", "err", err)
+		}
+		if err := router.Stop(); err != nil {
+			logger.Error("failed to stop router", "err", err)
+		}
+		closeStores()
+	})
+
+	// Run forever.
+	select {}
+}
+
+// openNodeStores opens the block and state stores of the node, which must be
+// stopped. The returned function closes them.
+func openNodeStores() (*store.BlockStore, state.Store, func(), error) {
+	dbType := tmdb.BackendType(config.DBBackend)
+	blockStoreDB, err := tmdb.NewDB("blockstore", dbType, config.DBDir())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	stateDB, err := tmdb.NewDB("state", dbType, config.DBDir())
+	if err != nil {
+		blockStoreDB.Close()
+		return nil, nil, nil, err
+	}
+	closeStores := func() {
+		if err := blockStoreDB.Close(); err != nil {
+			logger.Error("failed to close block store", "err", err)
+		}
+		if err := stateDB.Close(); err != nil {
+			logger.Error("failed to close state store", "err", err)
+		}
+	}
+	return store.NewBlockStore(blockStoreDB), state.NewStore(stateDB), closeStores, nil
+}
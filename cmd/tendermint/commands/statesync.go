@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/tendermint/internal/statesync"
+	"github.com/tendermint/tendermint/light"
+	"github.com/tendermint/tendermint/proxy"
+	"github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+// StateSyncCmd groups the offline tools restoring the state of a node from
+// state sync snapshots. The node must be stopped while they are used.
+var StateSyncCmd = &cobra.Command{
+	Use:   "statesync",
+	Short: "Restore a node from a state sync snapshot without peers (the node must be stopped)",
+}
+
+var stateSyncRestoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restore a snapshot stored in a local directory into the app, and bootstrap the node with its state",
+	Long: `Restore a snapshot stored in a local directory into the app, and bootstrap the node
+with the state at the snapshot height, e.g. to restore a node from a backup in an
+air-gapped environment. The node must be fresh, and the app reachable at proxy-app.
+
+With --trust-hash, the snapshot is verified from the trusted hash of the header
+at the snapshot height, using the light blocks and the consensus parameters
+stored along with it, without any network access. Otherwise it is verified by a
+light client, configured by the rpc-servers and the trust options of the
+[statesync] section of the config.
+
+Once restored, the node can be started: it syncs the blocks following the
+snapshot height from its peers.`,
+	RunE: restoreSnapshot,
+}
+
+var (
+	restoreFromDir   string
+	restoreTrustHash []byte
+)
+
+func init() {
+	stateSyncRestoreCmd.Flags().StringVar(&restoreFromDir, "from", "",
+		"directory of the snapshot to restore")
+	stateSyncRestoreCmd.Flags().BytesHexVar(&restoreTrustHash, "trust-hash", []byte{},
+		"trusted hash of the header at the snapshot height, to verify the snapshot without a light client")
+
+	StateSyncCmd.AddCommand(stateSyncRestoreCmd)
+}
+
+func restoreSnapshot(cmd *cobra.Command, args []string) error {
+	if restoreFromDir == "" {
+		return errors.New("no snapshot directory was provided. Please provide one (using --from)")
+	}
+
+	blockStore, stateStore, closeStores, err := openNodeStores()
+	if err != nil {
+		return err
+	}
+	defer closeStores()
+
+	current, err := stateStore.Load()
+	if err != nil {
+		return fmt.Errorf("cannot load state: %w", err)
+	}
+	if current.LastBlockHeight > 0 {
+		return fmt.Errorf("found local state at height %d, the node must be fresh to restore a snapshot",
+			current.LastBlockHeight)
+	}
+
+	genDoc, err := types.GenesisDocFromFile(config.GenesisFile())
+	if err != nil {
+		return err
+	}
+	genState, err := state.MakeGenesisState(genDoc)
+	if err != nil {
+		return fmt.Errorf("unable to derive state: %w", err)
+	}
+
+	stateProvider, err := restoreStateProvider(cmd.Context(), genState)
+	if err != nil {
+		return err
+	}
+
+	clientCreator, closer := proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir())
+	defer closer.Close()
+	proxyApp := proxy.NewAppConns(clientCreator)
+	proxyApp.SetLogger(logger.With("module", "proxy"))
+	if err := proxyApp.Start(); err != nil {
+		return fmt.Errorf("error starting proxy app connections: %w", err)
+	}
+	defer func() {
+		if err := proxyApp.Stop(); err != nil {
+			logger.Error("failed to stop proxy app connections", "err", err)
+		}
+	}()
+
+	restored, err := statesync.Restore(cmd.Context(), *config.StateSync, logger.With("module", "statesync"),
+		proxyApp.Snapshot(), proxyApp.Query(), stateProvider, stateStore, blockStore, restoreFromDir)
+	if err != nil {
+		return fmt.Errorf("failed to restore snapshot: %w", err)
+	}
+
+	logger.Info("restored snapshot", "height", restored.LastBlockHeight, "app_hash", restored.AppHash)
+	return nil
+}
+
+// restoreStateProvider returns the state provider verifying the snapshot:
+// from the trusted hash if one is given, or else with a light client.
+func restoreStateProvider(ctx context.Context, genState state.State) (statesync.StateProvider, error) {
+	if len(restoreTrustHash) > 0 {
+		local, err := statesync.LoadLocalSnapshot(restoreFromDir)
+		if err != nil {
+			return nil, err
+		}
+		return statesync.NewLocalStateProvider(genState.ChainID, genState.Version, genState.InitialHeight,
+			local, restoreTrustHash)
+	}
+
+	ssc := config.StateSync
+	if len(ssc.RPCServers) < 2 || ssc.TrustHeight <= 0 || ssc.TrustHash == "" {
+		return nil, errors.New("no trusted hash was provided (using --trust-hash), and the light client " +
+			"is not configured: at least 2 rpc-servers, a trust-height and a trust-hash are required in [statesync]")
+	}
+	trustHash, err := hex.DecodeString(ssc.TrustHash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trust-hash: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	return statesync.NewLightClientStateProvider(ctx, genState.ChainID, genState.Version, genState.InitialHeight,
+		ssc.RPCServers, light.TrustOptions{
+			Period: ssc.TrustPeriod,
+			Height: ssc.TrustHeight,
+			Hash:   trustHash,
+		}, logger.With("module", "light"))
+}
@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/tendermint/internal/consensus"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+)
+
+// WALCmd groups the offline tools operating on the consensus WAL. The node
+// must be stopped while they are used.
+var WALCmd = &cobra.Command{
+	Use:   "wal",
+	Short: "Inspect, repair or truncate the consensus WAL (the node must be stopped)",
+}
+
+var walInspectCmd = &cobra.Command{
+	Use:   "inspect",
+	Short: "Decode the WAL to JSON, one message per line, and validate its checksums",
+	RunE:  inspectWAL,
+}
+
+var walRepairCmd = &cobra.Command{
+	Use:   "repair",
+	Short: "Truncate the WAL at its first corrupted message",
+	Long: `Truncate the WAL at its first corrupted message, removing everything written after it.
+Every file which is modified or removed is first backed up with a ".CORRUPTED" suffix.`,
+	RunE: repairWAL,
+}
+
+var walTruncateCmd = &cobra.Command{
+	Use:   "truncate",
+	Short: "Remove every message written to the WAL after the end of the given height",
+	RunE:  truncateWAL,
+}
+
+var walTruncateHeight int64
+
+func init() {
+	walTruncateCmd.Flags().Int64Var(&walTruncateHeight, "height", 0,
+		"last height to keep in the WAL")
+
+	WALCmd.AddCommand(walInspectCmd)
+	WALCmd.AddCommand(walRepairCmd)
+	WALCmd.AddCommand(walTruncateCmd)
+}
+
+func inspectWAL(cmd *cobra.Command, args []string) error {
+	corruption, err := consensus.ScanWAL(config.Consensus.WalFile(), func(record consensus.WALRecord) error {
+		bz, err := tmjson.Marshal(record)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(cmd.OutOrStdout(), string(bz))
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if corruption != nil {
+		return fmt.Errorf("corrupted WAL message in %v", corruption)
+	}
+	return nil
+}
+
+func repairWAL(cmd *cobra.Command, args []string) error {
+	corruption, err := consensus.RepairWAL(config.Consensus.WalFile())
+	if err != nil {
+		return err
+	}
+	if corruption == nil {
+		logger.Info("WAL is intact, nothing to repair", "wal", config.Consensus.WalFile())
+		return nil
+	}
+	logger.Info("truncated WAL at corrupted message", "file", corruption.Path,
+		"offset", corruption.Offset, "err", corruption.Err)
+	return nil
+}
+
+func truncateWAL(cmd *cobra.Command, args []string) error {
+	if walTruncateHeight <= 0 {
+		return errors.New("--height must be greater than 0")
+	}
+	if err := consensus.TruncateWAL(config.Consensus.WalFile(), walTruncateHeight); err != nil {
+		return err
+	}
+	logger.Info("truncated WAL", "wal", config.Consensus.WalFile(), "height", walTruncateHeight)
+	return nil
+}
@@ -34,6 +34,9 @@ const (
 
 	MempoolV0 = "v0"
 	MempoolV1 = "v1"
+
+	MempoolGossipModePush = "push"
+	MempoolGossipModePull = "pull"
 )
 
 // NOTE: Most of the structs & relevant comments + the
@@ -128,6 +131,7 @@ func (cfg *Config) SetRoot(root string) *Config {
 	cfg.RPC.RootDir = root
 	cfg.P2P.RootDir = root
 	cfg.Mempool.RootDir = root
+	cfg.StateSync.RootDir = root
 	cfg.Consensus.RootDir = root
 	cfg.PrivValidator.RootDir = root
 	return cfg
@@ -811,6 +815,19 @@ type MempoolConfig struct {
 	// XXX: Unused due to https://github.com/tendermint/tendermint/issues/5796
 	MaxBatchBytes int `mapstructure:"max-batch-bytes"`
 
+	// CheckTxBatchSize, if non-zero, defines the maximum number of transactions
+	// sent to the application in a single CheckTxBatch request, when checking
+	// the transactions received in the same message from a peer and when
+	// rechecking the transactions after a block is committed. The application
+	// can then check them in parallel, e.g. to verify their signatures in
+	// batch. Zero sends a CheckTx request per transaction.
+	//
+	// Note, only the pull gossip mode sends several transactions per message;
+	// the push gossip mode sends each transaction in its own message, which is
+	// checked with CheckTx. Go applications not implementing
+	// abci.CheckTxBatcher have CheckTx called for each transaction of a batch.
+	CheckTxBatchSize int `mapstructure:"check-tx-batch-size"`
+
 	// TTLDuration, if non-zero, defines the maximum amount of time a transaction
 	// can exist for in the mempool.
 	//
@@ -826,6 +843,111 @@ type MempoolConfig struct {
 	// has existed in the mempool at least TTLNumBlocks number of blocks or if
 	// it's insertion time into the mempool is beyond TTLDuration.
 	TTLNumBlocks int64 `mapstructure:"ttl-num-blocks"`
+
+	// ReplaceByFee, if true, lets a transaction replace the transaction in the
+	// mempool with the same sender and nonce, as defined by the ABCI application,
+	// if its priority is higher by at least ReplacementPriorityBump percent.
+	// Otherwise, such a transaction is rejected.
+	//
+	// Note, only the v1 mempool supports replace-by-fee.
+	ReplaceByFee bool `mapstructure:"replace-by-fee"`
+
+	// ReplacementPriorityBump defines the minimum increase, in percent, of the
+	// priority of a transaction replacing another with ReplaceByFee.
+	ReplacementPriorityBump int64 `mapstructure:"replacement-priority-bump"`
+
+	// Journal, if true, persists the transactions accepted into the mempool to
+	// an on-disk journal, which is replayed through CheckTx on startup.
+	// Transactions which exceeded TTLDuration or TTLNumBlocks since they were
+	// first accepted are not replayed.
+	Journal bool `mapstructure:"journal"`
+
+	// Path to the mempool journal, relative to the home directory.
+	JournalPath string `mapstructure:"journal-file"`
+
+	// GossipMode defines how transactions are gossiped to peers: "push" sends
+	// every transaction to every peer, "pull" announces the hashes of the
+	// transactions to the peers, which request the transactions they do not
+	// have yet. The pull mode is only used with the peers which use it too,
+	// the push mode otherwise.
+	GossipMode string `mapstructure:"gossip-mode"`
+
+	// Maximum number of transaction hashes in an announcement or a request
+	// sent to a peer in the pull gossip mode.
+	MaxAnnounceBatchSize int `mapstructure:"max-announce-batch-size"`
+
+	// TxRequestTimeout defines how long to wait for a peer to send a requested
+	// transaction in the pull gossip mode, before requesting it from another
+	// peer which announced it.
+	TxRequestTimeout time.Duration `mapstructure:"tx-request-timeout"`
+
+	// PeerTxRate, if non-zero, defines the maximum number of transactions per
+	// second each peer can submit to CheckTx, so that a single peer cannot
+	// monopolize the CheckTx capacity of the application. The transactions
+	// received over this rate are dropped without being checked.
+	PeerTxRate int `mapstructure:"peer-tx-rate"`
+
+	// PeerTxBurst defines the maximum number of transactions a peer can submit
+	// to CheckTx at once, in excess of PeerTxRate.
+	PeerTxBurst int `mapstructure:"peer-tx-burst"`
+
+	// MaxPeerSpamScore, if non-zero, defines the spam score over which a peer
+	// is disconnected. Each transaction from a peer which fails CheckTx, or
+	// which the peer already sent us, adds one to the peer's spam score, which
+	// halves every minute.
+	MaxPeerSpamScore int `mapstructure:"max-peer-spam-score"`
+
+	// Lanes defines classes of transactions, assigned by the ABCI application
+	// in CheckTx, each with its own size limits, share of the block space and
+	// gossip priority, so that spam in one class of transactions does not crowd
+	// out the others. Transactions assigned to no lane or to an unknown lane
+	// belong to the "default" lane, which can be configured as any other.
+	//
+	// Note, only the v1 mempool supports lanes.
+	Lanes []MempoolLaneConfig `mapstructure:"lanes"`
+}
+
+// MempoolLaneConfig defines the configuration options for a lane of the
+// mempool.
+type MempoolLaneConfig struct {
+	// Name of the lane, as assigned by the ABCI application in CheckTx
+	Name string `mapstructure:"name"`
+
+	// Maximum number of transactions in the lane, in addition to the limit of
+	// the mempool. Zero means no limit.
+	Size int `mapstructure:"size"`
+
+	// Limit the total size of all txs in the lane, in addition to the limit of
+	// the mempool. Zero means no limit.
+	MaxTxsBytes int64 `mapstructure:"max-txs-bytes"`
+
+	// ReapShare defines the percentage of the bytes and gas of a block reserved
+	// to the transactions of the lane. The block space left once each lane used
+	// its share is filled with the transactions of any lane in priority order.
+	ReapShare int64 `mapstructure:"reap-share"`
+
+	// GossipPriority orders the lanes when gossiping transactions to peers. The
+	// transactions of a lane are gossiped before those of the lanes with a lower
+	// gossip priority.
+	GossipPriority int `mapstructure:"gossip-priority"`
+}
+
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg *MempoolLaneConfig) ValidateBasic() error {
+	if len(cfg.Name) == 0 {
+		return errors.New("name can't be empty")
+	}
+	if cfg.Size < 0 {
+		return errors.New("size can't be negative")
+	}
+	if cfg.MaxTxsBytes < 0 {
+		return errors.New("max-txs-bytes can't be negative")
+	}
+	if cfg.ReapShare < 0 || cfg.ReapShare > 100 {
+		return errors.New("reap-share must be between 0 and 100")
+	}
+	return nil
 }
 
 // DefaultMempoolConfig returns a default configuration for the Tendermint mempool.
@@ -842,6 +964,20 @@ func DefaultMempoolConfig() *MempoolConfig {
 		MaxTxBytes:   1024 * 1024, // 1MB
 		TTLDuration:  0 * time.Second,
 		TTLNumBlocks: 0,
+
+		ReplaceByFee:            false,
+		ReplacementPriorityBump: 10,
+
+		Journal:     false,
+		JournalPath: filepath.Join(defaultDataDir, "mempool.journal"),
+
+		GossipMode:           MempoolGossipModePush,
+		MaxAnnounceBatchSize: 1000,
+		TxRequestTimeout:     5 * time.Second,
+
+		PeerTxRate:       0,
+		PeerTxBurst:      1000,
+		MaxPeerSpamScore: 0,
 	}
 }
 
@@ -852,6 +988,11 @@ func TestMempoolConfig() *MempoolConfig {
 	return cfg
 }
 
+// JournalFile returns the full path to the mempool journal.
+func (cfg *MempoolConfig) JournalFile() string {
+	return rootify(cfg.JournalPath, cfg.RootDir)
+}
+
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *MempoolConfig) ValidateBasic() error {
@@ -873,6 +1014,54 @@ func (cfg *MempoolConfig) ValidateBasic() error {
 	if cfg.TTLNumBlocks < 0 {
 		return errors.New("ttl-num-blocks can't be negative")
 	}
+	if cfg.CheckTxBatchSize < 0 {
+		return errors.New("check-tx-batch-size can't be negative")
+	}
+	if cfg.ReplacementPriorityBump < 0 {
+		return errors.New("replacement-priority-bump can't be negative")
+	}
+	switch cfg.GossipMode {
+	case MempoolGossipModePush, MempoolGossipModePull:
+	default:
+		return fmt.Errorf("unknown gossip-mode %q, must be %q or %q",
+			cfg.GossipMode, MempoolGossipModePush, MempoolGossipModePull)
+	}
+	if cfg.MaxAnnounceBatchSize <= 0 {
+		return errors.New("max-announce-batch-size must be positive")
+	}
+	if cfg.TxRequestTimeout <= 0 {
+		return errors.New("tx-request-timeout must be positive")
+	}
+	if cfg.PeerTxRate < 0 {
+		return errors.New("peer-tx-rate can't be negative")
+	}
+	if cfg.PeerTxBurst < 0 {
+		return errors.New("peer-tx-burst can't be negative")
+	}
+	if cfg.PeerTxRate > 0 && cfg.PeerTxBurst == 0 {
+		return errors.New("peer-tx-burst must be positive with a peer-tx-rate")
+	}
+	if cfg.MaxPeerSpamScore < 0 {
+		return errors.New("max-peer-spam-score can't be negative")
+	}
+
+	var (
+		names     = make(map[string]struct{}, len(cfg.Lanes))
+		reapShare int64
+	)
+	for i, lane := range cfg.Lanes {
+		if err := lane.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid lane #%d: %w", i, err)
+		}
+		if _, ok := names[lane.Name]; ok {
+			return fmt.Errorf("duplicate lane %q", lane.Name)
+		}
+		names[lane.Name] = struct{}{}
+		reapShare += lane.ReapShare
+	}
+	if reapShare > 100 {
+		return fmt.Errorf("reap-share of the lanes adds up to %d%%, more than 100%%", reapShare)
+	}
 
 	return nil
 }
@@ -882,6 +1071,7 @@ func (cfg *MempoolConfig) ValidateBasic() error {
 
 // StateSyncConfig defines the configuration for the Tendermint state sync service
 type StateSyncConfig struct {
+	RootDir             string        `mapstructure:"home"`
 	Enable              bool          `mapstructure:"enable"`
 	TempDir             string        `mapstructure:"temp-dir"`
 	RPCServers          []string      `mapstructure:"rpc-servers"`
@@ -891,6 +1081,21 @@ type StateSyncConfig struct {
 	DiscoveryTime       time.Duration `mapstructure:"discovery-time"`
 	ChunkRequestTimeout time.Duration `mapstructure:"chunk-request-timeout"`
 	Fetchers            int32         `mapstructure:"fetchers"`
+
+	// SnapshotPath is the directory of the snapshots exported from the app
+	// with "tendermint snapshot export", which the node serves to its peers
+	// along with the snapshots of the app.
+	SnapshotPath string `mapstructure:"snapshot-dir"`
+
+	// ProgressPath is the file recording the snapshot being restored and the
+	// chunks applied to the app so far.
+	ProgressPath string `mapstructure:"progress-file"`
+
+	// Resume resumes an interrupted restore from the chunks recorded in the
+	// progress file, instead of restoring the snapshot from scratch. The app
+	// must support it: when offered the snapshot again, it must keep the
+	// chunks it has already applied.
+	Resume bool `mapstructure:"resume"`
 }
 
 func (cfg *StateSyncConfig) TrustHashBytes() []byte {
@@ -909,6 +1114,8 @@ func DefaultStateSyncConfig() *StateSyncConfig {
 		DiscoveryTime:       15 * time.Second,
 		ChunkRequestTimeout: 15 * time.Second,
 		Fetchers:            4,
+		SnapshotPath:        filepath.Join(defaultDataDir, "snapshots"),
+		ProgressPath:        filepath.Join(defaultDataDir, "statesync-progress.json"),
 	}
 }
 
@@ -917,6 +1124,16 @@ func TestStateSyncConfig() *StateSyncConfig {
 	return DefaultStateSyncConfig()
 }
 
+// SnapshotDir returns the full path to the directory of the exported snapshots.
+func (cfg *StateSyncConfig) SnapshotDir() string {
+	return rootify(cfg.SnapshotPath, cfg.RootDir)
+}
+
+// ProgressFile returns the full path to the state sync progress file.
+func (cfg *StateSyncConfig) ProgressFile() string {
+	return rootify(cfg.ProgressPath, cfg.RootDir)
+}
+
 // ValidateBasic performs basic validation.
 func (cfg *StateSyncConfig) ValidateBasic() error {
 	if cfg.Enable {
@@ -1007,6 +1224,9 @@ type ConsensusConfig struct {
 	RootDir string `mapstructure:"home"`
 	WalPath string `mapstructure:"wal-file"`
 	walFile string // overrides WalPath if set
+	// Periodically remove WAL files which only hold messages older than the
+	// previous height
+	WalCompaction bool `mapstructure:"wal-compaction"`
 
 	// TODO: remove timeout configs, these should be global not local
 	// How long we wait for a proposal block before prevoting nil
@@ -1029,10 +1249,24 @@ type ConsensusConfig struct {
 	// Make progress as soon as we have all the precommits (as if TimeoutCommit = 0)
 	SkipTimeoutCommit bool `mapstructure:"skip-timeout-commit"`
 
+	// Adjust timeout-propose, timeout-prevote and timeout-precommit from the
+	// observed latency of proposals and votes, within the bounds below. The
+	// static timeouts are used until the first observation is made.
+	AdaptiveTimeouts bool `mapstructure:"adaptive-timeouts"`
+	// Lower bound of an adaptively computed timeout (before the round delta is added)
+	AdaptiveTimeoutMin time.Duration `mapstructure:"adaptive-timeout-min"`
+	// Upper bound of an adaptively computed timeout (before the round delta is added)
+	AdaptiveTimeoutMax time.Duration `mapstructure:"adaptive-timeout-max"`
+
 	// EmptyBlocks mode and possible interval between empty blocks
 	CreateEmptyBlocks         bool          `mapstructure:"create-empty-blocks"`
 	CreateEmptyBlocksInterval time.Duration `mapstructure:"create-empty-blocks-interval"`
 
+	// Send peers proposal blocks with the hashes of their transactions rather
+	// than the block parts, letting them reconstruct the blocks from their
+	// mempool.
+	CompactBlocks bool `mapstructure:"compact-blocks"`
+
 	// Reactor sleep duration parameters
 	PeerGossipSleepDuration     time.Duration `mapstructure:"peer-gossip-sleep-duration"`
 	PeerQueryMaj23SleepDuration time.Duration `mapstructure:"peer-query-maj23-sleep-duration"`
@@ -1044,6 +1278,7 @@ type ConsensusConfig struct {
 func DefaultConsensusConfig() *ConsensusConfig {
 	return &ConsensusConfig{
 		WalPath:                     filepath.Join(defaultDataDir, "cs.wal", "wal"),
+		WalCompaction:               false,
 		TimeoutPropose:              3000 * time.Millisecond,
 		TimeoutProposeDelta:         500 * time.Millisecond,
 		TimeoutPrevote:              1000 * time.Millisecond,
@@ -1052,8 +1287,12 @@ func DefaultConsensusConfig() *ConsensusConfig {
 		TimeoutPrecommitDelta:       500 * time.Millisecond,
 		TimeoutCommit:               1000 * time.Millisecond,
 		SkipTimeoutCommit:           false,
+		AdaptiveTimeouts:            false,
+		AdaptiveTimeoutMin:          100 * time.Millisecond,
+		AdaptiveTimeoutMax:          10 * time.Second,
 		CreateEmptyBlocks:           true,
 		CreateEmptyBlocksInterval:   0 * time.Second,
+		CompactBlocks:               false,
 		PeerGossipSleepDuration:     100 * time.Millisecond,
 		PeerQueryMaj23SleepDuration: 2000 * time.Millisecond,
 		DoubleSignCheckHeight:       int64(0),
@@ -1146,6 +1385,15 @@ func (cfg *ConsensusConfig) ValidateBasic() error {
 	if cfg.TimeoutCommit < 0 {
 		return errors.New("timeout-commit can't be negative")
 	}
+	if cfg.AdaptiveTimeoutMin < 0 {
+		return errors.New("adaptive-timeout-min can't be negative")
+	}
+	if cfg.AdaptiveTimeoutMax < 0 {
+		return errors.New("adaptive-timeout-max can't be negative")
+	}
+	if cfg.AdaptiveTimeouts && cfg.AdaptiveTimeoutMin > cfg.AdaptiveTimeoutMax {
+		return errors.New("adaptive-timeout-min can't be greater than adaptive-timeout-max")
+	}
 	if cfg.CreateEmptyBlocksInterval < 0 {
 		return errors.New("create-empty-blocks-interval can't be negative")
 	}
@@ -111,6 +111,13 @@ func TestMempoolConfigValidateBasic(t *testing.T) {
 		"MaxTxsBytes",
 		"CacheSize",
 		"MaxTxBytes",
+		"CheckTxBatchSize",
+		"ReplacementPriorityBump",
+		"MaxAnnounceBatchSize",
+		"TxRequestTimeout",
+		"PeerTxRate",
+		"PeerTxBurst",
+		"MaxPeerSpamScore",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -120,6 +127,46 @@ func TestMempoolConfigValidateBasic(t *testing.T) {
 	}
 }
 
+func TestMempoolConfigValidateBasicGossipMode(t *testing.T) {
+	cfg := TestMempoolConfig()
+	cfg.GossipMode = MempoolGossipModePull
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.GossipMode = "flood"
+	assert.Error(t, cfg.ValidateBasic())
+}
+
+func TestMempoolConfigValidateBasicLanes(t *testing.T) {
+	cfg := TestMempoolConfig()
+	cfg.Lanes = []MempoolLaneConfig{
+		{Name: "oracle", Size: 100, ReapShare: 20, GossipPriority: 10},
+		{Name: "default", MaxTxsBytes: 1024, ReapShare: 80},
+	}
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.Lanes[1].ReapShare = 81
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.Lanes[1].ReapShare = 80
+
+	cfg.Lanes[1].Name = "oracle"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.Lanes[1].Name = ""
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.Lanes[1].Name = "default"
+
+	fieldsToTest := []string{
+		"Size",
+		"MaxTxsBytes",
+		"ReapShare",
+	}
+
+	for _, fieldName := range fieldsToTest {
+		reflect.ValueOf(&cfg.Lanes[0]).Elem().FieldByName(fieldName).SetInt(-1)
+		assert.Error(t, cfg.ValidateBasic())
+		reflect.ValueOf(&cfg.Lanes[0]).Elem().FieldByName(fieldName).SetInt(0)
+	}
+}
+
 func TestStateSyncConfigValidateBasic(t *testing.T) {
 	cfg := TestStateSyncConfig()
 	require.NoError(t, cfg.ValidateBasic())
@@ -162,6 +209,13 @@ func TestConsensusConfig_ValidateBasic(t *testing.T) {
 		"PeerQueryMaj23SleepDuration":          {func(c *ConsensusConfig) { c.PeerQueryMaj23SleepDuration = time.Second }, false},
 		"PeerQueryMaj23SleepDuration negative": {func(c *ConsensusConfig) { c.PeerQueryMaj23SleepDuration = -1 }, true},
 		"DoubleSignCheckHeight negative":       {func(c *ConsensusConfig) { c.DoubleSignCheckHeight = -1 }, true},
+		"AdaptiveTimeoutMin negative":          {func(c *ConsensusConfig) { c.AdaptiveTimeoutMin = -1 }, true},
+		"AdaptiveTimeoutMax negative":          {func(c *ConsensusConfig) { c.AdaptiveTimeoutMax = -1 }, true},
+		"AdaptiveTimeouts min > max": {func(c *ConsensusConfig) {
+			c.AdaptiveTimeouts = true
+			c.AdaptiveTimeoutMin = 2 * time.Second
+			c.AdaptiveTimeoutMax = time.Second
+		}, true},
 	}
 	for desc, tc := range testcases {
 		tc := tc // appease linter
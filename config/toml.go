@@ -399,6 +399,19 @@ max-tx-bytes = {{ .Mempool.MaxTxBytes }}
 # XXX: Unused due to https://github.com/tendermint/tendermint/issues/5796
 max-batch-bytes = {{ .Mempool.MaxBatchBytes }}
 
+# check-tx-batch-size, if non-zero, defines the maximum number of transactions
+# sent to the application in a single CheckTxBatch request, when checking the
+# transactions received in the same message from a peer and when rechecking the
+# transactions after a block is committed. The application can then check them
+# in parallel, e.g. to verify their signatures in batch. Zero sends a CheckTx
+# request per transaction.
+#
+# Note, only the pull gossip mode sends several transactions per message; the
+# push gossip mode sends each transaction in its own message, which is checked
+# with CheckTx. Go applications not implementing abci.CheckTxBatcher have
+# CheckTx called for each transaction of a batch.
+check-tx-batch-size = {{ .Mempool.CheckTxBatchSize }}
+
 # ttl-duration, if non-zero, defines the maximum amount of time a transaction
 # can exist for in the mempool.
 #
@@ -415,6 +428,94 @@ ttl-duration = "{{ .Mempool.TTLDuration }}"
 # it's insertion time into the mempool is beyond ttl-duration.
 ttl-num-blocks = {{ .Mempool.TTLNumBlocks }}
 
+# replace-by-fee, if true, lets a transaction replace the transaction in the
+# mempool with the same sender and nonce, as defined by the ABCI application,
+# if its priority is higher by at least replacement-priority-bump percent.
+# Otherwise, such a transaction is rejected.
+#
+# Note, only the v1 mempool supports replace-by-fee.
+replace-by-fee = {{ .Mempool.ReplaceByFee }}
+
+# replacement-priority-bump defines the minimum increase, in percent, of the
+# priority of a transaction replacing another with replace-by-fee.
+replacement-priority-bump = {{ .Mempool.ReplacementPriorityBump }}
+
+# journal, if true, persists the transactions accepted into the mempool to an
+# on-disk journal, which is replayed through CheckTx on startup. Transactions
+# which exceeded ttl-duration or ttl-num-blocks since they were first accepted
+# are not replayed.
+journal = {{ .Mempool.Journal }}
+
+# Path to the mempool journal, relative to the home directory.
+journal-file = "{{ js .Mempool.JournalPath }}"
+
+# gossip-mode defines how transactions are gossiped to peers:
+# 1) "push" - send every transaction to every peer
+# 2) "pull" - announce the hashes of the transactions to the peers, which
+#   request the transactions they do not have yet, cutting the bandwidth used
+#   on a densely connected network. The pull mode is only used with the peers
+#   which use it too, the push mode otherwise.
+gossip-mode = "{{ .Mempool.GossipMode }}"
+
+# Maximum number of transaction hashes in an announcement or a request sent to
+# a peer in the pull gossip mode.
+max-announce-batch-size = {{ .Mempool.MaxAnnounceBatchSize }}
+
+# tx-request-timeout defines how long to wait for a peer to send a requested
+# transaction in the pull gossip mode, before requesting it from another peer
+# which announced it.
+tx-request-timeout = "{{ .Mempool.TxRequestTimeout }}"
+
+# peer-tx-rate, if non-zero, defines the maximum number of transactions per
+# second each peer can submit to CheckTx, so that a single peer cannot
+# monopolize the CheckTx capacity of the application. The transactions received
+# over this rate are dropped without being checked.
+peer-tx-rate = {{ .Mempool.PeerTxRate }}
+
+# peer-tx-burst defines the maximum number of transactions a peer can submit to
+# CheckTx at once, in excess of peer-tx-rate.
+peer-tx-burst = {{ .Mempool.PeerTxBurst }}
+
+# max-peer-spam-score, if non-zero, defines the spam score over which a peer is
+# disconnected. Each transaction from a peer which fails CheckTx, or which the
+# peer already sent us, adds one to the peer's spam score, which halves every
+# minute.
+max-peer-spam-score = {{ .Mempool.MaxPeerSpamScore }}
+
+# lanes defines classes of transactions, assigned by the ABCI application in
+# CheckTx, each with its own size limits, share of the block space and gossip
+# priority, so that spam in one class of transactions does not crowd out the
+# others. Transactions assigned to no lane or to an unknown lane belong to the
+# "default" lane, which can be configured as any other. For each lane:
+#
+# - size and max-txs-bytes limit the number and total size of its transactions,
+#   in addition to the limits of the mempool. Zero means no limit.
+# - reap-share is the percentage of the bytes and gas of a block reserved to its
+#   transactions. The block space left once each lane used its share is filled
+#   with the transactions of any lane in priority order. The reap-share of all
+#   the lanes can't add up to more than 100.
+# - the transactions of a lane are gossiped before those of the lanes with a
+#   lower gossip-priority.
+#
+# Note, only the v1 mempool supports lanes.
+#
+# Example:
+#
+# [[mempool.lanes]]
+# name = "oracle"
+# size = 1000
+# max-txs-bytes = 1048576
+# reap-share = 20
+# gossip-priority = 10
+{{ range .Mempool.Lanes }}
+[[mempool.lanes]]
+name = "{{ js .Name }}"
+size = {{ .Size }}
+max-txs-bytes = {{ .MaxTxsBytes }}
+reap-share = {{ .ReapShare }}
+gossip-priority = {{ .GossipPriority }}
+{{ end }}
+
 #######################################################
 ###         State Sync Configuration Options        ###
 #######################################################
@@ -451,6 +552,19 @@ chunk-request-timeout = "{{ .StateSync.ChunkRequestTimeout }}"
 # The number of concurrent chunk and block fetchers to run (default: 4).
 fetchers = "{{ .StateSync.Fetchers }}"
 
+# Path to the snapshots exported from the app with "tendermint snapshot export", relative to the
+# home directory. The node serves them to its peers along with the snapshots of the app, even once
+# the app has pruned them.
+snapshot-dir = "{{ js .StateSync.SnapshotPath }}"
+
+# Path to the file recording the progress of a restore, relative to the home directory.
+progress-file = "{{ js .StateSync.ProgressPath }}"
+
+# Resume an interrupted restore from the chunks recorded in the progress file, instead of
+# restoring the snapshot from scratch. Only enable it if the app supports it: when offered the
+# same snapshot again, it must keep the chunks it has already applied.
+resume = {{ .StateSync.Resume }}
+
 #######################################################
 ###       Block Sync Configuration Connections       ###
 #######################################################
@@ -468,6 +582,10 @@ version = "{{ .BlockSync.Version }}"
 
 wal-file = "{{ js .Consensus.WalPath }}"
 
+# Periodically remove WAL files which only hold messages older than the
+# previous height. Older heights can then no longer be replayed from the WAL.
+wal-compaction = {{ .Consensus.WalCompaction }}
+
 # How long we wait for a proposal block before prevoting nil
 timeout-propose = "{{ .Consensus.TimeoutPropose }}"
 # How much timeout-propose increases with each round
@@ -494,10 +612,25 @@ double-sign-check-height = {{ .Consensus.DoubleSignCheckHeight }}
 # Make progress as soon as we have all the precommits (as if TimeoutCommit = 0)
 skip-timeout-commit = {{ .Consensus.SkipTimeoutCommit }}
 
+# Adjust timeout-propose, timeout-prevote and timeout-precommit from the
+# observed latency of proposals and votes. The static timeouts above are used
+# until the first observation is made. The *-delta parameters are still added
+# for every round.
+adaptive-timeouts = {{ .Consensus.AdaptiveTimeouts }}
+# Bounds of an adaptively computed timeout (before the round delta is added)
+adaptive-timeout-min = "{{ .Consensus.AdaptiveTimeoutMin }}"
+adaptive-timeout-max = "{{ .Consensus.AdaptiveTimeoutMax }}"
+
 # EmptyBlocks mode and possible interval between empty blocks
 create-empty-blocks = {{ .Consensus.CreateEmptyBlocks }}
 create-empty-blocks-interval = "{{ .Consensus.CreateEmptyBlocksInterval }}"
 
+# Send peers the proposal block with the hashes of its transactions rather than
+# the block parts, so that they reconstruct it from their mempool, only
+# requesting the transactions they are missing. Peers which can't reconstruct
+# the block, or which don't support compact blocks, are sent the block parts.
+compact-blocks = {{ .Consensus.CompactBlocks }}
+
 # Reactor sleep duration parameters
 peer-gossip-sleep-duration = "{{ .Consensus.PeerGossipSleepDuration }}"
 peer-query-maj23-sleep-duration = "{{ .Consensus.PeerQueryMaj23SleepDuration }}"
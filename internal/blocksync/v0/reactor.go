@@ -124,12 +124,15 @@ func NewReactor(
 	blockSync bool,
 	metrics *cons.Metrics,
 ) (*Reactor, error) {
-	if state.LastBlockHeight != store.Height() {
-		return nil, fmt.Errorf("state (%v) and store (%v) height mismatch", state.LastBlockHeight, store.Height())
-	}
-
 	startHeight := store.Height() + 1
-	if startHeight == 1 {
+	switch {
+	case restoredFromSnapshot(state, store):
+		// the store has no blocks until the block following the snapshot
+		// height is synced
+		startHeight = state.LastBlockHeight + 1
+	case state.LastBlockHeight != store.Height():
+		return nil, fmt.Errorf("state (%v) and store (%v) height mismatch", state.LastBlockHeight, store.Height())
+	case startHeight == 1:
 		startHeight = state.InitialHeight
 	}
 
@@ -157,6 +160,17 @@ func NewReactor(
 	return r, nil
 }
 
+// restoredFromSnapshot returns whether the state was restored from a snapshot,
+// by state sync or from a local directory: the store then holds the seen
+// commit of the snapshot height, but no blocks yet.
+func restoredFromSnapshot(state sm.State, store *store.BlockStore) bool {
+	if state.LastBlockHeight == 0 || store.Height() != 0 {
+		return false
+	}
+	seenCommit := store.LoadSeenCommit()
+	return seenCommit != nil && seenCommit.Height == state.LastBlockHeight
+}
+
 // OnStart starts separate go routines for each p2p Channel and listens for
 // envelopes on each. In addition, it also listens for peer updates and handles
 // messages on that p2p channel accordingly. The caller must be sure to execute
@@ -530,8 +544,14 @@ FOR_LOOP:
 				didProcessCh <- struct{}{}
 			}
 
+			// split the first block into parts the way its proposer did, which
+			// may have erasure coded it
+			var committedHeader types.PartSetHeader
+			if second.LastCommit != nil {
+				committedHeader = second.LastCommit.BlockID.PartSetHeader
+			}
 			var (
-				firstParts         = first.MakePartSet(types.BlockPartSizeBytes)
+				firstParts         = first.MakePartSetLike(types.BlockPartSizeBytes, committedHeader)
 				firstPartSetHeader = firstParts.Header()
 				firstID            = types.BlockID{Hash: first.Hash(), PartSetHeader: firstPartSetHeader}
 			)
@@ -16,6 +16,7 @@ import (
 	"github.com/tendermint/tendermint/internal/test/factory"
 	"github.com/tendermint/tendermint/libs/log"
 	bcproto "github.com/tendermint/tendermint/proto/tendermint/blocksync"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/proxy"
 	sm "github.com/tendermint/tendermint/state"
 	sf "github.com/tendermint/tendermint/state/test/factory"
@@ -355,3 +356,24 @@ func TestReactor_BadBlockStopsPeer(t *testing.T) {
 		len(rts.reactors[newNode.NodeID].pool.peers),
 	)
 }
+
+func TestNewReactor_RestoredFromSnapshot(t *testing.T) {
+	// the state was restored from a snapshot at height 10: the store only holds
+	// the seen commit of the snapshot height
+	state := sm.State{InitialHeight: 1, LastBlockHeight: 10}
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	vals, privVals := factory.RandValidatorSet(1, 10)
+	voteSet := types.NewVoteSet(factory.DefaultTestChainID, 10, 0, tmproto.PrecommitType, vals)
+	commit, err := factory.MakeCommit(factory.MakeBlockID(), 10, 0, voteSet, privVals, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, blockStore.SaveSeenCommit(10, commit))
+
+	r, err := NewReactor(log.TestingLogger(), state, nil, blockStore, nil, nil, nil, true, cons.NopMetrics())
+	require.NoError(t, err)
+	require.EqualValues(t, 11, r.pool.height)
+
+	// without the seen commit, the state doesn't match the store
+	_, err = NewReactor(log.TestingLogger(), state, nil, store.NewBlockStore(dbm.NewMemDB()), nil, nil, nil, true,
+		cons.NopMetrics())
+	require.Error(t, err)
+}
@@ -157,10 +157,17 @@ func (state *pcState) handle(event Event) (Event, error) {
 			return noOp, nil
 		}
 
+		first, second := firstItem.block, secondItem.block
+
+		// split +first+ into parts the way its proposer did, which may have
+		// erasure coded it
+		var committedHeader types.PartSetHeader
+		if second.LastCommit != nil {
+			committedHeader = second.LastCommit.BlockID.PartSetHeader
+		}
 		var (
-			first, second = firstItem.block, secondItem.block
-			firstParts    = first.MakePartSet(types.BlockPartSizeBytes)
-			firstID       = types.BlockID{Hash: first.Hash(), PartSetHeader: firstParts.Header()}
+			firstParts = first.MakePartSetLike(types.BlockPartSizeBytes, committedHeader)
+			firstID    = types.BlockID{Hash: first.Hash(), PartSetHeader: firstParts.Header()}
 		)
 
 		// verify if +second+ last commit "confirms" +first+ block
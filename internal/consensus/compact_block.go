@@ -0,0 +1,355 @@
+package consensus
+
+import (
+	"bytes"
+	"time"
+
+	cstypes "github.com/tendermint/tendermint/internal/consensus/types"
+	"github.com/tendermint/tendermint/internal/mempool"
+	"github.com/tendermint/tendermint/internal/p2p"
+	tmcons "github.com/tendermint/tendermint/proto/tendermint/consensus"
+	"github.com/tendermint/tendermint/types"
+)
+
+// Compact block relay: once a node has a complete proposal block, it sends
+// its peers the block with the transactions replaced by their hashes rather
+// than the block parts. A peer looks the transactions up in its mempool,
+// requests the missing ones and reconstructs the block parts from the block.
+// The parts are held back from the peer until it reports whether it could
+// reconstruct the block, for at most compactBlockTimeout.
+//
+// Compact blocks are only sent to the peers which advertised their support on
+// connect, with a CompactBlockSupportMessage. The other peers, e.g. running an
+// older version, are sent the block parts right away.
+
+const (
+	// compactBlockTimeout is how long the parts of a block are held back from a
+	// peer which was sent the compact block, waiting for it to report whether
+	// it could reconstruct the block.
+	compactBlockTimeout = time.Second
+
+	// maxCompactBlockTxsBytes bounds the size of the transactions sent in a
+	// single CompactBlockTxsMessage.
+	maxCompactBlockTxsBytes = maxMsgSize / 2
+)
+
+// compactBlockSent tracks the compact block sent to a peer.
+type compactBlockSent struct {
+	height    int64
+	round     int32
+	blockHash []byte
+	sentAt    time.Time
+	done      bool // the peer reported whether it could reconstruct the block
+}
+
+// compactBlockRecv tracks the reconstruction of a compact block received from
+// a peer.
+type compactBlockRecv struct {
+	msg     *CompactBlockMessage
+	txs     types.Txs // nil entries are missing
+	missing int
+}
+
+// ReactorMempool sets the mempool used to reconstruct the compact blocks
+// received from peers. Compact blocks are only sent to peers if the
+// compact-blocks config option is enabled and a mempool is set.
+func ReactorMempool(mp mempool.Mempool) ReactorOption {
+	return func(r *Reactor) { r.mempool = mp }
+}
+
+func (r *Reactor) compactBlocksEnabled() bool {
+	return r.state.config.CompactBlocks && r.mempool != nil
+}
+
+// sendCompactBlock sends the compact form of our complete proposal block to
+// the peer, unless it was already sent for the round. It returns true if the
+// block parts must be held back from the peer, as it may be reconstructing the
+// block.
+func (r *Reactor) sendCompactBlock(rs *cstypes.RoundState, prs *cstypes.PeerRoundState, ps *PeerState) bool {
+	if !r.compactBlocksEnabled() || !ps.supportsCompactBlocks() ||
+		rs.Height != prs.Height || rs.Round != prs.Round ||
+		rs.ProposalBlock == nil || !rs.ProposalBlockParts.IsComplete() {
+		return false
+	}
+	if sent, waiting := ps.compactBlockSent(rs.Height, rs.Round); sent {
+		return waiting
+	}
+
+	blockHash := rs.ProposalBlock.Hash()
+	msg, err := MsgToProto(NewCompactBlockMessage(rs.Height, rs.Round, rs.ProposalBlock))
+	if err != nil {
+		r.Logger.Error("failed to convert compact block to proto", "err", err)
+		ps.setCompactBlockSent(rs.Height, rs.Round, blockHash, true)
+		return false
+	}
+	if msg.Size() > maxMsgSize {
+		// fall back to block parts
+		ps.setCompactBlockSent(rs.Height, rs.Round, blockHash, true)
+		return false
+	}
+
+	r.Logger.Debug("sending compact block", "height", rs.Height, "round", rs.Round, "peer", ps.peerID)
+	r.dataCh.Out <- p2p.Envelope{
+		To:      ps.peerID,
+		Message: msg.GetCompactBlock(),
+	}
+	ps.setCompactBlockSent(rs.Height, rs.Round, blockHash, false)
+	return true
+}
+
+// sendCompactBlockSupport advertises the support of compact blocks to the peer.
+func (r *Reactor) sendCompactBlockSupport(peerID types.NodeID) {
+	select {
+	case r.dataCh.Out <- p2p.Envelope{
+		To:      peerID,
+		Message: &tmcons.CompactBlockSupport{},
+	}:
+	case <-r.closeCh:
+	}
+}
+
+// handleCompactBlock starts reconstructing a compact block received from a
+// peer, requesting the transactions missing from our mempool.
+func (r *Reactor) handleCompactBlock(ps *PeerState, msg *CompactBlockMessage) {
+	rs := r.state.GetRoundState()
+	switch {
+	case rs.Height != msg.Height || rs.ProposalBlockParts == nil || r.mempool == nil:
+		r.sendCompactBlockStatus(ps, msg.Height, msg.Round, false, nil)
+		return
+
+	case rs.ProposalBlockParts.IsComplete():
+		r.sendCompactBlockStatus(ps, msg.Height, msg.Round, true, nil)
+		return
+	}
+
+	recv := &compactBlockRecv{msg: msg, txs: make(types.Txs, len(msg.TxHashes))}
+	var missing []uint32
+	for i, hash := range msg.TxHashes {
+		var key [mempool.TxKeySize]byte
+		copy(key[:], hash)
+		if tx, ok := r.mempool.GetTxByKey(key); ok {
+			recv.txs[i] = tx
+		} else {
+			missing = append(missing, uint32(i))
+		}
+	}
+	recv.missing = len(missing)
+
+	if recv.missing == 0 {
+		r.completeCompactBlock(ps, recv)
+		return
+	}
+	ps.setCompactBlockRecv(recv)
+	r.sendCompactBlockStatus(ps, msg.Height, msg.Round, false, missing)
+}
+
+// handleCompactBlockTxs adds the transactions received from a peer to the
+// compact block being reconstructed.
+func (r *Reactor) handleCompactBlockTxs(ps *PeerState, msg *CompactBlockTxsMessage) {
+	recv := ps.getCompactBlockRecv(msg.Height, msg.Round)
+	if recv == nil {
+		return
+	}
+
+	for i, index := range msg.Indexes {
+		if int(index) >= len(recv.txs) || recv.txs[index] != nil {
+			continue
+		}
+		tx := msg.Txs[i]
+		if !bytes.Equal(tx.Hash(), recv.msg.TxHashes[index]) {
+			r.Logger.Debug("received compact block tx with wrong hash", "peer", ps.peerID, "index", index)
+			ps.setCompactBlockRecv(nil)
+			r.sendCompactBlockStatus(ps, msg.Height, msg.Round, false, nil)
+			return
+		}
+		recv.txs[index] = tx
+		recv.missing--
+	}
+
+	if recv.missing == 0 {
+		ps.setCompactBlockRecv(nil)
+		r.completeCompactBlock(ps, recv)
+	}
+}
+
+// completeCompactBlock reconstructs the parts of a compact block whose
+// transactions were all found and passes them to the consensus state, as if
+// they had been received from the peer.
+func (r *Reactor) completeCompactBlock(ps *PeerState, recv *compactBlockRecv) {
+	msg := recv.msg
+	rs := r.state.GetRoundState()
+	if rs.Height != msg.Height || rs.ProposalBlockParts == nil {
+		r.sendCompactBlockStatus(ps, msg.Height, msg.Round, false, nil)
+		return
+	}
+
+	header := rs.ProposalBlockParts.Header()
+	parts := msg.Block(recv.txs).MakePartSetLike(types.BlockPartSizeBytes, header)
+	if !parts.HasHeader(header) {
+		r.Logger.Debug("compact block doesn't match the proposal", "peer", ps.peerID,
+			"height", msg.Height, "round", msg.Round)
+		r.sendCompactBlockStatus(ps, msg.Height, msg.Round, false, nil)
+		return
+	}
+
+	// the data parts are enough to reconstruct an erasure coded part set
+	total := header.Total
+	if header.IsErasureCoded() {
+		total = header.DataParts
+	}
+	for i := 0; i < int(total); i++ {
+		r.state.peerMsgQueue <- msgInfo{&BlockPartMessage{msg.Height, msg.Round, parts.GetPart(i)}, ps.peerID}
+	}
+	r.sendCompactBlockStatus(ps, msg.Height, msg.Round, true, nil)
+}
+
+// handleCompactBlockStatus handles the response of a peer to the compact block
+// we sent it, sending it the transactions it requested if any.
+func (r *Reactor) handleCompactBlockStatus(ps *PeerState, msg *CompactBlockStatusMessage) {
+	if msg.Complete || len(msg.MissingTxs) == 0 {
+		ps.setCompactBlockDone(msg.Height, msg.Round, msg.Complete)
+		return
+	}
+
+	rs := r.state.GetRoundState()
+	if rs.Height != msg.Height || rs.ProposalBlock == nil ||
+		!ps.compactBlockSentFor(msg.Height, msg.Round, rs.ProposalBlock.Hash()) {
+		ps.setCompactBlockDone(msg.Height, msg.Round, false)
+		return
+	}
+
+	var (
+		txs     = rs.ProposalBlock.Data.Txs
+		batch   = &CompactBlockTxsMessage{Height: msg.Height, Round: msg.Round}
+		batchSz = 0
+	)
+	for _, index := range msg.MissingTxs {
+		if int(index) >= len(txs) || len(txs[index]) > maxCompactBlockTxsBytes {
+			ps.setCompactBlockDone(msg.Height, msg.Round, false)
+			return
+		}
+		if batchSz+len(txs[index]) > maxCompactBlockTxsBytes {
+			r.sendCompactBlockTxs(ps, batch)
+			batch = &CompactBlockTxsMessage{Height: msg.Height, Round: msg.Round}
+			batchSz = 0
+		}
+		batch.Indexes = append(batch.Indexes, index)
+		batch.Txs = append(batch.Txs, txs[index])
+		batchSz += len(txs[index])
+	}
+	r.sendCompactBlockTxs(ps, batch)
+}
+
+func (r *Reactor) sendCompactBlockTxs(ps *PeerState, msg *CompactBlockTxsMessage) {
+	pb, err := MsgToProto(msg)
+	if err != nil {
+		r.Logger.Error("failed to convert compact block txs to proto", "err", err)
+		return
+	}
+	r.dataCh.Out <- p2p.Envelope{
+		To:      ps.peerID,
+		Message: pb.GetCompactBlockTxs(),
+	}
+}
+
+func (r *Reactor) sendCompactBlockStatus(ps *PeerState, height int64, round int32, complete bool, missing []uint32) {
+	r.dataCh.Out <- p2p.Envelope{
+		To: ps.peerID,
+		Message: &tmcons.CompactBlockStatus{
+			Height:     height,
+			Round:      round,
+			Complete:   complete,
+			MissingTxs: missing,
+		},
+	}
+}
+
+// supportsCompactBlocks returns true if the peer advertised the support of
+// compact blocks.
+func (ps *PeerState) supportsCompactBlocks() bool {
+	ps.mtx.RLock()
+	defer ps.mtx.RUnlock()
+
+	return ps.compactBlocks
+}
+
+func (ps *PeerState) setCompactBlockSupport() {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	ps.compactBlocks = true
+}
+
+// compactBlockSent returns whether a compact block was sent to the peer for the
+// given height and round and, if so, whether we are still waiting for the
+// peer to report if it could reconstruct the block.
+func (ps *PeerState) compactBlockSent(height int64, round int32) (sent, waiting bool) {
+	ps.mtx.RLock()
+	defer ps.mtx.RUnlock()
+
+	cs := ps.compactSent
+	if cs.height != height || cs.round != round || cs.sentAt.IsZero() {
+		return false, false
+	}
+	return true, !cs.done && time.Since(cs.sentAt) < compactBlockTimeout
+}
+
+// compactBlockSentFor returns true if the compact form of the given block was
+// sent to the peer for the given height and round.
+func (ps *PeerState) compactBlockSentFor(height int64, round int32, blockHash []byte) bool {
+	ps.mtx.RLock()
+	defer ps.mtx.RUnlock()
+
+	cs := ps.compactSent
+	return cs.height == height && cs.round == round && bytes.Equal(cs.blockHash, blockHash)
+}
+
+func (ps *PeerState) setCompactBlockSent(height int64, round int32, blockHash []byte, done bool) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	ps.compactSent = compactBlockSent{
+		height:    height,
+		round:     round,
+		blockHash: blockHash,
+		sentAt:    time.Now(),
+		done:      done,
+	}
+}
+
+// setCompactBlockDone records that the peer reported whether it could
+// reconstruct the compact block we sent it. If it could, it is known to have
+// all the parts of the block.
+func (ps *PeerState) setCompactBlockDone(height int64, round int32, complete bool) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	if ps.compactSent.height != height || ps.compactSent.round != round {
+		return
+	}
+	ps.compactSent.done = true
+
+	if complete && ps.PRS.Height == height && ps.PRS.Round == round && ps.PRS.ProposalBlockParts != nil {
+		for i := 0; i < ps.PRS.ProposalBlockParts.Size(); i++ {
+			ps.PRS.ProposalBlockParts.SetIndex(i, true)
+		}
+	}
+}
+
+func (ps *PeerState) getCompactBlockRecv(height int64, round int32) *compactBlockRecv {
+	ps.mtx.RLock()
+	defer ps.mtx.RUnlock()
+
+	recv := ps.compactRecv
+	if recv == nil || recv.msg.Height != height || recv.msg.Round != round {
+		return nil
+	}
+	return recv
+}
+
+func (ps *PeerState) setCompactBlockRecv(recv *compactBlockRecv) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	ps.compactRecv = recv
+}
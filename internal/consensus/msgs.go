@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/tendermint/tendermint/crypto/tmhash"
 	cstypes "github.com/tendermint/tendermint/internal/consensus/types"
 	"github.com/tendermint/tendermint/libs/bits"
 	tmjson "github.com/tendermint/tendermint/libs/json"
@@ -26,6 +27,10 @@ func init() {
 	tmjson.RegisterType(&ProposalMessage{}, "tendermint/Proposal")
 	tmjson.RegisterType(&ProposalPOLMessage{}, "tendermint/ProposalPOL")
 	tmjson.RegisterType(&BlockPartMessage{}, "tendermint/BlockPart")
+	tmjson.RegisterType(&CompactBlockMessage{}, "tendermint/CompactBlock")
+	tmjson.RegisterType(&CompactBlockStatusMessage{}, "tendermint/CompactBlockStatus")
+	tmjson.RegisterType(&CompactBlockTxsMessage{}, "tendermint/CompactBlockTxs")
+	tmjson.RegisterType(&CompactBlockSupportMessage{}, "tendermint/CompactBlockSupport")
 	tmjson.RegisterType(&VoteMessage{}, "tendermint/Vote")
 	tmjson.RegisterType(&HasVoteMessage{}, "tendermint/HasVote")
 	tmjson.RegisterType(&VoteSetMaj23Message{}, "tendermint/VoteSetMaj23")
@@ -201,6 +206,141 @@ func (m *BlockPartMessage) String() string {
 	return fmt.Sprintf("[BlockPart H:%v R:%v P:%v]", m.Height, m.Round, m.Part)
 }
 
+// CompactBlockMessage is sent in place of the parts of a complete proposal
+// block. The transactions of the block are replaced by their hashes, so that the
+// peer can reconstruct the block from its mempool.
+type CompactBlockMessage struct {
+	Height     int64
+	Round      int32
+	Header     types.Header
+	Evidence   types.EvidenceData
+	LastCommit *types.Commit
+	TxHashes   [][]byte
+}
+
+// NewCompactBlockMessage returns the compact form of the given block.
+func NewCompactBlockMessage(height int64, round int32, block *types.Block) *CompactBlockMessage {
+	txHashes := make([][]byte, len(block.Data.Txs))
+	for i, tx := range block.Data.Txs {
+		txHashes[i] = tx.Hash()
+	}
+	return &CompactBlockMessage{
+		Height:     height,
+		Round:      round,
+		Header:     block.Header,
+		Evidence:   types.EvidenceData{Evidence: block.Evidence.Evidence},
+		LastCommit: block.LastCommit,
+		TxHashes:   txHashes,
+	}
+}
+
+// Block returns the block with the given transactions, which must match the
+// hashes of the message.
+func (m *CompactBlockMessage) Block(txs types.Txs) *types.Block {
+	return &types.Block{
+		Header:     m.Header,
+		Data:       types.Data{Txs: txs},
+		Evidence:   types.EvidenceData{Evidence: m.Evidence.Evidence},
+		LastCommit: m.LastCommit,
+	}
+}
+
+// ValidateBasic performs basic validation.
+func (m *CompactBlockMessage) ValidateBasic() error {
+	if m.Height < 0 {
+		return errors.New("negative Height")
+	}
+	if m.Round < 0 {
+		return errors.New("negative Round")
+	}
+	if err := m.Header.ValidateBasic(); err != nil {
+		return fmt.Errorf("wrong Header: %v", err)
+	}
+	for i, hash := range m.TxHashes {
+		if len(hash) != tmhash.Size {
+			return fmt.Errorf("wrong TxHashes #%d: expected size to be %d bytes, got %d bytes",
+				i, tmhash.Size, len(hash))
+		}
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *CompactBlockMessage) String() string {
+	return fmt.Sprintf("[CompactBlock H:%v R:%v B:%v Txs:%d]", m.Height, m.Round, m.Header.Hash(), len(m.TxHashes))
+}
+
+// CompactBlockStatusMessage is sent in response to a CompactBlockMessage,
+// either to request the transactions missing from the mempool or to report
+// whether the block could be reconstructed.
+type CompactBlockStatusMessage struct {
+	Height     int64
+	Round      int32
+	Complete   bool
+	MissingTxs []uint32
+}
+
+// ValidateBasic performs basic validation.
+func (m *CompactBlockStatusMessage) ValidateBasic() error {
+	if m.Height < 0 {
+		return errors.New("negative Height")
+	}
+	if m.Round < 0 {
+		return errors.New("negative Round")
+	}
+	if m.Complete && len(m.MissingTxs) > 0 {
+		return errors.New("complete status with missing txs")
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *CompactBlockStatusMessage) String() string {
+	return fmt.Sprintf("[CompactBlockStatus H:%v R:%v C:%v M:%d]", m.Height, m.Round, m.Complete, len(m.MissingTxs))
+}
+
+// CompactBlockTxsMessage is sent in response to a CompactBlockStatusMessage
+// requesting missing transactions.
+type CompactBlockTxsMessage struct {
+	Height  int64
+	Round   int32
+	Indexes []uint32
+	Txs     types.Txs
+}
+
+// ValidateBasic performs basic validation.
+func (m *CompactBlockTxsMessage) ValidateBasic() error {
+	if m.Height < 0 {
+		return errors.New("negative Height")
+	}
+	if m.Round < 0 {
+		return errors.New("negative Round")
+	}
+	if len(m.Indexes) != len(m.Txs) {
+		return fmt.Errorf("got %d indexes for %d txs", len(m.Indexes), len(m.Txs))
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *CompactBlockTxsMessage) String() string {
+	return fmt.Sprintf("[CompactBlockTxs H:%v R:%v Txs:%d]", m.Height, m.Round, len(m.Txs))
+}
+
+// CompactBlockSupportMessage is sent to a peer on connect to advertise the
+// support of compact blocks.
+type CompactBlockSupportMessage struct{}
+
+// ValidateBasic performs basic validation.
+func (m *CompactBlockSupportMessage) ValidateBasic() error {
+	return nil
+}
+
+// String returns a string representation.
+func (m *CompactBlockSupportMessage) String() string {
+	return "[CompactBlockSupport]"
+}
+
 // VoteMessage is sent when voting for a proposal (or lack thereof).
 type VoteMessage struct {
 	Vote *types.Vote
@@ -383,6 +523,57 @@ func MsgToProto(msg Message) (*tmcons.Message, error) {
 				},
 			},
 		}
+	case *CompactBlockMessage:
+		evidence, err := msg.Evidence.ToProto()
+		if err != nil {
+			return nil, fmt.Errorf("msg to proto error: %w", err)
+		}
+		pb = tmcons.Message{
+			Sum: &tmcons.Message_CompactBlock{
+				CompactBlock: &tmcons.CompactBlock{
+					Height: msg.Height,
+					Round:  msg.Round,
+					Block: &tmproto.Block{
+						Header:     *msg.Header.ToProto(),
+						Evidence:   *evidence,
+						LastCommit: msg.LastCommit.ToProto(),
+					},
+					TxHashes: msg.TxHashes,
+				},
+			},
+		}
+	case *CompactBlockStatusMessage:
+		pb = tmcons.Message{
+			Sum: &tmcons.Message_CompactBlockStatus{
+				CompactBlockStatus: &tmcons.CompactBlockStatus{
+					Height:     msg.Height,
+					Round:      msg.Round,
+					Complete:   msg.Complete,
+					MissingTxs: msg.MissingTxs,
+				},
+			},
+		}
+	case *CompactBlockTxsMessage:
+		txs := make([][]byte, len(msg.Txs))
+		for i, tx := range msg.Txs {
+			txs[i] = tx
+		}
+		pb = tmcons.Message{
+			Sum: &tmcons.Message_CompactBlockTxs{
+				CompactBlockTxs: &tmcons.CompactBlockTxs{
+					Height:  msg.Height,
+					Round:   msg.Round,
+					Indexes: msg.Indexes,
+					Txs:     txs,
+				},
+			},
+		}
+	case *CompactBlockSupportMessage:
+		pb = tmcons.Message{
+			Sum: &tmcons.Message_CompactBlockSupport{
+				CompactBlockSupport: &tmcons.CompactBlockSupport{},
+			},
+		}
 	case *VoteMessage:
 		vote := msg.Vote.ToProto()
 		pb = tmcons.Message{
@@ -513,6 +704,52 @@ func MsgFromProto(msg *tmcons.Message) (Message, error) {
 			Round:  msg.BlockPart.Round,
 			Part:   parts,
 		}
+	case *tmcons.Message_CompactBlock:
+		if msg.CompactBlock.Block == nil {
+			return nil, errors.New("compact block msg to proto error: nil block")
+		}
+		header, err := types.HeaderFromProto(&msg.CompactBlock.Block.Header)
+		if err != nil {
+			return nil, fmt.Errorf("compact block msg to proto error: %w", err)
+		}
+		var evidence types.EvidenceData
+		if err := evidence.FromProto(&msg.CompactBlock.Block.Evidence); err != nil {
+			return nil, fmt.Errorf("compact block msg to proto error: %w", err)
+		}
+		var lastCommit *types.Commit
+		if msg.CompactBlock.Block.LastCommit != nil {
+			if lastCommit, err = types.CommitFromProto(msg.CompactBlock.Block.LastCommit); err != nil {
+				return nil, fmt.Errorf("compact block msg to proto error: %w", err)
+			}
+		}
+		pb = &CompactBlockMessage{
+			Height:     msg.CompactBlock.Height,
+			Round:      msg.CompactBlock.Round,
+			Header:     header,
+			Evidence:   evidence,
+			LastCommit: lastCommit,
+			TxHashes:   msg.CompactBlock.TxHashes,
+		}
+	case *tmcons.Message_CompactBlockStatus:
+		pb = &CompactBlockStatusMessage{
+			Height:     msg.CompactBlockStatus.Height,
+			Round:      msg.CompactBlockStatus.Round,
+			Complete:   msg.CompactBlockStatus.Complete,
+			MissingTxs: msg.CompactBlockStatus.MissingTxs,
+		}
+	case *tmcons.Message_CompactBlockTxs:
+		txs := make(types.Txs, len(msg.CompactBlockTxs.Txs))
+		for i, tx := range msg.CompactBlockTxs.Txs {
+			txs[i] = tx
+		}
+		pb = &CompactBlockTxsMessage{
+			Height:  msg.CompactBlockTxs.Height,
+			Round:   msg.CompactBlockTxs.Round,
+			Indexes: msg.CompactBlockTxs.Indexes,
+			Txs:     txs,
+		}
+	case *tmcons.Message_CompactBlockSupport:
+		pb = &CompactBlockSupportMessage{}
 	case *tmcons.Message_Vote:
 		vote, err := types.VoteFromProto(msg.Vote.Vote)
 		if err != nil {
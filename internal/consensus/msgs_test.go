@@ -730,3 +730,73 @@ func TestHasVoteMessageValidateBasic(t *testing.T) {
 		})
 	}
 }
+
+func TestCompactBlockMessageRoundTrip(t *testing.T) {
+	txs := types.Txs{types.Tx("tx1"), types.Tx("tx2"), types.Tx("tx3")}
+	block := types.MakeBlock(1, txs, &types.Commit{}, nil)
+	block.ProposerAddress = tmrand.Bytes(20)
+
+	msg := NewCompactBlockMessage(2, 1, block)
+	require.Len(t, msg.TxHashes, len(txs))
+	require.NoError(t, msg.ValidateBasic())
+
+	pb, err := MsgToProto(msg)
+	require.NoError(t, err)
+	require.NotNil(t, pb.GetCompactBlock())
+	require.Empty(t, pb.GetCompactBlock().Block.Data.Txs)
+
+	decoded, err := MsgFromProto(pb)
+	require.NoError(t, err)
+	compact, ok := decoded.(*CompactBlockMessage)
+	require.True(t, ok)
+	assert.Equal(t, msg.TxHashes, compact.TxHashes)
+	assert.Equal(t, block.Hash(), compact.Block(txs).Hash())
+
+	for _, m := range []Message{
+		&CompactBlockStatusMessage{Height: 2, Round: 1, MissingTxs: []uint32{0, 2}},
+		&CompactBlockTxsMessage{Height: 2, Round: 1, Indexes: []uint32{0, 2}, Txs: types.Txs{txs[0], txs[2]}},
+		&CompactBlockSupportMessage{},
+	} {
+		pb, err := MsgToProto(m)
+		require.NoError(t, err)
+		decoded, err := MsgFromProto(pb)
+		require.NoError(t, err)
+		assert.Equal(t, m, decoded)
+	}
+}
+
+func TestCompactBlockMessagesValidateBasic(t *testing.T) {
+	block := types.MakeBlock(1, types.Txs{types.Tx("tx")}, &types.Commit{}, nil)
+	block.ProposerAddress = tmrand.Bytes(20)
+
+	testCases := []struct {
+		testName  string
+		message   Message
+		expectErr bool
+	}{
+		{"Valid CompactBlock", NewCompactBlockMessage(2, 0, block), false},
+		{"Negative CompactBlock Height", NewCompactBlockMessage(-1, 0, block), true},
+		{"Negative CompactBlock Round", NewCompactBlockMessage(2, -1, block), true},
+		{"Invalid CompactBlock TxHash", &CompactBlockMessage{
+			Height: 2, Header: block.Header, TxHashes: [][]byte{{0x01}},
+		}, true},
+		{"Valid CompactBlockStatus", &CompactBlockStatusMessage{Height: 2, MissingTxs: []uint32{0}}, false},
+		{"Complete CompactBlockStatus", &CompactBlockStatusMessage{Height: 2, Complete: true}, false},
+		{"Complete CompactBlockStatus With Missing", &CompactBlockStatusMessage{
+			Height: 2, Complete: true, MissingTxs: []uint32{0},
+		}, true},
+		{"Negative CompactBlockStatus Round", &CompactBlockStatusMessage{Height: 2, Round: -1}, true},
+		{"Valid CompactBlockTxs", &CompactBlockTxsMessage{
+			Height: 2, Indexes: []uint32{0}, Txs: types.Txs{types.Tx("tx")},
+		}, false},
+		{"Mismatched CompactBlockTxs", &CompactBlockTxsMessage{Height: 2, Indexes: []uint32{0, 1}}, true},
+		{"Negative CompactBlockTxs Height", &CompactBlockTxsMessage{Height: -1}, true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testName, func(t *testing.T) {
+			assert.Equal(t, tc.expectErr, tc.message.ValidateBasic() != nil, "Validate Basic had an unexpected result")
+		})
+	}
+}
@@ -45,6 +45,11 @@ type PeerState struct {
 	PRS     cstypes.PeerRoundState `json:"round_state"`
 	Stats   *peerStateStats        `json:"stats"`
 
+	// compact blocks sent to and received from the peer, see compact_block.go
+	compactBlocks bool // the peer advertised the support of compact blocks
+	compactSent   compactBlockSent
+	compactRecv   *compactBlockRecv
+
 	broadcastWG sync.WaitGroup
 	closer      *tmsync.Closer
 }
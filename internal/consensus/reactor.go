@@ -7,6 +7,7 @@ import (
 
 	cstypes "github.com/tendermint/tendermint/internal/consensus/types"
 	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
+	"github.com/tendermint/tendermint/internal/mempool"
 	"github.com/tendermint/tendermint/internal/p2p"
 	"github.com/tendermint/tendermint/libs/bits"
 	tmevents "github.com/tendermint/tendermint/libs/events"
@@ -127,6 +128,7 @@ type Reactor struct {
 	state    *State
 	eventBus *types.EventBus
 	Metrics  *Metrics
+	mempool  mempool.Mempool
 
 	mtx      tmsync.RWMutex
 	peers    map[types.NodeID]*PeerState
@@ -417,6 +419,22 @@ func (r *Reactor) subscribeToBroadcastEvents() {
 		r.Logger.Error("failed to add listener for events", "err", err)
 	}
 
+	err = r.state.evsw.AddListenerForEvent(
+		listenerIDConsensus,
+		types.EventCompleteProposalValue,
+		func(data tmevents.EventData) {
+			// The parts of an erasure coded block reconstructed from some of them
+			// are reported, so that peers stop sending them.
+			rs := data.(*cstypes.RoundState)
+			if rs.ProposalBlockParts.IsErasureCoded() {
+				r.broadcastNewValidBlockMessage(rs)
+			}
+		},
+	)
+	if err != nil {
+		r.Logger.Error("failed to add listener for events", "err", err)
+	}
+
 	err = r.state.evsw.AddListenerForEvent(
 		listenerIDConsensus,
 		types.EventVoteValue,
@@ -539,8 +557,11 @@ OUTER_LOOP:
 		rs := r.state.GetRoundState()
 		prs := ps.GetRoundState()
 
-		// Send proposal Block parts?
-		if rs.ProposalBlockParts.HasHeader(prs.ProposalBlockPartSetHeader) {
+		// Send proposal Block parts? Parts are held back while the peer
+		// reconstructs the block from the compact block we sent it. A peer which
+		// reconstructs an erasure coded block from some of its parts reports it,
+		// after which no more parts are sent to it.
+		if rs.ProposalBlockParts.HasHeader(prs.ProposalBlockPartSetHeader) && !r.sendCompactBlock(rs, prs, ps) {
 			if index, ok := rs.ProposalBlockParts.BitArray().Sub(prs.ProposalBlockParts.Copy()).PickRandom(); ok {
 				part := rs.ProposalBlockParts.GetPart(index)
 				partProto, err := part.ToProto()
@@ -974,6 +995,12 @@ func (r *Reactor) processPeerUpdate(peerUpdate p2p.PeerUpdate) {
 			if !r.waitSync {
 				go r.sendNewRoundStepMessage(ps.peerID)
 			}
+
+			// Advertise the support of compact blocks to the peer, which keeps
+			// sending us the block parts otherwise.
+			if r.compactBlocksEnabled() {
+				go r.sendCompactBlockSupport(ps.peerID)
+			}
 		}
 
 	case p2p.PeerStatusDown:
@@ -1095,6 +1122,13 @@ func (r *Reactor) handleDataMessage(envelope p2p.Envelope, msgI Message) error {
 		return nil
 	}
 
+	// the support of compact blocks is only advertised on connect, possibly
+	// while we are syncing
+	if _, ok := envelope.Message.(*tmcons.CompactBlockSupport); ok {
+		ps.setCompactBlockSupport()
+		return nil
+	}
+
 	if r.WaitSync() {
 		logger.Info("ignoring message received during sync", "msg", msgI)
 		return nil
@@ -1117,6 +1151,15 @@ func (r *Reactor) handleDataMessage(envelope p2p.Envelope, msgI Message) error {
 		r.Metrics.BlockParts.With("peer_id", string(envelope.From)).Add(1)
 		r.state.peerMsgQueue <- msgInfo{bpMsg, envelope.From}
 
+	case *tmcons.CompactBlock:
+		r.handleCompactBlock(ps, msgI.(*CompactBlockMessage))
+
+	case *tmcons.CompactBlockStatus:
+		r.handleCompactBlockStatus(ps, msgI.(*CompactBlockStatusMessage))
+
+	case *tmcons.CompactBlockTxs:
+		r.handleCompactBlockTxs(ps, msgI.(*CompactBlockTxsMessage))
+
 	default:
 		return fmt.Errorf("received unknown message on DataChannel: %T", msg)
 	}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/fortytw2/leaktest"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
@@ -87,6 +88,7 @@ func setup(t *testing.T, numNodes int, states []*State, size int) *reactorTestSu
 			rts.voteSetBitsChannels[nodeID],
 			node.MakePeerUpdates(t),
 			true,
+			ReactorMempool(assertMempool(state.txNotifier)),
 		)
 
 		reactor.SetEventBus(state.eventBus)
@@ -461,6 +463,172 @@ func TestReactorCreatesBlockWhenEmptyBlocksFalse(t *testing.T) {
 	wg.Wait()
 }
 
+func TestReactorCompactBlocks(t *testing.T) {
+	config := configSetup(t)
+
+	n := 4
+	states, cleanup := randConsensusState(
+		t,
+		config,
+		n,
+		"consensus_reactor_test",
+		newMockTickerFunc(true),
+		newKVStore,
+		func(c *cfg.Config) {
+			c.Consensus.CompactBlocks = true
+		},
+	)
+
+	t.Cleanup(cleanup)
+
+	rts := setup(t, n, states, 100) // buffer must be large enough to not deadlock
+
+	// every node has a tx the others are missing, so that they have to request
+	// it to reconstruct the compact block
+	for i, state := range states {
+		require.NoError(
+			t,
+			assertMempool(state.txNotifier).CheckTx(
+				context.Background(),
+				[]byte{byte(i)},
+				nil,
+				mempool.TxInfo{},
+			),
+		)
+	}
+
+	for _, reactor := range rts.reactors {
+		state := reactor.state.GetState()
+		reactor.SwitchToConsensus(state, false)
+	}
+
+	var wg sync.WaitGroup
+	for _, sub := range rts.subs {
+		wg.Add(1)
+
+		// wait till everyone makes the first block, with a tx
+		go func(s types.Subscription) {
+			defer wg.Done()
+			msg := <-s.Out()
+			block := msg.Data().(types.EventDataNewBlock).Block
+			assert.Len(t, block.Data.Txs, 1)
+		}(sub)
+	}
+
+	wg.Wait()
+}
+
+func TestReactorErasureCodedBlocks(t *testing.T) {
+	config := configSetup(t)
+
+	n := 4
+	states, cleanup := randConsensusState(
+		t,
+		config,
+		n,
+		"consensus_reactor_test",
+		newMockTickerFunc(true),
+		newKVStore,
+	)
+
+	t.Cleanup(cleanup)
+
+	for _, state := range states {
+		state.state.ConsensusParams.Block.PartParityPercent = 100
+	}
+
+	rts := setup(t, n, states, 100) // buffer must be large enough to not deadlock
+
+	for _, reactor := range rts.reactors {
+		state := reactor.state.GetState()
+		reactor.SwitchToConsensus(state, false)
+	}
+
+	var wg sync.WaitGroup
+	for _, sub := range rts.subs {
+		wg.Add(1)
+
+		// wait till everyone makes a few erasure coded blocks
+		go func(s types.Subscription) {
+			defer wg.Done()
+			for i := 0; i < 3; i++ {
+				msg := <-s.Out()
+				blockID := msg.Data().(types.EventDataNewBlock).BlockID
+				assert.True(t, blockID.PartSetHeader.IsErasureCoded())
+			}
+		}(sub)
+	}
+
+	wg.Wait()
+}
+
+func TestReactorCompactBlocksUnsupported(t *testing.T) {
+	config := configSetup(t)
+
+	n := 4
+	states, cleanup := randConsensusState(
+		t,
+		config,
+		n,
+		"consensus_reactor_test",
+		newMockTickerFunc(true),
+		newKVStore,
+		func(c *cfg.Config) {
+			c.Consensus.CompactBlocks = true
+		},
+	)
+
+	t.Cleanup(cleanup)
+
+	// the first node doesn't support compact blocks, and is sent block parts
+	states[0].config.CompactBlocks = false
+
+	rts := setup(t, n, states, 100) // buffer must be large enough to not deadlock
+
+	for _, reactor := range rts.reactors {
+		state := reactor.state.GetState()
+		reactor.SwitchToConsensus(state, false)
+	}
+
+	var wg sync.WaitGroup
+	for _, sub := range rts.subs {
+		wg.Add(1)
+
+		// wait till everyone makes the first block
+		go func(s types.Subscription) {
+			defer wg.Done()
+			<-s.Out()
+		}(sub)
+	}
+
+	wg.Wait()
+
+	var unsupported types.NodeID
+	for nodeID, state := range rts.states {
+		if state == states[0] {
+			unsupported = nodeID
+		}
+	}
+
+	// only the peers which advertised the support of compact blocks are
+	// recorded as supporting them
+	for nodeID, reactor := range rts.reactors {
+		if nodeID == unsupported {
+			continue
+		}
+
+		for peerID := range rts.states {
+			if peerID == nodeID {
+				continue
+			}
+
+			ps, ok := reactor.GetPeerState(peerID)
+			require.True(t, ok)
+			require.Equal(t, peerID != unsupported, ps.supportsCompactBlocks(), "peer %v", peerID)
+		}
+	}
+}
+
 func TestReactorRecordsVotesAndBlockParts(t *testing.T) {
 	config := configSetup(t)
 
@@ -39,6 +39,13 @@ func (emptyMempool) FlushAppConn() error           { return nil }
 func (emptyMempool) TxsAvailable() <-chan struct{} { return make(chan struct{}) }
 func (emptyMempool) EnableTxsAvailable()           {}
 func (emptyMempool) SizeBytes() int64              { return 0 }
+func (emptyMempool) GetTxByKey(_ [mempl.TxKeySize]byte) (types.Tx, bool) {
+	return nil, false
+}
+func (emptyMempool) GetTxRecord(_ [mempl.TxKeySize]byte) (mempl.TxRecord, bool) {
+	return mempl.TxRecord{}, false
+}
+func (emptyMempool) GetTxRecords() []mempl.TxRecord { return nil }
 
 func (emptyMempool) TxsFront() *clist.CElement    { return nil }
 func (emptyMempool) TxsWaitChan() <-chan struct{} { return nil }
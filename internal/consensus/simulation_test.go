@@ -0,0 +1,422 @@
+package consensus
+
+import (
+	"container/heap"
+	"fmt"
+	"math/rand"
+	"os"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/abci/example/kvstore"
+	abci "github.com/tendermint/tendermint/abci/types"
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	cstypes "github.com/tendermint/tendermint/internal/consensus/types"
+	tmevents "github.com/tendermint/tendermint/libs/events"
+	"github.com/tendermint/tendermint/libs/log"
+	tmtime "github.com/tendermint/tendermint/libs/time"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
+	"github.com/tendermint/tendermint/types"
+)
+
+// A simulation drives a network of consensus states deterministically: time
+// is virtual, every timeout and message delivery is an event in a single
+// queue processed by the test goroutine, and all randomness (validator keys,
+// latencies, drops) is derived from a seed. Running a simulation twice with
+// the same seed and the same network conditions yields the same trace, so a
+// liveness bug found with a given seed can be reproduced exactly.
+//
+// The states are never started: the simulation calls handleMsg and
+// handleTimeout itself, in place of the receiveRoutine. Messages a state sends
+// to itself are broadcast to the other nodes, which periodically re-gossip
+// their own messages for the height a peer is at, like the reactor does.
+type simulation struct {
+	t   *testing.T
+	rng *rand.Rand
+
+	now   time.Duration // virtual time since the start of the simulation
+	seq   uint64
+	queue simEventQueue
+	nodes []*simNode
+
+	// network conditions
+	minLatency     time.Duration
+	maxLatency     time.Duration
+	dropRate       float64
+	partitions     []int // partition of each node, nil if the network is whole
+	gossipInterval time.Duration
+
+	trace []string
+}
+
+type simNode struct {
+	id     types.NodeID
+	cs     *State
+	ticker *simTicker
+
+	// messages sent by the node, by height
+	sent map[int64][]Message
+}
+
+type simEventKind int
+
+const (
+	simEventDeliver simEventKind = iota
+	simEventTimeout
+	simEventGossip
+)
+
+type simEvent struct {
+	at   time.Duration
+	seq  uint64 // breaks ties between events scheduled at the same time
+	kind simEventKind
+	node int
+
+	// simEventDeliver
+	from int
+	msg  Message
+
+	// simEventTimeout
+	ti  timeoutInfo
+	gen uint64
+}
+
+type simEventQueue []*simEvent
+
+func (q simEventQueue) Len() int { return len(q) }
+func (q simEventQueue) Less(i, j int) bool {
+	if q[i].at != q[j].at {
+		return q[i].at < q[j].at
+	}
+	return q[i].seq < q[j].seq
+}
+func (q simEventQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *simEventQueue) Push(x interface{}) { *q = append(*q, x.(*simEvent)) }
+func (q *simEventQueue) Pop() interface{} {
+	old := *q
+	ev := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return ev
+}
+
+// simTicker replaces the TimeoutTicker of a simulated node, scheduling the
+// timeouts on the virtual clock of the simulation.
+type simTicker struct {
+	sim  *simulation
+	node int
+
+	ti  timeoutInfo
+	gen uint64 // incremented on every scheduled timeout, so that stale ones are skipped
+}
+
+var _ TimeoutTicker = (*simTicker)(nil)
+
+func (t *simTicker) Start() error                   { return nil }
+func (t *simTicker) Stop() error                    { return nil }
+func (t *simTicker) Chan() <-chan timeoutInfo       { return nil }
+func (t *simTicker) SetLogger(log.Logger)           {}
+func (t *simTicker) ScheduleTimeout(ti timeoutInfo) { t.schedule(ti) }
+
+func (t *simTicker) schedule(newti timeoutInfo) {
+	// ignore timeouts for old height/round/step, as the timeoutTicker does
+	if newti.Height < t.ti.Height {
+		return
+	} else if newti.Height == t.ti.Height {
+		if newti.Round < t.ti.Round {
+			return
+		} else if newti.Round == t.ti.Round && t.ti.Step > 0 && newti.Step <= t.ti.Step {
+			return
+		}
+	}
+
+	// The timeout of the new height step is computed from the wall clock, so
+	// use the commit timeout it's derived from instead.
+	if newti.Step == cstypes.RoundStepNewHeight {
+		newti.Duration = t.sim.nodes[t.node].cs.config.TimeoutCommit
+	}
+
+	t.ti = newti
+	t.gen++
+	t.sim.schedule(&simEvent{
+		at:   t.sim.now + newti.Duration,
+		kind: simEventTimeout,
+		node: t.node,
+		ti:   newti,
+		gen:  t.gen,
+	})
+}
+
+// newSimulation creates a network of nValidators consensus states whose keys
+// and network conditions are derived from seed.
+func newSimulation(t *testing.T, nValidators int, seed int64, configOpts ...func(*cfg.Config)) *simulation {
+	t.Helper()
+
+	sim := &simulation{
+		t:              t,
+		rng:            rand.New(rand.NewSource(seed)), // nolint:gosec // deterministic on purpose
+		minLatency:     time.Millisecond,
+		maxLatency:     time.Millisecond,
+		gossipInterval: 100 * time.Millisecond,
+	}
+
+	privVals := make([]types.PrivValidator, nValidators)
+	validators := make([]types.GenesisValidator, nValidators)
+	for i := 0; i < nValidators; i++ {
+		privKey := ed25519.GenPrivKeyFromSecret([]byte(fmt.Sprintf("simulation/%d/%d", seed, i)))
+		privVals[i] = types.NewMockPVWithParams(privKey, false, false)
+		validators[i] = types.GenesisValidator{PubKey: privKey.PubKey(), Power: testMinPower}
+	}
+	sort.Sort(types.PrivValidatorsByAddress(privVals))
+
+	genDoc := &types.GenesisDoc{
+		GenesisTime:   tmtime.Now(),
+		InitialHeight: 1,
+		ChainID:       fmt.Sprintf("simulation-%d", seed),
+		Validators:    validators,
+	}
+
+	for i := 0; i < nValidators; i++ {
+		config := cfg.ResetTestRoot(fmt.Sprintf("simulation_%d", i))
+		t.Cleanup(func() { os.RemoveAll(config.RootDir) })
+		for _, opt := range configOpts {
+			opt(config)
+		}
+
+		state, err := sm.MakeGenesisState(genDoc)
+		require.NoError(t, err)
+
+		app := kvstore.NewApplication()
+		app.InitChain(abci.RequestInitChain{Validators: types.TM2PB.ValidatorUpdates(state.Validators)})
+
+		cs := newStateWithConfigAndBlockStore(config, state, privVals[i], app, store.NewBlockStore(dbm.NewMemDB()))
+		cs.SetLogger(consensusLogger().With("validator", i))
+		t.Cleanup(func() { _ = cs.eventBus.Stop() })
+
+		node := &simNode{
+			id:     types.NodeID(fmt.Sprintf("%040x", i)),
+			cs:     cs,
+			ticker: &simTicker{sim: sim, node: i},
+			sent:   make(map[int64][]Message),
+		}
+		cs.SetTimeoutTicker(node.ticker)
+
+		i := i
+		cs.evsw.AddListenerForEvent("simulation", types.EventNewRoundStepValue, func(data tmevents.EventData) {
+			rs := data.(*cstypes.RoundState)
+			sim.tracef("node=%d %d/%d/%v", i, rs.Height, rs.Round, rs.Step)
+		})
+
+		sim.nodes = append(sim.nodes, node)
+	}
+
+	return sim
+}
+
+// setLatency makes the delivery of every message take a random duration in
+// [min, max], reordering messages when the range is wide enough.
+func (s *simulation) setLatency(min, max time.Duration) {
+	s.minLatency, s.maxLatency = min, max
+}
+
+// setDropRate makes every message be dropped with probability p.
+func (s *simulation) setDropRate(p float64) {
+	s.dropRate = p
+}
+
+// partition splits the network so that messages are only delivered between
+// nodes of the same group. Nodes not in any group are isolated.
+func (s *simulation) partition(groups ...[]int) {
+	s.partitions = make([]int, len(s.nodes))
+	for i := range s.partitions {
+		s.partitions[i] = -1 - i
+	}
+	for g, nodes := range groups {
+		for _, i := range nodes {
+			s.partitions[i] = g
+		}
+	}
+	s.tracef("partition %v", groups)
+}
+
+// heal reconnects all the nodes. Lost messages are recovered by gossip.
+func (s *simulation) heal() {
+	s.partitions = nil
+	s.tracef("heal")
+}
+
+func (s *simulation) tracef(format string, args ...interface{}) {
+	s.trace = append(s.trace, fmt.Sprintf("%v ", s.now)+fmt.Sprintf(format, args...))
+}
+
+func (s *simulation) schedule(ev *simEvent) {
+	s.seq++
+	ev.seq = s.seq
+	heap.Push(&s.queue, ev)
+}
+
+// start schedules the first round and the gossip of every node.
+func (s *simulation) start() {
+	for i, node := range s.nodes {
+		node.cs.scheduleRound0(node.cs.GetRoundState())
+		s.schedule(&simEvent{at: s.now + s.gossipInterval, kind: simEventGossip, node: i})
+	}
+}
+
+// runUntil processes events in order until cond is true or the virtual clock
+// would go past deadline, in which case it returns false.
+func (s *simulation) runUntil(deadline time.Duration, cond func() bool) bool {
+	for !cond() {
+		if s.queue.Len() == 0 || s.queue[0].at > deadline {
+			s.now = deadline
+			return false
+		}
+
+		ev := heap.Pop(&s.queue).(*simEvent)
+		s.now = ev.at
+		s.handle(ev)
+	}
+	return true
+}
+
+// runUntilHeight runs the simulation until every node has committed height.
+func (s *simulation) runUntilHeight(deadline time.Duration, height int64) bool {
+	return s.runUntil(deadline, func() bool {
+		for _, node := range s.nodes {
+			if node.cs.Height <= height {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func (s *simulation) handle(ev *simEvent) {
+	node := s.nodes[ev.node]
+
+	switch ev.kind {
+	case simEventDeliver:
+		node.cs.handleMsg(msgInfo{Msg: ev.msg, PeerID: s.nodes[ev.from].id})
+
+	case simEventTimeout:
+		if ev.gen != node.ticker.gen {
+			return // superseded by a later timeout
+		}
+		node.cs.handleTimeout(ev.ti, node.cs.RoundState)
+
+	case simEventGossip:
+		s.gossip(ev.node)
+		s.schedule(&simEvent{at: s.now + s.gossipInterval, kind: simEventGossip, node: ev.node})
+	}
+
+	s.process(ev.node)
+}
+
+// process handles the messages a node sent to itself, broadcasting them to
+// the other nodes.
+func (s *simulation) process(i int) {
+	cs := s.nodes[i].cs
+	for {
+		select {
+		case mi := <-cs.internalMsgQueue:
+			height := simMsgHeight(mi.Msg)
+			s.nodes[i].sent[height] = append(s.nodes[i].sent[height], mi.Msg)
+			for j := range s.nodes {
+				if j != i {
+					s.send(i, j, mi.Msg)
+				}
+			}
+			cs.handleMsg(mi)
+
+		case <-cs.statsMsgQueue:
+
+		default:
+			return
+		}
+	}
+}
+
+// gossip re-sends the messages node i sent for the height each peer is at.
+func (s *simulation) gossip(i int) {
+	for j, peer := range s.nodes {
+		if j == i {
+			continue
+		}
+		for _, msg := range s.nodes[i].sent[peer.cs.Height] {
+			s.send(i, j, msg)
+		}
+	}
+}
+
+func (s *simulation) send(from, to int, msg Message) {
+	if s.partitions != nil && s.partitions[from] != s.partitions[to] {
+		return
+	}
+	if s.dropRate > 0 && s.rng.Float64() < s.dropRate {
+		return
+	}
+
+	latency := s.minLatency
+	if s.maxLatency > s.minLatency {
+		latency += time.Duration(s.rng.Int63n(int64(s.maxLatency - s.minLatency + 1)))
+	}
+
+	// every node gets its own copy, as if it was received over the wire
+	pb, err := MsgToProto(msg)
+	require.NoError(s.t, err)
+	msg, err = MsgFromProto(pb)
+	require.NoError(s.t, err)
+
+	s.schedule(&simEvent{at: s.now + latency, kind: simEventDeliver, node: to, from: from, msg: msg})
+}
+
+func simMsgHeight(msg Message) int64 {
+	switch msg := msg.(type) {
+	case *ProposalMessage:
+		return msg.Proposal.Height
+	case *BlockPartMessage:
+		return msg.Height
+	case *VoteMessage:
+		return msg.Vote.Height
+	default:
+		panic(fmt.Sprintf("unexpected message type %T", msg))
+	}
+}
+
+func TestSimulationDeterministic(t *testing.T) {
+	run := func(seed int64) []string {
+		sim := newSimulation(t, 4, seed)
+		sim.setLatency(time.Millisecond, 50*time.Millisecond)
+		sim.setDropRate(0.1)
+		sim.start()
+		require.True(t, sim.runUntilHeight(time.Minute, 5), "seed %d, trace:\n%v", seed, sim.trace)
+		return sim.trace
+	}
+
+	for _, seed := range []int64{1, 2} {
+		require.Equal(t, run(seed), run(seed), "seed %d", seed)
+	}
+}
+
+func TestSimulationPartition(t *testing.T) {
+	const seed = 3
+
+	sim := newSimulation(t, 4, seed)
+	sim.setLatency(time.Millisecond, 20*time.Millisecond)
+	sim.start()
+	require.True(t, sim.runUntilHeight(time.Minute, 2), "trace:\n%v", sim.trace)
+
+	// no side of an even split has +2/3 of the voting power
+	height := sim.nodes[0].cs.Height
+	sim.partition([]int{0, 1}, []int{2, 3})
+	require.False(t, sim.runUntilHeight(sim.now+10*time.Second, height), "trace:\n%v", sim.trace)
+
+	// and the network recovers once the partition heals
+	sim.heal()
+	require.True(t, sim.runUntilHeight(sim.now+time.Minute, height+1), "trace:\n%v", sim.trace)
+}
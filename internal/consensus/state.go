@@ -81,6 +81,7 @@ type State struct {
 
 	// config details
 	config            *cfg.ConsensusConfig
+	timeouts          *timeoutEstimator
 	privValidator     types.PrivValidator // for signing votes
 	privValidatorType types.PrivValidatorType
 
@@ -162,6 +163,7 @@ func NewState(
 ) *State {
 	cs := &State{
 		config:           config,
+		timeouts:         newTimeoutEstimator(config),
 		blockExec:        blockExec,
 		blockStore:       blockStore,
 		txNotifier:       txNotifier,
@@ -483,6 +485,7 @@ func (cs *State) OpenWAL(walFile string) (WAL, error) {
 	}
 
 	wal.SetLogger(cs.Logger.With("wal", walFile))
+	wal.SetCompaction(cs.config.WalCompaction)
 
 	if err := wal.Start(); err != nil {
 		cs.Logger.Error("failed to start WAL", "err", err)
@@ -949,6 +952,8 @@ func (cs *State) handleTimeout(ti timeoutInfo, rs cstypes.RoundState) {
 			cs.Logger.Error("failed publishing timeout propose", "err", err)
 		}
 
+		cs.timeouts.propose.timedOut(ti.Height, ti.Round, ti.Duration)
+
 		cs.enterPrevote(ti.Height, ti.Round)
 
 	case cstypes.RoundStepPrevoteWait:
@@ -1116,7 +1121,8 @@ func (cs *State) enterPropose(height int64, round int32) {
 	}()
 
 	// If we don't get the proposal and all block parts quick enough, enterPrevote
-	cs.scheduleTimeout(cs.config.Propose(round), height, round, cstypes.RoundStepPropose)
+	cs.timeouts.propose.begin(height, round, tmtime.Now())
+	cs.scheduleTimeout(cs.timeouts.Propose(round), height, round, cstypes.RoundStepPropose)
 
 	// Nothing more to do if we're not a validator
 	if cs.privValidator == nil {
@@ -1147,6 +1153,8 @@ func (cs *State) enterPropose(height int64, round int32) {
 			"proposer", address,
 		)
 
+		// our own proposal arrives without any network latency
+		cs.timeouts.propose.cancel()
 		cs.decideProposal(height, round)
 	} else {
 		logger.Debug(
@@ -1286,6 +1294,8 @@ func (cs *State) enterPrevote(height int64, round int32) {
 
 	logger.Debug("entering prevote step", "current", fmt.Sprintf("%v/%v/%v", cs.Height, cs.Round, cs.Step))
 
+	cs.timeouts.prevote.begin(height, round, tmtime.Now())
+
 	// Sign and broadcast vote as necessary
 	cs.doPrevote(height, round)
 
@@ -1319,6 +1329,17 @@ func (cs *State) defaultDoPrevote(height int64, round int32) {
 		return
 	}
 
+	// Validate the erasure coding of the proposal block parts, which the
+	// consensus params determine
+	err = cs.ProposalBlockParts.Header().ValidateParams(
+		cs.state.ConsensusParams.Block, cs.ProposalBlockParts.ByteSize(), types.BlockPartSizeBytes,
+	)
+	if err != nil {
+		logger.Error("prevote step: ProposalBlockParts are invalid", "err", err)
+		cs.signAddVote(tmproto.PrevoteType, nil, types.PartSetHeader{})
+		return
+	}
+
 	// Prevote cs.ProposalBlock
 	// NOTE: the proposal signature is validated when it is received,
 	// and the proposal block parts are validated as they are received (against the merkle hash in the proposal)
@@ -1354,7 +1375,8 @@ func (cs *State) enterPrevoteWait(height int64, round int32) {
 	}()
 
 	// Wait for some more prevotes; enterPrecommit
-	cs.scheduleTimeout(cs.config.Prevote(round), height, round, cstypes.RoundStepPrevoteWait)
+	cs.timeouts.prevote.end(height, round, tmtime.Now())
+	cs.scheduleTimeout(cs.timeouts.Prevote(round), height, round, cstypes.RoundStepPrevoteWait)
 }
 
 // Enter: `timeoutPrevote` after any +2/3 prevotes.
@@ -1376,6 +1398,10 @@ func (cs *State) enterPrecommit(height int64, round int32) {
 
 	logger.Debug("entering precommit step", "current", fmt.Sprintf("%v/%v/%v", cs.Height, cs.Round, cs.Step))
 
+	now := tmtime.Now()
+	cs.timeouts.prevote.end(height, round, now)
+	cs.timeouts.precommit.begin(height, round, now)
+
 	defer func() {
 		// Done enterPrecommit:
 		cs.updateRoundStep(round, cstypes.RoundStepPrecommit)
@@ -1513,7 +1539,8 @@ func (cs *State) enterPrecommitWait(height int64, round int32) {
 	}()
 
 	// wait for some more precommits; enterNewRound
-	cs.scheduleTimeout(cs.config.Precommit(round), height, round, cstypes.RoundStepPrecommitWait)
+	cs.timeouts.precommit.end(height, round, tmtime.Now())
+	cs.scheduleTimeout(cs.timeouts.Precommit(round), height, round, cstypes.RoundStepPrecommitWait)
 }
 
 // Enter: +2/3 precommits for block
@@ -1530,6 +1557,8 @@ func (cs *State) enterCommit(height int64, commitRound int32) {
 
 	logger.Debug("entering commit step", "current", fmt.Sprintf("%v/%v/%v", cs.Height, cs.Round, cs.Step))
 
+	cs.timeouts.precommit.end(height, commitRound, tmtime.Now())
+
 	defer func() {
 		// Done enterCommit:
 		// keep cs.Round the same, commitRound points to the right Precommits set.
@@ -1885,6 +1914,14 @@ func (cs *State) addProposalBlockPart(msg *BlockPartMessage, peerID types.NodeID
 
 	added, err = cs.ProposalBlockParts.AddPart(part)
 	if err != nil {
+		if errors.Is(err, types.ErrPartSetInvalidCoding) && cs.Step <= cstypes.RoundStepPropose {
+			// The proposal block can't be recovered from a badly encoded part
+			// set, which every node finds out, so prevote nil without waiting
+			// for the propose timeout.
+			cs.Logger.Error("proposal block parts are not properly encoded", "height", height, "err", err)
+			cs.timeouts.propose.end(height, cs.Round, tmtime.Now())
+			cs.enterPrevote(height, cs.Round)
+		}
 		return added, err
 	}
 	if cs.ProposalBlockParts.ByteSize() > cs.state.ConsensusParams.Block.MaxBytes {
@@ -1917,6 +1954,7 @@ func (cs *State) addProposalBlockPart(msg *BlockPartMessage, peerID types.NodeID
 		if err := cs.eventBus.PublishEventCompleteProposal(cs.CompleteProposalEvent()); err != nil {
 			cs.Logger.Error("failed publishing event complete proposal", "err", err)
 		}
+		cs.evsw.FireEvent(types.EventCompleteProposalValue, &cs.RoundState)
 
 		// Update Valid* if we can.
 		prevotes := cs.Votes.Prevotes(cs.Round)
@@ -1941,6 +1979,8 @@ func (cs *State) addProposalBlockPart(msg *BlockPartMessage, peerID types.NodeID
 		}
 
 		if cs.Step <= cstypes.RoundStepPropose && cs.isProposalComplete() {
+			cs.timeouts.propose.end(height, cs.Round, tmtime.Now())
+
 			// Move onto the next step
 			cs.enterPrevote(height, cs.Round)
 			if hasTwoThirds { // this is optimisation as this will be triggered when prevote is added
@@ -7,10 +7,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/abci/example/kvstore"
+	"github.com/tendermint/tendermint/crypto/merkle"
 	"github.com/tendermint/tendermint/crypto/tmhash"
 	cstypes "github.com/tendermint/tendermint/internal/consensus/types"
 	p2pmock "github.com/tendermint/tendermint/internal/p2p/mock"
@@ -249,6 +251,87 @@ func TestStateBadProposal(t *testing.T) {
 	signAddVotes(config, cs1, tmproto.PrecommitType, propBlock.Hash(), propBlock.MakePartSet(partSize).Header(), vs2)
 }
 
+func TestStateProposalNotErasureCodedAsParams(t *testing.T) {
+	config := configSetup(t)
+
+	cs1, vss := randState(config, 2)
+	height, round := cs1.Height, cs1.Round
+	vs2 := vss[1]
+
+	proposalCh := subscribe(cs1.eventBus, types.EventQueryCompleteProposal)
+	voteCh := subscribe(cs1.eventBus, types.EventQueryVote)
+
+	propBlock, _ := cs1.createProposalBlock()
+	round++
+	incrementRound(vss[1:]...)
+
+	// erasure code the block although the consensus params don't enable it
+	propBlockParts := propBlock.MakeErasureCodedPartSet(types.BlockPartSizeBytes, 1)
+	require.True(t, propBlockParts.IsErasureCoded())
+	blockID := types.BlockID{Hash: propBlock.Hash(), PartSetHeader: propBlockParts.Header()}
+	proposal := types.NewProposal(vs2.Height, round, -1, blockID)
+	p := proposal.ToProto()
+	require.NoError(t, vs2.SignProposal(context.Background(), config.ChainID(), p))
+	proposal.Signature = p.Signature
+
+	require.NoError(t, cs1.SetProposalAndBlock(proposal, propBlock, propBlockParts, "some peer"))
+
+	startTestRound(cs1, height, round)
+
+	ensureProposal(proposalCh, height, round, blockID)
+	ensurePrevote(voteCh, height, round)
+	validatePrevote(t, cs1, round, vss[0], nil)
+}
+
+func TestStateBadlyEncodedProposal(t *testing.T) {
+	config := configSetup(t)
+	// the proposal must be rejected without waiting for the propose timeout
+	config.Consensus.TimeoutPropose = time.Minute
+
+	cs1, vss := randState(config, 2)
+	height, round := cs1.Height, cs1.Round
+	vs2 := vss[1]
+
+	voteCh := subscribe(cs1.eventBus, types.EventQueryVote)
+
+	propBlock, _ := cs1.createProposalBlock()
+	round++
+	incrementRound(vss[1:]...)
+
+	// erasure code the block with a parity part which doesn't match the data
+	pbb, err := propBlock.ToProto()
+	require.NoError(t, err)
+	bz, err := proto.Marshal(pbb)
+	require.NoError(t, err)
+	propBlockParts := types.NewErasureCodedPartSetFromData(bz, types.BlockPartSizeBytes, 1)
+	shards := make([][]byte, propBlockParts.Total())
+	for i := range shards {
+		shards[i] = append([]byte(nil), propBlockParts.GetPart(i).Bytes...)
+	}
+	shards[len(shards)-1][0]++
+	root, proofs := merkle.ProofsFromByteSlices(shards)
+
+	header := propBlockParts.Header()
+	header.Hash = root
+	blockID := types.BlockID{Hash: propBlock.Hash(), PartSetHeader: header}
+	proposal := types.NewProposal(vs2.Height, round, -1, blockID)
+	p := proposal.ToProto()
+	require.NoError(t, vs2.SignProposal(context.Background(), config.ChainID(), p))
+	proposal.Signature = p.Signature
+
+	require.NoError(t, cs1.SetProposal(proposal, "some peer"))
+	for i := range shards {
+		part := &types.Part{Index: uint32(i), Bytes: shards[i], Proof: *proofs[i]}
+		require.NoError(t, cs1.AddProposalBlockPart(height, round, part, "some peer"))
+	}
+
+	startTestRound(cs1, height, round)
+
+	ensurePrevote(voteCh, height, round)
+	validatePrevote(t, cs1, round, vss[0], nil)
+	assert.True(t, cs1.GetRoundState().ProposalBlockParts.IsInvalid())
+}
+
 func TestStateOversizedBlock(t *testing.T) {
 	config := configSetup(t)
 
@@ -354,6 +437,42 @@ func TestStateFullRound1(t *testing.T) {
 	validateLastPrecommit(t, cs, vss[0], propBlockHash)
 }
 
+func TestStateFullRoundErasureCoded(t *testing.T) {
+	config := configSetup(t)
+
+	state, privVals := randGenesisState(config, 1, false, 10)
+	state.ConsensusParams.Block.PartParityPercent = 100
+	cs := newStateWithConfig(config, state, privVals[0], kvstore.NewApplication())
+	vss := []*validatorStub{newValidatorStub(privVals[0], 0)}
+	height, round := cs.Height, cs.Round
+
+	voteCh := subscribe(cs.eventBus, types.EventQueryVote)
+	propCh := subscribe(cs.eventBus, types.EventQueryCompleteProposal)
+	newRoundCh := subscribe(cs.eventBus, types.EventQueryNewRound)
+
+	startTestRound(cs, height, round)
+
+	ensureNewRound(newRoundCh, height, round)
+
+	ensureNewProposal(propCh, height, round)
+	rs := cs.GetRoundState()
+	propBlockHash := rs.ProposalBlock.Hash()
+	require.True(t, rs.ProposalBlockParts.IsErasureCoded())
+	require.EqualValues(t, 1, rs.ProposalBlockParts.Header().ParityParts())
+
+	ensurePrevote(voteCh, height, round)
+	validatePrevote(t, cs, round, vss[0], propBlockHash)
+
+	ensurePrecommit(voteCh, height, round)
+	ensureNewRound(newRoundCh, height+1, 0)
+
+	// the committed block is loaded back from its erasure coded parts
+	meta := cs.blockStore.LoadBlockMeta(height)
+	require.NotNil(t, meta)
+	assert.True(t, meta.BlockID.PartSetHeader.IsErasureCoded())
+	assert.Equal(t, propBlockHash, cs.blockStore.LoadBlock(height).Hash())
+}
+
 // nil is proposed, so prevote and precommit nil
 func TestStateFullRoundNil(t *testing.T) {
 	config := configSetup(t)
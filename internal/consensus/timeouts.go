@@ -0,0 +1,124 @@
+package consensus
+
+import (
+	"time"
+
+	cfg "github.com/tendermint/tendermint/config"
+)
+
+const (
+	// latencyWeight is the weight given to a new latency observation when
+	// updating the moving average of a step's latency.
+	latencyWeight = 0.2
+
+	// adaptiveTimeoutMultiplier is applied to the average latency of a step
+	// to leave some headroom for slower than usual rounds.
+	adaptiveTimeoutMultiplier = 2
+)
+
+// stepLatency tracks the exponentially weighted moving average of how long a
+// single consensus step takes to complete.
+type stepLatency struct {
+	height  int64
+	round   int32
+	started time.Time // zero if no measurement is in progress
+
+	average time.Duration // zero if nothing has been observed yet
+}
+
+// begin starts a measurement for the given height and round, discarding any
+// measurement in progress.
+func (sl *stepLatency) begin(height int64, round int32, now time.Time) {
+	sl.height, sl.round, sl.started = height, round, now
+}
+
+// end completes the measurement started for the given height and round and
+// folds it into the average. It is a no-op if no such measurement exists.
+func (sl *stepLatency) end(height int64, round int32, now time.Time) {
+	if sl.started.IsZero() || sl.height != height || sl.round != round {
+		return
+	}
+
+	latency := now.Sub(sl.started)
+	sl.started = time.Time{}
+	if latency < 0 {
+		return
+	}
+	sl.observe(latency)
+}
+
+// timedOut completes the measurement started for the given height and round,
+// which timed out after the given timeout, and folds the timeout into the
+// average, so that a step which keeps timing out raises its timeout. It is a
+// no-op if no such measurement exists.
+func (sl *stepLatency) timedOut(height int64, round int32, timeout time.Duration) {
+	if sl.started.IsZero() || sl.height != height || sl.round != round {
+		return
+	}
+
+	sl.started = time.Time{}
+	sl.observe(timeout)
+}
+
+// cancel discards the measurement in progress, if any.
+func (sl *stepLatency) cancel() {
+	sl.started = time.Time{}
+}
+
+func (sl *stepLatency) observe(latency time.Duration) {
+	if sl.average == 0 {
+		sl.average = latency
+		return
+	}
+	sl.average = time.Duration(latencyWeight*float64(latency) + (1-latencyWeight)*float64(sl.average))
+}
+
+// timeoutEstimator computes the propose, prevote and precommit timeouts for a
+// round. With adaptive timeouts disabled it returns the static timeouts from
+// the consensus config, otherwise the base timeout of a step is derived from
+// its observed latency and bounded by the configured minimum and maximum. In
+// both cases the per-round delta is added on top.
+//
+// NOTE: not goroutine-safe; it's owned by the consensus state.
+type timeoutEstimator struct {
+	config *cfg.ConsensusConfig
+
+	propose   stepLatency
+	prevote   stepLatency
+	precommit stepLatency
+}
+
+func newTimeoutEstimator(config *cfg.ConsensusConfig) *timeoutEstimator {
+	return &timeoutEstimator{config: config}
+}
+
+// Propose returns the amount of time to wait for a proposal.
+func (te *timeoutEstimator) Propose(round int32) time.Duration {
+	return te.timeout(&te.propose, te.config.TimeoutPropose, te.config.TimeoutProposeDelta, round)
+}
+
+// Prevote returns the amount of time to wait for straggler votes after
+// receiving any +2/3 prevotes.
+func (te *timeoutEstimator) Prevote(round int32) time.Duration {
+	return te.timeout(&te.prevote, te.config.TimeoutPrevote, te.config.TimeoutPrevoteDelta, round)
+}
+
+// Precommit returns the amount of time to wait for straggler votes after
+// receiving any +2/3 precommits.
+func (te *timeoutEstimator) Precommit(round int32) time.Duration {
+	return te.timeout(&te.precommit, te.config.TimeoutPrecommit, te.config.TimeoutPrecommitDelta, round)
+}
+
+func (te *timeoutEstimator) timeout(sl *stepLatency, base, delta time.Duration, round int32) time.Duration {
+	if te.config.AdaptiveTimeouts && sl.average > 0 {
+		base = sl.average * adaptiveTimeoutMultiplier
+		if base < te.config.AdaptiveTimeoutMin {
+			base = te.config.AdaptiveTimeoutMin
+		}
+		if base > te.config.AdaptiveTimeoutMax {
+			base = te.config.AdaptiveTimeoutMax
+		}
+	}
+
+	return base + delta*time.Duration(round)
+}
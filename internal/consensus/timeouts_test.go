@@ -0,0 +1,77 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/tendermint/tendermint/config"
+)
+
+func TestTimeoutEstimatorStatic(t *testing.T) {
+	config := cfg.DefaultConsensusConfig()
+	te := newTimeoutEstimator(config)
+
+	now := time.Now()
+	te.propose.begin(1, 0, now)
+	te.propose.end(1, 0, now.Add(50*time.Millisecond))
+
+	// without adaptive timeouts, the observations are ignored
+	for round := int32(0); round < 3; round++ {
+		require.Equal(t, config.Propose(round), te.Propose(round))
+		require.Equal(t, config.Prevote(round), te.Prevote(round))
+		require.Equal(t, config.Precommit(round), te.Precommit(round))
+	}
+}
+
+func TestTimeoutEstimatorAdaptive(t *testing.T) {
+	config := cfg.DefaultConsensusConfig()
+	config.AdaptiveTimeouts = true
+	config.AdaptiveTimeoutMin = 100 * time.Millisecond
+	config.AdaptiveTimeoutMax = 5 * time.Second
+	te := newTimeoutEstimator(config)
+
+	// nothing observed yet, so we fall back to the static timeouts
+	require.Equal(t, config.Propose(1), te.Propose(1))
+
+	now := time.Now()
+	te.propose.begin(1, 0, now)
+	te.propose.end(1, 0, now.Add(500*time.Millisecond))
+	require.Equal(t, time.Second, te.Propose(0))
+	require.Equal(t, time.Second+config.TimeoutProposeDelta, te.Propose(1))
+
+	// observations are folded into a moving average
+	te.propose.begin(2, 0, now)
+	te.propose.end(2, 0, now.Add(time.Second))
+	require.Equal(t, 1200*time.Millisecond, te.Propose(0))
+
+	// an observation for another height or round is ignored
+	te.prevote.begin(2, 1, now)
+	te.prevote.end(2, 0, now.Add(time.Second))
+	te.prevote.end(3, 1, now.Add(time.Second))
+	require.Equal(t, config.Prevote(0), te.Prevote(0))
+
+	// and a measurement can only be completed once
+	te.prevote.end(2, 1, now.Add(10*time.Millisecond))
+	te.prevote.end(2, 1, now.Add(time.Minute))
+	require.Equal(t, config.AdaptiveTimeoutMin, te.Prevote(0))
+
+	// a timeout is observed at the timeout value
+	te.propose.begin(3, 0, now)
+	te.propose.timedOut(3, 0, 2600*time.Millisecond)
+	require.Equal(t, 2*time.Second, te.Propose(0))
+
+	// a cancelled measurement is not observed
+	te.propose.begin(4, 0, now)
+	te.propose.cancel()
+	te.propose.end(4, 0, now.Add(time.Minute))
+	te.propose.timedOut(4, 0, time.Minute)
+	require.Equal(t, 2*time.Second, te.Propose(0))
+
+	// timeouts are bounded by the configured maximum
+	te.precommit.begin(2, 1, now)
+	te.precommit.end(2, 1, now.Add(time.Minute))
+	require.Equal(t, config.AdaptiveTimeoutMax, te.Precommit(0))
+	require.Equal(t, config.AdaptiveTimeoutMax+2*config.TimeoutPrecommitDelta, te.Precommit(2))
+}
@@ -58,8 +58,9 @@ func (prs PeerRoundState) Copy() PeerRoundState {
 	hashCopy := make([]byte, len(headerHash))
 	copy(hashCopy, headerHash)
 	prs.ProposalBlockPartSetHeader = types.PartSetHeader{
-		Total: prs.ProposalBlockPartSetHeader.Total,
-		Hash:  hashCopy,
+		Total:     prs.ProposalBlockPartSetHeader.Total,
+		Hash:      hashCopy,
+		DataParts: prs.ProposalBlockPartSetHeader.DataParts,
 	}
 	prs.ProposalBlockParts = prs.ProposalBlockParts.Copy()
 	prs.ProposalPOL = prs.ProposalPOL.Copy()
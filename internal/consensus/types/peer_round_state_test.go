@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/libs/bits"
+	"github.com/tendermint/tendermint/types"
 )
 
 func TestCopy(t *testing.T) {
@@ -26,4 +27,11 @@ func TestCopy(t *testing.T) {
 
 		require.NotEqual(t, prsOne.Prevotes, prsTwo.Prevotes)
 	})
+	t.Run("PartSetHeader", func(t *testing.T) {
+		prsOne := PeerRoundState{}
+		prsOne.ProposalBlockPartSetHeader = types.PartSetHeader{Total: 3, Hash: []byte{1}, DataParts: 2}
+		prsTwo := prsOne.Copy()
+
+		require.Equal(t, prsOne.ProposalBlockPartSetHeader, prsTwo.ProposalBlockPartSetHeader)
+	})
 }
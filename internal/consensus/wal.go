@@ -7,6 +7,7 @@ import (
 	"hash/crc32"
 	"io"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
@@ -82,6 +83,13 @@ type BaseWAL struct {
 
 	flushTicker   *time.Ticker
 	flushInterval time.Duration
+
+	// compaction removes group files which only hold messages older than the
+	// previous height; see SetCompaction.
+	compactMtx     sync.Mutex
+	compact        bool
+	endHeightIndex map[int64]int // group file index of recent EndHeightMessages
+	compactBefore  int           // group files with a lower index can be removed
 }
 
 var _ WAL = &BaseWAL{}
@@ -112,6 +120,17 @@ func (wal *BaseWAL) SetFlushInterval(i time.Duration) {
 	wal.flushInterval = i
 }
 
+// SetCompaction enables or disables the background compaction of the WAL.
+// When enabled, the group files holding only messages older than the previous
+// height are periodically removed, so that the WAL keeps the current and the
+// previous height's messages.
+func (wal *BaseWAL) SetCompaction(enabled bool) {
+	wal.compactMtx.Lock()
+	defer wal.compactMtx.Unlock()
+	wal.compact = enabled
+	wal.endHeightIndex = make(map[int64]int)
+}
+
 func (wal *BaseWAL) Group() *auto.Group {
 	return wal.group
 }
@@ -146,6 +165,9 @@ func (wal *BaseWAL) processFlushTicks() {
 			if err := wal.FlushAndSync(); err != nil {
 				wal.Logger.Error("Periodic WAL flush failed", "err", err)
 			}
+			if err := wal.compactGroup(); err != nil {
+				wal.Logger.Error("WAL compaction failed", "err", err)
+			}
 		case <-wal.Quit():
 			return
 		}
@@ -186,15 +208,61 @@ func (wal *BaseWAL) Write(msg WALMessage) error {
 		return nil
 	}
 
+	// NOTE: the index is read before writing the message, so if the head gets
+	// rotated in between we underestimate it and compact less.
+	index := wal.group.MaxIndex()
+
 	if err := wal.enc.Encode(&TimedWALMessage{tmtime.Now(), msg}); err != nil {
 		wal.Logger.Error("Error writing msg to consensus wal. WARNING: recover may not be possible for the current height",
 			"err", err, "msg", msg)
 		return err
 	}
 
+	if m, ok := msg.(EndHeightMessage); ok {
+		wal.trackEndHeight(m.Height, index)
+	}
+
 	return nil
 }
 
+// trackEndHeight records the index of the group file the EndHeightMessage for
+// the given height was written to. Once height has ended, replay needs the
+// EndHeightMessage for height-1 to recover the previous height, so all the
+// files before the one holding it can be removed.
+func (wal *BaseWAL) trackEndHeight(height int64, index int) {
+	wal.compactMtx.Lock()
+	defer wal.compactMtx.Unlock()
+
+	if !wal.compact {
+		return
+	}
+
+	wal.endHeightIndex[height] = index
+	if i, ok := wal.endHeightIndex[height-1]; ok {
+		wal.compactBefore = i
+	}
+	for h := range wal.endHeightIndex {
+		if h < height-1 {
+			delete(wal.endHeightIndex, h)
+		}
+	}
+}
+
+// compactGroup removes the group files which are no longer needed to replay
+// the current and previous heights.
+func (wal *BaseWAL) compactGroup() error {
+	wal.compactMtx.Lock()
+	index := wal.compactBefore
+	wal.compactMtx.Unlock()
+
+	if index <= wal.group.MinIndex() {
+		return nil
+	}
+
+	wal.Logger.Debug("Compacting WAL", "min", wal.group.MinIndex(), "new_min", index)
+	return wal.group.RemoveFilesBefore(index)
+}
+
 // WriteSync is called when we receive a msg from ourselves
 // so that we write to disk before sending signed messages.
 // NOTE: calls fsync()
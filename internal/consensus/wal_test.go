@@ -3,6 +3,7 @@ package consensus
 import (
 	"bytes"
 	"crypto/rand"
+	"os"
 	"path/filepath"
 
 	// "sync"
@@ -274,3 +275,41 @@ func BenchmarkWalDecode100MB(b *testing.B) {
 func BenchmarkWalDecode1GB(b *testing.B) {
 	benchmarkWalDecode(b, 1024*1024*1024)
 }
+
+func TestWALCompaction(t *testing.T) {
+	walDir := t.TempDir()
+	walFile := filepath.Join(walDir, "wal")
+	wal, err := NewWAL(walFile)
+	require.NoError(t, err)
+
+	wal.SetFlushInterval(walTestFlushInterval)
+	wal.SetCompaction(true)
+	wal.SetLogger(log.TestingLogger())
+
+	require.NoError(t, wal.Start())
+	t.Cleanup(func() {
+		if err := wal.Stop(); err != nil {
+			t.Error(err)
+		}
+		wal.Wait()
+	})
+
+	// every height is written to its own file
+	for h := int64(1); h <= 5; h++ {
+		require.NoError(t, wal.Write(timeoutInfo{Duration: time.Second, Height: h}))
+		require.NoError(t, wal.WriteSync(EndHeightMessage{h}))
+		wal.Group().RotateFile()
+	}
+
+	require.Eventually(t, func() bool {
+		return wal.Group().MinIndex() == 3
+	}, 10*walTestFlushInterval, walTestFlushInterval)
+
+	// the current and previous heights can still be replayed
+	gr, found, err := wal.SearchForEndHeight(4, &WALSearchOptions{})
+	require.NoError(t, err)
+	require.True(t, found)
+	require.NoError(t, gr.Close())
+	_, err = os.Stat(walFile + ".002")
+	require.True(t, os.IsNotExist(err))
+}
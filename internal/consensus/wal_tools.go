@@ -0,0 +1,230 @@
+package consensus
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	auto "github.com/tendermint/tendermint/internal/libs/autofile"
+	tmos "github.com/tendermint/tendermint/libs/os"
+)
+
+// The functions below operate on the WAL files directly and must not be used
+// while a node is running with the same WAL.
+
+// WALRecord is a decoded WAL message along with its position in the WAL group.
+type WALRecord struct {
+	Path   string     `json:"path"`   // file of the group holding the record
+	Offset int64      `json:"offset"` // offset of the record within that file
+	Time   time.Time  `json:"time"`
+	Msg    WALMessage `json:"msg"`
+}
+
+// WALCorruption describes the first corrupted record found in a WAL group.
+type WALCorruption struct {
+	Path   string
+	Offset int64
+	Err    error
+}
+
+func (c WALCorruption) String() string {
+	return fmt.Sprintf("%s at offset %d: %v", c.Path, c.Offset, c.Err)
+}
+
+// walFile is a file of a WAL group along with its position in the byte stream
+// formed by concatenating all the files of the group.
+type walFile struct {
+	path  string
+	start int64
+	size  int64
+}
+
+// walFiles is the ordered list of files in a WAL group.
+type walFiles []walFile
+
+// locate maps an offset in the byte stream of the group to a file and an
+// offset within that file. It returns -1 if the offset is past the end.
+func (fs walFiles) locate(offset int64) (int, int64) {
+	for i, f := range fs {
+		if offset < f.start+f.size {
+			return i, offset - f.start
+		}
+	}
+	return -1, 0
+}
+
+// countingReader keeps track of the number of bytes read so far.
+type countingReader struct {
+	rd io.Reader
+	n  int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.rd.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
+
+// ScanWAL decodes the records of the WAL group with head at walPath, from the
+// oldest file to the head, calling fn for each of them. Records can't be
+// resynchronized after a corrupted one, so scanning stops at the first
+// corrupted record, which is returned. A nil corruption means the WAL group is
+// intact.
+func ScanWAL(walPath string, fn func(WALRecord) error) (*WALCorruption, error) {
+	files, corruption, err := scanWAL(walPath, func(files walFiles, start, _ int64, msg *TimedWALMessage) error {
+		i, local := files.locate(start)
+		return fn(WALRecord{Path: files[i].path, Offset: local, Time: msg.Time, Msg: msg.Msg})
+	})
+	if err != nil || corruption == nil {
+		return nil, err
+	}
+
+	i, local := files.locate(corruption.Offset)
+	if i < 0 {
+		i, local = len(files)-1, files[len(files)-1].size
+	}
+	return &WALCorruption{Path: files[i].path, Offset: local, Err: corruption.Err}, nil
+}
+
+// RepairWAL truncates the WAL group with head at walPath at its first
+// corrupted record, removing everything written after it. Every file which
+// gets modified or removed is first backed up with a ".CORRUPTED" suffix. The
+// corruption which was repaired is returned, or nil if the WAL group was
+// intact.
+func RepairWAL(walPath string) (*WALCorruption, error) {
+	files, corruption, err := scanWAL(walPath, nil)
+	if err != nil || corruption == nil {
+		return nil, err
+	}
+
+	i, local := files.locate(corruption.Offset)
+	if i < 0 {
+		// the tail is corrupted but there is nothing to truncate
+		return nil, nil
+	}
+	for _, f := range files[i:] {
+		if err := tmos.CopyFile(f.path, f.path+".CORRUPTED"); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := truncateWALFiles(files, corruption.Offset); err != nil {
+		return nil, err
+	}
+	return &WALCorruption{Path: files[i].path, Offset: local, Err: corruption.Err}, nil
+}
+
+// TruncateWAL removes every record written to the WAL group with head at
+// walPath after the EndHeightMessage for the given height, so that replay
+// resumes from height+1. It fails if the WAL group is corrupted before that
+// message or doesn't contain it.
+func TruncateWAL(walPath string, height int64) error {
+	end := int64(-1)
+	files, corruption, err := scanWAL(walPath, func(_ walFiles, _, next int64, msg *TimedWALMessage) error {
+		if m, ok := msg.Msg.(EndHeightMessage); ok && m.Height == height {
+			end = next
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if end < 0 {
+		if corruption != nil {
+			return fmt.Errorf("end of height %d not found before corrupted record: %w", height, corruption.Err)
+		}
+		return fmt.Errorf("end of height %d not found", height)
+	}
+
+	return truncateWALFiles(files, end)
+}
+
+// scanWAL decodes the records of the WAL group with head at walPath, calling
+// fn for each of them with the offsets of the record and of the following one
+// in the byte stream of the group. The first corruption is returned with an
+// offset in the byte stream of the group.
+func scanWAL(
+	walPath string,
+	fn func(files walFiles, start, next int64, msg *TimedWALMessage) error,
+) (walFiles, *WALCorruption, error) {
+	if _, err := os.Stat(walPath); err != nil {
+		return nil, nil, err
+	}
+
+	group, err := auto.OpenGroup(walPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer group.Close()
+
+	var (
+		files  walFiles
+		offset int64
+	)
+	for index := group.MinIndex(); index <= group.MaxIndex(); index++ {
+		path := group.FilePath(index)
+		fi, err := os.Stat(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		files = append(files, walFile{path: path, start: offset, size: fi.Size()})
+		offset += fi.Size()
+	}
+
+	gr, err := group.NewReader(group.MinIndex())
+	if err != nil {
+		return nil, nil, err
+	}
+	defer gr.Close()
+
+	rd := &countingReader{rd: gr}
+	dec := NewWALDecoder(rd)
+	for {
+		start := rd.n
+		msg, err := dec.Decode()
+		switch {
+		case errors.Is(err, io.EOF) && rd.n > start:
+			return files, &WALCorruption{Offset: start, Err: DataCorruptionError{io.ErrUnexpectedEOF}}, nil
+		case errors.Is(err, io.EOF):
+			return files, nil, nil
+		case IsDataCorruptionError(err):
+			return files, &WALCorruption{Offset: start, Err: err}, nil
+		case err != nil:
+			return nil, nil, err
+		}
+
+		if fn != nil {
+			if err := fn(files, start, rd.n, msg); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+}
+
+// truncateWALFiles discards everything at or after the given offset in the
+// byte stream of the group. Files of the group left empty are removed, except
+// for the head.
+func truncateWALFiles(files walFiles, offset int64) error {
+	i, local := files.locate(offset)
+	if i < 0 {
+		return nil
+	}
+
+	head := len(files) - 1
+	for ; i < len(files); i, local = i+1, 0 {
+		path := files[i].path
+		if local > 0 || i == head {
+			if err := os.Truncate(path, local); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
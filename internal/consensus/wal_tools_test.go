@@ -0,0 +1,107 @@
+package consensus
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// newTestWALGroup writes nHeights heights to a WAL, rotating the group's head
+// after each of them, and returns the path to the head.
+func newTestWALGroup(t *testing.T, nHeights int64) string {
+	walFile := filepath.Join(t.TempDir(), "wal")
+
+	wal, err := NewWAL(walFile)
+	require.NoError(t, err)
+	wal.SetLogger(log.TestingLogger())
+
+	for h := int64(1); h <= nHeights; h++ {
+		require.NoError(t, wal.Write(timeoutInfo{Duration: time.Second, Height: h}))
+		require.NoError(t, wal.Write(EndHeightMessage{h}))
+		require.NoError(t, wal.FlushAndSync())
+		wal.Group().RotateFile()
+	}
+	wal.Group().Close()
+
+	return walFile
+}
+
+func scanTestWAL(t *testing.T, walFile string) ([]WALRecord, *WALCorruption) {
+	var records []WALRecord
+	corruption, err := ScanWAL(walFile, func(record WALRecord) error {
+		records = append(records, record)
+		return nil
+	})
+	require.NoError(t, err)
+	return records, corruption
+}
+
+func TestScanWAL(t *testing.T) {
+	walFile := newTestWALGroup(t, 3)
+
+	records, corruption := scanTestWAL(t, walFile)
+	require.Nil(t, corruption)
+	require.Len(t, records, 6)
+	require.Equal(t, walFile+".000", records[0].Path)
+	require.Zero(t, records[0].Offset)
+	require.NotZero(t, records[1].Offset)
+	require.Equal(t, EndHeightMessage{3}, records[5].Msg)
+	require.Equal(t, walFile+".002", records[5].Path)
+
+	_, err := ScanWAL(filepath.Join(t.TempDir(), "missing"), func(WALRecord) error { return nil })
+	require.Error(t, err)
+}
+
+func TestRepairWAL(t *testing.T) {
+	walFile := newTestWALGroup(t, 3)
+
+	// nothing to repair
+	corruption, err := RepairWAL(walFile)
+	require.NoError(t, err)
+	require.Nil(t, corruption)
+
+	// corrupt the checksum of the last message of the second file
+	second := walFile + ".001"
+	records, _ := scanTestWAL(t, walFile)
+	bz, err := os.ReadFile(second)
+	require.NoError(t, err)
+	bz[records[3].Offset] ^= 0xff
+	require.NoError(t, os.WriteFile(second, bz, 0600))
+
+	records, corruption = scanTestWAL(t, walFile)
+	require.NotNil(t, corruption)
+	require.True(t, IsDataCorruptionError(corruption.Err))
+	require.Equal(t, second, corruption.Path)
+	require.Len(t, records, 3)
+
+	corruption, err = RepairWAL(walFile)
+	require.NoError(t, err)
+	require.NotNil(t, corruption)
+	require.Equal(t, second, corruption.Path)
+	require.FileExists(t, second+".CORRUPTED")
+	require.FileExists(t, walFile+".CORRUPTED")
+	require.NoFileExists(t, walFile+".002")
+
+	records, corruption = scanTestWAL(t, walFile)
+	require.Nil(t, corruption)
+	require.Len(t, records, 3)
+}
+
+func TestTruncateWAL(t *testing.T) {
+	walFile := newTestWALGroup(t, 3)
+
+	require.NoError(t, TruncateWAL(walFile, 1))
+
+	records, corruption := scanTestWAL(t, walFile)
+	require.Nil(t, corruption)
+	require.Len(t, records, 2)
+	require.Equal(t, EndHeightMessage{1}, records[1].Msg)
+	require.NoFileExists(t, walFile+".001")
+
+	require.Error(t, TruncateWAL(walFile, 2))
+}
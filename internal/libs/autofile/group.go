@@ -326,6 +326,33 @@ func (g *Group) RotateFile() {
 	g.maxIndex++
 }
 
+// RemoveFilesBefore removes the files of the group with an index lower than
+// the given one. The head is never removed.
+func (g *Group) RemoveFilesBefore(index int) error {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	if index > g.maxIndex {
+		index = g.maxIndex
+	}
+
+	for ; g.minIndex < index; g.minIndex++ {
+		pathToRemove := filePathForIndex(g.Head.Path, g.minIndex, g.maxIndex)
+		if err := os.Remove(pathToRemove); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FilePath returns the path of the file with the given index in the group.
+func (g *Group) FilePath(index int) string {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	return filePathForIndex(g.Head.Path, index, g.maxIndex)
+}
+
 // NewReader returns a new group reader.
 // CONTRACT: Caller must close the returned GroupReader.
 func (g *Group) NewReader(index int) (*GroupReader, error) {
@@ -289,3 +289,30 @@ func TestMaxIndex(t *testing.T) {
 	// Cleanup
 	destroyTestGroup(t, g)
 }
+
+func TestRemoveFilesBefore(t *testing.T) {
+	g := createTestGroupWithHeadSizeLimit(t, 0)
+
+	for i := 0; i < 3; i++ {
+		err := g.WriteLine("Line")
+		require.NoError(t, err)
+		err = g.FlushAndSync()
+		require.NoError(t, err)
+		g.RotateFile()
+	}
+	assertGroupInfo(t, g.ReadGroupInfo(), 0, 3, 15, 0)
+
+	err := g.RemoveFilesBefore(2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, g.MinIndex())
+	assertGroupInfo(t, g.ReadGroupInfo(), 2, 3, 5, 0)
+
+	// the head is never removed
+	err = g.RemoveFilesBefore(10)
+	require.NoError(t, err)
+	assert.Equal(t, 3, g.MinIndex())
+	assert.Equal(t, g.Head.Path, g.FilePath(3))
+
+	// Cleanup
+	destroyTestGroup(t, g)
+}
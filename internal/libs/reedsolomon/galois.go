@@ -0,0 +1,151 @@
+package reedsolomon
+
+import "errors"
+
+var errSingularMatrix = errors.New("matrix is singular")
+
+// generatorPolynomial is the primitive polynomial x^8 + x^4 + x^3 + x^2 + 1
+// used to build GF(2^8).
+const generatorPolynomial = 0x11d
+
+var (
+	expTable [510]byte
+	logTable [256]byte
+)
+
+func init() {
+	x := 1
+	for i := 0; i < 255; i++ {
+		expTable[i] = byte(x)
+		expTable[i+255] = byte(x)
+		logTable[x] = byte(i)
+		x <<= 1
+		if x&0x100 != 0 {
+			x ^= generatorPolynomial
+		}
+	}
+}
+
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return expTable[int(logTable[a])+int(logTable[b])]
+}
+
+func gfInv(a byte) byte {
+	// CONTRACT: a != 0
+	return expTable[255-int(logTable[a])]
+}
+
+func gfExp(a byte, n int) byte {
+	if n == 0 {
+		return 1
+	}
+	if a == 0 {
+		return 0
+	}
+	return expTable[(int(logTable[a])*n)%255]
+}
+
+// mulAdd adds c*in to out, element-wise.
+func mulAdd(c byte, in, out []byte) {
+	switch c {
+	case 0:
+		return
+	case 1:
+		for i, b := range in {
+			out[i] ^= b
+		}
+		return
+	}
+	logC := int(logTable[c])
+	for i, b := range in {
+		if b != 0 {
+			out[i] ^= expTable[logC+int(logTable[b])]
+		}
+	}
+}
+
+// matrix is a row-major matrix over GF(2^8).
+type matrix [][]byte
+
+func newMatrix(rows, cols int) matrix {
+	m := make(matrix, rows)
+	for r := range m {
+		m[r] = make([]byte, cols)
+	}
+	return m
+}
+
+// vandermonde returns the rows x cols matrix with m[r][c] = r^c.
+func vandermonde(rows, cols int) matrix {
+	m := newMatrix(rows, cols)
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			m[r][c] = gfExp(byte(r), c)
+		}
+	}
+	return m
+}
+
+func (m matrix) subMatrix(from, to int) matrix {
+	sub := newMatrix(to-from, len(m[0]))
+	for r := from; r < to; r++ {
+		copy(sub[r-from], m[r])
+	}
+	return sub
+}
+
+func (m matrix) multiply(other matrix) matrix {
+	out := newMatrix(len(m), len(other[0]))
+	for r := range m {
+		for c := range other[0] {
+			var v byte
+			for i := range other {
+				v ^= gfMul(m[r][i], other[i][c])
+			}
+			out[r][c] = v
+		}
+	}
+	return out
+}
+
+// invert returns the inverse of the square matrix m, computed with Gauss-Jordan
+// elimination. m is left untouched.
+func (m matrix) invert() (matrix, error) {
+	n := len(m)
+	work := newMatrix(n, 2*n)
+	for r := 0; r < n; r++ {
+		copy(work[r], m[r])
+		work[r][n+r] = 1
+	}
+
+	for c := 0; c < n; c++ {
+		pivot := c
+		for pivot < n && work[pivot][c] == 0 {
+			pivot++
+		}
+		if pivot == n {
+			return nil, errSingularMatrix
+		}
+		work[c], work[pivot] = work[pivot], work[c]
+
+		if inv := gfInv(work[c][c]); inv != 1 {
+			for i := range work[c] {
+				work[c][i] = gfMul(work[c][i], inv)
+			}
+		}
+		for r := 0; r < n; r++ {
+			if r != c && work[r][c] != 0 {
+				mulAdd(work[r][c], work[c], work[r])
+			}
+		}
+	}
+
+	inv := newMatrix(n, n)
+	for r := 0; r < n; r++ {
+		copy(inv[r], work[r][n:])
+	}
+	return inv, nil
+}
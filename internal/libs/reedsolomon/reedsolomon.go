@@ -0,0 +1,155 @@
+// Package reedsolomon implements a systematic Reed-Solomon erasure code over
+// GF(2^8). Data is split into k equally sized data shards, from which m parity
+// shards are computed, so that any k of the k+m shards are enough to recover
+// all of them.
+package reedsolomon
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MaxShards is the maximum total number of shards supported by the code.
+const MaxShards = 256
+
+var (
+	ErrShardSize       = errors.New("shards must be non-empty and of equal size")
+	ErrTooFewShards    = errors.New("too few shards to reconstruct the data")
+	ErrInvalidShardNum = errors.New("invalid number of shards")
+)
+
+// Encoder computes parity shards and reconstructs missing shards for a fixed
+// number of data and parity shards. It is safe for concurrent use.
+type Encoder struct {
+	dataShards   int
+	parityShards int
+
+	// matrix is the (dataShards+parityShards) x dataShards encoding matrix. Its
+	// top square is the identity, so that data shards are stored as is.
+	matrix matrix
+}
+
+// New returns an Encoder for the given number of data and parity shards.
+func New(dataShards, parityShards int) (*Encoder, error) {
+	if dataShards <= 0 || parityShards < 0 || dataShards+parityShards > MaxShards {
+		return nil, fmt.Errorf("%w: %d data and %d parity shards", ErrInvalidShardNum, dataShards, parityShards)
+	}
+
+	// Any k rows of a Vandermonde matrix are linearly independent, and so are
+	// any k rows of the matrix obtained by multiplying it by the inverse of its
+	// top square.
+	vm := vandermonde(dataShards+parityShards, dataShards)
+	top, err := vm.subMatrix(0, dataShards).invert()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Encoder{
+		dataShards:   dataShards,
+		parityShards: parityShards,
+		matrix:       vm.multiply(top),
+	}, nil
+}
+
+// DataShards returns the number of data shards.
+func (e *Encoder) DataShards() int { return e.dataShards }
+
+// ParityShards returns the number of parity shards.
+func (e *Encoder) ParityShards() int { return e.parityShards }
+
+// Encode computes the parity shards from the data shards. shards must hold
+// DataShards()+ParityShards() shards of equal size, the parity shards are
+// overwritten.
+func (e *Encoder) Encode(shards [][]byte) error {
+	if len(shards) != e.dataShards+e.parityShards {
+		return ErrInvalidShardNum
+	}
+	size, err := shardSize(shards, true)
+	if err != nil {
+		return err
+	}
+
+	for i := e.dataShards; i < len(shards); i++ {
+		if len(shards[i]) != size {
+			return ErrShardSize
+		}
+		e.codeShard(e.matrix[i], shards[:e.dataShards], shards[i])
+	}
+	return nil
+}
+
+// Reconstruct recovers the missing shards, represented by nil entries, from
+// the ones present. At least DataShards() shards must be present.
+func (e *Encoder) Reconstruct(shards [][]byte) error {
+	if len(shards) != e.dataShards+e.parityShards {
+		return ErrInvalidShardNum
+	}
+	size, err := shardSize(shards, false)
+	if err != nil {
+		return err
+	}
+
+	// pick the first dataShards present shards along with the matching rows
+	// of the encoding matrix
+	var (
+		rows    = make(matrix, 0, e.dataShards)
+		present = make([][]byte, 0, e.dataShards)
+	)
+	for i := 0; i < len(shards) && len(present) < e.dataShards; i++ {
+		if shards[i] != nil {
+			rows = append(rows, e.matrix[i])
+			present = append(present, shards[i])
+		}
+	}
+	if len(present) < e.dataShards {
+		return ErrTooFewShards
+	}
+
+	decode, err := rows.invert()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < e.dataShards; i++ {
+		if shards[i] == nil {
+			shards[i] = make([]byte, size)
+			e.codeShard(decode[i], present, shards[i])
+		}
+	}
+	for i := e.dataShards; i < len(shards); i++ {
+		if shards[i] == nil {
+			shards[i] = make([]byte, size)
+			e.codeShard(e.matrix[i], shards[:e.dataShards], shards[i])
+		}
+	}
+	return nil
+}
+
+// codeShard sets out to the linear combination of the inputs with the given
+// coefficients.
+func (e *Encoder) codeShard(coefficients []byte, inputs [][]byte, out []byte) {
+	for i := range out {
+		out[i] = 0
+	}
+	for c, in := range inputs {
+		mulAdd(coefficients[c], in, out)
+	}
+}
+
+// shardSize returns the size of the shards, which must all be equal. If
+// requireAll is false, nil shards are ignored.
+func shardSize(shards [][]byte, requireAll bool) (int, error) {
+	size := 0
+	for _, shard := range shards {
+		if shard == nil && !requireAll {
+			continue
+		}
+		if len(shard) == 0 || (size != 0 && len(shard) != size) {
+			return 0, ErrShardSize
+		}
+		size = len(shard)
+	}
+	if size == 0 {
+		return 0, ErrShardSize
+	}
+	return size, nil
+}
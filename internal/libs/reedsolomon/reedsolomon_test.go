@@ -0,0 +1,63 @@
+package reedsolomon
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewInvalid(t *testing.T) {
+	for _, tc := range []struct{ data, parity int }{
+		{0, 1},
+		{1, -1},
+		{200, 57},
+	} {
+		_, err := New(tc.data, tc.parity)
+		assert.ErrorIs(t, err, ErrInvalidShardNum, "%d data, %d parity", tc.data, tc.parity)
+	}
+}
+
+func TestEncodeReconstruct(t *testing.T) {
+	for _, tc := range []struct{ data, parity int }{
+		{1, 0},
+		{1, 1},
+		{4, 2},
+		{10, 10},
+		{128, 128},
+	} {
+		enc, err := New(tc.data, tc.parity)
+		require.NoError(t, err)
+
+		shards := make([][]byte, tc.data+tc.parity)
+		for i := range shards {
+			shards[i] = make([]byte, 100)
+			if i < tc.data {
+				rand.Read(shards[i]) // nolint: gosec
+			}
+		}
+		require.NoError(t, enc.Encode(shards))
+
+		// drop random shards, keeping exactly tc.data of them
+		partial := make([][]byte, len(shards))
+		for _, i := range rand.Perm(len(shards))[:tc.data] {
+			partial[i] = append([]byte(nil), shards[i]...)
+		}
+		require.NoError(t, enc.Reconstruct(partial))
+		assert.Equal(t, shards, partial, "%d data, %d parity", tc.data, tc.parity)
+	}
+}
+
+func TestReconstructErrors(t *testing.T) {
+	enc, err := New(3, 2)
+	require.NoError(t, err)
+
+	shards := [][]byte{{1}, nil, nil, {4}, nil}
+	assert.ErrorIs(t, enc.Reconstruct(shards), ErrTooFewShards)
+
+	shards = [][]byte{{1}, {2, 3}, nil, {4}, nil}
+	assert.ErrorIs(t, enc.Reconstruct(shards), ErrShardSize)
+
+	assert.ErrorIs(t, enc.Reconstruct(shards[:4]), ErrInvalidShardNum)
+}
@@ -0,0 +1,129 @@
+package mempool
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/tendermint/tendermint/config"
+	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
+	"github.com/tendermint/tendermint/types"
+)
+
+// SpamScoreHalfLife defines how long it takes for the spam score of a peer to
+// halve.
+const SpamScoreHalfLife = time.Minute
+
+// PeerAdmission controls the admission of the transactions received from
+// peers into CheckTx. Each peer can submit transactions at the rate, and with
+// the burst, defined by the configuration; the transactions over this quota
+// are not admitted. Each peer also has a spam score, increased by each of its
+// transactions which fail CheckTx or which it already sent us, and decaying
+// over time. The peers whose score exceeds the maximum are reported.
+type PeerAdmission struct {
+	mtx      tmsync.Mutex
+	rate     float64
+	burst    float64
+	maxScore float64
+	peers    map[types.NodeID]*peerAdmission
+}
+
+// peerAdmission holds the admission state of a peer.
+type peerAdmission struct {
+	// tokens defines the number of transactions the peer can still submit.
+	tokens float64
+
+	// score defines the spam score of the peer.
+	score float64
+
+	// updated defines when tokens and score were last updated.
+	updated time.Time
+}
+
+// NewPeerAdmission returns a new PeerAdmission with the given configuration.
+func NewPeerAdmission(cfg *config.MempoolConfig) *PeerAdmission {
+	return &PeerAdmission{
+		rate:     float64(cfg.PeerTxRate),
+		burst:    float64(cfg.PeerTxBurst),
+		maxScore: float64(cfg.MaxPeerSpamScore),
+		peers:    make(map[types.NodeID]*peerAdmission),
+	}
+}
+
+// Admit returns true if a transaction received from the given peer is within
+// the peer's quota, and can be submitted to CheckTx.
+func (a *PeerAdmission) Admit(peerID types.NodeID) bool {
+	return a.admit(peerID, time.Now())
+}
+
+func (a *PeerAdmission) admit(peerID types.NodeID, now time.Time) bool {
+	if a.rate == 0 {
+		return true
+	}
+
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	peer := a.peer(peerID, now)
+	if peer.tokens < 1 {
+		return false
+	}
+
+	peer.tokens--
+	return true
+}
+
+// Penalize increases the spam score of the given peer for a transaction which
+// failed CheckTx or which the peer already sent us. It returns an error once
+// the score exceeds the maximum, after which the peer should be reported. The
+// error is returned only once as long as the score stays over the maximum.
+func (a *PeerAdmission) Penalize(peerID types.NodeID) error {
+	return a.penalize(peerID, time.Now())
+}
+
+func (a *PeerAdmission) penalize(peerID types.NodeID, now time.Time) error {
+	if a.maxScore == 0 {
+		return nil
+	}
+
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	peer := a.peer(peerID, now)
+	peer.score++
+
+	if peer.score > a.maxScore && peer.score-1 <= a.maxScore {
+		return fmt.Errorf("spam score %.1f exceeds the maximum of %.0f", peer.score, a.maxScore)
+	}
+	return nil
+}
+
+// RemovePeer forgets the admission state of the given peer.
+func (a *PeerAdmission) RemovePeer(peerID types.NodeID) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	delete(a.peers, peerID)
+}
+
+// peer returns the admission state of the given peer, with its tokens refilled
+// and its score decayed up to now. The caller must hold the mutex.
+func (a *PeerAdmission) peer(peerID types.NodeID, now time.Time) *peerAdmission {
+	peer, ok := a.peers[peerID]
+	if !ok {
+		peer = &peerAdmission{tokens: a.burst, updated: now}
+		a.peers[peerID] = peer
+		return peer
+	}
+
+	elapsed := now.Sub(peer.updated)
+	if elapsed <= 0 {
+		return peer
+	}
+
+	peer.tokens = math.Min(a.burst, peer.tokens+a.rate*elapsed.Seconds())
+	peer.score *= math.Exp2(-float64(elapsed) / float64(SpamScoreHalfLife))
+	peer.updated = now
+
+	return peer
+}
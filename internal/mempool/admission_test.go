@@ -0,0 +1,94 @@
+package mempool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestPeerAdmission_Admit(t *testing.T) {
+	cfg := config.TestMempoolConfig()
+	cfg.PeerTxRate = 10
+	cfg.PeerTxBurst = 5
+	admission := NewPeerAdmission(cfg)
+
+	var (
+		peer1 = types.NodeID("aa")
+		peer2 = types.NodeID("bb")
+		now   = time.Now()
+	)
+
+	// a peer can submit up to the burst at once
+	for i := 0; i < 5; i++ {
+		require.True(t, admission.admit(peer1, now))
+	}
+	require.False(t, admission.admit(peer1, now))
+
+	// the quota of each peer is independent
+	require.True(t, admission.admit(peer2, now))
+
+	// the quota is refilled at the rate, up to the burst
+	now = now.Add(200 * time.Millisecond)
+	require.True(t, admission.admit(peer1, now))
+	require.True(t, admission.admit(peer1, now))
+	require.False(t, admission.admit(peer1, now))
+
+	now = now.Add(time.Hour)
+	for i := 0; i < 5; i++ {
+		require.True(t, admission.admit(peer1, now))
+	}
+	require.False(t, admission.admit(peer1, now))
+
+	// a removed peer starts over with a full quota
+	admission.RemovePeer(peer1)
+	require.True(t, admission.admit(peer1, now))
+
+	// no rate means no limit
+	admission = NewPeerAdmission(config.TestMempoolConfig())
+	for i := 0; i < 10000; i++ {
+		require.True(t, admission.admit(peer1, now))
+	}
+}
+
+func TestPeerAdmission_Penalize(t *testing.T) {
+	cfg := config.TestMempoolConfig()
+	cfg.MaxPeerSpamScore = 3
+	admission := NewPeerAdmission(cfg)
+
+	var (
+		peer1 = types.NodeID("aa")
+		peer2 = types.NodeID("bb")
+		now   = time.Now()
+	)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, admission.penalize(peer1, now))
+	}
+	require.NoError(t, admission.penalize(peer2, now))
+
+	// the peer is reported once its score exceeds the maximum, and only once
+	require.Error(t, admission.penalize(peer1, now))
+	require.NoError(t, admission.penalize(peer1, now))
+
+	// the score halves every half-life, from 5 to 2.5 here, so that the peer is
+	// reported again once it exceeds the maximum
+	now = now.Add(SpamScoreHalfLife)
+	require.Error(t, admission.penalize(peer1, now))
+	require.NoError(t, admission.penalize(peer1, now))
+
+	// a removed peer starts over with no score
+	admission.RemovePeer(peer1)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, admission.penalize(peer1, now))
+	}
+
+	// no maximum score means no penalty
+	admission = NewPeerAdmission(config.TestMempoolConfig())
+	for i := 0; i < 100; i++ {
+		require.NoError(t, admission.penalize(peer1, now))
+	}
+}
@@ -22,6 +22,10 @@ type TxCache interface {
 
 	// Remove removes the given raw transaction from the cache.
 	Remove(tx types.Tx)
+
+	// Has returns true if the transaction with the given key (see TxKey) is in
+	// the cache.
+	Has(key [TxKeySize]byte) bool
 }
 
 var _ TxCache = (*LRUTxCache)(nil)
@@ -97,11 +101,20 @@ func (c *LRUTxCache) Remove(tx types.Tx) {
 	}
 }
 
+func (c *LRUTxCache) Has(key [TxKeySize]byte) bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	_, ok := c.cacheMap[key]
+	return ok
+}
+
 // NopTxCache defines a no-op raw transaction cache.
 type NopTxCache struct{}
 
 var _ TxCache = (*NopTxCache)(nil)
 
-func (NopTxCache) Reset()             {}
-func (NopTxCache) Push(types.Tx) bool { return true }
-func (NopTxCache) Remove(types.Tx)    {}
+func (NopTxCache) Reset()                   {}
+func (NopTxCache) Push(types.Tx) bool       { return true }
+func (NopTxCache) Remove(types.Tx)          {}
+func (NopTxCache) Has([TxKeySize]byte) bool { return false }
@@ -20,6 +20,7 @@ func TestCacheRemove(t *testing.T) {
 
 		txs[i] = txBytes
 		cache.Push(txBytes)
+		require.True(t, cache.Has(TxKey(txBytes)))
 
 		// make sure its added to both the linked list and the map
 		require.Equal(t, i+1, len(cache.cacheMap))
@@ -28,6 +29,7 @@ func TestCacheRemove(t *testing.T) {
 
 	for i := 0; i < numTxs; i++ {
 		cache.Remove(txs[i])
+		require.False(t, cache.Has(TxKey(txs[i])))
 		// make sure its removed from both the map and the linked list
 		require.Equal(t, numTxs-(i+1), len(cache.cacheMap))
 		require.Equal(t, numTxs-(i+1), cache.list.Len())
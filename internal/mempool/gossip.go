@@ -0,0 +1,425 @@
+package mempool
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/tendermint/tendermint/config"
+	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
+	"github.com/tendermint/tendermint/internal/p2p"
+	"github.com/tendermint/tendermint/libs/log"
+	protomem "github.com/tendermint/tendermint/proto/tendermint/mempool"
+	"github.com/tendermint/tendermint/types"
+)
+
+// MaxMsgSize returns the maximum size of a message received on the
+// MempoolChannel with the given configuration, that is a transaction of the
+// maximum size or a batch of transaction hashes of the maximum size.
+func MaxMsgSize(cfg *config.MempoolConfig) int {
+	txsMsg := protomem.Message{
+		Sum: &protomem.Message_Txs{
+			Txs: &protomem.Txs{Txs: [][]byte{make([]byte, cfg.MaxTxBytes)}},
+		},
+	}
+
+	hashes := make([][]byte, cfg.MaxAnnounceBatchSize)
+	for i := range hashes {
+		hashes[i] = make([]byte, TxKeySize)
+	}
+	announcementMsg := protomem.Message{
+		Sum: &protomem.Message_TxAnnouncement{
+			TxAnnouncement: &protomem.TxAnnouncement{Hashes: hashes},
+		},
+	}
+
+	if size := announcementMsg.Size(); size > txsMsg.Size() {
+		return size
+	}
+	return txsMsg.Size()
+}
+
+// BatchTxs groups the given transactions into batches, each of which can be
+// sent to a peer in a single Txs message no larger than the message holding a
+// transaction of the maximum size.
+func BatchTxs(cfg *config.MempoolConfig, txs [][]byte) [][][]byte {
+	maxSize := txSize(cfg.MaxTxBytes)
+
+	var (
+		batches [][][]byte
+		batch   [][]byte
+		size    int
+	)
+	for _, tx := range txs {
+		if len(batch) > 0 && size+txSize(len(tx)) > maxSize {
+			batches = append(batches, batch)
+			batch, size = nil, 0
+		}
+		batch = append(batch, tx)
+		size += txSize(len(tx))
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+
+	return batches
+}
+
+// txSize returns the encoded size of a transaction of the given length within
+// a Txs message.
+func txSize(n int) int {
+	return 1 + proto.SizeVarint(uint64(n)) + n
+}
+
+// TxRequests tracks the transactions requested from peers in the pull gossip
+// mode, so that a transaction announced by several peers is requested from one
+// of them at a time. A transaction not received within the request timeout is
+// requested from the next peer which announced it.
+type TxRequests struct {
+	mtx      tmsync.Mutex
+	timeout  time.Duration
+	requests map[[TxKeySize]byte]*txRequest
+}
+
+// txRequest is the request of a transaction from a peer.
+type txRequest struct {
+	peerID      types.NodeID
+	requestedAt time.Time
+
+	// announcers are the other peers which announced the transaction, in the
+	// order to request it from them.
+	announcers []types.NodeID
+}
+
+// NewTxRequests returns a new TxRequests with the given request timeout.
+func NewTxRequests(timeout time.Duration) *TxRequests {
+	return &TxRequests{
+		timeout:  timeout,
+		requests: make(map[[TxKeySize]byte]*txRequest),
+	}
+}
+
+// Announce records that the given peer announced the transaction with the
+// given key, and returns true if the transaction must be requested from it,
+// that is unless it was already requested from a peer less than the request
+// timeout ago. The peer is then recorded to request the transaction from next.
+func (r *TxRequests) Announce(key [TxKeySize]byte, peerID types.NodeID) bool {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	now := time.Now()
+	req, ok := r.requests[key]
+	switch {
+	case !ok:
+		r.requests[key] = &txRequest{peerID: peerID, requestedAt: now}
+		return true
+
+	case now.Sub(req.requestedAt) >= r.timeout:
+		req.peerID, req.requestedAt = peerID, now
+		req.announcers = removePeer(req.announcers, peerID)
+		return true
+
+	case req.peerID != peerID && !hasPeer(req.announcers, peerID):
+		req.announcers = append(req.announcers, peerID)
+	}
+	return false
+}
+
+// Retry requests the transactions not received within the request timeout
+// from the next peers which announced them, and returns their keys by peer.
+// The transactions which no other peer announced, or which the given function
+// reports we have already, are forgotten.
+func (r *TxRequests) Retry(has func([TxKeySize]byte) bool) map[types.NodeID][][]byte {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	now := time.Now()
+	retries := make(map[types.NodeID][][]byte)
+	for key, req := range r.requests {
+		if now.Sub(req.requestedAt) < r.timeout {
+			continue
+		}
+		if len(req.announcers) == 0 || has(key) {
+			delete(r.requests, key)
+			continue
+		}
+
+		req.peerID, req.announcers = req.announcers[0], req.announcers[1:]
+		req.requestedAt = now
+
+		key := key
+		retries[req.peerID] = append(retries[req.peerID], key[:])
+	}
+	return retries
+}
+
+// Received marks the transaction with the given key as received.
+func (r *TxRequests) Received(key [TxKeySize]byte) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	delete(r.requests, key)
+}
+
+// RemovePeer removes the given peer from the peers to request transactions
+// from. The transactions requested from it are requested from the next peers
+// on the next retry.
+func (r *TxRequests) RemovePeer(peerID types.NodeID) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	for _, req := range r.requests {
+		if req.peerID == peerID {
+			req.requestedAt = time.Time{}
+		} else {
+			req.announcers = removePeer(req.announcers, peerID)
+		}
+	}
+}
+
+// Len returns the number of transactions requested and not received yet.
+func (r *TxRequests) Len() int {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	return len(r.requests)
+}
+
+func hasPeer(peers []types.NodeID, peerID types.NodeID) bool {
+	for _, id := range peers {
+		if id == peerID {
+			return true
+		}
+	}
+	return false
+}
+
+func removePeer(peers []types.NodeID, peerID types.NodeID) []types.NodeID {
+	for i, id := range peers {
+		if id == peerID {
+			return append(peers[:i:i], peers[i+1:]...)
+		}
+	}
+	return peers
+}
+
+// Gossip implements the parts of the pull gossip mode shared by the mempool
+// reactors: it tracks the gossip mode of the peers and the transactions
+// requested from them, and sends the gossip messages on the mempool channel.
+type Gossip struct {
+	config   *config.MempoolConfig
+	logger   log.Logger
+	ch       *p2p.Channel
+	closeCh  <-chan struct{}
+	requests *TxRequests
+
+	mtx tmsync.Mutex
+	// pullPeers defines the peers which advertised the pull gossip mode.
+	pullPeers map[types.NodeID]bool
+}
+
+// NewGossip returns a new Gossip sending its messages on the given channel
+// until closeCh is closed.
+func NewGossip(
+	cfg *config.MempoolConfig,
+	logger log.Logger,
+	ch *p2p.Channel,
+	closeCh <-chan struct{},
+) *Gossip {
+	return &Gossip{
+		config:    cfg,
+		logger:    logger,
+		ch:        ch,
+		closeCh:   closeCh,
+		requests:  NewTxRequests(cfg.TxRequestTimeout),
+		pullPeers: make(map[types.NodeID]bool),
+	}
+}
+
+// SetGossipMode records the gossip mode advertised by the given peer.
+func (g *Gossip) SetGossipMode(peerID types.NodeID, pull bool) {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	g.pullPeers[peerID] = pull
+}
+
+// RemovePeer forgets the given peer, which is disconnected.
+func (g *Gossip) RemovePeer(peerID types.NodeID) {
+	g.mtx.Lock()
+	delete(g.pullPeers, peerID)
+	g.mtx.Unlock()
+
+	g.requests.RemovePeer(peerID)
+}
+
+// UsePullGossip returns true if the transactions are gossiped to the given
+// peer in the pull mode, that is if both the reactor and the peer use it.
+func (g *Gossip) UsePullGossip(peerID types.NodeID) bool {
+	if g.config.GossipMode != config.MempoolGossipModePull {
+		return false
+	}
+
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	return g.pullPeers[peerID]
+}
+
+// SendGossipMode advertises the pull gossip mode to the given peer.
+func (g *Gossip) SendGossipMode(peerID types.NodeID) {
+	g.send(peerID, &protomem.GossipMode{Pull: true})
+}
+
+// AnnounceTxs announces the given tx hashes to the given peer.
+func (g *Gossip) AnnounceTxs(peerID types.NodeID, hashes [][]byte) {
+	if g.send(peerID, &protomem.TxAnnouncement{Hashes: hashes}) {
+		g.logger.Debug("announced txs to peer", "num_txs", len(hashes), "peer", peerID)
+	}
+}
+
+// HandleAnnouncement requests from the given peer the announced txs we do not
+// have yet, as reported by the given function, and which are not already
+// requested from another peer.
+func (g *Gossip) HandleAnnouncement(
+	peerID types.NodeID,
+	announcement *protomem.TxAnnouncement,
+	has func([TxKeySize]byte) bool,
+) error {
+	keys, err := g.TxKeysFromHashes(announcement.GetHashes())
+	if err != nil {
+		return fmt.Errorf("invalid tx announcement: %w", err)
+	}
+
+	var hashes [][]byte
+	for _, key := range keys {
+		if has(key) {
+			continue
+		}
+		if g.requests.Announce(key, peerID) {
+			hashes = append(hashes, key[:])
+		}
+	}
+
+	if len(hashes) > 0 {
+		g.send(peerID, &protomem.TxRequest{Hashes: hashes})
+	}
+	return nil
+}
+
+// HandleRequest sends the given peer the requested txs we have, as returned by
+// the given function. A request holds at most MaxAnnounceBatchSize hashes, and
+// the txs sent in response to it are at most MaxTxRequestBytes large, or a
+// single tx, so that a peer can't make us look up and send any number of txs
+// with a single request.
+func (g *Gossip) HandleRequest(
+	peerID types.NodeID,
+	request *protomem.TxRequest,
+	get func([TxKeySize]byte) (types.Tx, bool),
+) error {
+	keys, err := g.TxKeysFromHashes(request.GetHashes())
+	if err != nil {
+		return fmt.Errorf("invalid tx request: %w", err)
+	}
+
+	var (
+		txs  [][]byte
+		size int
+		seen = make(map[[TxKeySize]byte]bool, len(keys))
+	)
+	for _, key := range keys {
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		tx, ok := get(key)
+		if !ok {
+			continue
+		}
+		if len(txs) > 0 && size+len(tx) > MaxTxRequestBytes {
+			g.logger.Debug("truncated the response to a tx request", "num_txs", len(txs), "peer", peerID)
+			break
+		}
+		txs = append(txs, tx)
+		size += len(tx)
+	}
+
+	for _, batch := range BatchTxs(g.config, txs) {
+		if !g.send(peerID, &protomem.Txs{Txs: batch}) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// send sends the given message to the given peer, unless closeCh is closed
+// first. It returns false if the message wasn't sent.
+func (g *Gossip) send(peerID types.NodeID, msg proto.Message) bool {
+	select {
+	case g.ch.Out <- p2p.Envelope{To: peerID, Message: msg}:
+		return true
+	case <-g.closeCh:
+		return false
+	}
+}
+
+// Received marks the given tx, received from a peer, as no longer requested.
+func (g *Gossip) Received(tx []byte) {
+	g.requests.Received(TxKey(tx))
+}
+
+// RetryRequestsRoutine requests the txs not received within the request
+// timeout from the next peers which announced them, checking them every half
+// request timeout until closeCh is closed. The given function reports the txs
+// we have already, which are not requested again.
+func (g *Gossip) RetryRequestsRoutine(has func([TxKeySize]byte) bool) {
+	ticker := time.NewTicker(g.config.TxRequestTimeout / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			for peerID, hashes := range g.requests.Retry(has) {
+				for start := 0; start < len(hashes); start += g.config.MaxAnnounceBatchSize {
+					end := start + g.config.MaxAnnounceBatchSize
+					if end > len(hashes) {
+						end = len(hashes)
+					}
+
+					if !g.send(peerID, &protomem.TxRequest{Hashes: hashes[start:end]}) {
+						return
+					}
+				}
+				g.logger.Debug("requested timed out txs from peer", "num_txs", len(hashes), "peer", peerID)
+			}
+
+		case <-g.closeCh:
+			return
+		}
+	}
+}
+
+// TxKeysFromHashes returns the keys of the given tx hashes received from a
+// peer. It returns an error if there are no hashes, more than the maximum
+// batch size or a hash of an invalid size.
+func (g *Gossip) TxKeysFromHashes(hashes [][]byte) ([][TxKeySize]byte, error) {
+	if len(hashes) == 0 {
+		return nil, errors.New("empty tx hashes")
+	}
+	if len(hashes) > g.config.MaxAnnounceBatchSize {
+		return nil, fmt.Errorf("%d tx hashes, more than the maximum of %d", len(hashes), g.config.MaxAnnounceBatchSize)
+	}
+
+	keys := make([][TxKeySize]byte, len(hashes))
+	for i, hash := range hashes {
+		if len(hash) != TxKeySize {
+			return nil, fmt.Errorf("tx hash of %d bytes, expected %d", len(hash), TxKeySize)
+		}
+		copy(keys[i][:], hash)
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,159 @@
+package mempool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/p2p"
+	"github.com/tendermint/tendermint/libs/log"
+	protomem "github.com/tendermint/tendermint/proto/tendermint/mempool"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestMaxMsgSize(t *testing.T) {
+	cfg := config.TestMempoolConfig()
+
+	txsMsg := protomem.Message{
+		Sum: &protomem.Message_Txs{
+			Txs: &protomem.Txs{Txs: [][]byte{make([]byte, cfg.MaxTxBytes)}},
+		},
+	}
+	require.Equal(t, txsMsg.Size(), MaxMsgSize(cfg))
+
+	// with small transactions, a batch of hashes is the largest message
+	cfg.MaxTxBytes = 100
+	require.Greater(t, MaxMsgSize(cfg), cfg.MaxAnnounceBatchSize*TxKeySize)
+}
+
+func TestBatchTxs(t *testing.T) {
+	cfg := config.TestMempoolConfig()
+	cfg.MaxTxBytes = 100
+
+	maxSize := (&protomem.Txs{Txs: [][]byte{make([]byte, cfg.MaxTxBytes)}}).Size()
+
+	txs := [][]byte{
+		make([]byte, 40),
+		make([]byte, 40),
+		make([]byte, 40),
+		make([]byte, 10),
+		make([]byte, cfg.MaxTxBytes),
+	}
+	batches := BatchTxs(cfg, txs)
+	require.Len(t, batches, 3)
+	require.Len(t, batches[0], 2)
+	require.Len(t, batches[1], 2)
+	require.Len(t, batches[2], 1)
+
+	var n int
+	for _, batch := range batches {
+		require.LessOrEqual(t, (&protomem.Txs{Txs: batch}).Size(), maxSize)
+		n += len(batch)
+	}
+	require.Equal(t, len(txs), n)
+
+	require.Empty(t, BatchTxs(cfg, nil))
+}
+
+func TestTxRequests(t *testing.T) {
+	requests := NewTxRequests(50 * time.Millisecond)
+	hasNone := func([TxKeySize]byte) bool { return false }
+
+	key1 := TxKey(types.Tx("tx1"))
+	key2 := TxKey(types.Tx("tx2"))
+	peer1 := types.NodeID("01")
+	peer2 := types.NodeID("02")
+	peer3 := types.NodeID("03")
+
+	// a tx is requested from the first peer announcing it
+	require.True(t, requests.Announce(key1, peer1))
+	require.False(t, requests.Announce(key1, peer1))
+	require.False(t, requests.Announce(key1, peer2))
+	require.False(t, requests.Announce(key1, peer3))
+	require.True(t, requests.Announce(key2, peer1))
+	require.Equal(t, 2, requests.Len())
+	require.Empty(t, requests.Retry(hasNone))
+
+	// a received tx can be requested again
+	requests.Received(key2)
+	require.Equal(t, 1, requests.Len())
+	require.True(t, requests.Announce(key2, peer2))
+
+	// a request which timed out is sent to the next peer which announced the
+	// tx, and is forgotten once no peer is left
+	time.Sleep(60 * time.Millisecond)
+	require.Equal(t, map[types.NodeID][][]byte{peer2: {key1[:]}}, requests.Retry(hasNone))
+	require.Equal(t, 1, requests.Len())
+
+	// the request to a removed peer is sent to the next peer on retry
+	requests.RemovePeer(peer2)
+	require.Equal(t, map[types.NodeID][][]byte{peer3: {key1[:]}}, requests.Retry(hasNone))
+
+	time.Sleep(60 * time.Millisecond)
+	require.Empty(t, requests.Retry(hasNone))
+	require.Zero(t, requests.Len())
+
+	// a tx we have already is not requested again
+	require.True(t, requests.Announce(key1, peer1))
+	require.False(t, requests.Announce(key1, peer2))
+	time.Sleep(60 * time.Millisecond)
+	require.Empty(t, requests.Retry(func([TxKeySize]byte) bool { return true }))
+	require.Zero(t, requests.Len())
+}
+
+func TestGossip_HandleRequest(t *testing.T) {
+	cfg := config.TestMempoolConfig()
+	cfg.MaxAnnounceBatchSize = 3
+
+	outCh := make(chan p2p.Envelope, 10)
+	closeCh := make(chan struct{})
+	ch := p2p.NewChannel(MempoolChannel, new(protomem.Message), nil, outCh, nil)
+	g := NewGossip(cfg, log.TestingLogger(), ch, closeCh)
+
+	peerID := types.NodeID("aa")
+	small, large := types.Tx("small"), types.Tx(make([]byte, MaxTxRequestBytes))
+	txs := map[[TxKeySize]byte]types.Tx{TxKey(small): small, TxKey(large): large}
+	get := func(key [TxKeySize]byte) (types.Tx, bool) {
+		tx, ok := txs[key]
+		return tx, ok
+	}
+	hash := func(tx types.Tx) []byte {
+		key := TxKey(tx)
+		return key[:]
+	}
+
+	// a request over the maximum batch size is rejected
+	request := &protomem.TxRequest{
+		Hashes: [][]byte{hash(small), hash(large), hash(types.Tx("a")), hash(types.Tx("b"))},
+	}
+	require.Error(t, g.HandleRequest(peerID, request, get))
+	require.Empty(t, outCh)
+
+	// the duplicate and unknown txs are skipped
+	request = &protomem.TxRequest{Hashes: [][]byte{hash(small), hash(small), hash(types.Tx("a"))}}
+	require.NoError(t, g.HandleRequest(peerID, request, get))
+	require.Equal(t, p2p.Envelope{To: peerID, Message: &protomem.Txs{Txs: [][]byte{small}}}, <-outCh)
+
+	// the response is cut off at the maximum size
+	request = &protomem.TxRequest{Hashes: [][]byte{hash(small), hash(large)}}
+	require.NoError(t, g.HandleRequest(peerID, request, get))
+	require.Equal(t, p2p.Envelope{To: peerID, Message: &protomem.Txs{Txs: [][]byte{small}}}, <-outCh)
+	require.Empty(t, outCh)
+
+	// a single tx over the maximum size is still sent
+	request = &protomem.TxRequest{Hashes: [][]byte{hash(large)}}
+	require.NoError(t, g.HandleRequest(peerID, request, get))
+	require.Len(t, outCh, 1)
+	<-outCh
+
+	// sending gives up once closed, rather than blocking on a full channel
+	for i := 0; i < cap(outCh); i++ {
+		outCh <- p2p.Envelope{}
+	}
+	close(closeCh)
+	request = &protomem.TxRequest{Hashes: [][]byte{hash(small)}}
+	require.NoError(t, g.HandleRequest(peerID, request, get))
+	g.AnnounceTxs(peerID, [][]byte{hash(small)})
+}
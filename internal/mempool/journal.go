@@ -0,0 +1,293 @@
+package mempool
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/tendermint/tendermint/config"
+	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
+	"github.com/tendermint/tendermint/types"
+)
+
+// The journal is an append-only file of records, each made of the CRC32C
+// checksum (4 bytes) and the length (4 bytes) of its payload followed by the
+// payload. The payload of a record adding a transaction is journalOpAdd, the
+// height and Unix time in nanoseconds at which the transaction was accepted (8
+// bytes each) and the transaction. The payload of a record removing a
+// transaction is journalOpRemove and the transaction key.
+
+const (
+	journalOpAdd    byte = 1
+	journalOpRemove byte = 2
+
+	journalHeaderSize = 8
+	journalAddSize    = 1 + 8 + 8
+
+	// journalCompactionMinRecords is the minimum number of records of the
+	// journal before it is compacted.
+	journalCompactionMinRecords = 1000
+)
+
+var crc32c = crc32.MakeTable(crc32.Castagnoli)
+
+// JournalEntry is a transaction recorded in the mempool journal, along with
+// the height and time at which it was accepted into the mempool.
+type JournalEntry struct {
+	Tx        types.Tx
+	Height    int64
+	Timestamp time.Time
+}
+
+// Expired returns true if the entry exceeded the height or time based TTLs of
+// the given mempool config at the given height and time.
+func (e JournalEntry) Expired(cfg *config.MempoolConfig, height int64, now time.Time) bool {
+	if cfg.TTLNumBlocks > 0 && height-e.Height > cfg.TTLNumBlocks {
+		return true
+	}
+	if cfg.TTLDuration > 0 && now.Sub(e.Timestamp) > cfg.TTLDuration {
+		return true
+	}
+	return false
+}
+
+// Journal persists the transactions accepted into a mempool, so that they can
+// be replayed through CheckTx when the node restarts. Transactions are added
+// when they are accepted and removed when they leave the mempool. The journal
+// is periodically compacted by rewriting it with the transactions left in the
+// mempool.
+//
+// The journal is safe for concurrent use. Records are written to the file as
+// they are added, but only synced to disk by Sync.
+type Journal struct {
+	mtx     tmsync.Mutex
+	path    string
+	file    *os.File
+	records int
+}
+
+// OpenJournal opens the journal at the given path, creating it if it doesn't
+// exist, and returns the transactions it holds in the order they were added.
+// A corrupted or truncated record, as left by a crash, ends the journal. The
+// journal is compacted to the returned transactions.
+func OpenJournal(path string) (*Journal, []JournalEntry, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, nil, fmt.Errorf("failed to create mempool journal directory: %w", err)
+	}
+
+	entries, err := readJournal(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	j := &Journal{path: path}
+	if err := j.Compact(entries); err != nil {
+		return nil, nil, err
+	}
+
+	return j, entries, nil
+}
+
+// readJournal returns the transactions left in the journal at the given path.
+func readJournal(path string) ([]JournalEntry, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to open mempool journal: %w", err)
+	}
+	defer file.Close()
+
+	var (
+		r       = bufio.NewReader(file)
+		entries []JournalEntry
+		index   = make(map[[TxKeySize]byte]int)
+		header  = make([]byte, journalHeaderSize)
+	)
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			break
+		}
+
+		length := binary.BigEndian.Uint32(header[4:])
+		if length == 0 || length > journalAddSize+types.MaxBlockSizeBytes {
+			break
+		}
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(r, payload); err != nil {
+			break
+		}
+		if crc32.Checksum(payload, crc32c) != binary.BigEndian.Uint32(header) {
+			break
+		}
+
+		switch {
+		case payload[0] == journalOpAdd && len(payload) > journalAddSize:
+			tx := types.Tx(payload[journalAddSize:])
+			key := TxKey(tx)
+			if _, ok := index[key]; ok {
+				continue
+			}
+			index[key] = len(entries)
+			entries = append(entries, JournalEntry{
+				Tx:        tx,
+				Height:    int64(binary.BigEndian.Uint64(payload[1:])),
+				Timestamp: time.Unix(0, int64(binary.BigEndian.Uint64(payload[9:]))).UTC(),
+			})
+
+		case payload[0] == journalOpRemove && len(payload) == 1+TxKeySize:
+			var key [TxKeySize]byte
+			copy(key[:], payload[1:])
+			if i, ok := index[key]; ok {
+				entries[i].Tx = nil
+				delete(index, key)
+			}
+		}
+	}
+
+	live := make([]JournalEntry, 0, len(index))
+	for _, entry := range entries {
+		if entry.Tx != nil {
+			live = append(live, entry)
+		}
+	}
+
+	return live, nil
+}
+
+// Add records a transaction accepted into the mempool at the given height and
+// time.
+func (j *Journal) Add(tx types.Tx, height int64, timestamp time.Time) error {
+	payload := journalAddPayload(tx, height, timestamp)
+
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+
+	return j.write(j.file, payload)
+}
+
+// Remove records a transaction leaving the mempool.
+func (j *Journal) Remove(tx types.Tx) error {
+	key := TxKey(tx)
+	payload := make([]byte, 1+TxKeySize)
+	payload[0] = journalOpRemove
+	copy(payload[1:], key[:])
+
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+
+	return j.write(j.file, payload)
+}
+
+func journalAddPayload(tx types.Tx, height int64, timestamp time.Time) []byte {
+	payload := make([]byte, journalAddSize+len(tx))
+	payload[0] = journalOpAdd
+	binary.BigEndian.PutUint64(payload[1:], uint64(height))
+	binary.BigEndian.PutUint64(payload[9:], uint64(timestamp.UnixNano()))
+	copy(payload[journalAddSize:], tx)
+	return payload
+}
+
+// write writes a record with the given payload to w.
+func (j *Journal) write(w io.Writer, payload []byte) error {
+	record := make([]byte, journalHeaderSize+len(payload))
+	binary.BigEndian.PutUint32(record, crc32.Checksum(payload, crc32c))
+	binary.BigEndian.PutUint32(record[4:], uint32(len(payload)))
+	copy(record[journalHeaderSize:], payload)
+
+	if _, err := w.Write(record); err != nil {
+		return fmt.Errorf("failed to write to mempool journal: %w", err)
+	}
+	j.records++
+	return nil
+}
+
+// Sync commits the records of the journal to disk.
+func (j *Journal) Sync() error {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+
+	return j.file.Sync()
+}
+
+// ShouldCompact returns true if the journal holds many more records than the
+// given number of transactions left in the mempool.
+func (j *Journal) ShouldCompact(numTxs int) bool {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+
+	return j.records > journalCompactionMinRecords && j.records > 2*numTxs
+}
+
+// Compact atomically rewrites the journal with the given transactions, which
+// must be those left in the mempool.
+func (j *Journal) Compact(entries []JournalEntry) error {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+
+	tmpPath := j.path + ".tmp"
+	tmp, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create mempool journal: %w", err)
+	}
+	defer os.Remove(tmpPath) // nolint: errcheck // no-op once renamed
+
+	j.records = 0
+	w := bufio.NewWriter(tmp)
+	for _, entry := range entries {
+		payload := journalAddPayload(entry.Tx, entry.Height, entry.Timestamp)
+		if err := j.write(w, payload); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write to mempool journal: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync mempool journal: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close mempool journal: %w", err)
+	}
+
+	if j.file != nil {
+		if err := j.file.Close(); err != nil {
+			return fmt.Errorf("failed to close mempool journal: %w", err)
+		}
+		j.file = nil
+	}
+	if err := os.Rename(tmpPath, j.path); err != nil {
+		return fmt.Errorf("failed to replace mempool journal: %w", err)
+	}
+
+	j.file, err = os.OpenFile(j.path, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open mempool journal: %w", err)
+	}
+	return nil
+}
+
+// Close syncs and closes the journal.
+func (j *Journal) Close() error {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+
+	if j.file == nil {
+		return nil
+	}
+	if err := j.file.Sync(); err != nil {
+		return err
+	}
+	err := j.file.Close()
+	j.file = nil
+	return err
+}
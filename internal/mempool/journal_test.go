@@ -0,0 +1,128 @@
+package mempool
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", "mempool.journal")
+
+	journal, entries, err := OpenJournal(path)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	now := time.Now().UTC()
+	expected := make([]JournalEntry, 0)
+	for i := 0; i < 5; i++ {
+		entry := JournalEntry{
+			Tx:        types.Tx(fmt.Sprintf("tx-%d", i)),
+			Height:    int64(i),
+			Timestamp: now.Add(time.Duration(i) * time.Second),
+		}
+		require.NoError(t, journal.Add(entry.Tx, entry.Height, entry.Timestamp))
+		if i%2 == 0 {
+			expected = append(expected, entry)
+		}
+	}
+	for i := 1; i < 5; i += 2 {
+		require.NoError(t, journal.Remove(types.Tx(fmt.Sprintf("tx-%d", i))))
+	}
+	// duplicate adds and removals of unknown txs are ignored
+	require.NoError(t, journal.Add(expected[0].Tx, 10, now))
+	require.NoError(t, journal.Remove(types.Tx("unknown")))
+	require.NoError(t, journal.Close())
+
+	journal, entries, err = OpenJournal(path)
+	require.NoError(t, err)
+	require.Equal(t, expected, entries)
+
+	// a removed tx can be added again
+	require.NoError(t, journal.Add(types.Tx("tx-1"), 1, now))
+	require.NoError(t, journal.Sync())
+	require.NoError(t, journal.Close())
+
+	_, entries, err = OpenJournal(path)
+	require.NoError(t, err)
+	require.Len(t, entries, len(expected)+1)
+	require.Equal(t, types.Tx("tx-1"), entries[len(expected)].Tx)
+}
+
+func TestJournalCorruptedTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mempool.journal")
+
+	journal, _, err := OpenJournal(path)
+	require.NoError(t, err)
+	require.NoError(t, journal.Add(types.Tx("tx-0"), 1, time.Now()))
+	require.NoError(t, journal.Add(types.Tx("tx-1"), 1, time.Now()))
+	require.NoError(t, journal.Close())
+
+	// truncate the last record, as a crash while writing it would
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(path, info.Size()-1))
+
+	journal, entries, err := OpenJournal(path)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, types.Tx("tx-0"), entries[0].Tx)
+
+	// the journal is compacted when opened, dropping the truncated record
+	require.NoError(t, journal.Add(types.Tx("tx-2"), 1, time.Now()))
+	require.NoError(t, journal.Close())
+
+	_, entries, err = OpenJournal(path)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, types.Tx("tx-2"), entries[1].Tx)
+}
+
+func TestJournalCompact(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mempool.journal")
+
+	journal, _, err := OpenJournal(path)
+	require.NoError(t, err)
+
+	for i := 0; i <= journalCompactionMinRecords; i++ {
+		tx := types.Tx(fmt.Sprintf("tx-%d", i))
+		require.NoError(t, journal.Add(tx, 1, time.Now()))
+		require.NoError(t, journal.Remove(tx))
+	}
+	require.True(t, journal.ShouldCompact(1))
+	require.False(t, journal.ShouldCompact(2*journalCompactionMinRecords))
+
+	entry := JournalEntry{Tx: types.Tx("tx"), Height: 2, Timestamp: time.Now().UTC()}
+	require.NoError(t, journal.Compact([]JournalEntry{entry}))
+	require.False(t, journal.ShouldCompact(1))
+	require.NoError(t, journal.Close())
+
+	_, entries, err := OpenJournal(path)
+	require.NoError(t, err)
+	require.Equal(t, []JournalEntry{entry}, entries)
+}
+
+func TestJournalEntryExpired(t *testing.T) {
+	now := time.Now()
+	entry := JournalEntry{Tx: types.Tx("tx"), Height: 10, Timestamp: now.Add(-time.Minute)}
+
+	cfg := config.TestMempoolConfig()
+	require.False(t, entry.Expired(cfg, 100, now))
+
+	cfg.TTLNumBlocks = 5
+	require.False(t, entry.Expired(cfg, 15, now))
+	require.True(t, entry.Expired(cfg, 16, now))
+
+	cfg.TTLNumBlocks = 0
+	cfg.TTLDuration = time.Hour
+	require.False(t, entry.Expired(cfg, 100, now))
+	cfg.TTLDuration = time.Second
+	require.True(t, entry.Expired(cfg, 100, now))
+}
@@ -21,6 +21,11 @@ const (
 	UnknownPeerID uint16 = 0
 
 	MaxActiveIDs = math.MaxUint16
+
+	// MaxTxRequestBytes is the maximum size of the transactions sent to a peer
+	// in response to a single request in the pull gossip mode, unless a single
+	// transaction is larger.
+	MaxTxRequestBytes = 16 * 1024 * 1024
 )
 
 // Mempool defines the mempool interface.
@@ -92,6 +97,17 @@ type Mempool interface {
 
 	// SizeBytes returns the total size of all txs in the mempool.
 	SizeBytes() int64
+
+	// GetTxByKey returns the transaction with the given key (see TxKey), if it
+	// is in the mempool.
+	GetTxByKey(key [TxKeySize]byte) (types.Tx, bool)
+
+	// GetTxRecord returns the record of the transaction with the given key (see
+	// TxKey), if it is in the mempool or recently left it.
+	GetTxRecord(key [TxKeySize]byte) (TxRecord, bool)
+
+	// GetTxRecords returns the records of all the transactions in the mempool.
+	GetTxRecords() []TxRecord
 }
 
 // PreCheckFunc is an optional filter executed before CheckTx and rejects
@@ -37,8 +37,27 @@ type Metrics struct {
 	// CheckTx.
 	EvictedTxs metrics.Counter
 
+	// ReplacedTxs defines the number of replaced transactions. These are valid
+	// transactions that passed CheckTx and existed in the mempool but were later
+	// replaced by a transaction of the same sender and nonce with a higher
+	// priority.
+	ReplacedTxs metrics.Counter
+
 	// Number of times transactions are rechecked in the mempool.
 	RecheckTimes metrics.Counter
+
+	// Number of transactions not rechecked as they are unaffected by a block.
+	RecheckSkipped metrics.Counter
+
+	// Number of rechecks canceled by the next block before they finished.
+	RecheckCanceled metrics.Counter
+
+	// Number of transactions received from peers over their admission quota,
+	// dropped without being checked.
+	RateLimitedTxs metrics.Counter
+
+	// Number of peers reported for exceeding the maximum spam score.
+	SpamPeers metrics.Counter
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -86,23 +105,63 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Help:      "Number of evicted transactions.",
 		}, labels).With(labelsAndValues...),
 
+		ReplacedTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "replaced_txs",
+			Help:      "Number of replaced transactions.",
+		}, labels).With(labelsAndValues...),
+
 		RecheckTimes: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
 			Name:      "recheck_times",
 			Help:      "Number of times transactions are rechecked in the mempool.",
 		}, labels).With(labelsAndValues...),
+
+		RecheckSkipped: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "recheck_skipped",
+			Help:      "Number of transactions not rechecked as they are unaffected by a block.",
+		}, labels).With(labelsAndValues...),
+
+		RecheckCanceled: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "recheck_canceled",
+			Help:      "Number of rechecks canceled by the next block before they finished.",
+		}, labels).With(labelsAndValues...),
+
+		RateLimitedTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "rate_limited_txs",
+			Help:      "Number of transactions received from peers over their admission quota.",
+		}, labels).With(labelsAndValues...),
+
+		SpamPeers: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "spam_peers",
+			Help:      "Number of peers reported for exceeding the maximum spam score.",
+		}, labels).With(labelsAndValues...),
 	}
 }
 
 // NopMetrics returns no-op Metrics.
 func NopMetrics() *Metrics {
 	return &Metrics{
-		Size:         discard.NewGauge(),
-		TxSizeBytes:  discard.NewHistogram(),
-		FailedTxs:    discard.NewCounter(),
-		RejectedTxs:  discard.NewCounter(),
-		EvictedTxs:   discard.NewCounter(),
-		RecheckTimes: discard.NewCounter(),
+		Size:            discard.NewGauge(),
+		TxSizeBytes:     discard.NewHistogram(),
+		FailedTxs:       discard.NewCounter(),
+		RejectedTxs:     discard.NewCounter(),
+		EvictedTxs:      discard.NewCounter(),
+		ReplacedTxs:     discard.NewCounter(),
+		RecheckTimes:    discard.NewCounter(),
+		RecheckSkipped:  discard.NewCounter(),
+		RecheckCanceled: discard.NewCounter(),
+		RateLimitedTxs:  discard.NewCounter(),
+		SpamPeers:       discard.NewCounter(),
 	}
 }
@@ -36,6 +36,13 @@ func (Mempool) FlushAppConn() error           { return nil }
 func (Mempool) TxsAvailable() <-chan struct{} { return make(chan struct{}) }
 func (Mempool) EnableTxsAvailable()           {}
 func (Mempool) SizeBytes() int64              { return 0 }
+func (Mempool) GetTxByKey(_ [mempl.TxKeySize]byte) (types.Tx, bool) {
+	return nil, false
+}
+func (Mempool) GetTxRecord(_ [mempl.TxKeySize]byte) (mempl.TxRecord, bool) {
+	return mempl.TxRecord{}, false
+}
+func (Mempool) GetTxRecords() []mempl.TxRecord { return nil }
 
 func (Mempool) TxsFront() *clist.CElement    { return nil }
 func (Mempool) TxsWaitChan() <-chan struct{} { return nil }
@@ -2,6 +2,7 @@ package mempool
 
 import (
 	"crypto/sha256"
+	"time"
 
 	"github.com/tendermint/tendermint/types"
 )
@@ -30,3 +31,46 @@ type TxInfo struct {
 	// SenderNodeID is the actual types.NodeID of the sender.
 	SenderNodeID types.NodeID
 }
+
+// TxStatus is the status of a transaction in the mempool, or the reason it left
+// the mempool.
+type TxStatus string
+
+const (
+	// TxStatusPending is the status of a transaction in the mempool.
+	TxStatusPending TxStatus = "pending"
+
+	// TxStatusCommitted is the status of a transaction removed from the mempool
+	// as it was committed in a block.
+	TxStatusCommitted TxStatus = "committed"
+
+	// TxStatusEvicted is the status of a transaction evicted from the mempool to
+	// make room for a transaction with a higher priority, or replaced by one.
+	TxStatusEvicted TxStatus = "evicted"
+
+	// TxStatusExpired is the status of a transaction removed from the mempool as
+	// it exceeded the height or time based TTLs.
+	TxStatusExpired TxStatus = "expired"
+
+	// TxStatusRecheckFailed is the status of a transaction removed from the
+	// mempool as it failed to pass CheckTx again after a block was committed.
+	TxStatusRecheckFailed TxStatus = "recheck_failed"
+)
+
+// TxRecord describes a transaction in the mempool, or which recently left the
+// mempool.
+type TxRecord struct {
+	Tx     types.Tx
+	Status TxStatus
+
+	// Priority, Sender and GasWanted are assigned by the application in
+	// CheckTx.
+	Priority  int64
+	Sender    string
+	GasWanted int64
+
+	// Height and Timestamp are the height and time at which the transaction
+	// entered the mempool.
+	Height    int64
+	Timestamp time.Time
+}
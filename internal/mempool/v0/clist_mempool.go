@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	cfg "github.com/tendermint/tendermint/config"
@@ -58,6 +59,9 @@ type CListMempool struct {
 	// This reduces the pressure on the proxyApp.
 	cache mempool.TxCache
 
+	// If set, persists the txs so that they can be replayed on startup.
+	journal *mempool.Journal
+
 	logger  log.Logger
 	metrics *mempool.Metrics
 }
@@ -131,6 +135,42 @@ func WithMetrics(metrics *mempool.Metrics) CListMempoolOption {
 	return func(mem *CListMempool) { mem.metrics = metrics }
 }
 
+// WithJournal sets the journal persisting the txs of the mempool. The txs it
+// held when opened can be replayed with ReplayJournal.
+func WithJournal(journal *mempool.Journal) CListMempoolOption {
+	return func(mem *CListMempool) { mem.journal = journal }
+}
+
+// ReplayJournal replays the given journal entries through CheckTx, as txs
+// received before a restart. Entries which exceeded the configured TTLs are
+// skipped. Once replayed, the journal is compacted to the txs accepted back
+// into the mempool.
+func (mem *CListMempool) ReplayJournal(entries []mempool.JournalEntry) error {
+	now := time.Now()
+	for _, entry := range entries {
+		if entry.Expired(mem.config, mem.height, now) {
+			continue
+		}
+
+		entry := entry
+		err := mem.checkTx(context.Background(), entry.Tx, nil, mempool.TxInfo{SenderID: mempool.UnknownPeerID}, &entry)
+		if err != nil {
+			mem.logger.Debug("failed to replay journaled tx", "tx", mempool.TxHashFromBytes(entry.Tx), "err", err)
+		}
+	}
+
+	if err := mem.proxyAppConn.FlushSync(context.Background()); err != nil {
+		return err
+	}
+
+	mem.logger.Info("replayed mempool journal", "entries", len(entries), "total", mem.Size())
+
+	mem.updateMtx.Lock()
+	defer mem.updateMtx.Unlock()
+
+	return mem.compactJournal()
+}
+
 // Safe for concurrent use by multiple goroutines.
 func (mem *CListMempool) Lock() {
 	mem.updateMtx.Lock()
@@ -151,6 +191,39 @@ func (mem *CListMempool) SizeBytes() int64 {
 	return atomic.LoadInt64(&mem.txsBytes)
 }
 
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) GetTxByKey(key [mempool.TxKeySize]byte) (types.Tx, bool) {
+	if e, ok := mem.txsMap.Load(key); ok {
+		return e.(*clist.CElement).Value.(*mempoolTx).tx, true
+	}
+	return nil, false
+}
+
+// GetTxRecord returns the record of the transaction with the given key, if it
+// is in the mempool. The mempool keeps no record of the transactions which left
+// it.
+//
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) GetTxRecord(key [mempool.TxKeySize]byte) (mempool.TxRecord, bool) {
+	e, ok := mem.txsMap.Load(key)
+	if !ok {
+		return mempool.TxRecord{}, false
+	}
+
+	return e.(*clist.CElement).Value.(*mempoolTx).record(), true
+}
+
+// GetTxRecords returns the records of all the transactions in the mempool.
+//
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) GetTxRecords() []mempool.TxRecord {
+	records := make([]mempool.TxRecord, 0, mem.Size())
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		records = append(records, e.Value.(*mempoolTx).record())
+	}
+	return records
+}
+
 // Lock() must be help by the caller during execution.
 func (mem *CListMempool) FlushAppConn() error {
 	return mem.proxyAppConn.FlushSync(context.Background())
@@ -173,6 +246,10 @@ func (mem *CListMempool) Flush() {
 		mem.txsMap.Delete(key)
 		return true
 	})
+
+	if err := mem.compactJournal(); err != nil {
+		mem.logger.Error("failed to compact mempool journal", "err", err)
+	}
 }
 
 // TxsFront returns the first transaction in the ordered list for peer
@@ -205,19 +282,126 @@ func (mem *CListMempool) CheckTx(
 	cb func(*abci.Response),
 	txInfo mempool.TxInfo,
 ) error {
+	return mem.checkTx(ctx, tx, cb, txInfo, nil)
+}
+
+// checkTx executes CheckTx for a new tx, or for a tx replayed from the journal
+// if entry is set, which keeps the height and time of the entry.
+func (mem *CListMempool) checkTx(
+	ctx context.Context,
+	tx types.Tx,
+	cb func(*abci.Response),
+	txInfo mempool.TxInfo,
+	entry *mempool.JournalEntry,
+) error {
 
 	mem.updateMtx.RLock()
 	// use defer to unlock mutex because application (*local client*) might panic
 	defer mem.updateMtx.RUnlock()
 
+	if ok, err := mem.prepareCheckTx(tx, txInfo); !ok {
+		return err
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	reqRes, err := mem.proxyAppConn.CheckTxAsync(ctx, abci.RequestCheckTx{Tx: tx})
+	if err != nil {
+		mem.cache.Remove(tx)
+		return err
+	}
+	reqRes.SetCallback(mem.reqResCb(tx, txInfo.SenderID, txInfo.SenderNodeID, cb, entry))
+
+	return nil
+}
+
+// CheckTxBatch executes CheckTx for the given transactions, received together
+// from the same peer, in a single CheckTxBatch request to the application. It
+// returns, for each transaction, the error CheckTx would return, if any. The
+// callback, if set, is called with the response of each transaction checked.
+//
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) CheckTxBatch(
+	ctx context.Context,
+	txs types.Txs,
+	cb func(*abci.Response),
+	txInfo mempool.TxInfo,
+) []error {
+	mem.updateMtx.RLock()
+	// use defer to unlock mutex because application (*local client*) might panic
+	defer mem.updateMtx.RUnlock()
+
+	var (
+		errs    = make([]error, len(txs))
+		batch   = make([]int, 0, len(txs))
+		request = abci.RequestCheckTxBatch{Requests: make([]abci.RequestCheckTx, 0, len(txs))}
+	)
+	for i, tx := range txs {
+		ok, err := mem.prepareCheckTx(tx, txInfo)
+		if !ok {
+			errs[i] = err
+			continue
+		}
+
+		batch = append(batch, i)
+		request.Requests = append(request.Requests, abci.RequestCheckTx{Tx: tx})
+	}
+	if len(batch) == 0 {
+		return errs
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	reqRes, err := mem.proxyAppConn.CheckTxBatchAsync(ctx, request)
+	if err != nil {
+		for _, i := range batch {
+			mem.cache.Remove(txs[i])
+			errs[i] = err
+		}
+		return errs
+	}
+
+	reqRes.SetCallback(func(res *abci.Response) {
+		responses := res.GetCheckTxBatch().GetResponses()
+		if len(responses) != len(batch) {
+			mem.logger.Error(
+				"invalid CheckTxBatch response",
+				"num_txs", len(batch),
+				"num_responses", len(responses),
+			)
+			for _, i := range batch {
+				mem.cache.Remove(txs[i])
+			}
+			return
+		}
+
+		for j, i := range batch {
+			txCb := mem.reqResCb(txs[i], txInfo.SenderID, txInfo.SenderNodeID, cb, nil)
+			txCb(abci.ToResponseCheckTx(responses[j]))
+		}
+	})
+
+	return errs
+}
+
+// prepareCheckTx performs the checks preceding the execution of CheckTx for the
+// given transaction and adds it to the cache. It returns false if CheckTx must
+// not be executed, along with the error to return to the caller, if any.
+//
+// The caller must hold a read-lock on updateMtx.
+func (mem *CListMempool) prepareCheckTx(tx types.Tx, txInfo mempool.TxInfo) (bool, error) {
 	txSize := len(tx)
 
 	if err := mem.isFull(txSize); err != nil {
-		return err
+		return false, err
 	}
 
 	if txSize > mem.config.MaxTxBytes {
-		return pubmempool.ErrTxTooLarge{
+		return false, pubmempool.ErrTxTooLarge{
 			Max:    mem.config.MaxTxBytes,
 			Actual: txSize,
 		}
@@ -225,7 +409,7 @@ func (mem *CListMempool) CheckTx(
 
 	if mem.preCheck != nil {
 		if err := mem.preCheck(tx); err != nil {
-			return pubmempool.ErrPreCheck{
+			return false, pubmempool.ErrPreCheck{
 				Reason: err,
 			}
 		}
@@ -233,7 +417,7 @@ func (mem *CListMempool) CheckTx(
 
 	// NOTE: proxyAppConn may error if tx buffer is full
 	if err := mem.proxyAppConn.Error(); err != nil {
-		return err
+		return false, err
 	}
 
 	if !mem.cache.Push(tx) { // if the transaction already exists in the cache
@@ -244,30 +428,17 @@ func (mem *CListMempool) CheckTx(
 		if e, ok := mem.txsMap.Load(mempool.TxKey(tx)); ok {
 			memTx := e.(*clist.CElement).Value.(*mempoolTx)
 			_, loaded := memTx.senders.LoadOrStore(txInfo.SenderID, true)
-			// TODO: consider punishing peer for dups,
-			// its non-trivial since invalid txs can become valid,
-			// but they can spam the same tx with little cost to them atm.
+			// The reactor penalizes the peers which send us the same tx again.
 			if loaded {
-				return pubmempool.ErrTxInCache
+				return false, pubmempool.ErrTxInCache
 			}
 		}
 
 		mem.logger.Debug("tx exists already in cache", "tx_hash", tx.Hash())
-		return nil
+		return false, nil
 	}
 
-	if ctx == nil {
-		ctx = context.Background()
-	}
-
-	reqRes, err := mem.proxyAppConn.CheckTxAsync(ctx, abci.RequestCheckTx{Tx: tx})
-	if err != nil {
-		mem.cache.Remove(tx)
-		return err
-	}
-	reqRes.SetCallback(mem.reqResCb(tx, txInfo.SenderID, txInfo.SenderNodeID, cb))
-
-	return nil
+	return true, nil
 }
 
 // Global callback that will be called after every ABCI response.
@@ -284,7 +455,6 @@ func (mem *CListMempool) globalCb(req *abci.Request, res *abci.Response) {
 		return
 	}
 
-	mem.metrics.RecheckTimes.Add(1)
 	mem.resCbRecheck(req, res)
 
 	// update metrics
@@ -305,6 +475,7 @@ func (mem *CListMempool) reqResCb(
 	peerID uint16,
 	peerP2PID types.NodeID,
 	externalCb func(*abci.Response),
+	entry *mempool.JournalEntry,
 ) func(res *abci.Response) {
 	return func(res *abci.Response) {
 		if mem.recheckCursor != nil {
@@ -312,7 +483,7 @@ func (mem *CListMempool) reqResCb(
 			panic("recheck cursor is not nil in reqResCb")
 		}
 
-		mem.resCbFirstTime(tx, peerID, peerP2PID, res)
+		mem.resCbFirstTime(tx, peerID, peerP2PID, res, entry)
 
 		// update metrics
 		mem.metrics.Size.Set(float64(mem.Size()))
@@ -331,6 +502,12 @@ func (mem *CListMempool) addTx(memTx *mempoolTx) {
 	mem.txsMap.Store(mempool.TxKey(memTx.tx), e)
 	atomic.AddInt64(&mem.txsBytes, int64(len(memTx.tx)))
 	mem.metrics.TxSizeBytes.Observe(float64(len(memTx.tx)))
+
+	if mem.journal != nil {
+		if err := mem.journal.Add(memTx.tx, memTx.height, memTx.timestamp); err != nil {
+			mem.logger.Error("failed to journal tx", "err", err)
+		}
+	}
 }
 
 // Called from:
@@ -345,6 +522,12 @@ func (mem *CListMempool) removeTx(tx types.Tx, elem *clist.CElement, removeFromC
 	if removeFromCache {
 		mem.cache.Remove(tx)
 	}
+
+	if mem.journal != nil {
+		if err := mem.journal.Remove(tx); err != nil {
+			mem.logger.Error("failed to journal tx removal", "err", err)
+		}
+	}
 }
 
 // RemoveTxByKey removes a transaction from the mempool by its TxKey index.
@@ -376,6 +559,8 @@ func (mem *CListMempool) isFull(txSize int) error {
 }
 
 // callback, which is called after the app checked the tx for the first time.
+// A tx replayed from the journal, with entry set, keeps the height and time of
+// the entry, so that its TTLs do not restart.
 //
 // The case where the app checks the tx for the second and subsequent times is
 // handled by the resCbRecheck callback.
@@ -384,6 +569,7 @@ func (mem *CListMempool) resCbFirstTime(
 	peerID uint16,
 	peerP2PID types.NodeID,
 	res *abci.Response,
+	entry *mempool.JournalEntry,
 ) {
 	switch r := res.Value.(type) {
 	case *abci.Response_CheckTx:
@@ -403,9 +589,14 @@ func (mem *CListMempool) resCbFirstTime(
 
 			memTx := &mempoolTx{
 				height:    mem.height,
+				timestamp: time.Now().UTC(),
 				gasWanted: r.CheckTx.GasWanted,
 				tx:        tx,
 			}
+			if entry != nil {
+				memTx.height = entry.Height
+				memTx.timestamp = entry.Timestamp
+			}
 			memTx.senders.Store(peerID, true)
 			mem.addTx(memTx)
 			mem.logger.Debug(
@@ -445,45 +636,72 @@ func (mem *CListMempool) resCbFirstTime(
 func (mem *CListMempool) resCbRecheck(req *abci.Request, res *abci.Response) {
 	switch r := res.Value.(type) {
 	case *abci.Response_CheckTx:
-		tx := req.GetCheckTx().Tx
-		memTx := mem.recheckCursor.Value.(*mempoolTx)
-		if !bytes.Equal(tx, memTx.tx) {
-			panic(fmt.Sprintf(
-				"Unexpected tx response from proxy during recheck\nExpected %X, got %X",
-				memTx.tx,
-				tx))
-		}
-		var postCheckErr error
-		if mem.postCheck != nil {
-			postCheckErr = mem.postCheck(tx, r.CheckTx)
-		}
-		if (r.CheckTx.Code == abci.CodeTypeOK) && postCheckErr == nil {
-			// Good, nothing to do.
-		} else {
-			// Tx became invalidated due to newly committed block.
-			mem.logger.Debug("tx is no longer valid", "tx", mempool.TxHashFromBytes(tx), "res", r, "err", postCheckErr)
-			// NOTE: we remove tx from the cache because it might be good later
-			mem.removeTx(tx, mem.recheckCursor, !mem.config.KeepInvalidTxsInCache)
+		mem.recheckTxCallback(req.GetCheckTx().Tx, r.CheckTx)
+
+	case *abci.Response_CheckTxBatch:
+		requests := req.GetCheckTxBatch().Requests
+		if len(requests) == 0 || requests[0].Type != abci.CheckTxType_Recheck {
+			return
 		}
-		if mem.recheckCursor == mem.recheckEnd {
-			mem.recheckCursor = nil
-		} else {
-			mem.recheckCursor = mem.recheckCursor.Next()
+		if len(r.CheckTxBatch.Responses) != len(requests) {
+			panic(fmt.Sprintf(
+				"Unexpected number of tx responses from proxy during recheck\nExpected %d, got %d",
+				len(requests),
+				len(r.CheckTxBatch.Responses)))
 		}
-		if mem.recheckCursor == nil {
-			// Done!
-			mem.logger.Debug("done rechecking txs")
 
-			// incase the recheck removed all txs
-			if mem.Size() > 0 {
-				mem.notifyTxsAvailable()
+		for i := range requests {
+			if mem.recheckCursor == nil {
+				return
 			}
+			mem.recheckTxCallback(requests[i].Tx, &r.CheckTxBatch.Responses[i])
 		}
+
 	default:
 		// ignore other messages
 	}
 }
 
+// recheckTxCallback handles the response of the application to re-CheckTx for
+// the tx at the recheck cursor.
+func (mem *CListMempool) recheckTxCallback(tx types.Tx, r *abci.ResponseCheckTx) {
+	mem.metrics.RecheckTimes.Add(1)
+
+	memTx := mem.recheckCursor.Value.(*mempoolTx)
+	if !bytes.Equal(tx, memTx.tx) {
+		panic(fmt.Sprintf(
+			"Unexpected tx response from proxy during recheck\nExpected %X, got %X",
+			memTx.tx,
+			tx))
+	}
+	var postCheckErr error
+	if mem.postCheck != nil {
+		postCheckErr = mem.postCheck(tx, r)
+	}
+	if (r.Code == abci.CodeTypeOK) && postCheckErr == nil {
+		// Good, nothing to do.
+	} else {
+		// Tx became invalidated due to newly committed block.
+		mem.logger.Debug("tx is no longer valid", "tx", mempool.TxHashFromBytes(tx), "res", r, "err", postCheckErr)
+		// NOTE: we remove tx from the cache because it might be good later
+		mem.removeTx(tx, mem.recheckCursor, !mem.config.KeepInvalidTxsInCache)
+	}
+	if mem.recheckCursor == mem.recheckEnd {
+		mem.recheckCursor = nil
+	} else {
+		mem.recheckCursor = mem.recheckCursor.Next()
+	}
+	if mem.recheckCursor == nil {
+		// Done!
+		mem.logger.Debug("done rechecking txs")
+
+		// incase the recheck removed all txs
+		if mem.Size() > 0 {
+			mem.notifyTxsAvailable()
+		}
+	}
+}
+
 // Safe for concurrent use by multiple goroutines.
 func (mem *CListMempool) TxsAvailable() <-chan struct{} {
 	return mem.txsAvailable
@@ -604,6 +822,8 @@ func (mem *CListMempool) Update(
 		}
 	}
 
+	mem.syncJournal()
+
 	// Either recheck non-committed txs to see if they became invalid
 	// or just notify there're some txs left.
 	if mem.Size() > 0 {
@@ -634,19 +854,33 @@ func (mem *CListMempool) recheckTxs() {
 
 	ctx := context.Background()
 
-	// Push txs to proxyAppConn
+	// Push txs to proxyAppConn, in batches if CheckTxBatchSize is set
 	// NOTE: globalCb may be called concurrently.
+	var batch []abci.RequestCheckTx
 	for e := mem.txs.Front(); e != nil; e = e.Next() {
 		memTx := e.Value.(*mempoolTx)
-		_, err := mem.proxyAppConn.CheckTxAsync(ctx, abci.RequestCheckTx{
+		req := abci.RequestCheckTx{
 			Tx:   memTx.tx,
 			Type: abci.CheckTxType_Recheck,
-		})
-		if err != nil {
+		}
+
+		if mem.config.CheckTxBatchSize > 0 {
+			batch = append(batch, req)
+			if len(batch) == mem.config.CheckTxBatchSize {
+				mem.recheckTxBatch(ctx, batch)
+				batch = nil
+			}
+			continue
+		}
+
+		if _, err := mem.proxyAppConn.CheckTxAsync(ctx, req); err != nil {
 			// No need in retrying since memTx will be rechecked after next block.
 			mem.logger.Error("Can't check tx", "err", err)
 		}
 	}
+	if len(batch) > 0 {
+		mem.recheckTxBatch(ctx, batch)
+	}
 
 	_, err := mem.proxyAppConn.FlushAsync(ctx)
 	if err != nil {
@@ -654,13 +888,64 @@ func (mem *CListMempool) recheckTxs() {
 	}
 }
 
+// syncJournal syncs the journal, if any, to disk and compacts it once it holds
+// many more records than there are txs in the mempool.
+//
+// Lock() must be held by the caller during execution.
+// recheckTxBatch executes CheckTxBatchAsync for the given batch of txs to
+// recheck.
+func (mem *CListMempool) recheckTxBatch(ctx context.Context, batch []abci.RequestCheckTx) {
+	_, err := mem.proxyAppConn.CheckTxBatchAsync(ctx, abci.RequestCheckTxBatch{Requests: batch})
+	if err != nil {
+		// No need in retrying since the txs will be rechecked after next block.
+		mem.logger.Error("Can't check tx batch", "err", err)
+	}
+}
+
+func (mem *CListMempool) syncJournal() {
+	if mem.journal == nil {
+		return
+	}
+
+	if mem.journal.ShouldCompact(mem.Size()) {
+		if err := mem.compactJournal(); err != nil {
+			mem.logger.Error("failed to compact mempool journal", "err", err)
+		}
+		return
+	}
+
+	if err := mem.journal.Sync(); err != nil {
+		mem.logger.Error("failed to sync mempool journal", "err", err)
+	}
+}
+
+// compactJournal rewrites the journal, if any, with the txs of the mempool.
+func (mem *CListMempool) compactJournal() error {
+	if mem.journal == nil {
+		return nil
+	}
+
+	entries := make([]mempool.JournalEntry, 0, mem.Size())
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		memTx := e.Value.(*mempoolTx)
+		entries = append(entries, mempool.JournalEntry{
+			Tx:        memTx.tx,
+			Height:    memTx.Height(),
+			Timestamp: memTx.timestamp,
+		})
+	}
+
+	return mem.journal.Compact(entries)
+}
+
 //--------------------------------------------------------------------------------
 
 // mempoolTx is a transaction that successfully ran
 type mempoolTx struct {
-	height    int64    // height that this tx had been validated in
-	gasWanted int64    // amount of gas this tx states it will require
-	tx        types.Tx //
+	height    int64     // height that this tx had been validated in
+	timestamp time.Time // time that this tx was first validated at
+	gasWanted int64     // amount of gas this tx states it will require
+	tx        types.Tx  //
 
 	// ids of peers who've sent us this tx (as a map for quick lookups).
 	// senders: PeerID -> bool
@@ -671,3 +956,14 @@ type mempoolTx struct {
 func (memTx *mempoolTx) Height() int64 {
 	return atomic.LoadInt64(&memTx.height)
 }
+
+// record returns the record of this transaction, which is in the mempool
+func (memTx *mempoolTx) record() mempool.TxRecord {
+	return mempool.TxRecord{
+		Tx:        memTx.tx,
+		Status:    mempool.TxStatusPending,
+		GasWanted: memTx.gasWanted,
+		Height:    memTx.Height(),
+		Timestamp: memTx.timestamp,
+	}
+}
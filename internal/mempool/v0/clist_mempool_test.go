@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	mrand "math/rand"
 	"os"
@@ -15,6 +16,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	abcicli "github.com/tendermint/tendermint/abci/client"
 	"github.com/tendermint/tendermint/abci/example/kvstore"
 	abciserver "github.com/tendermint/tendermint/abci/server"
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -215,6 +217,134 @@ func TestMempoolUpdate(t *testing.T) {
 	}
 }
 
+func TestMempoolJournal(t *testing.T) {
+	config := cfg.ResetTestRoot("mempool_test")
+	journal, entries, err := mempool.OpenJournal(config.Mempool.JournalFile())
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	mp, cleanup := newMempoolWithAppAndConfig(cc, config)
+	defer cleanup()
+	mp.journal = journal
+
+	txs := checkTxs(t, mp, 5, mempool.UnknownPeerID)
+	require.NoError(t, mp.Update(1, txs[:2], abciResponses(2, abci.CodeTypeOK), nil, nil))
+	require.Equal(t, 3, mp.Size())
+	require.NoError(t, journal.Close())
+
+	// the txs left in the mempool are replayed into a new mempool
+	journal, entries, err = mempool.OpenJournal(config.Mempool.JournalFile())
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+
+	mp2, cleanup2 := newMempoolWithAppAndConfig(proxy.NewLocalClientCreator(kvstore.NewApplication()), config)
+	defer cleanup2()
+	mp2.journal = journal
+	mp2.height = 1
+
+	require.NoError(t, mp2.ReplayJournal(entries))
+	require.Equal(t, txs[2:], mp2.ReapMaxTxs(-1))
+
+	// the replayed txs keep the height and time of their entries, so that their
+	// TTLs do not restart
+	for _, entry := range entries {
+		record, ok := mp2.GetTxRecord(mempool.TxKey(entry.Tx))
+		require.True(t, ok)
+		require.Equal(t, entry.Height, record.Height)
+		require.True(t, entry.Timestamp.Equal(record.Timestamp))
+	}
+	require.NoError(t, journal.Close())
+}
+
+// batchCountingAppConn counts the CheckTxBatch requests sent to the
+// application.
+type batchCountingAppConn struct {
+	proxy.AppConnMempool
+
+	batches int
+}
+
+func (c *batchCountingAppConn) CheckTxBatchAsync(
+	ctx context.Context,
+	req abci.RequestCheckTxBatch,
+) (*abcicli.ReqRes, error) {
+	c.batches++
+	return c.AppConnMempool.CheckTxBatchAsync(ctx, req)
+}
+
+func TestMempoolCheckTxBatch(t *testing.T) {
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	mp, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+	appConn := &batchCountingAppConn{AppConnMempool: mp.proxyAppConn}
+	mp.proxyAppConn = appConn
+
+	txInfo := mempool.TxInfo{SenderID: 1}
+	require.NoError(t, mp.CheckTx(context.Background(), types.Tx("a=1"), nil, txInfo))
+
+	txs := types.Txs{
+		types.Tx("a=1"),                        // already seen from the same peer
+		types.Tx("b=2"),                        // valid
+		make(types.Tx, mp.config.MaxTxBytes+1), // too large
+		types.Tx("c=3"),                        // valid
+	}
+	errs := mp.CheckTxBatch(context.Background(), txs, nil, txInfo)
+	require.Len(t, errs, len(txs))
+	require.Equal(t, pubmempool.ErrTxInCache, errs[0])
+	require.NoError(t, errs[1])
+	require.Error(t, errs[2])
+	require.NoError(t, errs[3])
+
+	require.Equal(t, 1, appConn.batches)
+	require.Equal(t, types.Txs{types.Tx("a=1"), types.Tx("b=2"), types.Tx("c=3")}, mp.ReapMaxTxs(-1))
+}
+
+func TestMempoolRecheckTxBatch(t *testing.T) {
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	config := cfg.ResetTestRoot("mempool_test")
+	config.Mempool.CheckTxBatchSize = 3
+	mp, cleanup := newMempoolWithAppAndConfig(cc, config)
+	defer cleanup()
+	appConn := &batchCountingAppConn{AppConnMempool: mp.proxyAppConn}
+	mp.proxyAppConn = appConn
+
+	txs := checkTxs(t, mp, 10, mempool.UnknownPeerID)
+	require.Equal(t, 10, mp.Size())
+
+	// the first tx is committed and every other remaining tx fails to pass
+	// CheckTx again
+	invalid := make(map[string]bool)
+	for i, tx := range txs[1:] {
+		if i%2 == 0 {
+			invalid[string(tx)] = true
+		}
+	}
+	postCheck := func(tx types.Tx, _ *abci.ResponseCheckTx) error {
+		if invalid[string(tx)] {
+			return errors.New("invalid")
+		}
+		return nil
+	}
+
+	mp.Lock()
+	require.NoError(t, mp.Update(1, txs[:1], abciResponses(1, abci.CodeTypeOK), nil, postCheck))
+	require.NoError(t, mp.FlushAppConn())
+	mp.Unlock()
+
+	// the 9 remaining txs are rechecked in batches of 3
+	require.Equal(t, 3, appConn.batches)
+	require.Nil(t, mp.recheckCursor)
+	require.Equal(t, 9-len(invalid), mp.Size())
+	for _, tx := range txs[1:] {
+		_, ok := mp.GetTxByKey(mempool.TxKey(tx))
+		require.Equal(t, !invalid[string(tx)], ok)
+	}
+}
+
 func TestMempool_KeepInvalidTxsInCache(t *testing.T) {
 	app := kvstore.NewApplication()
 	cc := proxy.NewLocalClientCreator(app)
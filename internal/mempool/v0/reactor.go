@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	abci "github.com/tendermint/tendermint/abci/types"
 	cfg "github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/internal/libs/clist"
 	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
@@ -15,6 +16,7 @@ import (
 	"github.com/tendermint/tendermint/internal/p2p"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/libs/service"
+	pubmempool "github.com/tendermint/tendermint/pkg/mempool"
 	protomem "github.com/tendermint/tendermint/proto/tendermint/mempool"
 	"github.com/tendermint/tendermint/types"
 )
@@ -54,6 +56,14 @@ type Reactor struct {
 	// goroutines.
 	peerWG sync.WaitGroup
 
+	// gossip tracks the gossip mode of the peers and the transactions requested
+	// from them in the pull gossip mode.
+	gossip *mempool.Gossip
+
+	// admission limits the rate of the txs received from each peer and scores
+	// the peers on the txs they send.
+	admission *mempool.PeerAdmission
+
 	mtx          tmsync.Mutex
 	peerRoutines map[types.NodeID]*tmsync.Closer
 }
@@ -76,8 +86,10 @@ func NewReactor(
 		mempoolCh:    mempoolCh,
 		peerUpdates:  peerUpdates,
 		closeCh:      make(chan struct{}),
+		admission:    mempool.NewPeerAdmission(config),
 		peerRoutines: make(map[types.NodeID]*tmsync.Closer),
 	}
+	r.gossip = mempool.NewGossip(config, logger, mempoolCh, r.closeCh)
 
 	r.BaseService = *service.NewBaseService(logger, "Mempool", r)
 	return r
@@ -91,20 +103,13 @@ func NewReactor(
 // TODO: Remove once p2p refactor is complete.
 // ref: https://github.com/tendermint/tendermint/issues/5670
 func GetChannelShims(config *cfg.MempoolConfig) map[p2p.ChannelID]*p2p.ChannelDescriptorShim {
-	largestTx := make([]byte, config.MaxTxBytes)
-	batchMsg := protomem.Message{
-		Sum: &protomem.Message_Txs{
-			Txs: &protomem.Txs{Txs: [][]byte{largestTx}},
-		},
-	}
-
 	return map[p2p.ChannelID]*p2p.ChannelDescriptorShim{
 		mempool.MempoolChannel: {
 			MsgType: new(protomem.Message),
 			Descriptor: &p2p.ChannelDescriptor{
 				ID:                  byte(mempool.MempoolChannel),
 				Priority:            5,
-				RecvMessageCapacity: batchMsg.Size(),
+				RecvMessageCapacity: mempool.MaxMsgSize(config),
 				RecvBufferCapacity:  128,
 				MaxSendBytes:        5000,
 			},
@@ -124,6 +129,10 @@ func (r *Reactor) OnStart() error {
 	go r.processMempoolCh()
 	go r.processPeerUpdates()
 
+	if r.config.GossipMode == cfg.MempoolGossipModePull {
+		go r.gossip.RetryRequestsRoutine(r.hasTx)
+	}
+
 	return nil
 }
 
@@ -151,9 +160,12 @@ func (r *Reactor) OnStop() {
 }
 
 // handleMempoolMessage handles envelopes sent from peers on the MempoolChannel.
-// For every tx in the message, we execute CheckTx. It returns an error if an
-// empty set of txs are sent in an envelope or if we receive an unexpected
-// message type.
+// For every tx in the message within the peer's admission quota, we execute
+// CheckTx, penalizing the peer for each tx which fails CheckTx or which it
+// already sent us. In the pull gossip mode, we
+// request the announced txs we do not have yet and send the requested txs we
+// have. It returns an error if an empty set of txs or hashes are sent in an
+// envelope or if we receive an unexpected message type.
 func (r *Reactor) handleMempoolMessage(envelope p2p.Envelope) error {
 	logger := r.Logger.With("peer", envelope.From)
 
@@ -169,12 +181,54 @@ func (r *Reactor) handleMempoolMessage(envelope p2p.Envelope) error {
 			txInfo.SenderNodeID = envelope.From
 		}
 
+		txs := make(types.Txs, 0, len(protoTxs))
 		for _, tx := range protoTxs {
-			if err := r.mempool.CheckTx(context.Background(), types.Tx(tx), nil, txInfo); err != nil {
-				logger.Error("checktx failed for tx", "tx", fmt.Sprintf("%X", mempool.TxHashFromBytes(tx)), "err", err)
+			r.gossip.Received(tx)
+			if r.admission.Admit(envelope.From) {
+				txs = append(txs, types.Tx(tx))
+			}
+		}
+
+		if dropped := len(protoTxs) - len(txs); dropped > 0 {
+			r.mempool.metrics.RateLimitedTxs.Add(float64(dropped))
+			logger.Debug("dropped txs over the peer's admission quota", "num_txs", dropped)
+		}
+
+		cb := r.checkTxCallback(envelope.From)
+
+		// check the txs in batches, if enabled, so that the application can
+		// verify them in parallel
+		if r.config.CheckTxBatchSize > 0 && len(txs) > 1 {
+			for start := 0; start < len(txs); start += r.config.CheckTxBatchSize {
+				end := start + r.config.CheckTxBatchSize
+				if end > len(txs) {
+					end = len(txs)
+				}
+
+				errs := r.mempool.CheckTxBatch(context.Background(), txs[start:end], cb, txInfo)
+				for i, err := range errs {
+					if err != nil {
+						r.checkTxFailed(envelope.From, txs[start+i], err)
+					}
+				}
+			}
+		} else {
+			for _, tx := range txs {
+				if err := r.mempool.CheckTx(context.Background(), tx, cb, txInfo); err != nil {
+					r.checkTxFailed(envelope.From, tx, err)
+				}
 			}
 		}
 
+	case *protomem.GossipMode:
+		r.gossip.SetGossipMode(envelope.From, msg.Pull)
+
+	case *protomem.TxAnnouncement:
+		return r.gossip.HandleAnnouncement(envelope.From, msg, r.hasTx)
+
+	case *protomem.TxRequest:
+		return r.gossip.HandleRequest(envelope.From, msg, r.mempool.GetTxByKey)
+
 	default:
 		return fmt.Errorf("received unknown message: %T", msg)
 	}
@@ -182,6 +236,54 @@ func (r *Reactor) handleMempoolMessage(envelope p2p.Envelope) error {
 	return nil
 }
 
+// checkTxCallback returns the callback of CheckTx for the txs received from
+// the given peer, which penalizes the peer for each tx rejected by the
+// application.
+func (r *Reactor) checkTxCallback(peerID types.NodeID) func(*abci.Response) {
+	return func(res *abci.Response) {
+		if checkTxRes, ok := res.Value.(*abci.Response_CheckTx); ok && checkTxRes.CheckTx.Code != abci.CodeTypeOK {
+			r.penalizePeer(peerID)
+		}
+	}
+}
+
+// checkTxFailed handles the error returned by CheckTx for a tx received from
+// the given peer, penalizing the peer if it already sent us the tx.
+func (r *Reactor) checkTxFailed(peerID types.NodeID, tx types.Tx, err error) {
+	r.Logger.Error("checktx failed for tx", "peer", peerID, "tx", fmt.Sprintf("%X", tx.Hash()), "err", err)
+
+	if errors.Is(err, pubmempool.ErrTxInCache) {
+		r.penalizePeer(peerID)
+	}
+}
+
+// penalizePeer increases the spam score of the given peer, which is reported to
+// be disconnected once its score exceeds the maximum.
+func (r *Reactor) penalizePeer(peerID types.NodeID) {
+	err := r.admission.Penalize(peerID)
+	if err == nil {
+		return
+	}
+
+	r.mempool.metrics.SpamPeers.Add(1)
+	r.Logger.Info("reporting spamming peer", "peer", peerID, "err", err)
+
+	select {
+	case r.mempoolCh.Error <- p2p.PeerError{NodeID: peerID, Err: err}:
+	case <-r.closeCh:
+	}
+}
+
+// hasTx returns true if the tx with the given key is in the mempool or in the
+// cache of the txs seen recently.
+func (r *Reactor) hasTx(key [mempool.TxKeySize]byte) bool {
+	if r.mempool.cache.Has(key) {
+		return true
+	}
+	_, ok := r.mempool.GetTxByKey(key)
+	return ok
+}
+
 // handleMessage handles an Envelope sent from a peer on a specific p2p Channel.
 // It will handle errors and any possible panics gracefully. A caller can handle
 // any error returned by sending a PeerError on the respective channel.
@@ -241,6 +343,13 @@ func (r *Reactor) processMempoolCh() {
 func (r *Reactor) processPeerUpdate(peerUpdate p2p.PeerUpdate) {
 	r.Logger.Debug("received peer update", "peer", peerUpdate.NodeID, "status", peerUpdate.Status)
 
+	// Advertise the pull gossip mode to the peer, which keeps pushing txs to us
+	// otherwise.
+	if peerUpdate.Status == p2p.PeerStatusUp && r.IsRunning() &&
+		r.config.GossipMode == cfg.MempoolGossipModePull {
+		r.gossip.SendGossipMode(peerUpdate.NodeID)
+	}
+
 	r.mtx.Lock()
 	defer r.mtx.Unlock()
 
@@ -275,6 +384,8 @@ func (r *Reactor) processPeerUpdate(peerUpdate p2p.PeerUpdate) {
 
 	case p2p.PeerStatusDown:
 		r.ids.Reclaim(peerUpdate.NodeID)
+		r.admission.RemovePeer(peerUpdate.NodeID)
+		r.gossip.RemovePeer(peerUpdate.NodeID)
 
 		// Check if we've started a tx broadcasting goroutine for this peer.
 		// If we have, we signal to terminate the goroutine via the channel's closure.
@@ -305,10 +416,16 @@ func (r *Reactor) processPeerUpdates() {
 	}
 }
 
+// broadcastTxRoutine gossips the transactions of the mempool to the given peer.
+// In the pull gossip mode, the hashes of the transactions are announced in
+// batches, sent once full or once there is no transaction left to gossip.
 func (r *Reactor) broadcastTxRoutine(peerID types.NodeID, closer *tmsync.Closer) {
 	peerMempoolID := r.ids.GetForPeer(peerID)
 	var next *clist.CElement
 
+	// announcement holds the hashes of the transactions to announce to the peer
+	var announcement [][]byte
+
 	// remove the peer ID from the map of routines and mark the waitgroup as done
 	defer func() {
 		r.mtx.Lock()
@@ -335,6 +452,11 @@ func (r *Reactor) broadcastTxRoutine(peerID types.NodeID, closer *tmsync.Closer)
 		// collected (removed). That is, .NextWait() returned nil. Go ahead and
 		// start from the beginning.
 		if next == nil {
+			if len(announcement) > 0 {
+				r.gossip.AnnounceTxs(peerID, announcement)
+				announcement = nil
+			}
+
 			select {
 			case <-r.mempool.TxsWaitChan(): // wait until a tx is available
 				if next = r.mempool.TxsFront(); next == nil {
@@ -367,7 +489,14 @@ func (r *Reactor) broadcastTxRoutine(peerID types.NodeID, closer *tmsync.Closer)
 		// NOTE: Transaction batching was disabled due to:
 		// https://github.com/tendermint/tendermint/issues/5796
 
-		if _, ok := memTx.senders.Load(peerMempoolID); !ok {
+		if _, ok := memTx.senders.Load(peerMempoolID); !ok && r.gossip.UsePullGossip(peerID) {
+			key := mempool.TxKey(memTx.tx)
+			announcement = append(announcement, key[:])
+			if len(announcement) >= r.config.MaxAnnounceBatchSize {
+				r.gossip.AnnounceTxs(peerID, announcement)
+				announcement = nil
+			}
+		} else if !ok {
 			// Send the mempool tx to the corresponding peer. Note, the peer may be
 			// behind and thus would not be able to process the mempool tx correctly.
 			r.mempoolCh.Out <- p2p.Envelope{
@@ -383,6 +512,16 @@ func (r *Reactor) broadcastTxRoutine(peerID types.NodeID, closer *tmsync.Closer)
 			)
 		}
 
+		if len(announcement) > 0 {
+			select {
+			case <-next.NextWaitChan():
+			default:
+				// announce the batched txs before waiting for the next tx
+				r.gossip.AnnounceTxs(peerID, announcement)
+				announcement = nil
+			}
+		}
+
 		select {
 		case <-next.NextWaitChan():
 			// see the start of the for loop for nil check
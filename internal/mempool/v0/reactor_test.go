@@ -181,6 +181,57 @@ func TestReactorBroadcastTxs(t *testing.T) {
 	rts.assertMempoolChannelsDrained(t)
 }
 
+func TestReactorBroadcastTxsPullGossip(t *testing.T) {
+	numTxs := 100
+	numNodes := 4
+	config := cfg.TestConfig()
+	config.Mempool.GossipMode = cfg.MempoolGossipModePull
+	config.Mempool.MaxAnnounceBatchSize = 10
+
+	rts := setup(t, config.Mempool, numNodes, 0)
+
+	primary := rts.nodes[0]
+	secondaries := rts.nodes[1:]
+
+	// the primary node is the only one using the push mode, with which its
+	// peers fall back to the push mode
+	pushConfig := *config.Mempool
+	pushConfig.GossipMode = cfg.MempoolGossipModePush
+	rts.reactors[secondaries[0]].config = &pushConfig
+
+	// run the router
+	rts.start(t)
+
+	// wait for the nodes to learn the gossip mode of their peers
+	require.Eventually(t, func() bool {
+		for _, nodeID := range secondaries[1:] {
+			if !rts.reactors[primary].gossip.UsePullGossip(nodeID) {
+				return false
+			}
+		}
+		return !rts.reactors[primary].gossip.UsePullGossip(secondaries[0]) &&
+			!rts.reactors[secondaries[1]].gossip.UsePullGossip(secondaries[0])
+	}, 5*time.Second, 10*time.Millisecond)
+
+	txs := checkTxs(t, rts.reactors[primary].mempool, numTxs, mempool.UnknownPeerID)
+
+	// Transactions are received in any order, as they are requested from the
+	// peers which announced them first.
+	for _, nodeID := range secondaries {
+		pool := rts.mempools[nodeID]
+		require.Eventually(t, func() bool {
+			return pool.Size() == numTxs
+		}, 10*time.Second, 50*time.Millisecond, "node %s", nodeID)
+
+		for _, tx := range txs {
+			_, ok := pool.GetTxByKey(mempool.TxKey(tx))
+			require.True(t, ok, "node %s is missing tx %X", nodeID, tx.Hash())
+		}
+	}
+
+	rts.assertMempoolChannelsDrained(t)
+}
+
 // regression test for https://github.com/tendermint/tendermint/issues/5408
 func TestReactorConcurrency(t *testing.T) {
 	numTxs := 5
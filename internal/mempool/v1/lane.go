@@ -0,0 +1,127 @@
+package v1
+
+import (
+	"math"
+	"sort"
+	"sync/atomic"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/libs/clist"
+)
+
+// DefaultLane is the lane of the transactions the application assigned to no
+// lane, or to a lane which is not configured.
+const DefaultLane = "default"
+
+// txLane defines a class of transactions, assigned by the application in the
+// ResponseCheckTx, with its own size limits, share of the block space when
+// reaping and gossip priority.
+type txLane struct {
+	name           string
+	maxTxs         int
+	maxTxsBytes    int64
+	reapShare      int64
+	gossipPriority int
+
+	// numTxs and sizeBytes define the number and total size of the transactions
+	// of the lane. They are accessed atomically.
+	numTxs    int64
+	sizeBytes int64
+
+	// gossipIndex defines the gossiping index of the valid transactions of the
+	// lane via a thread-safe linked-list.
+	gossipIndex *clist.CList
+}
+
+func newTxLane(cfg config.MempoolLaneConfig) *txLane {
+	return &txLane{
+		name:           cfg.Name,
+		maxTxs:         cfg.Size,
+		maxTxsBytes:    cfg.MaxTxsBytes,
+		reapShare:      cfg.ReapShare,
+		gossipPriority: cfg.GossipPriority,
+		gossipIndex:    clist.New(),
+	}
+}
+
+// newTxLanes returns the lanes of the given mempool configuration, including
+// the default lane whether it is configured or not, in descending gossip
+// priority order.
+func newTxLanes(cfg *config.MempoolConfig) []*txLane {
+	lanes := make([]*txLane, 0, len(cfg.Lanes)+1)
+	hasDefault := false
+	for _, laneCfg := range cfg.Lanes {
+		lanes = append(lanes, newTxLane(laneCfg))
+		hasDefault = hasDefault || laneCfg.Name == DefaultLane
+	}
+	if !hasDefault {
+		lanes = append(lanes, newTxLane(config.MempoolLaneConfig{Name: DefaultLane}))
+	}
+
+	sort.SliceStable(lanes, func(i, j int) bool {
+		return lanes[i].gossipPriority > lanes[j].gossipPriority
+	})
+
+	return lanes
+}
+
+// NumTxs returns the number of transactions in the lane. It is thread-safe.
+func (l *txLane) NumTxs() int {
+	return int(atomic.LoadInt64(&l.numTxs))
+}
+
+// SizeBytes returns the total size of the transactions in the lane. It is
+// thread-safe.
+func (l *txLane) SizeBytes() int64 {
+	return atomic.LoadInt64(&l.sizeBytes)
+}
+
+// isFull returns true if the lane has no room for a transaction of the given
+// size.
+func (l *txLane) isFull(txSize int) bool {
+	if l.maxTxs > 0 && l.NumTxs() >= l.maxTxs {
+		return true
+	}
+	return l.maxTxsBytes > 0 && l.SizeBytes()+int64(txSize) > l.maxTxsBytes
+}
+
+// maxBytes returns the limit on the total size of the transactions of the lane,
+// or math.MaxInt64 if there is none.
+func (l *txLane) maxBytes() int64 {
+	if l.maxTxsBytes > 0 {
+		return l.maxTxsBytes
+	}
+	return math.MaxInt64
+}
+
+func (l *txLane) pushTx(wtx *WrappedTx) {
+	// Insert the transaction into the gossip index of the lane and mark the
+	// reference to the linked-list element, which will be needed at a later
+	// point when the transaction is removed.
+	wtx.laneEl = l.gossipIndex.PushBack(wtx)
+
+	atomic.AddInt64(&l.numTxs, 1)
+	atomic.AddInt64(&l.sizeBytes, int64(wtx.Size()))
+}
+
+func (l *txLane) removeTx(wtx *WrappedTx) {
+	// Remove the transaction from the gossip index and cleanup the linked-list
+	// element so it can be garbage collected.
+	l.gossipIndex.Remove(wtx.laneEl)
+	wtx.laneEl.DetachPrev()
+
+	atomic.AddInt64(&l.numTxs, -1)
+	atomic.AddInt64(&l.sizeBytes, int64(-wtx.Size()))
+}
+
+// reapShareOf returns the share of the given limit reserved to the lane, or -1
+// if there is no limit.
+func (l *txLane) reapShareOf(limit int64) int64 {
+	if limit < 0 {
+		return -1
+	}
+	if limit > math.MaxInt64/100 {
+		return limit / 100 * l.reapShare
+	}
+	return limit * l.reapShare / 100
+}
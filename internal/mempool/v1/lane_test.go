@@ -0,0 +1,61 @@
+package v1
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/config"
+)
+
+func TestNewTxLanes(t *testing.T) {
+	cfg := config.TestMempoolConfig()
+
+	lanes := newTxLanes(cfg)
+	require.Len(t, lanes, 1)
+	require.Equal(t, DefaultLane, lanes[0].name)
+
+	cfg.Lanes = []config.MempoolLaneConfig{
+		{Name: "governance", GossipPriority: 5},
+		{Name: "default", Size: 10, GossipPriority: -1},
+		{Name: "oracle", GossipPriority: 10},
+		{Name: "ibc", GossipPriority: 5},
+	}
+	lanes = newTxLanes(cfg)
+
+	names := make([]string, len(lanes))
+	for i, lane := range lanes {
+		names[i] = lane.name
+	}
+	require.Equal(t, []string{"oracle", "governance", "ibc", DefaultLane}, names)
+	require.Equal(t, 10, lanes[3].maxTxs)
+}
+
+func TestTxLane_IsFull(t *testing.T) {
+	lane := newTxLane(config.MempoolLaneConfig{Name: "oracle"})
+	lane.pushTx(&WrappedTx{tx: make([]byte, 100)})
+	require.False(t, lane.isFull(math.MaxInt32))
+	require.Equal(t, int64(math.MaxInt64), lane.maxBytes())
+
+	lane.maxTxs = 2
+	require.False(t, lane.isFull(1))
+	wtx := &WrappedTx{tx: make([]byte, 100)}
+	lane.pushTx(wtx)
+	require.True(t, lane.isFull(1))
+
+	lane.removeTx(wtx)
+	lane.maxTxsBytes = 150
+	require.False(t, lane.isFull(50))
+	require.True(t, lane.isFull(51))
+	require.Equal(t, 1, lane.NumTxs())
+	require.Equal(t, int64(100), lane.SizeBytes())
+}
+
+func TestTxLane_ReapShareOf(t *testing.T) {
+	lane := newTxLane(config.MempoolLaneConfig{Name: "oracle", ReapShare: 30})
+	require.Equal(t, int64(-1), lane.reapShareOf(-1))
+	require.Equal(t, int64(0), lane.reapShareOf(0))
+	require.Equal(t, int64(3), lane.reapShareOf(10))
+	require.Equal(t, int64(math.MaxInt64/100*30), lane.reapShareOf(math.MaxInt64))
+}
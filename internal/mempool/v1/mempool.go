@@ -1,12 +1,14 @@
 package v1
 
 import (
-	"bytes"
 	"context"
 	"fmt"
+	"math"
+	"sort"
 	"sync/atomic"
 	"time"
 
+	abcicli "github.com/tendermint/tendermint/abci/client"
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/internal/libs/clist"
@@ -21,6 +23,10 @@ import (
 
 var _ mempool.Mempool = (*TxMempool)(nil)
 
+// txHistorySize defines the number of transactions which left the mempool that
+// are recorded, so that their status can be looked up.
+const txHistorySize = 10000
+
 // TxMempoolOption sets an optional parameter on the TxMempool.
 type TxMempoolOption func(*TxMempool)
 
@@ -53,22 +59,14 @@ type TxMempool struct {
 	txStore *TxStore
 
 	// gossipIndex defines the gossiping index of valid transactions via a
-	// thread-safe linked-list. We also use the gossip index as a cursor for
-	// rechecking transactions already in the mempool.
+	// thread-safe linked-list.
 	gossipIndex *clist.CList
 
-	// recheckCursor and recheckEnd are used as cursors based on the gossip index
-	// to recheck transactions that are already in the mempool. Iteration is not
-	// thread-safe and transaction may be mutated in serial order.
-	//
-	// XXX/TODO: It might be somewhat of a codesmell to use the gossip index for
-	// iterator and cursor management when rechecking transactions. If the gossip
-	// index changes or is removed in a future refactor, this will have to be
-	// refactored. Instead, we should consider just keeping a slice of a snapshot
-	// of the mempool's current transactions during Update and an integer cursor
-	// into that slice. This, however, requires additional O(n) space complexity.
-	recheckCursor *clist.CElement // next expected response
-	recheckEnd    *clist.CElement // re-checking stops here
+	// recheck is the re-CheckTx of the transactions remaining in the mempool
+	// after the last block, if any. recheckMtx guards it and serializes the
+	// handling of its responses.
+	recheckMtx tmsync.Mutex
+	recheck    *txRecheck
 
 	// priorityIndex defines the priority index of valid transactions via a
 	// thread-safe priority queue.
@@ -82,6 +80,30 @@ type TxMempool struct {
 	// index. i.e. older transactions are first.
 	timestampIndex *WrappedTxList
 
+	// lanes defines the lanes of the mempool in descending gossip priority order
+	// and laneIndex the lanes by name. reapShares is true if any lane has a
+	// share of the block space reserved when reaping.
+	lanes      []*txLane
+	laneIndex  map[string]*txLane
+	reapShares bool
+
+	// gossipCh is closed, and replaced, whenever a transaction is inserted into
+	// the mempool, to wake up the routines gossiping the transactions of the
+	// lanes.
+	gossipMtx tmsync.Mutex
+	gossipCh  chan struct{}
+
+	// history records the transactions which recently left the mempool.
+	history *TxHistory
+
+	// eventBus publishes the events of transactions entering or leaving the
+	// mempool.
+	eventBus types.MempoolTxEventPublisher
+
+	// journal, if set, persists the transactions of the mempool so that they can
+	// be replayed on startup.
+	journal *mempool.Journal
+
 	// A read/write lock is used to safe guard updates, insertions and deletions
 	// from the mempool. A read-lock is implicitly acquired when executing CheckTx,
 	// however, a caller must explicitly grab a write-lock via Lock when updating
@@ -107,7 +129,12 @@ func NewTxMempool(
 		cache:         mempool.NopTxCache{},
 		metrics:       mempool.NopMetrics(),
 		txStore:       NewTxStore(),
+		history:       NewTxHistory(txHistorySize),
+		eventBus:      types.NopEventBus{},
 		gossipIndex:   clist.New(),
+		lanes:         newTxLanes(cfg),
+		laneIndex:     make(map[string]*txLane),
+		gossipCh:      make(chan struct{}),
 		priorityIndex: NewTxPriorityQueue(),
 		heightIndex: NewWrappedTxList(func(wtx1, wtx2 *WrappedTx) bool {
 			return wtx1.height >= wtx2.height
@@ -121,7 +148,10 @@ func NewTxMempool(
 		txmp.cache = mempool.NewLRUTxCache(cfg.CacheSize)
 	}
 
-	proxyAppConn.SetResponseCallback(txmp.defaultTxCallback)
+	for _, lane := range txmp.lanes {
+		txmp.laneIndex[lane.name] = lane
+		txmp.reapShares = txmp.reapShares || lane.reapShare > 0
+	}
 
 	for _, opt := range options {
 		opt(txmp)
@@ -149,6 +179,18 @@ func WithMetrics(metrics *mempool.Metrics) TxMempoolOption {
 	return func(txmp *TxMempool) { txmp.metrics = metrics }
 }
 
+// WithEventBus sets the event bus publishing the events of transactions entering
+// or leaving the mempool.
+func WithEventBus(eventBus types.MempoolTxEventPublisher) TxMempoolOption {
+	return func(txmp *TxMempool) { txmp.eventBus = eventBus }
+}
+
+// WithJournal sets the journal persisting the transactions of the mempool. The
+// transactions it held when opened can be replayed with ReplayJournal.
+func WithJournal(journal *mempool.Journal) TxMempoolOption {
+	return func(txmp *TxMempool) { txmp.journal = journal }
+}
+
 // Lock obtains a write-lock on the mempool. A caller must be sure to explicitly
 // release the lock when finished.
 func (txmp *TxMempool) Lock() {
@@ -172,6 +214,37 @@ func (txmp *TxMempool) SizeBytes() int64 {
 	return atomic.LoadInt64(&txmp.sizeBytes)
 }
 
+// GetTxByKey returns the valid transaction with the given key, if any. It is
+// thread-safe.
+func (txmp *TxMempool) GetTxByKey(key [mempool.TxKeySize]byte) (types.Tx, bool) {
+	if wtx := txmp.txStore.GetTxByHash(key); wtx != nil {
+		return wtx.tx, true
+	}
+	return nil, false
+}
+
+// GetTxRecord returns the record of the transaction with the given key, if it
+// is in the mempool or is among the last transactions which left it. It is
+// thread-safe.
+func (txmp *TxMempool) GetTxRecord(key [mempool.TxKeySize]byte) (mempool.TxRecord, bool) {
+	if wtx := txmp.txStore.GetTxByHash(key); wtx != nil {
+		return newTxRecord(wtx, mempool.TxStatusPending), true
+	}
+	return txmp.history.Get(key)
+}
+
+// GetTxRecords returns the records of all the transactions in the mempool. It
+// is thread-safe.
+func (txmp *TxMempool) GetTxRecords() []mempool.TxRecord {
+	wtxs := txmp.txStore.GetAllTxs()
+
+	records := make([]mempool.TxRecord, len(wtxs))
+	for i, wtx := range wtxs {
+		records[i] = newTxRecord(wtx, mempool.TxStatusPending)
+	}
+	return records
+}
+
 // FlushAppConn executes FlushSync on the mempool's proxyAppConn.
 //
 // NOTE: The caller must obtain a write-lock via Lock() prior to execution.
@@ -192,6 +265,34 @@ func (txmp *TxMempool) NextGossipTx() *clist.CElement {
 	return txmp.gossipIndex.Front()
 }
 
+// waitForNextLaneTx returns a blocking channel that will be closed when the
+// next valid transaction is inserted into any lane. It is thread-safe.
+func (txmp *TxMempool) waitForNextLaneTx() <-chan struct{} {
+	txmp.gossipMtx.Lock()
+	defer txmp.gossipMtx.Unlock()
+
+	return txmp.gossipCh
+}
+
+// notifyGossip wakes up the routines waiting for the next transaction to gossip
+// in any lane.
+func (txmp *TxMempool) notifyGossip() {
+	txmp.gossipMtx.Lock()
+	defer txmp.gossipMtx.Unlock()
+
+	close(txmp.gossipCh)
+	txmp.gossipCh = make(chan struct{})
+}
+
+// getLane returns the lane with the given name, or the default lane if there
+// is no such lane.
+func (txmp *TxMempool) getLane(name string) *txLane {
+	if lane, ok := txmp.laneIndex[name]; ok {
+		return lane
+	}
+	return txmp.laneIndex[DefaultLane]
+}
+
 // EnableTxsAvailable enables the mempool to trigger events when transactions
 // are available on a block by block basis.
 func (txmp *TxMempool) EnableTxsAvailable() {
@@ -233,13 +334,170 @@ func (txmp *TxMempool) CheckTx(
 	cb func(*abci.Response),
 	txInfo mempool.TxInfo,
 ) error {
+	return txmp.checkTx(ctx, tx, cb, txInfo, nil)
+}
 
+// ReplayJournal replays the given journal entries through CheckTx, as
+// transactions received before a restart. Entries which exceeded the mempool's
+// TTLs are skipped. Replayed transactions keep the height and time at which they
+// were first accepted, which the TTLs apply to. Once replayed, the journal is
+// compacted to the transactions accepted back into the mempool.
+func (txmp *TxMempool) ReplayJournal(entries []mempool.JournalEntry) error {
+	var (
+		now      = time.Now()
+		replayed int
+	)
+	for i := range entries {
+		entry := entries[i]
+		if entry.Expired(txmp.config, txmp.height, now) {
+			continue
+		}
+
+		err := txmp.checkTx(context.Background(), entry.Tx, nil, mempool.TxInfo{SenderID: mempool.UnknownPeerID}, &entry)
+		if err != nil {
+			txmp.logger.Debug("failed to replay journaled transaction", "tx", fmt.Sprintf("%X", entry.Tx.Hash()), "err", err)
+			continue
+		}
+		replayed++
+	}
+
+	if err := txmp.proxyAppConn.FlushSync(context.Background()); err != nil {
+		return err
+	}
+
+	txmp.logger.Info(
+		"replayed mempool journal",
+		"num_entries", len(entries),
+		"num_replayed", replayed,
+		"num_txs", txmp.Size(),
+	)
+
+	txmp.mtx.Lock()
+	defer txmp.mtx.Unlock()
+
+	return txmp.compactJournal()
+}
+
+// checkTx executes CheckTx for a new transaction, or for a transaction replayed
+// from the journal if entry is set.
+func (txmp *TxMempool) checkTx(
+	ctx context.Context,
+	tx types.Tx,
+	cb func(*abci.Response),
+	txInfo mempool.TxInfo,
+	entry *mempool.JournalEntry,
+) error {
+
+	txmp.mtx.RLock()
+	defer txmp.mtx.RUnlock()
+
+	if ok, err := txmp.prepareCheckTx(tx, txInfo); !ok {
+		return err
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	reqRes, err := txmp.proxyAppConn.CheckTxAsync(ctx, abci.RequestCheckTx{Tx: tx})
+	if err != nil {
+		txmp.cache.Remove(tx)
+		return err
+	}
+
+	reqRes.SetCallback(func(res *abci.Response) {
+		txmp.checkTxCallback(tx, res, txInfo, entry)
+
+		if cb != nil {
+			cb(res)
+		}
+	})
+
+	return nil
+}
+
+// CheckTxBatch executes CheckTx for the given transactions, received together
+// from the same peer, in a single CheckTxBatch request to the application. It
+// returns, for each transaction, the error CheckTx would return, if any. The
+// callback, if set, is called with the response of each transaction checked.
+func (txmp *TxMempool) CheckTxBatch(
+	ctx context.Context,
+	txs types.Txs,
+	cb func(*abci.Response),
+	txInfo mempool.TxInfo,
+) []error {
 	txmp.mtx.RLock()
 	defer txmp.mtx.RUnlock()
 
+	var (
+		errs    = make([]error, len(txs))
+		batch   = make([]int, 0, len(txs))
+		request = abci.RequestCheckTxBatch{Requests: make([]abci.RequestCheckTx, 0, len(txs))}
+	)
+	for i, tx := range txs {
+		ok, err := txmp.prepareCheckTx(tx, txInfo)
+		if !ok {
+			errs[i] = err
+			continue
+		}
+
+		batch = append(batch, i)
+		request.Requests = append(request.Requests, abci.RequestCheckTx{Tx: tx})
+	}
+	if len(batch) == 0 {
+		return errs
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	reqRes, err := txmp.proxyAppConn.CheckTxBatchAsync(ctx, request)
+	if err != nil {
+		for _, i := range batch {
+			txmp.cache.Remove(txs[i])
+			errs[i] = err
+		}
+		return errs
+	}
+
+	reqRes.SetCallback(func(res *abci.Response) {
+		responses := res.GetCheckTxBatch().GetResponses()
+		if len(responses) != len(batch) {
+			txmp.logger.Error(
+				"invalid CheckTxBatch response",
+				"num_txs", len(batch),
+				"num_responses", len(responses),
+			)
+			for _, i := range batch {
+				txmp.cache.Remove(txs[i])
+			}
+			return
+		}
+
+		for j, i := range batch {
+			txRes := abci.ToResponseCheckTx(responses[j])
+			txmp.checkTxCallback(txs[i], txRes, txInfo, nil)
+
+			if cb != nil {
+				cb(txRes)
+			}
+		}
+	})
+
+	return errs
+}
+
+// prepareCheckTx performs the checks preceding the execution of CheckTx for the
+// given transaction and adds it to the cache. It returns false if CheckTx must
+// not be executed, along with the error to return to the caller, if any.
+//
+// NOTE:
+// - The caller must have a read-lock when executing prepareCheckTx.
+func (txmp *TxMempool) prepareCheckTx(tx types.Tx, txInfo mempool.TxInfo) (bool, error) {
 	txSize := len(tx)
 	if txSize > txmp.config.MaxTxBytes {
-		return pubmempool.ErrTxTooLarge{
+		return false, pubmempool.ErrTxTooLarge{
 			Max:    txmp.config.MaxTxBytes,
 			Actual: txSize,
 		}
@@ -247,62 +505,53 @@ func (txmp *TxMempool) CheckTx(
 
 	if txmp.preCheck != nil {
 		if err := txmp.preCheck(tx); err != nil {
-			return pubmempool.ErrPreCheck{
+			return false, pubmempool.ErrPreCheck{
 				Reason: err,
 			}
 		}
 	}
 
 	if err := txmp.proxyAppConn.Error(); err != nil {
-		return err
+		return false, err
 	}
 
-	txHash := mempool.TxKey(tx)
-
 	// We add the transaction to the mempool's cache and if the transaction already
 	// exists, i.e. false is returned, then we check if we've seen this transaction
 	// from the same sender and error if we have. Otherwise, we return nil.
 	if !txmp.cache.Push(tx) {
-		wtx, ok := txmp.txStore.GetOrSetPeerByTxHash(txHash, txInfo.SenderID)
+		wtx, ok := txmp.txStore.GetOrSetPeerByTxHash(mempool.TxKey(tx), txInfo.SenderID)
 		if wtx != nil && ok {
 			// We already have the transaction stored and the we've already seen this
 			// transaction from txInfo.SenderID.
-			return pubmempool.ErrTxInCache
+			return false, pubmempool.ErrTxInCache
 		}
 
 		txmp.logger.Debug("tx exists already in cache", "tx_hash", tx.Hash())
-		return nil
+		return false, nil
 	}
 
-	if ctx == nil {
-		ctx = context.Background()
-	}
+	return true, nil
+}
 
-	reqRes, err := txmp.proxyAppConn.CheckTxAsync(ctx, abci.RequestCheckTx{Tx: tx})
-	if err != nil {
-		txmp.cache.Remove(tx)
-		return err
+// checkTxCallback handles the response of the application to CheckTx for a new
+// transaction, or for a transaction replayed from the journal if entry is set.
+func (txmp *TxMempool) checkTxCallback(
+	tx types.Tx,
+	res *abci.Response,
+	txInfo mempool.TxInfo,
+	entry *mempool.JournalEntry,
+) {
+	wtx := &WrappedTx{
+		tx:        tx,
+		hash:      mempool.TxKey(tx),
+		timestamp: time.Now().UTC(),
+		height:    txmp.height,
 	}
-
-	reqRes.SetCallback(func(res *abci.Response) {
-		if txmp.recheckCursor != nil {
-			panic("recheck cursor is non-nil in CheckTx callback")
-		}
-
-		wtx := &WrappedTx{
-			tx:        tx,
-			hash:      txHash,
-			timestamp: time.Now().UTC(),
-			height:    txmp.height,
-		}
-		txmp.initTxCallback(wtx, res, txInfo)
-
-		if cb != nil {
-			cb(res)
-		}
-	})
-
-	return nil
+	if entry != nil {
+		wtx.timestamp = entry.Timestamp
+		wtx.height = entry.Height
+	}
+	txmp.initTxCallback(wtx, res, txInfo)
 }
 
 // Flush flushes out the mempool. It acquires a read-lock, fetches all the
@@ -324,70 +573,124 @@ func (txmp *TxMempool) Flush() {
 
 	atomic.SwapInt64(&txmp.sizeBytes, 0)
 	txmp.cache.Reset()
+	txmp.history.Reset()
 }
 
 // ReapMaxBytesMaxGas returns a list of transactions within the provided size
-// and gas constraints. Transaction are retrieved in priority order.
+// and gas constraints. Transaction are retrieved in priority order, except that
+// the transactions of the same sender are retrieved in nonce order.
+//
+// The transactions of each lane are first retrieved within the share of the
+// size and gas constraints reserved to the lane. The size and gas left are then
+// filled with the transactions of any lane.
 //
 // NOTE:
 // - A read-lock is acquired.
+// - The handling of re-CheckTx responses is paused while reaping, so that the
+//   transactions are not removed or reprioritized meanwhile.
 // - Transactions returned are not actually removed from the mempool transaction
 //   store or indexes.
 func (txmp *TxMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
 	txmp.mtx.RLock()
 	defer txmp.mtx.RUnlock()
+	txmp.recheckMtx.Lock()
+	defer txmp.recheckMtx.Unlock()
 
 	var (
 		totalGas  int64
 		totalSize int64
 	)
 
-	// wTxs contains a list of *WrappedTx retrieved from the priority queue that
-	// need to be re-enqueued prior to returning.
-	wTxs := make([]*WrappedTx, 0, txmp.priorityIndex.NumTxs())
-	defer func() {
-		for _, wtx := range wTxs {
-			txmp.priorityIndex.PushTx(wtx)
+	reaper := newTxReaper(txmp.txStore, txmp.priorityIndex)
+	defer reaper.Close()
+
+	wtxs := make([]*WrappedTx, 0, txmp.priorityIndex.NumTxs())
+	sizes := make([]int64, 0, txmp.priorityIndex.NumTxs())
+	for wtx := reaper.Next(); wtx != nil; wtx = reaper.Next() {
+		wtxs = append(wtxs, wtx)
+		sizes = append(sizes, types.ComputeProtoSizeForTxs([]types.Tx{wtx.tx}))
+	}
+
+	reaped := make([]bool, len(wtxs))
+
+	if txmp.reapShares {
+		var (
+			laneGas  = make(map[*txLane]int64)
+			laneSize = make(map[*txLane]int64)
+
+			// senders whose next transaction was not reaped, so that their
+			// transactions with a higher nonce are not reaped either
+			skipped = make(map[string]struct{})
+		)
+
+		for i, wtx := range wtxs {
+			if _, ok := skipped[wtx.sender]; ok {
+				continue
+			}
+
+			lane := wtx.lane
+			if fitsLimit(laneSize[lane], sizes[i], lane.reapShareOf(maxBytes)) &&
+				fitsLimit(laneGas[lane], wtx.gasWanted, lane.reapShareOf(maxGas)) &&
+				fitsLimit(totalSize, sizes[i], maxBytes) &&
+				fitsLimit(totalGas, wtx.gasWanted, maxGas) {
+				reaped[i] = true
+				laneSize[lane] += sizes[i]
+				laneGas[lane] += wtx.gasWanted
+				totalSize += sizes[i]
+				totalGas += wtx.gasWanted
+			} else if len(wtx.sender) > 0 {
+				skipped[wtx.sender] = struct{}{}
+			}
 		}
-	}()
+	}
 
-	txs := make([]types.Tx, 0, txmp.priorityIndex.NumTxs())
-	for txmp.priorityIndex.NumTxs() > 0 {
-		wtx := txmp.priorityIndex.PopTx()
-		txs = append(txs, wtx.tx)
-		wTxs = append(wTxs, wtx)
-		size := types.ComputeProtoSizeForTxs([]types.Tx{wtx.tx})
+	for i, wtx := range wtxs {
+		if reaped[i] {
+			continue
+		}
 
 		// Ensure we have capacity for the transaction with respect to the
-		// transaction size.
-		if maxBytes > -1 && totalSize+size > maxBytes {
-			return txs[:len(txs)-1]
+		// transaction size and total gas.
+		if !fitsLimit(totalSize, sizes[i], maxBytes) || !fitsLimit(totalGas, wtx.gasWanted, maxGas) {
+			break
 		}
 
-		totalSize += size
+		reaped[i] = true
+		totalSize += sizes[i]
+		totalGas += wtx.gasWanted
+	}
 
-		// ensure we have capacity for the transaction with respect to total gas
-		gas := totalGas + wtx.gasWanted
-		if maxGas > -1 && gas > maxGas {
-			return txs[:len(txs)-1]
+	txs := make([]types.Tx, 0, len(wtxs))
+	for i, wtx := range wtxs {
+		if reaped[i] {
+			txs = append(txs, wtx.tx)
 		}
-
-		totalGas = gas
 	}
 
 	return txs
 }
 
+// fitsLimit returns true if adding the given amount to the used amount does not
+// exceed the given limit. A negative limit means no limit.
+func fitsLimit(used, amount, limit int64) bool {
+	return limit < 0 || used+amount <= limit
+}
+
 // ReapMaxTxs returns a list of transactions within the provided number of
-// transactions bound. Transaction are retrieved in priority order.
+// transactions bound. Transaction are retrieved in priority order, except that
+// the transactions of the same sender are retrieved in nonce order.
 //
 // NOTE:
 // - A read-lock is acquired.
+// - The handling of re-CheckTx responses is paused while reaping, so that the
+//   transactions are not removed or reprioritized meanwhile.
 // - Transactions returned are not actually removed from the mempool transaction
 //   store or indexes.
 func (txmp *TxMempool) ReapMaxTxs(max int) types.Txs {
 	txmp.mtx.RLock()
 	defer txmp.mtx.RUnlock()
+	txmp.recheckMtx.Lock()
+	defer txmp.recheckMtx.Unlock()
 
 	numTxs := txmp.priorityIndex.NumTxs()
 	if max < 0 {
@@ -396,20 +699,16 @@ func (txmp *TxMempool) ReapMaxTxs(max int) types.Txs {
 
 	cap := tmmath.MinInt(numTxs, max)
 
-	// wTxs contains a list of *WrappedTx retrieved from the priority queue that
-	// need to be re-enqueued prior to returning.
-	wTxs := make([]*WrappedTx, 0, cap)
-	defer func() {
-		for _, wtx := range wTxs {
-			txmp.priorityIndex.PushTx(wtx)
-		}
-	}()
+	reaper := newTxReaper(txmp.txStore, txmp.priorityIndex)
+	defer reaper.Close()
 
 	txs := make([]types.Tx, 0, cap)
-	for txmp.priorityIndex.NumTxs() > 0 && len(txs) < max {
-		wtx := txmp.priorityIndex.PopTx()
+	for len(txs) < max {
+		wtx := reaper.Next()
+		if wtx == nil {
+			break
+		}
 		txs = append(txs, wtx.tx)
-		wTxs = append(wTxs, wtx)
 	}
 
 	return txs
@@ -419,8 +718,8 @@ func (txmp *TxMempool) ReapMaxTxs(max int) types.Txs {
 // block producer, and removes them from the cache (if applicable) and removes
 // the transactions from the main transaction store and associated indexes.
 // Finally, if there are trainsactions remaining in the mempool, we initiate a
-// re-CheckTx for them (if applicable), otherwise, we notify the caller more
-// transactions are available.
+// re-CheckTx for them (if applicable), executed in the background until the
+// next Update, otherwise, we notify the caller more transactions are available.
 //
 // NOTE:
 // - The caller must explicitly acquire a write-lock via Lock().
@@ -432,6 +731,11 @@ func (txmp *TxMempool) Update(
 	newPostFn mempool.PostCheckFunc,
 ) error {
 
+	// cancel the re-CheckTx of the transactions after the previous block, if
+	// still in progress, as they are rechecked against the state after this
+	// block instead
+	txmp.cancelRecheck()
+
 	txmp.height = blockHeight
 	txmp.notifiedTxsAvailable = false
 
@@ -453,23 +757,19 @@ func (txmp *TxMempool) Update(
 
 		// remove the committed transaction from the transaction store and indexes
 		if wtx := txmp.txStore.GetTxByHash(mempool.TxKey(tx)); wtx != nil {
-			txmp.removeTx(wtx, false)
+			txmp.removeTxWithStatus(wtx, false, mempool.TxStatusCommitted)
 		}
 	}
 
 	txmp.purgeExpiredTxs(blockHeight)
+	txmp.syncJournal()
 
 	// If there any uncommitted transactions left in the mempool, we either
 	// initiate re-CheckTx per remaining transaction or notify that remaining
 	// transactions are left.
 	if txmp.Size() > 0 {
 		if txmp.config.Recheck {
-			txmp.logger.Debug(
-				"executing re-CheckTx for all remaining transactions",
-				"num_txs", txmp.Size(),
-				"height", blockHeight,
-			)
-			txmp.updateReCheckTxs()
+			txmp.updateReCheckTxs(deliverTxResponses)
 		} else {
 			txmp.notifyTxsAvailable()
 		}
@@ -497,6 +797,10 @@ func (txmp *TxMempool) Update(
 // place of the new incoming transaction. If no such transaction exists, the
 // new incoming transaction is rejected.
 //
+// If a transaction of the same sender and nonce already exists, the new incoming
+// transaction is rejected, unless replace-by-fee is enabled and its priority is
+// sufficiently higher, in which case it replaces the existing transaction.
+//
 // If the new incoming transaction fails CheckTx or postCheck fails, we reject
 // the new incoming transaction.
 //
@@ -536,25 +840,73 @@ func (txmp *TxMempool) initTxCallback(wtx *WrappedTx, res *abci.Response, txInfo
 	}
 
 	sender := checkTxRes.CheckTx.Sender
+	nonce := checkTxRes.CheckTx.Nonce
 	priority := checkTxRes.CheckTx.Priority
+	lane := txmp.getLane(checkTxRes.CheckTx.Lane)
 
 	if len(sender) > 0 {
-		if wtx := txmp.txStore.GetTxBySender(sender); wtx != nil {
+		if existing := txmp.txStore.GetTxBySender(sender, nonce); existing != nil {
+			if !txmp.canReplaceTx(existing, wtx, priority, lane) {
+				txmp.logger.Error(
+					"rejected incoming good transaction; tx already exists for sender and nonce",
+					"tx", fmt.Sprintf("%X", existing.tx.Hash()),
+					"sender", sender,
+					"nonce", nonce,
+				)
+				txmp.metrics.RejectedTxs.Add(1)
+				return
+			}
+
+			// Replace the existing transaction, removing it from the cache so that
+			// it can be resubmitted. The transactions of the sender with a higher
+			// nonce are kept.
+			txmp.removeTxWithStatus(existing, true, mempool.TxStatusEvicted)
+			txmp.logger.Debug(
+				"replaced existing good transaction",
+				"old_tx", fmt.Sprintf("%X", existing.tx.Hash()),
+				"old_priority", existing.priority,
+				"new_tx", fmt.Sprintf("%X", wtx.tx.Hash()),
+				"new_priority", priority,
+			)
+			txmp.metrics.ReplacedTxs.Add(1)
+		}
+	}
+
+	// Find the transactions to evict to make room for the new transaction in
+	// its lane and then in the mempool before evicting any of them, so that
+	// nothing is evicted unless the new transaction fits within both limits.
+	var laneEvictTxs []*WrappedTx
+	if lane.isFull(wtx.Size()) {
+		laneEvictTxs = txmp.priorityIndex.GetEvictableLaneTxs(
+			lane,
+			priority,
+			int64(wtx.Size()),
+			lane.SizeBytes(),
+			lane.maxBytes(),
+		)
+		if len(laneEvictTxs) == 0 {
+			// No room for the new incoming transaction in its lane so we just
+			// remove it from the cache.
+			txmp.cache.Remove(wtx.tx)
 			txmp.logger.Error(
-				"rejected incoming good transaction; tx already exists for sender",
+				"rejected incoming good transaction; lane full",
 				"tx", fmt.Sprintf("%X", wtx.tx.Hash()),
-				"sender", sender,
+				"lane", lane.name,
+				"num_txs", lane.NumTxs(),
+				"txs_bytes", lane.SizeBytes(),
 			)
 			txmp.metrics.RejectedTxs.Add(1)
 			return
 		}
 	}
 
-	if err := txmp.canAddTx(wtx); err != nil {
-		evictTxs := txmp.priorityIndex.GetEvictableTxs(
+	var evictTxs []*WrappedTx
+	if err := txmp.canAddTx(wtx, laneEvictTxs); err != nil {
+		evictTxs = txmp.priorityIndex.GetEvictableTxsExcept(
+			laneEvictTxs,
 			priority,
 			int64(wtx.Size()),
-			txmp.SizeBytes(),
+			txmp.SizeBytes()-txsSize(laneEvictTxs),
 			txmp.config.MaxTxsBytes,
 		)
 		if len(evictTxs) == 0 {
@@ -569,28 +921,43 @@ func (txmp *TxMempool) initTxCallback(wtx *WrappedTx, res *abci.Response, txInfo
 			txmp.metrics.RejectedTxs.Add(1)
 			return
 		}
+	}
 
-		// evict an existing transaction(s)
-		//
-		// NOTE:
-		// - The transaction, toEvict, can be removed while a concurrent
-		//   reCheckTx callback is being executed for the same transaction.
-		for _, toEvict := range evictTxs {
-			txmp.removeTx(toEvict, true)
-			txmp.logger.Debug(
-				"evicted existing good transaction; mempool full",
-				"old_tx", fmt.Sprintf("%X", toEvict.tx.Hash()),
-				"old_priority", toEvict.priority,
-				"new_tx", fmt.Sprintf("%X", wtx.tx.Hash()),
-				"new_priority", wtx.priority,
-			)
-			txmp.metrics.EvictedTxs.Add(1)
-		}
+	// evict the existing transaction(s)
+	//
+	// NOTE:
+	// - The transaction, toEvict, can be removed while a concurrent
+	//   reCheckTx callback is being executed for the same transaction.
+	for _, toEvict := range laneEvictTxs {
+		txmp.removeTxAndDependents(toEvict, true, mempool.TxStatusEvicted)
+		txmp.logger.Debug(
+			"evicted existing good transaction; lane full",
+			"old_tx", fmt.Sprintf("%X", toEvict.tx.Hash()),
+			"old_priority", toEvict.priority,
+			"new_tx", fmt.Sprintf("%X", wtx.tx.Hash()),
+			"new_priority", priority,
+			"lane", lane.name,
+		)
+		txmp.metrics.EvictedTxs.Add(1)
+	}
+	for _, toEvict := range evictTxs {
+		txmp.removeTxAndDependents(toEvict, true, mempool.TxStatusEvicted)
+		txmp.logger.Debug(
+			"evicted existing good transaction; mempool full",
+			"old_tx", fmt.Sprintf("%X", toEvict.tx.Hash()),
+			"old_priority", toEvict.priority,
+			"new_tx", fmt.Sprintf("%X", wtx.tx.Hash()),
+			"new_priority", priority,
+		)
+		txmp.metrics.EvictedTxs.Add(1)
 	}
 
 	wtx.gasWanted = checkTxRes.CheckTx.GasWanted
 	wtx.priority = priority
 	wtx.sender = sender
+	wtx.nonce = nonce
+	wtx.lane = lane
+	wtx.recheckKeys = checkTxRes.CheckTx.RecheckKeys
 	wtx.peers = map[uint16]struct{}{
 		txInfo.SenderID: {},
 	}
@@ -610,121 +977,340 @@ func (txmp *TxMempool) initTxCallback(wtx *WrappedTx, res *abci.Response, txInfo
 
 }
 
-// defaultTxCallback performs the default CheckTx application callback. This is
-// NOT executed when a transaction is first seen/received. Instead, this callback
-// is executed during re-checking transactions (if enabled). A caller, i.e a
-// block proposer, acquires a mempool write-lock via Lock() and when executing
-// Update(), if the mempool is non-empty and Recheck is enabled, then all
-// remaining transactions will be rechecked via CheckTxAsync. The order in which
-// they are rechecked must be the same order in which this callback is called
-// per transaction.
-func (txmp *TxMempool) defaultTxCallback(req *abci.Request, res *abci.Response) {
-	if txmp.recheckCursor == nil {
+// txRecheck is the re-CheckTx of the transactions remaining in the mempool
+// after a block. It is executed in the background, concurrently with consensus,
+// until all the transactions are rechecked or it is canceled by the next block.
+type txRecheck struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// pending is the number of transactions not rechecked yet
+	pending int
+
+	// done is closed when the re-CheckTx is finished or canceled
+	done chan struct{}
+}
+
+// updateReCheckTxs starts the re-CheckTx of the transactions remaining in the
+// mempool after a block, in the background. The transactions unaffected by the
+// block, according to their recheck keys and the keys updated by the block
+// transactions, are skipped. The other transactions are rechecked in priority
+// order, except that the transactions of the same sender are rechecked in nonce
+// order, so that the transactions of highest priority are rechecked first if
+// the re-CheckTx is canceled by the next block.
+//
+// NOTE:
+// - The caller must have a write-lock when executing updateReCheckTxs.
+func (txmp *TxMempool) updateReCheckTxs(deliverTxResponses []*abci.ResponseDeliverTx) {
+	if txmp.Size() == 0 {
+		panic("attempted to update re-CheckTx txs when mempool is empty")
+	}
+
+	updatedKeys := make(map[string]struct{})
+	for _, res := range deliverTxResponses {
+		for _, key := range res.UpdatedKeys {
+			updatedKeys[string(key)] = struct{}{}
+		}
+	}
+
+	var (
+		wtxs = txmp.txStore.GetAllTxs()
+		txs  = make([]*WrappedTx, 0, len(wtxs))
+	)
+	for _, wtx := range wtxs {
+		if !wtx.unaffectedBy(updatedKeys) {
+			txs = append(txs, wtx)
+		}
+	}
+	sortRecheckTxs(txs)
+
+	txmp.logger.Debug(
+		"executing re-CheckTx for remaining transactions",
+		"num_txs", len(txs),
+		"num_skipped", len(wtxs)-len(txs),
+		"height", txmp.height,
+	)
+	txmp.metrics.RecheckSkipped.Add(float64(len(wtxs) - len(txs)))
+
+	if len(txs) == 0 {
+		txmp.notifyTxsAvailable()
 		return
 	}
 
-	txmp.metrics.RecheckTimes.Add(1)
+	ctx, cancel := context.WithCancel(context.Background())
+	recheck := &txRecheck{
+		ctx:     ctx,
+		cancel:  cancel,
+		pending: len(txs),
+		done:    make(chan struct{}),
+	}
 
-	checkTxRes, ok := res.Value.(*abci.Response_CheckTx)
-	if ok {
-		tx := req.GetCheckTx().Tx
-		wtx := txmp.recheckCursor.Value.(*WrappedTx)
-		if !bytes.Equal(tx, wtx.tx) {
-			panic(fmt.Sprintf("re-CheckTx transaction mismatch; got: %X, expected: %X", wtx.tx.Hash(), mempool.TxKey(tx)))
-		}
-
-		// Only evaluate transactions that have not been removed. This can happen
-		// if an existing transaction is evicted during CheckTx and while this
-		// callback is being executed for the same evicted transaction.
-		if !txmp.txStore.IsTxRemoved(wtx.hash) {
-			var err error
-			if txmp.postCheck != nil {
-				err = txmp.postCheck(tx, checkTxRes.CheckTx)
-			}
+	txmp.recheckMtx.Lock()
+	txmp.recheck = recheck
+	txmp.recheckMtx.Unlock()
 
-			if checkTxRes.CheckTx.Code == abci.CodeTypeOK && err == nil {
-				wtx.priority = checkTxRes.CheckTx.Priority
-			} else {
-				txmp.logger.Debug(
-					"existing transaction no longer valid; failed re-CheckTx callback",
-					"priority", wtx.priority,
-					"tx", fmt.Sprintf("%X", mempool.TxHashFromBytes(wtx.tx)),
-					"err", err,
-					"code", checkTxRes.CheckTx.Code,
-				)
+	go txmp.recheckTxs(recheck, txs)
+}
 
-				if wtx.gossipEl != txmp.recheckCursor {
-					panic("corrupted reCheckTx cursor")
-				}
+// sortRecheckTxs sorts the given transactions in priority order, except that
+// the transactions of the same sender are sorted in nonce order together, at
+// the position of the transaction of the sender with the highest priority.
+func sortRecheckTxs(txs []*WrappedTx) {
+	sort.Slice(txs, func(i, j int) bool {
+		if txs[i].priority == txs[j].priority {
+			return txs[i].timestamp.Before(txs[j].timestamp)
+		}
+		return txs[i].priority > txs[j].priority
+	})
 
-				txmp.removeTx(wtx, !txmp.config.KeepInvalidTxsInCache)
-			}
+	senderTxs := make(map[string][]*WrappedTx)
+	for _, wtx := range txs {
+		if len(wtx.sender) > 0 {
+			senderTxs[wtx.sender] = append(senderTxs[wtx.sender], wtx)
 		}
+	}
 
-		// move reCheckTx cursor to next element
-		if txmp.recheckCursor == txmp.recheckEnd {
-			txmp.recheckCursor = nil
-		} else {
-			txmp.recheckCursor = txmp.recheckCursor.Next()
+	sorted := make([]*WrappedTx, 0, len(txs))
+	for _, wtx := range txs {
+		if len(wtx.sender) == 0 {
+			sorted = append(sorted, wtx)
+			continue
 		}
 
-		if txmp.recheckCursor == nil {
-			txmp.logger.Debug("finished rechecking transactions")
+		queue, ok := senderTxs[wtx.sender]
+		if !ok {
+			// already added with the transaction of the sender of highest priority
+			continue
+		}
+		sort.Slice(queue, func(i, j int) bool {
+			return queue[i].nonce < queue[j].nonce
+		})
+		sorted = append(sorted, queue...)
+		delete(senderTxs, wtx.sender)
+	}
 
-			if txmp.Size() > 0 {
-				txmp.notifyTxsAvailable()
-			}
+	copy(txs, sorted)
+}
+
+// recheckTxs executes re-CheckTx for the given transactions, in order, with
+// CheckTxAsync or, if CheckTxBatchSize is set, CheckTxBatchAsync for each batch
+// of transactions. It stops sending transactions to the application once the
+// re-CheckTx is canceled.
+func (txmp *TxMempool) recheckTxs(recheck *txRecheck, txs []*WrappedTx) {
+	batchSize := 1
+	if txmp.config.CheckTxBatchSize > 0 {
+		batchSize = txmp.config.CheckTxBatchSize
+	}
+
+	for start := 0; start < len(txs); start += batchSize {
+		end := start + batchSize
+		if end > len(txs) {
+			end = len(txs)
+		}
+
+		if !txmp.recheckTxBatch(recheck, txs[start:end]) {
+			return
 		}
+	}
 
-		txmp.metrics.Size.Set(float64(txmp.Size()))
+	if _, err := txmp.proxyAppConn.FlushAsync(recheck.ctx); err != nil && recheck.ctx.Err() == nil {
+		txmp.logger.Error("failed to flush transactions during rechecking", "err", err)
 	}
 }
 
-// updateReCheckTxs updates the recheck cursors by using the gossipIndex. For
-// each transaction, it executes CheckTxAsync. The global callback defined on
-// the proxyAppConn will be executed for each transaction after CheckTx is
-// executed.
+// recheckTxBatch sends the given batch of transactions to the application to
+// be rechecked. It returns false if the re-CheckTx is canceled.
 //
 // NOTE:
-// - The caller must have a write-lock when executing updateReCheckTxs.
-func (txmp *TxMempool) updateReCheckTxs() {
-	if txmp.Size() == 0 {
-		panic("attempted to update re-CheckTx txs when mempool is empty")
+// - A read-lock is acquired, so that the transactions are not rechecked while
+//   the application commits a block.
+func (txmp *TxMempool) recheckTxBatch(recheck *txRecheck, batch []*WrappedTx) bool {
+	txmp.mtx.RLock()
+	defer txmp.mtx.RUnlock()
+
+	if recheck.ctx.Err() != nil {
+		return false
 	}
 
-	txmp.recheckCursor = txmp.gossipIndex.Front()
-	txmp.recheckEnd = txmp.gossipIndex.Back()
-	ctx := context.Background()
+	var (
+		reqRes *abcicli.ReqRes
+		err    error
+	)
+	if txmp.config.CheckTxBatchSize > 0 {
+		requests := make([]abci.RequestCheckTx, len(batch))
+		for i, wtx := range batch {
+			requests[i] = abci.RequestCheckTx{Tx: wtx.tx, Type: abci.CheckTxType_Recheck}
+		}
+		reqRes, err = txmp.proxyAppConn.CheckTxBatchAsync(recheck.ctx, abci.RequestCheckTxBatch{Requests: requests})
+	} else {
+		reqRes, err = txmp.proxyAppConn.CheckTxAsync(
+			recheck.ctx,
+			abci.RequestCheckTx{Tx: batch[0].tx, Type: abci.CheckTxType_Recheck},
+		)
+	}
+	if err != nil {
+		if recheck.ctx.Err() != nil {
+			return false
+		}
 
-	for e := txmp.gossipIndex.Front(); e != nil; e = e.Next() {
-		wtx := e.Value.(*WrappedTx)
+		// no need in retrying since the txs will be rechecked after the next block
+		txmp.logger.Error("failed to execute CheckTx during rechecking", "err", err)
 
-		// Only execute CheckTx if the transaction is not marked as removed which
-		// could happen if the transaction was evicted.
-		if !txmp.txStore.IsTxRemoved(wtx.hash) {
-			_, err := txmp.proxyAppConn.CheckTxAsync(ctx, abci.RequestCheckTx{
-				Tx:   wtx.tx,
-				Type: abci.CheckTxType_Recheck,
-			})
-			if err != nil {
-				// no need in retrying since the tx will be rechecked after the next block
-				txmp.logger.Error("failed to execute CheckTx during rechecking", "err", err)
-			}
+		txmp.recheckMtx.Lock()
+		txmp.recheckTxsDone(recheck, len(batch))
+		txmp.recheckMtx.Unlock()
+		return true
+	}
+
+	reqRes.SetCallback(func(res *abci.Response) {
+		txmp.recheckTxsCallback(recheck, batch, res)
+	})
+	return true
+}
+
+// recheckTxsCallback handles the response of the application to re-CheckTx for
+// the given batch of transactions. The response is ignored if the re-CheckTx
+// was canceled.
+func (txmp *TxMempool) recheckTxsCallback(recheck *txRecheck, batch []*WrappedTx, res *abci.Response) {
+	txmp.recheckMtx.Lock()
+	defer txmp.recheckMtx.Unlock()
+
+	if recheck.ctx.Err() != nil {
+		return
+	}
+
+	switch r := res.Value.(type) {
+	case *abci.Response_CheckTx:
+		txmp.recheckTxCallback(batch[0], r.CheckTx)
+
+	case *abci.Response_CheckTxBatch:
+		if len(r.CheckTxBatch.Responses) != len(batch) {
+			txmp.logger.Error(
+				"invalid CheckTxBatch response during rechecking",
+				"num_txs", len(batch),
+				"num_responses", len(r.CheckTxBatch.Responses),
+			)
+			break
+		}
+
+		for i, wtx := range batch {
+			txmp.recheckTxCallback(wtx, &r.CheckTxBatch.Responses[i])
 		}
 	}
 
-	if _, err := txmp.proxyAppConn.FlushAsync(ctx); err != nil {
-		txmp.logger.Error("failed to flush transactions during rechecking", "err", err)
+	txmp.recheckTxsDone(recheck, len(batch))
+}
+
+// recheckTxCallback handles the response of the application to re-CheckTx for
+// the given transaction.
+func (txmp *TxMempool) recheckTxCallback(wtx *WrappedTx, checkTxRes *abci.ResponseCheckTx) {
+	txmp.metrics.RecheckTimes.Add(1)
+
+	// Only evaluate transactions that have not been removed. This can happen
+	// if an existing transaction is evicted during CheckTx and while this
+	// callback is being executed for the same evicted transaction.
+	if txmp.txStore.IsTxRemoved(wtx) {
+		return
+	}
+
+	var err error
+	if txmp.postCheck != nil {
+		err = txmp.postCheck(wtx.tx, checkTxRes)
 	}
+
+	if checkTxRes.Code == abci.CodeTypeOK && err == nil {
+		txmp.priorityIndex.UpdatePriority(wtx, checkTxRes.Priority)
+		wtx.recheckKeys = checkTxRes.RecheckKeys
+		return
+	}
+
+	txmp.logger.Debug(
+		"existing transaction no longer valid; failed re-CheckTx callback",
+		"priority", wtx.priority,
+		"tx", fmt.Sprintf("%X", mempool.TxHashFromBytes(wtx.tx)),
+		"err", err,
+		"code", checkTxRes.Code,
+	)
+
+	txmp.removeTxAndDependents(wtx, !txmp.config.KeepInvalidTxsInCache, mempool.TxStatusRecheckFailed)
+	txmp.metrics.Size.Set(float64(txmp.Size()))
+}
+
+// recheckTxsDone records that the given number of transactions were rechecked
+// and finishes the re-CheckTx once all the transactions are rechecked.
+//
+// NOTE:
+// - The caller must hold recheckMtx.
+func (txmp *TxMempool) recheckTxsDone(recheck *txRecheck, n int) {
+	recheck.pending -= n
+	if recheck.pending > 0 {
+		return
+	}
+
+	txmp.logger.Debug("finished rechecking transactions")
+	recheck.cancel()
+	close(recheck.done)
+
+	if txmp.Size() > 0 {
+		txmp.notifyTxsAvailable()
+	}
+}
+
+// cancelRecheck cancels the re-CheckTx in progress, if any. The responses of
+// the application to the transactions already sent are ignored.
+func (txmp *TxMempool) cancelRecheck() {
+	txmp.recheckMtx.Lock()
+	defer txmp.recheckMtx.Unlock()
+
+	if txmp.recheck == nil {
+		return
+	}
+
+	if txmp.recheck.ctx.Err() == nil {
+		txmp.logger.Debug("canceled rechecking transactions", "num_pending", txmp.recheck.pending)
+		txmp.metrics.RecheckCanceled.Add(1)
+		txmp.recheck.cancel()
+		close(txmp.recheck.done)
+	}
+	txmp.recheck = nil
+}
+
+// canReplaceTx returns true if the incoming transaction, with the given
+// priority and lane, can replace the existing transaction of the same sender
+// and nonce. Replace-by-fee must be enabled, the incoming transaction must have
+// a priority higher by at least the configured percentage and the mempool and
+// the lane must have room for it once the existing transaction is removed.
+func (txmp *TxMempool) canReplaceTx(existing, wtx *WrappedTx, priority int64, lane *txLane) bool {
+	if !txmp.config.ReplaceByFee || priority <= existing.priority {
+		return false
+	}
+
+	// NOTE: The bump is computed on the magnitude of the existing priority so
+	// that it is an increase for negative priorities too.
+	increase := (float64(priority) - float64(existing.priority)) * 100
+	if increase < math.Abs(float64(existing.priority))*float64(txmp.config.ReplacementPriorityBump) {
+		return false
+	}
+
+	if existing.lane != lane && lane.isFull(wtx.Size()) {
+		return false
+	}
+	if existing.lane == lane && lane.maxTxsBytes > 0 &&
+		lane.SizeBytes()-int64(existing.Size())+int64(wtx.Size()) > lane.maxTxsBytes {
+		return false
+	}
+
+	return txmp.SizeBytes()-int64(existing.Size())+int64(wtx.Size()) <= txmp.config.MaxTxsBytes
 }
 
 // canAddTx returns an error if we cannot insert the provided *WrappedTx into
-// the mempool due to mempool configured constraints. Otherwise, nil is returned
-// and the transaction can be inserted into the mempool.
-func (txmp *TxMempool) canAddTx(wtx *WrappedTx) error {
+// the mempool, once the given transactions are evicted, due to mempool
+// configured constraints. Otherwise, nil is returned and the transaction can be
+// inserted into the mempool.
+func (txmp *TxMempool) canAddTx(wtx *WrappedTx, evictTxs []*WrappedTx) error {
 	var (
-		numTxs    = txmp.Size()
-		sizeBytes = txmp.SizeBytes()
+		numTxs    = txmp.Size() - len(evictTxs)
+		sizeBytes = txmp.SizeBytes() - txsSize(evictTxs)
 	)
 
 	if numTxs >= txmp.config.Size || int64(wtx.Size())+sizeBytes > txmp.config.MaxTxsBytes {
@@ -739,7 +1325,20 @@ func (txmp *TxMempool) canAddTx(wtx *WrappedTx) error {
 	return nil
 }
 
+// txsSize returns the total size of the given transactions.
+func txsSize(wtxs []*WrappedTx) int64 {
+	var size int64
+	for _, wtx := range wtxs {
+		size += int64(wtx.Size())
+	}
+	return size
+}
+
 func (txmp *TxMempool) insertTx(wtx *WrappedTx) {
+	if wtx.lane == nil {
+		wtx.lane = txmp.getLane(DefaultLane)
+	}
+
 	txmp.txStore.SetTx(wtx)
 	txmp.priorityIndex.PushTx(wtx)
 	txmp.heightIndex.Insert(wtx)
@@ -750,12 +1349,24 @@ func (txmp *TxMempool) insertTx(wtx *WrappedTx) {
 	// transaction is removed.
 	gossipEl := txmp.gossipIndex.PushBack(wtx)
 	wtx.gossipEl = gossipEl
+	wtx.lane.pushTx(wtx)
+	txmp.notifyGossip()
 
 	atomic.AddInt64(&txmp.sizeBytes, int64(wtx.Size()))
+
+	if txmp.journal != nil {
+		if err := txmp.journal.Add(wtx.tx, wtx.height, wtx.timestamp); err != nil {
+			txmp.logger.Error("failed to journal transaction", "err", err)
+		}
+	}
+
+	if err := txmp.eventBus.PublishEventMempoolTxAdded(newEventDataMempoolTx(wtx)); err != nil {
+		txmp.logger.Error("failed to publish mempool tx event", "status", mempool.TxStatusPending, "err", err)
+	}
 }
 
 func (txmp *TxMempool) removeTx(wtx *WrappedTx, removeFromCache bool) {
-	if txmp.txStore.IsTxRemoved(wtx.hash) {
+	if txmp.txStore.IsTxRemoved(wtx) {
 		return
 	}
 
@@ -768,12 +1379,128 @@ func (txmp *TxMempool) removeTx(wtx *WrappedTx, removeFromCache bool) {
 	// element so it can be garbage collected.
 	txmp.gossipIndex.Remove(wtx.gossipEl)
 	wtx.gossipEl.DetachPrev()
+	wtx.lane.removeTx(wtx)
 
 	atomic.AddInt64(&txmp.sizeBytes, int64(-wtx.Size()))
 
 	if removeFromCache {
 		txmp.cache.Remove(wtx.tx)
 	}
+
+	if txmp.journal != nil {
+		if err := txmp.journal.Remove(wtx.tx); err != nil {
+			txmp.logger.Error("failed to journal transaction removal", "err", err)
+		}
+	}
+}
+
+// removeTxWithStatus removes a transaction, unless it was already removed,
+// records it with the given status and publishes the matching event, if any.
+func (txmp *TxMempool) removeTxWithStatus(wtx *WrappedTx, removeFromCache bool, status mempool.TxStatus) {
+	if txmp.txStore.IsTxRemoved(wtx) {
+		return
+	}
+
+	txmp.removeTx(wtx, removeFromCache)
+	txmp.history.Add(newTxRecord(wtx, status))
+
+	var (
+		data = newEventDataMempoolTx(wtx)
+		err  error
+	)
+	switch status {
+	case mempool.TxStatusEvicted:
+		err = txmp.eventBus.PublishEventMempoolTxEvicted(data)
+	case mempool.TxStatusExpired:
+		err = txmp.eventBus.PublishEventMempoolTxExpired(data)
+	case mempool.TxStatusRecheckFailed:
+		err = txmp.eventBus.PublishEventMempoolTxRecheckFailed(data)
+	}
+	if err != nil {
+		txmp.logger.Error("failed to publish mempool tx event", "status", status, "err", err)
+	}
+}
+
+// syncJournal syncs the journal, if any, to disk and compacts it once it holds
+// many more records than there are transactions in the mempool.
+//
+// NOTE: The caller must hold a write-lock on the mempool.
+func (txmp *TxMempool) syncJournal() {
+	if txmp.journal == nil {
+		return
+	}
+
+	if txmp.journal.ShouldCompact(txmp.Size()) {
+		if err := txmp.compactJournal(); err != nil {
+			txmp.logger.Error("failed to compact mempool journal", "err", err)
+		}
+		return
+	}
+
+	if err := txmp.journal.Sync(); err != nil {
+		txmp.logger.Error("failed to sync mempool journal", "err", err)
+	}
+}
+
+// compactJournal rewrites the journal, if any, with the transactions of the
+// mempool, in the order they were received except that the transactions of the
+// same sender are in nonce order, so that they replay in that order.
+//
+// NOTE: The caller must hold a write-lock on the mempool.
+func (txmp *TxMempool) compactJournal() error {
+	if txmp.journal == nil {
+		return nil
+	}
+
+	wtxs := txmp.txStore.GetAllTxs()
+	sort.Slice(wtxs, func(i, j int) bool {
+		return wtxs[i].timestamp.Before(wtxs[j].timestamp)
+	})
+
+	// reorder the transactions of each sender by nonce, within the positions they
+	// hold
+	senderIdxs := make(map[string][]int)
+	for i, wtx := range wtxs {
+		if len(wtx.sender) > 0 {
+			senderIdxs[wtx.sender] = append(senderIdxs[wtx.sender], i)
+		}
+	}
+	for sender, idxs := range senderIdxs {
+		for i, wtx := range txmp.txStore.GetTxsBySender(sender) {
+			if i < len(idxs) {
+				wtxs[idxs[i]] = wtx
+			}
+		}
+	}
+
+	entries := make([]mempool.JournalEntry, len(wtxs))
+	for i, wtx := range wtxs {
+		entries[i] = mempool.JournalEntry{
+			Tx:        wtx.tx,
+			Height:    wtx.height,
+			Timestamp: wtx.timestamp,
+		}
+	}
+
+	return txmp.journal.Compact(entries)
+}
+
+// removeTxAndDependents removes a transaction which is no longer valid along
+// with the transactions of the same sender with a higher nonce, as they can no
+// longer be executed. They are all recorded with the given status.
+func (txmp *TxMempool) removeTxAndDependents(wtx *WrappedTx, removeFromCache bool, status mempool.TxStatus) {
+	dependents := txmp.txStore.GetDependentTxs(wtx)
+	txmp.removeTxWithStatus(wtx, removeFromCache, status)
+
+	for _, dependent := range dependents {
+		txmp.removeTxWithStatus(dependent, removeFromCache, status)
+		txmp.logger.Debug(
+			"removed dependent transaction",
+			"tx", fmt.Sprintf("%X", dependent.tx.Hash()),
+			"sender", dependent.sender,
+			"nonce", dependent.nonce,
+		)
+	}
 }
 
 // purgeExpiredTxs removes all transactions that have exceeded their respective
@@ -822,7 +1549,7 @@ func (txmp *TxMempool) purgeExpiredTxs(blockHeight int64) {
 	}
 
 	for _, wtx := range expiredTxs {
-		txmp.removeTx(wtx, false)
+		txmp.removeTxAndDependents(wtx, false, mempool.TxStatusExpired)
 	}
 }
 
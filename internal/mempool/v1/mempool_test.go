@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"math/rand"
 	"os"
+	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
@@ -15,18 +16,22 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+	abcicli "github.com/tendermint/tendermint/abci/client"
 	"github.com/tendermint/tendermint/abci/example/code"
 	"github.com/tendermint/tendermint/abci/example/kvstore"
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/internal/mempool"
 	"github.com/tendermint/tendermint/libs/log"
+	tmquery "github.com/tendermint/tendermint/libs/pubsub/query"
+	pubmempool "github.com/tendermint/tendermint/pkg/mempool"
 	"github.com/tendermint/tendermint/proxy"
 	"github.com/tendermint/tendermint/types"
 )
 
 // application extends the KV store application by overriding CheckTx to provide
-// transaction priority based on the value in the key/value pair.
+// transaction priority based on the value in the key/value pair, an optional
+// nonce following it and an optional lane prefixing the key (lane:key).
 type application struct {
 	*kvstore.Application
 }
@@ -40,11 +45,14 @@ func (app *application) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
 	var (
 		priority int64
 		sender   string
+		nonce    uint64
+		lane     string
 	)
 
-	// infer the priority from the raw transaction value (sender=key=value)
+	// infer the priority from the raw transaction value (sender=key=value) and
+	// the nonce, if any (sender=key=value=nonce)
 	parts := bytes.Split(req.Tx, []byte("="))
-	if len(parts) == 3 {
+	if len(parts) == 3 || len(parts) == 4 {
 		v, err := strconv.ParseInt(string(parts[2]), 10, 64)
 		if err != nil {
 			return abci.ResponseCheckTx{
@@ -54,6 +62,21 @@ func (app *application) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
 			}
 		}
 
+		if len(parts) == 4 {
+			nonce, err = strconv.ParseUint(string(parts[3]), 10, 64)
+			if err != nil {
+				return abci.ResponseCheckTx{
+					Priority:  priority,
+					Code:      100,
+					GasWanted: 1,
+				}
+			}
+		}
+
+		if i := bytes.IndexByte(parts[1], ':'); i >= 0 {
+			lane = string(parts[1][:i])
+		}
+
 		priority = v
 		sender = string(parts[0])
 	} else {
@@ -67,19 +90,37 @@ func (app *application) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
 	return abci.ResponseCheckTx{
 		Priority:  priority,
 		Sender:    sender,
+		Nonce:     nonce,
+		Lane:      lane,
 		Code:      code.CodeTypeOK,
 		GasWanted: 1,
 	}
 }
 
+func (app *application) CheckTxBatch(req abci.RequestCheckTxBatch) abci.ResponseCheckTxBatch {
+	responses := make([]abci.ResponseCheckTx, len(req.Requests))
+	for i := range req.Requests {
+		responses[i] = app.CheckTx(req.Requests[i])
+	}
+	return abci.ResponseCheckTxBatch{Responses: responses}
+}
+
 func setup(t testing.TB, cacheSize int, options ...TxMempoolOption) *TxMempool {
 	t.Helper()
 
+	return setupWithConfig(t, func(cfg *config.MempoolConfig) {
+		cfg.CacheSize = cacheSize
+	}, options...)
+}
+
+func setupWithConfig(t testing.TB, configure func(*config.MempoolConfig), options ...TxMempoolOption) *TxMempool {
+	t.Helper()
+
 	app := &application{kvstore.NewApplication()}
 	cc := proxy.NewLocalClientCreator(app)
 
 	cfg := config.ResetTestRoot(strings.ReplaceAll(t.Name(), "/", "|"))
-	cfg.Mempool.CacheSize = cacheSize
+	configure(cfg.Mempool)
 
 	appConnMem, err := cc.NewABCIClient()
 	require.NoError(t, err)
@@ -93,6 +134,24 @@ func setup(t testing.TB, cacheSize int, options ...TxMempoolOption) *TxMempool {
 	return NewTxMempool(log.TestingLogger().With("test", t.Name()), cfg.Mempool, appConnMem, 0, options...)
 }
 
+// waitForRecheck waits for the re-CheckTx in progress, if any, to finish.
+func waitForRecheck(t testing.TB, txmp *TxMempool) {
+	t.Helper()
+
+	txmp.recheckMtx.Lock()
+	recheck := txmp.recheck
+	txmp.recheckMtx.Unlock()
+
+	if recheck == nil {
+		return
+	}
+	select {
+	case <-recheck.done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for re-CheckTx to finish")
+	}
+}
+
 func checkTxs(t *testing.T, txmp *TxMempool, numTxs int, peerID uint16) []testTx {
 	txs := make([]testTx, numTxs)
 	txInfo := mempool.TxInfo{SenderID: peerID}
@@ -372,6 +431,405 @@ func TestTxMempool_CheckTxSameSender(t *testing.T) {
 	require.Equal(t, 1, txmp.Size())
 }
 
+func TestTxMempool_CheckTxSameSenderNonces(t *testing.T) {
+	txmp := setup(t, 100)
+	peerID := uint16(1)
+
+	for nonce := 0; nonce < 3; nonce++ {
+		tx := []byte(fmt.Sprintf("sender-0=key-%d=%d=%d", nonce, 50, nonce))
+		require.NoError(t, txmp.CheckTx(context.Background(), tx, nil, mempool.TxInfo{SenderID: peerID}))
+	}
+	require.Equal(t, 3, txmp.Size())
+
+	// a tx reusing a nonce of the sender is rejected
+	tx := []byte(fmt.Sprintf("sender-0=key-%d=%d=%d", 3, 50, 1))
+	require.NoError(t, txmp.CheckTx(context.Background(), tx, nil, mempool.TxInfo{SenderID: peerID}))
+	require.Equal(t, 3, txmp.Size())
+
+	senderTxs := txmp.txStore.GetTxsBySender("sender-0")
+	require.Len(t, senderTxs, 3)
+	for i, wtx := range senderTxs {
+		require.Equal(t, uint64(i), wtx.nonce)
+	}
+}
+
+func TestTxMempool_ReapNonceOrder(t *testing.T) {
+	txmp := setup(t, 100)
+
+	txs := []types.Tx{
+		[]byte("sender-a=key-1=100=1"),
+		[]byte("sender-b=key-0=50=0"),
+		[]byte("sender-a=key-2=200=2"),
+		[]byte("sender-a=key-0=10=0"),
+		[]byte("sender-c=key-0=20"),
+	}
+	for _, tx := range txs {
+		require.NoError(t, txmp.CheckTx(context.Background(), tx, nil, mempool.TxInfo{}))
+	}
+	require.Equal(t, len(txs), txmp.Size())
+
+	// sender-a's txs are reaped in nonce order once its lowest nonce tx is,
+	// despite their higher priority
+	expected := types.Txs{txs[1], txs[4], txs[3], txs[0], txs[2]}
+	require.Equal(t, expected, txmp.ReapMaxTxs(-1))
+	require.Equal(t, expected, txmp.ReapMaxBytesMaxGas(-1, -1))
+	require.Equal(t, expected[:3], txmp.ReapMaxTxs(3))
+	require.Equal(t, expected[:3], txmp.ReapMaxBytesMaxGas(-1, 3))
+
+	// reaping doesn't remove txs from the priority index
+	require.Equal(t, len(txs), txmp.priorityIndex.NumTxs())
+}
+
+func TestTxMempool_RemoveDependentTxs(t *testing.T) {
+	txmp := setup(t, 100)
+
+	txs := []types.Tx{
+		[]byte("sender-a=key-0=10=0"),
+		[]byte("sender-a=key-1=10=1"),
+		[]byte("sender-a=key-2=10=2"),
+		[]byte("sender-b=key-0=10=0"),
+	}
+	for _, tx := range txs {
+		require.NoError(t, txmp.CheckTx(context.Background(), tx, nil, mempool.TxInfo{}))
+	}
+	require.Equal(t, len(txs), txmp.Size())
+
+	// fail the recheck of sender-a's second tx, which also removes its third
+	postCheck := func(tx types.Tx, _ *abci.ResponseCheckTx) error {
+		if bytes.Equal(tx, txs[1]) {
+			return errors.New("invalid tx")
+		}
+		return nil
+	}
+
+	txmp.Lock()
+	require.NoError(t, txmp.Update(1, nil, nil, nil, postCheck))
+	txmp.Unlock()
+	waitForRecheck(t, txmp)
+
+	require.Equal(t, 2, txmp.Size())
+	require.Equal(t, types.Txs{txs[0], txs[3]}, txmp.ReapMaxTxs(-1))
+	require.Len(t, txmp.txStore.GetTxsBySender("sender-a"), 1)
+}
+
+func TestTxMempool_ReplaceByFee(t *testing.T) {
+	txmp := setup(t, 100)
+	txmp.config.ReplaceByFee = true
+	txmp.config.ReplacementPriorityBump = 10
+
+	checkTx := func(tx types.Tx) {
+		require.NoError(t, txmp.CheckTx(context.Background(), tx, nil, mempool.TxInfo{}))
+	}
+
+	original := types.Tx("sender-0=key-0=100=0")
+	checkTx(original)
+	checkTx(types.Tx("sender-0=key-1=100=1"))
+	require.Equal(t, 2, txmp.Size())
+
+	// a replacement without a sufficient priority bump is rejected
+	checkTx(types.Tx("sender-0=key-2=105=0"))
+	require.Equal(t, 2, txmp.Size())
+	require.Equal(t, original, txmp.txStore.GetTxBySender("sender-0", 0).tx)
+
+	// a replacement with a sufficient priority bump replaces the original tx,
+	// keeping the tx with a higher nonce
+	replacement := types.Tx("sender-0=key-3=110=0")
+	checkTx(replacement)
+	require.Equal(t, 2, txmp.Size())
+	require.Equal(t, replacement, txmp.txStore.GetTxBySender("sender-0", 0).tx)
+	require.Equal(t, types.Txs{replacement, types.Tx("sender-0=key-1=100=1")}, txmp.ReapMaxTxs(-1))
+	require.Equal(t, int64(len(replacement)+len("sender-0=key-1=100=1")), txmp.SizeBytes())
+
+	// the original tx is removed from the gossip index and the cache
+	require.Equal(t, 2, txmp.gossipIndex.Len())
+	for e := txmp.gossipIndex.Front(); e != nil; e = e.Next() {
+		require.NotEqual(t, original, e.Value.(*WrappedTx).tx)
+	}
+	require.True(t, txmp.cache.Push(original))
+}
+
+func TestTxMempool_ReplaceByFeeDisabled(t *testing.T) {
+	txmp := setup(t, 100)
+
+	original := types.Tx("sender-0=key-0=100=0")
+	require.NoError(t, txmp.CheckTx(context.Background(), original, nil, mempool.TxInfo{}))
+	require.NoError(t, txmp.CheckTx(context.Background(), types.Tx("sender-0=key-1=1000=0"), nil, mempool.TxInfo{}))
+	require.Equal(t, 1, txmp.Size())
+	require.Equal(t, types.Txs{original}, txmp.ReapMaxTxs(-1))
+}
+
+func TestTxMempool_LaneSize(t *testing.T) {
+	txmp := setupWithConfig(t, func(cfg *config.MempoolConfig) {
+		cfg.Lanes = []config.MempoolLaneConfig{{Name: "oracle", Size: 2}}
+	})
+
+	txInfo := mempool.TxInfo{SenderID: 0}
+	checkTx := func(tx string) {
+		require.NoError(t, txmp.CheckTx(context.Background(), types.Tx(tx), nil, txInfo))
+	}
+
+	checkTx("a=oracle:1=20")
+	checkTx("b=oracle:2=10")
+	for i := 0; i < 5; i++ {
+		checkTx(fmt.Sprintf("default-%d=key-%d=1", i, i))
+	}
+	require.Equal(t, 7, txmp.Size())
+	require.Equal(t, 2, txmp.getLane("oracle").NumTxs())
+	require.Equal(t, 5, txmp.getLane(DefaultLane).NumTxs())
+
+	// the lowest priority transaction of the lane is evicted, regardless of the
+	// transactions of the other lanes
+	checkTx("c=oracle:3=30")
+	require.Equal(t, 7, txmp.Size())
+	require.Equal(t, 2, txmp.getLane("oracle").NumTxs())
+	_, ok := txmp.GetTxByKey(mempool.TxKey(types.Tx("b=oracle:2=10")))
+	require.False(t, ok)
+
+	// a transaction with a lower priority than those of the full lane is rejected
+	checkTx("d=oracle:4=5")
+	require.Equal(t, 7, txmp.Size())
+	_, ok = txmp.GetTxByKey(mempool.TxKey(types.Tx("d=oracle:4=5")))
+	require.False(t, ok)
+
+	// an unknown lane is the default lane
+	checkTx("e=unknown:5=1")
+	require.Equal(t, 6, txmp.getLane(DefaultLane).NumTxs())
+}
+
+func TestTxMempool_LaneEvictionMempoolFull(t *testing.T) {
+	txs := []string{"a=oracle:1=20", "b=oracle:2=10", "default-0=key-0=100"}
+	var txsBytes int64
+	for _, tx := range txs {
+		txsBytes += int64(len(tx))
+	}
+
+	txmp := setupWithConfig(t, func(cfg *config.MempoolConfig) {
+		cfg.Lanes = []config.MempoolLaneConfig{{Name: "oracle", Size: 2}}
+		cfg.MaxTxsBytes = txsBytes
+	})
+
+	txInfo := mempool.TxInfo{SenderID: 0}
+	for _, tx := range txs {
+		require.NoError(t, txmp.CheckTx(context.Background(), types.Tx(tx), nil, txInfo))
+	}
+	require.Equal(t, 3, txmp.Size())
+
+	// evicting the lowest priority transaction of the lane does not make room
+	// for the new transaction in the mempool, whose other transactions have a
+	// higher priority, so nothing is evicted
+	tx := types.Tx("c=oracle:3=00000000015")
+	require.NoError(t, txmp.CheckTx(context.Background(), tx, nil, txInfo))
+	require.Equal(t, 3, txmp.Size())
+	require.Equal(t, txsBytes, txmp.SizeBytes())
+	_, ok := txmp.GetTxByKey(mempool.TxKey(tx))
+	require.False(t, ok)
+	_, ok = txmp.GetTxByKey(mempool.TxKey(types.Tx("b=oracle:2=10")))
+	require.True(t, ok)
+}
+
+func TestTxMempool_ReapLaneShare(t *testing.T) {
+	txmp := setupWithConfig(t, func(cfg *config.MempoolConfig) {
+		cfg.Lanes = []config.MempoolLaneConfig{{Name: "oracle", ReapShare: 30}}
+	})
+
+	txInfo := mempool.TxInfo{SenderID: 0}
+	for i := 0; i < 10; i++ {
+		tx := types.Tx(fmt.Sprintf("default-%d=key-%d=%d", i, i, 1000+i))
+		require.NoError(t, txmp.CheckTx(context.Background(), tx, nil, txInfo))
+
+		tx = types.Tx(fmt.Sprintf("oracle-%d=oracle:%d=%d", i, i, i))
+		require.NoError(t, txmp.CheckTx(context.Background(), tx, nil, txInfo))
+	}
+	// the transactions of the same sender are reaped in nonce order
+	for i := 0; i < 3; i++ {
+		tx := types.Tx(fmt.Sprintf("oracle=oracle:nonce-%d=%d=%d", i, 100-i, i))
+		require.NoError(t, txmp.CheckTx(context.Background(), tx, nil, txInfo))
+	}
+	require.Equal(t, 23, txmp.Size())
+
+	laneTxs := func(txs types.Txs, lane string) types.Txs {
+		var laneTxs types.Txs
+		for _, tx := range txs {
+			wtx := txmp.txStore.GetTxByHash(mempool.TxKey(tx))
+			require.NotNil(t, wtx)
+			if wtx.lane.name == lane {
+				laneTxs = append(laneTxs, tx)
+			}
+		}
+		return laneTxs
+	}
+
+	// each transaction wants 1 gas: 3 of the 10 are reserved to the oracle lane
+	reaped := txmp.ReapMaxBytesMaxGas(-1, 10)
+	require.Len(t, reaped, 10)
+	require.Equal(t, types.Txs{
+		types.Tx("oracle=oracle:nonce-0=100=0"),
+		types.Tx("oracle=oracle:nonce-1=99=1"),
+		types.Tx("oracle=oracle:nonce-2=98=2"),
+	}, laneTxs(reaped, "oracle"))
+
+	// the block space left by a lane is filled with the transactions of any lane
+	reaped = txmp.ReapMaxBytesMaxGas(-1, 20)
+	require.Len(t, reaped, 20)
+	require.Len(t, laneTxs(reaped, DefaultLane), 10)
+
+	// without limits, all the transactions are reaped
+	require.Len(t, txmp.ReapMaxBytesMaxGas(-1, -1), 23)
+}
+
+func TestTxMempool_Journal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mempool.journal")
+
+	journal, _, err := mempool.OpenJournal(path)
+	require.NoError(t, err)
+
+	txmp := setup(t, 100, WithJournal(journal))
+	txmp.height = 10
+	tTxs := checkTxs(t, txmp, 10, 0)
+	require.Equal(t, len(tTxs), txmp.Size())
+
+	// commit a block with the first 2 txs
+	responses := []*abci.ResponseDeliverTx{{Code: abci.CodeTypeOK}, {Code: abci.CodeTypeOK}}
+	txmp.Lock()
+	require.NoError(t, txmp.Update(11, types.Txs{tTxs[0].tx, tTxs[1].tx}, responses, nil, nil))
+	txmp.Unlock()
+	require.Equal(t, 8, txmp.Size())
+	require.NoError(t, journal.Close())
+
+	// the remaining txs are replayed into a new mempool, keeping their height
+	journal, entries, err := mempool.OpenJournal(path)
+	require.NoError(t, err)
+	require.Len(t, entries, 8)
+
+	txmp2 := setup(t, 100, WithJournal(journal))
+	txmp2.height = 11
+	require.NoError(t, txmp2.ReplayJournal(entries))
+	require.Equal(t, 8, txmp2.Size())
+	require.ElementsMatch(t, txmp.ReapMaxTxs(-1), txmp2.ReapMaxTxs(-1))
+	for _, wtx := range txmp2.txStore.GetAllTxs() {
+		require.Equal(t, int64(10), wtx.height)
+	}
+	require.NoError(t, journal.Close())
+
+	// txs which exceeded the TTL are not replayed
+	journal, entries, err = mempool.OpenJournal(path)
+	require.NoError(t, err)
+	require.Len(t, entries, 8)
+
+	txmp3 := setup(t, 100, WithJournal(journal))
+	txmp3.height = 21
+	txmp3.config.TTLNumBlocks = 10
+	require.NoError(t, txmp3.ReplayJournal(entries))
+	require.Equal(t, 0, txmp3.Size())
+	require.NoError(t, journal.Close())
+
+	_, entries, err = mempool.OpenJournal(path)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestTxMempool_TxRecordsAndEvents(t *testing.T) {
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() {
+		require.NoError(t, eventBus.Stop())
+	})
+
+	sub, err := eventBus.Subscribe(context.Background(), "test", tmquery.Empty{}, 10)
+	require.NoError(t, err)
+
+	txmp := setupWithConfig(t, func(cfg *config.MempoolConfig) {
+		cfg.Size = 2
+	}, WithEventBus(eventBus))
+
+	checkTx := func(tx string) types.Tx {
+		require.NoError(t, txmp.CheckTx(context.Background(), types.Tx(tx), nil, mempool.TxInfo{}))
+		return types.Tx(tx)
+	}
+	requireRecord := func(tx types.Tx, status mempool.TxStatus) mempool.TxRecord {
+		record, ok := txmp.GetTxRecord(mempool.TxKey(tx))
+		require.True(t, ok)
+		require.Equal(t, tx, record.Tx)
+		require.Equal(t, status, record.Status)
+		return record
+	}
+	update := func(height int64, txs types.Txs, postCheck mempool.PostCheckFunc) {
+		responses := make([]*abci.ResponseDeliverTx, len(txs))
+		for i := range responses {
+			responses[i] = &abci.ResponseDeliverTx{Code: abci.CodeTypeOK}
+		}
+		txmp.Lock()
+		require.NoError(t, txmp.Update(height, txs, responses, nil, postCheck))
+		require.NoError(t, txmp.FlushAppConn())
+		txmp.Unlock()
+		waitForRecheck(t, txmp)
+	}
+
+	txA := checkTx("a=key-a=10")
+	txB := checkTx("b=key-b=20")
+	record := requireRecord(txA, mempool.TxStatusPending)
+	require.Equal(t, int64(10), record.Priority)
+	require.Equal(t, "a", record.Sender)
+
+	// the mempool is full, so the lowest priority transaction is evicted
+	txC := checkTx("c=key-c=30")
+	requireRecord(txA, mempool.TxStatusEvicted)
+
+	// only the transactions in the mempool are listed
+	records := txmp.GetTxRecords()
+	require.Len(t, records, 2)
+	sort.Slice(records, func(i, j int) bool { return records[i].Priority < records[j].Priority })
+	require.Equal(t, txB, records[0].Tx)
+	require.Equal(t, txC, records[1].Tx)
+	require.Equal(t, mempool.TxStatusPending, records[1].Status)
+	require.Equal(t, int64(1), records[1].GasWanted)
+
+	// txB is committed while txC fails to pass CheckTx again
+	update(1, types.Txs{txB}, func(tx types.Tx, _ *abci.ResponseCheckTx) error {
+		if bytes.Equal(tx, txC) {
+			return errors.New("invalid")
+		}
+		return nil
+	})
+	requireRecord(txB, mempool.TxStatusCommitted)
+	requireRecord(txC, mempool.TxStatusRecheckFailed)
+
+	txmp.config.TTLNumBlocks = 1
+	txD := checkTx("d=key-d=40")
+	update(3, nil, func(types.Tx, *abci.ResponseCheckTx) error { return nil })
+	requireRecord(txD, mempool.TxStatusExpired)
+
+	_, ok := txmp.GetTxRecord(mempool.TxKey(types.Tx("unknown")))
+	require.False(t, ok)
+
+	expected := []struct {
+		event string
+		tx    types.Tx
+	}{
+		{types.EventMempoolTxAddedValue, txA},
+		{types.EventMempoolTxAddedValue, txB},
+		{types.EventMempoolTxEvictedValue, txA},
+		{types.EventMempoolTxAddedValue, txC},
+		{types.EventMempoolTxRecheckFailedValue, txC},
+		{types.EventMempoolTxAddedValue, txD},
+		{types.EventMempoolTxExpiredValue, txD},
+	}
+	for _, e := range expected {
+		select {
+		case msg := <-sub.Out():
+			require.Equal(t, e.event, msg.Events()[0].Attributes[0].Value)
+			require.Equal(t, e.tx, msg.Data().(types.EventDataMempoolTx).Tx)
+		case <-time.After(time.Second):
+			require.Fail(t, "timed out waiting for mempool tx event", e.event)
+		}
+	}
+
+	// flushing the mempool drops the records of the transactions which left it
+	txmp.Flush()
+	_, ok = txmp.GetTxRecord(mempool.TxKey(txA))
+	require.False(t, ok)
+}
+
 func TestTxMempool_ConcurrentTxs(t *testing.T) {
 	txmp := setup(t, 100)
 	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
@@ -486,6 +944,256 @@ func TestTxMempool_ExpiredTxs_NumBlocks(t *testing.T) {
 	require.GreaterOrEqual(t, txmp.heightIndex.Size(), 45)
 }
 
+// batchCountingAppConn counts the CheckTxBatch requests sent to the
+// application.
+type batchCountingAppConn struct {
+	proxy.AppConnMempool
+
+	batches int
+}
+
+func (c *batchCountingAppConn) CheckTxBatchAsync(
+	ctx context.Context,
+	req abci.RequestCheckTxBatch,
+) (*abcicli.ReqRes, error) {
+	c.batches++
+	return c.AppConnMempool.CheckTxBatchAsync(ctx, req)
+}
+
+func TestTxMempool_CheckTxBatch(t *testing.T) {
+	txmp := setup(t, 100)
+	appConn := &batchCountingAppConn{AppConnMempool: txmp.proxyAppConn}
+	txmp.proxyAppConn = appConn
+
+	txInfo := mempool.TxInfo{SenderID: 1}
+	require.NoError(t, txmp.CheckTx(context.Background(), types.Tx("a=key-a=10"), nil, txInfo))
+
+	txs := types.Txs{
+		types.Tx("a=key-a=10"),                   // already seen from the same peer
+		types.Tx("b=key-b=20"),                   // valid
+		types.Tx("invalid"),                      // rejected by the application
+		make(types.Tx, txmp.config.MaxTxBytes+1), // too large
+		types.Tx("c=key-c=30"),                   // valid
+	}
+	errs := txmp.CheckTxBatch(context.Background(), txs, nil, txInfo)
+	require.Len(t, errs, len(txs))
+	require.Equal(t, pubmempool.ErrTxInCache, errs[0])
+	require.NoError(t, errs[1])
+	require.NoError(t, errs[2])
+	require.Error(t, errs[3])
+	require.NoError(t, errs[4])
+
+	require.Equal(t, 1, appConn.batches)
+	require.Equal(t, 3, txmp.Size())
+	for _, tx := range []types.Tx{txs[1], txs[4]} {
+		_, ok := txmp.GetTxByKey(mempool.TxKey(tx))
+		require.True(t, ok)
+	}
+	_, ok := txmp.GetTxByKey(mempool.TxKey(txs[2]))
+	require.False(t, ok)
+}
+
+func TestTxMempool_RecheckTxBatch(t *testing.T) {
+	txmp := setupWithConfig(t, func(cfg *config.MempoolConfig) {
+		cfg.CheckTxBatchSize = 3
+	})
+	appConn := &batchCountingAppConn{AppConnMempool: txmp.proxyAppConn}
+	txmp.proxyAppConn = appConn
+
+	txs := checkTxs(t, txmp, 10, 0)
+	require.Equal(t, 10, txmp.Size())
+
+	// the first tx is committed and every other remaining tx fails to pass
+	// CheckTx again
+	invalid := make(map[string]bool)
+	for i, wtx := range txs[1:] {
+		if i%2 == 0 {
+			invalid[string(wtx.tx)] = true
+		}
+	}
+	postCheck := func(tx types.Tx, _ *abci.ResponseCheckTx) error {
+		if invalid[string(tx)] {
+			return errors.New("invalid")
+		}
+		return nil
+	}
+
+	txmp.Lock()
+	responses := []*abci.ResponseDeliverTx{{Code: abci.CodeTypeOK}}
+	require.NoError(t, txmp.Update(1, types.Txs{txs[0].tx}, responses, nil, postCheck))
+	txmp.Unlock()
+	waitForRecheck(t, txmp)
+
+	// the 9 remaining txs are rechecked in batches of 3
+	require.Equal(t, 3, appConn.batches)
+	require.Equal(t, 9-len(invalid), txmp.Size())
+	for _, wtx := range txs[1:] {
+		_, ok := txmp.GetTxByKey(mempool.TxKey(wtx.tx))
+		require.Equal(t, !invalid[string(wtx.tx)], ok)
+	}
+}
+
+// recheckAppConn holds back the re-CheckTx requests sent to the application
+// until the test responds to them, and sets the recheck keys of the responses
+// to CheckTx, if recheckKeys is set.
+type recheckAppConn struct {
+	proxy.AppConnMempool
+
+	mtx         sync.Mutex
+	rechecks    []*abcicli.ReqRes
+	recheckKeys func(tx types.Tx) [][]byte
+}
+
+func (c *recheckAppConn) CheckTxAsync(ctx context.Context, req abci.RequestCheckTx) (*abcicli.ReqRes, error) {
+	if req.Type == abci.CheckTxType_Recheck {
+		c.mtx.Lock()
+		defer c.mtx.Unlock()
+
+		reqRes := abcicli.NewReqRes(abci.ToRequestCheckTx(req))
+		c.rechecks = append(c.rechecks, reqRes)
+		return reqRes, nil
+	}
+
+	reqRes, err := c.AppConnMempool.CheckTxAsync(ctx, req)
+	if err == nil && c.recheckKeys != nil {
+		reqRes.Response.GetCheckTx().RecheckKeys = c.recheckKeys(req.Tx)
+	}
+	return reqRes, err
+}
+
+// pendingTxs returns the transactions of the re-CheckTx requests not responded
+// to yet, in the order they were sent.
+func (c *recheckAppConn) pendingTxs() types.Txs {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	txs := make(types.Txs, len(c.rechecks))
+	for i, reqRes := range c.rechecks {
+		txs[i] = reqRes.Request.GetCheckTx().Tx
+	}
+	return txs
+}
+
+// respond responds to the first n pending re-CheckTx requests with the given
+// code.
+func (c *recheckAppConn) respond(n int, code uint32) {
+	c.mtx.Lock()
+	rechecks := c.rechecks[:n]
+	c.rechecks = c.rechecks[n:]
+	c.mtx.Unlock()
+
+	for _, reqRes := range rechecks {
+		reqRes.Response = abci.ToResponseCheckTx(abci.ResponseCheckTx{Code: code})
+		reqRes.SetDone()
+		reqRes.InvokeCallback()
+	}
+}
+
+func TestTxMempool_RecheckPriorityOrder(t *testing.T) {
+	txmp := setup(t, 100)
+	appConn := &recheckAppConn{AppConnMempool: txmp.proxyAppConn}
+	txmp.proxyAppConn = appConn
+
+	txs := types.Txs{
+		types.Tx("sender-a=key-0=10=0"),
+		types.Tx("sender-a=key-1=50=1"),
+		types.Tx("sender-b=key-2=30=0"),
+		types.Tx("sender-c=key-3=20=0"),
+	}
+	for _, tx := range txs {
+		require.NoError(t, txmp.CheckTx(context.Background(), tx, nil, mempool.TxInfo{}))
+	}
+
+	txmp.Lock()
+	require.NoError(t, txmp.Update(1, nil, nil, nil, nil))
+	txmp.Unlock()
+
+	// the txs are rechecked in priority order, except that sender-a's txs are
+	// rechecked in nonce order
+	require.Eventually(t, func() bool {
+		return len(appConn.pendingTxs()) == len(txs)
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, types.Txs{txs[0], txs[1], txs[2], txs[3]}, appConn.pendingTxs())
+
+	appConn.respond(len(txs), abci.CodeTypeOK)
+	waitForRecheck(t, txmp)
+	require.Equal(t, len(txs), txmp.Size())
+}
+
+func TestTxMempool_RecheckCanceled(t *testing.T) {
+	txmp := setup(t, 100)
+	appConn := &recheckAppConn{AppConnMempool: txmp.proxyAppConn}
+	txmp.proxyAppConn = appConn
+
+	txs := checkTxs(t, txmp, 10, 0)
+	require.Equal(t, 10, txmp.Size())
+
+	txmp.Lock()
+	require.NoError(t, txmp.Update(1, nil, nil, nil, nil))
+	txmp.Unlock()
+	require.Eventually(t, func() bool {
+		return len(appConn.pendingTxs()) == len(txs)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	txmp.recheckMtx.Lock()
+	canceled := txmp.recheck
+	txmp.recheckMtx.Unlock()
+
+	// the next block cancels the re-CheckTx in progress, whose responses are
+	// then ignored, and starts another one
+	txmp.Lock()
+	require.NoError(t, txmp.Update(2, nil, nil, nil, nil))
+	txmp.Unlock()
+	<-canceled.done
+
+	appConn.respond(len(txs), 1)
+	require.Equal(t, 10, txmp.Size())
+
+	require.Eventually(t, func() bool {
+		return len(appConn.pendingTxs()) == len(txs)
+	}, 5*time.Second, 10*time.Millisecond)
+	appConn.respond(len(txs), 1)
+	waitForRecheck(t, txmp)
+	require.Zero(t, txmp.Size())
+}
+
+func TestTxMempool_RecheckKeys(t *testing.T) {
+	txmp := setup(t, 100)
+	appConn := &recheckAppConn{
+		AppConnMempool: txmp.proxyAppConn,
+		recheckKeys: func(tx types.Tx) [][]byte {
+			// the validity of a tx depends on its key
+			return [][]byte{bytes.Split(tx, []byte("="))[1]}
+		},
+	}
+	txmp.proxyAppConn = appConn
+
+	txs := types.Txs{
+		types.Tx("sender-a=key-a=10"),
+		types.Tx("sender-b=key-b=20"),
+		types.Tx("sender-c=key-c=30"),
+	}
+	for _, tx := range txs {
+		require.NoError(t, txmp.CheckTx(context.Background(), tx, nil, mempool.TxInfo{}))
+	}
+
+	// only the tx depending on a key updated by the block is rechecked
+	txmp.Lock()
+	require.NoError(t, txmp.Update(1, types.Txs{types.Tx("other")}, []*abci.ResponseDeliverTx{
+		{Code: abci.CodeTypeOK, UpdatedKeys: [][]byte{[]byte("key-b")}},
+	}, nil, nil))
+	txmp.Unlock()
+
+	require.Eventually(t, func() bool {
+		return len(appConn.pendingTxs()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, types.Txs{txs[1]}, appConn.pendingTxs())
+
+	appConn.respond(1, 1)
+	waitForRecheck(t, txmp)
+	require.Equal(t, types.Txs{txs[2], txs[0]}, txmp.ReapMaxTxs(-1))
+}
+
 func TestTxMempool_CheckTxPostCheckError(t *testing.T) {
 	cases := []struct {
 		name string
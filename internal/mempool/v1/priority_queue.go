@@ -32,11 +32,43 @@ func NewTxPriorityQueue() *TxPriorityQueue {
 // priority and that their total sum in size allows room for the incoming
 // transaction according to the mempool's configured limits.
 func (pq *TxPriorityQueue) GetEvictableTxs(priority, txSize, totalSize, cap int64) []*WrappedTx {
+	return pq.getEvictableTxs(nil, nil, priority, txSize, totalSize, cap)
+}
+
+// GetEvictableTxsExcept is like GetEvictableTxs, except that it skips the given
+// transactions, which are already to be evicted and not counted in totalSize.
+func (pq *TxPriorityQueue) GetEvictableTxsExcept(
+	except []*WrappedTx,
+	priority, txSize, totalSize, cap int64,
+) []*WrappedTx {
+	return pq.getEvictableTxs(nil, except, priority, txSize, totalSize, cap)
+}
+
+// GetEvictableLaneTxs is like GetEvictableTxs, except that it only evaluates
+// the transactions of the given lane, according to the lane's configured limits.
+func (pq *TxPriorityQueue) GetEvictableLaneTxs(lane *txLane, priority, txSize, totalSize, cap int64) []*WrappedTx {
+	return pq.getEvictableTxs(lane, nil, priority, txSize, totalSize, cap)
+}
+
+func (pq *TxPriorityQueue) getEvictableTxs(
+	lane *txLane,
+	except []*WrappedTx,
+	priority, txSize, totalSize, cap int64,
+) []*WrappedTx {
 	pq.mtx.RLock()
 	defer pq.mtx.RUnlock()
 
-	txs := make([]*WrappedTx, len(pq.txs))
-	copy(txs, pq.txs)
+	skip := make(map[*WrappedTx]bool, len(except))
+	for _, tx := range except {
+		skip[tx] = true
+	}
+
+	txs := make([]*WrappedTx, 0, len(pq.txs))
+	for _, tx := range pq.txs {
+		if (lane == nil || tx.lane == lane) && !skip[tx] {
+			txs = append(txs, tx)
+		}
+	}
 
 	sort.Slice(txs, func(i, j int) bool {
 		return txs[i].priority < txs[j].priority
@@ -86,6 +118,18 @@ func (pq *TxPriorityQueue) RemoveTx(tx *WrappedTx) {
 	}
 }
 
+// UpdatePriority sets the priority of a transaction of the priority queue and
+// restores the ordering of the queue. It is thread safe.
+func (pq *TxPriorityQueue) UpdatePriority(tx *WrappedTx, priority int64) {
+	pq.mtx.Lock()
+	defer pq.mtx.Unlock()
+
+	tx.priority = priority
+	if tx.heapIndex >= 0 && tx.heapIndex < len(pq.txs) && pq.txs[tx.heapIndex] == tx {
+		heap.Fix(pq, tx.heapIndex)
+	}
+}
+
 // PushTx adds a valid transaction to the priority queue. It is thread safe.
 func (pq *TxPriorityQueue) PushTx(tx *WrappedTx) {
 	pq.mtx.Lock()
@@ -157,3 +201,84 @@ func (pq *TxPriorityQueue) Swap(i, j int) {
 	pq.txs[i].heapIndex = i
 	pq.txs[j].heapIndex = j
 }
+
+// txReaper pops the transactions of a priority queue in priority order, except
+// that a transaction is held back until the transactions of the same sender
+// with a lower nonce were popped. A transaction held back is returned right
+// after its predecessors, as it has a higher priority than the transactions
+// still in the queue.
+//
+// NOTE: The caller must call Close to re-enqueue the popped transactions and
+// must hold a read-lock on the mempool while reaping.
+type txReaper struct {
+	txStore *TxStore
+	pq      *TxPriorityQueue
+
+	popped []*WrappedTx            // all the transactions popped from pq
+	queues map[string][]*WrappedTx // nonce ordered transactions by sender
+	next   map[string]int          // index of the next transaction to reap by sender
+	held   map[*WrappedTx]struct{} // transactions waiting for their predecessors
+	ready  []*WrappedTx            // transactions ready to be reaped
+}
+
+func newTxReaper(txStore *TxStore, pq *TxPriorityQueue) *txReaper {
+	return &txReaper{
+		txStore: txStore,
+		pq:      pq,
+		queues:  make(map[string][]*WrappedTx),
+		next:    make(map[string]int),
+		held:    make(map[*WrappedTx]struct{}),
+	}
+}
+
+// Next returns the next transaction to reap, or nil if there are none left.
+func (r *txReaper) Next() *WrappedTx {
+	for len(r.ready) == 0 && r.pq.NumTxs() > 0 {
+		wtx := r.pq.PopTx()
+		r.popped = append(r.popped, wtx)
+
+		if len(wtx.sender) == 0 {
+			return wtx
+		}
+
+		queue, ok := r.queues[wtx.sender]
+		if !ok {
+			queue = r.txStore.GetTxsBySender(wtx.sender)
+			r.queues[wtx.sender] = queue
+		}
+
+		next := r.next[wtx.sender]
+		if next < len(queue) && queue[next] != wtx {
+			r.held[wtx] = struct{}{}
+			continue
+		}
+
+		// wtx is the next transaction of the sender, followed by those which
+		// were held back waiting for it
+		r.ready = append(r.ready, wtx)
+		for next++; next < len(queue); next++ {
+			if _, ok := r.held[queue[next]]; !ok {
+				break
+			}
+			delete(r.held, queue[next])
+			r.ready = append(r.ready, queue[next])
+		}
+		r.next[wtx.sender] = next
+	}
+
+	if len(r.ready) == 0 {
+		return nil
+	}
+
+	wtx := r.ready[0]
+	r.ready = r.ready[1:]
+	return wtx
+}
+
+// Close re-enqueues all the popped transactions into the priority queue.
+func (r *txReaper) Close() {
+	for _, wtx := range r.popped {
+		r.pq.PushTx(wtx)
+	}
+	r.popped = nil
+}
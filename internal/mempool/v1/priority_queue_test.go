@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/config"
 )
 
 func TestTxPriorityQueue(t *testing.T) {
@@ -141,6 +143,51 @@ func TestTxPriorityQueue_GetEvictableTxs(t *testing.T) {
 	}
 }
 
+func TestTxPriorityQueue_GetEvictableLaneTxs(t *testing.T) {
+	pq := NewTxPriorityQueue()
+
+	oracle := newTxLane(config.MempoolLaneConfig{Name: "oracle"})
+	other := newTxLane(config.MempoolLaneConfig{Name: "other"})
+
+	for i := 0; i < 10; i++ {
+		lane := oracle
+		if i%2 == 0 {
+			lane = other
+		}
+		pq.PushTx(&WrappedTx{
+			tx:       make([]byte, 5),
+			priority: int64(i),
+			lane:     lane,
+		})
+	}
+
+	// only the lowest priority transactions of the lane are evicted
+	evictTxs := pq.GetEvictableLaneTxs(oracle, 10, 10, 25, 25)
+	require.Len(t, evictTxs, 2)
+	require.Equal(t, int64(1), evictTxs[0].priority)
+	require.Equal(t, int64(3), evictTxs[1].priority)
+
+	evictTxs = pq.GetEvictableLaneTxs(oracle, 2, 10, 25, 25)
+	require.Empty(t, evictTxs)
+}
+
+func TestTxPriorityQueue_GetEvictableTxsExcept(t *testing.T) {
+	pq := NewTxPriorityQueue()
+
+	txs := make([]*WrappedTx, 5)
+	for i := range txs {
+		txs[i] = &WrappedTx{tx: make([]byte, 5), priority: int64(i)}
+		pq.PushTx(txs[i])
+	}
+
+	// the transactions already to be evicted are skipped
+	evictTxs := pq.GetEvictableTxsExcept(txs[:1], 10, 10, 20, 20)
+	require.Equal(t, txs[1:3], evictTxs)
+
+	evictTxs = pq.GetEvictableTxsExcept(txs[:2], 2, 10, 15, 15)
+	require.Empty(t, evictTxs)
+}
+
 func TestTxPriorityQueue_RemoveTx(t *testing.T) {
 	pq := NewTxPriorityQueue()
 	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
@@ -1,10 +1,13 @@
 package v1
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/abci/example/kvstore"
@@ -145,3 +148,260 @@ func TestReactorBroadcastDoesNotPanic(t *testing.T) {
 	primaryReactor.peerWG.Wait()
 	wg.Wait()
 }
+
+func TestReactorBroadcastLanePriority(t *testing.T) {
+	txmp := setupWithConfig(t, func(cfg *config.MempoolConfig) {
+		cfg.Lanes = []config.MempoolLaneConfig{{Name: "oracle", GossipPriority: 10}}
+	})
+
+	numTxs := 5
+	txInfo := mempool.TxInfo{SenderID: 0}
+	for i := 0; i < numTxs; i++ {
+		tx := types.Tx(fmt.Sprintf("default-%d=key-%d=1", i, i))
+		require.NoError(t, txmp.CheckTx(context.Background(), tx, nil, txInfo))
+	}
+	for i := 0; i < numTxs; i++ {
+		tx := types.Tx(fmt.Sprintf("oracle-%d=oracle:%d=1", i, i))
+		require.NoError(t, txmp.CheckTx(context.Background(), tx, nil, txInfo))
+	}
+
+	outCh := make(chan p2p.Envelope, 2*numTxs)
+	mempoolCh := p2p.NewChannel(
+		mempool.MempoolChannel,
+		new(protomem.Message),
+		make(chan p2p.Envelope),
+		outCh,
+		make(chan p2p.PeerError),
+	)
+	peerUpdates := p2p.NewPeerUpdates(make(chan p2p.PeerUpdate), 1)
+
+	reactor := NewReactor(log.TestingLogger(), txmp.config, nil, txmp, mempoolCh, peerUpdates)
+	require.NoError(t, reactor.Start())
+
+	closer := tmsync.NewCloser()
+	t.Cleanup(func() {
+		closer.Close()
+		require.NoError(t, reactor.Stop())
+	})
+
+	peerID := types.NodeID("00ff")
+	reactor.ids.ReserveForPeer(peerID)
+	reactor.peerWG.Add(1)
+	go reactor.broadcastTxRoutine(peerID, closer)
+
+	receiveTx := func() string {
+		select {
+		case envelope := <-outCh:
+			txs := envelope.Message.(*protomem.Txs).Txs
+			require.Len(t, txs, 1)
+			return string(txs[0])
+		case <-time.After(time.Second):
+			require.Fail(t, "timed out waiting for gossiped tx")
+			return ""
+		}
+	}
+
+	// the transactions of the oracle lane are gossiped first
+	for i := 0; i < numTxs; i++ {
+		require.True(t, strings.HasPrefix(receiveTx(), "oracle-"))
+	}
+	for i := 0; i < numTxs; i++ {
+		require.True(t, strings.HasPrefix(receiveTx(), "default-"))
+	}
+
+	// new transactions are gossiped as they are inserted
+	tx := types.Tx("default-new=key-new=1")
+	require.NoError(t, txmp.CheckTx(context.Background(), tx, nil, txInfo))
+	require.Equal(t, string(tx), receiveTx())
+}
+
+func TestReactorPullGossip(t *testing.T) {
+	txmp := setupWithConfig(t, func(cfg *config.MempoolConfig) {
+		cfg.GossipMode = config.MempoolGossipModePull
+		cfg.MaxAnnounceBatchSize = 2
+		cfg.TxRequestTimeout = 100 * time.Millisecond
+	})
+
+	numTxs := 3
+	txInfo := mempool.TxInfo{SenderID: 0}
+	txs := make(types.Txs, numTxs)
+	for i := 0; i < numTxs; i++ {
+		txs[i] = types.Tx(fmt.Sprintf("sender-%d=key-%d=1", i, i))
+		require.NoError(t, txmp.CheckTx(context.Background(), txs[i], nil, txInfo))
+	}
+
+	outCh := make(chan p2p.Envelope, 2*numTxs)
+	mempoolCh := p2p.NewChannel(
+		mempool.MempoolChannel,
+		new(protomem.Message),
+		make(chan p2p.Envelope),
+		outCh,
+		make(chan p2p.PeerError),
+	)
+	peerUpdates := p2p.NewPeerUpdates(make(chan p2p.PeerUpdate), 1)
+
+	reactor := NewReactor(log.TestingLogger(), txmp.config, nil, txmp, mempoolCh, peerUpdates)
+	require.NoError(t, reactor.Start())
+
+	closer := tmsync.NewCloser()
+	t.Cleanup(func() {
+		closer.Close()
+		require.NoError(t, reactor.Stop())
+	})
+
+	receive := func() p2p.Envelope {
+		select {
+		case envelope := <-outCh:
+			return envelope
+		case <-time.After(time.Second):
+			require.Fail(t, "timed out waiting for envelope")
+			return p2p.Envelope{}
+		}
+	}
+	hashOf := func(tx types.Tx) []byte {
+		key := mempool.TxKey(tx)
+		return key[:]
+	}
+
+	// the peer advertises the pull gossip mode
+	peerID := types.NodeID("00ff")
+	require.NoError(t, reactor.handleMempoolMessage(p2p.Envelope{
+		From:    peerID,
+		Message: &protomem.GossipMode{Pull: true},
+	}))
+	require.True(t, reactor.gossip.UsePullGossip(peerID))
+
+	reactor.ids.ReserveForPeer(peerID)
+	reactor.peerWG.Add(1)
+	go reactor.broadcastTxRoutine(peerID, closer)
+
+	// the txs are announced in batches of at most two hashes
+	require.Equal(t, &protomem.TxAnnouncement{Hashes: [][]byte{hashOf(txs[0]), hashOf(txs[1])}}, receive().Message)
+	require.Equal(t, &protomem.TxAnnouncement{Hashes: [][]byte{hashOf(txs[2])}}, receive().Message)
+
+	// the requested txs we have are sent
+	require.NoError(t, reactor.handleMempoolMessage(p2p.Envelope{
+		From:    peerID,
+		Message: &protomem.TxRequest{Hashes: [][]byte{hashOf(txs[1]), hashOf(types.Tx("unknown"))}},
+	}))
+	require.Equal(t, &protomem.Txs{Txs: [][]byte{txs[1]}}, receive().Message)
+
+	// the announced txs we do not have are requested, once
+	newTx := types.Tx("sender-new=key-new=1")
+	announcement := &protomem.TxAnnouncement{Hashes: [][]byte{hashOf(txs[0]), hashOf(newTx)}}
+	require.NoError(t, reactor.handleMempoolMessage(p2p.Envelope{From: peerID, Message: announcement}))
+	require.Equal(t, &protomem.TxRequest{Hashes: [][]byte{hashOf(newTx)}}, receive().Message)
+
+	require.NoError(t, reactor.handleMempoolMessage(p2p.Envelope{From: peerID, Message: announcement}))
+	require.Empty(t, outCh)
+
+	// a tx announced by another peer while requested is requested from it once
+	// the request times out
+	otherPeerID := types.NodeID("01ff")
+	require.NoError(t, reactor.handleMempoolMessage(p2p.Envelope{From: otherPeerID, Message: announcement}))
+	require.Empty(t, outCh)
+
+	envelope := receive()
+	require.Equal(t, otherPeerID, envelope.To)
+	require.Equal(t, &protomem.TxRequest{Hashes: [][]byte{hashOf(newTx)}}, envelope.Message)
+
+	// invalid announcements are rejected
+	require.Error(t, reactor.handleMempoolMessage(p2p.Envelope{
+		From:    peerID,
+		Message: &protomem.TxAnnouncement{},
+	}))
+	require.Error(t, reactor.handleMempoolMessage(p2p.Envelope{
+		From:    peerID,
+		Message: &protomem.TxAnnouncement{Hashes: [][]byte{[]byte("short")}},
+	}))
+	require.Error(t, reactor.handleMempoolMessage(p2p.Envelope{
+		From:    peerID,
+		Message: &protomem.TxAnnouncement{Hashes: [][]byte{hashOf(txs[0]), hashOf(txs[1]), hashOf(newTx)}},
+	}))
+}
+
+func TestReactorPeerTxRate(t *testing.T) {
+	txmp := setupWithConfig(t, func(cfg *config.MempoolConfig) {
+		cfg.PeerTxRate = 1
+		cfg.PeerTxBurst = 2
+	})
+
+	mempoolCh := p2p.NewChannel(
+		mempool.MempoolChannel,
+		new(protomem.Message),
+		make(chan p2p.Envelope),
+		make(chan p2p.Envelope),
+		make(chan p2p.PeerError),
+	)
+	peerUpdates := p2p.NewPeerUpdates(make(chan p2p.PeerUpdate), 1)
+	reactor := NewReactor(log.TestingLogger(), txmp.config, nil, txmp, mempoolCh, peerUpdates)
+
+	txs := make([][]byte, 3)
+	for i := range txs {
+		txs[i] = []byte(fmt.Sprintf("sender-%d=key-%d=1", i, i))
+	}
+
+	// the txs over the peer's burst are dropped
+	peer1 := types.NodeID("00aa")
+	require.NoError(t, reactor.handleMempoolMessage(p2p.Envelope{From: peer1, Message: &protomem.Txs{Txs: txs}}))
+	require.Equal(t, 2, txmp.Size())
+
+	// the quota of each peer is independent
+	peer2 := types.NodeID("00bb")
+	require.NoError(t, reactor.handleMempoolMessage(p2p.Envelope{From: peer2, Message: &protomem.Txs{Txs: txs[2:]}}))
+	require.Equal(t, 3, txmp.Size())
+}
+
+func TestReactorPeerSpamScore(t *testing.T) {
+	txmp := setupWithConfig(t, func(cfg *config.MempoolConfig) {
+		cfg.MaxPeerSpamScore = 2
+		cfg.CheckTxBatchSize = 2
+	})
+
+	errCh := make(chan p2p.PeerError, 1)
+	mempoolCh := p2p.NewChannel(
+		mempool.MempoolChannel,
+		new(protomem.Message),
+		make(chan p2p.Envelope),
+		make(chan p2p.Envelope),
+		errCh,
+	)
+	peerUpdates := p2p.NewPeerUpdates(make(chan p2p.PeerUpdate), 1)
+	reactor := NewReactor(log.TestingLogger(), txmp.config, nil, txmp, mempoolCh, peerUpdates)
+
+	peerID := types.NodeID("00aa")
+	reactor.ids.ReserveForPeer(peerID)
+	send := func(txs ...string) {
+		msg := &protomem.Txs{}
+		for _, tx := range txs {
+			msg.Txs = append(msg.Txs, []byte(tx))
+		}
+		require.NoError(t, reactor.handleMempoolMessage(p2p.Envelope{From: peerID, Message: msg}))
+	}
+
+	// a valid tx is not penalized, but sending it again is
+	send("sender-0=key-0=1")
+	send("sender-0=key-0=1")
+	require.Empty(t, errCh)
+
+	// so are the txs rejected by the application, checked one by one or in a
+	// batch, until the peer is reported once its score exceeds the maximum
+	send("invalid-0")
+	require.Empty(t, errCh)
+
+	send("invalid-1", "invalid-2")
+	select {
+	case peerErr := <-errCh:
+		require.Equal(t, peerID, peerErr.NodeID)
+		require.Error(t, peerErr.Err)
+	case <-time.After(time.Second):
+		require.Fail(t, "timed out waiting for peer error")
+	}
+	require.Empty(t, errCh)
+
+	// the score of a removed peer is forgotten
+	reactor.processPeerUpdate(p2p.PeerUpdate{NodeID: peerID, Status: p2p.PeerStatusDown})
+	send("invalid-3")
+	send("invalid-4")
+	require.Empty(t, errCh)
+}
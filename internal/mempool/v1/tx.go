@@ -33,6 +33,10 @@ type WrappedTx struct {
 	// the ResponseCheckTx response.
 	sender string
 
+	// nonce orders the transactions of the same sender, as specified by the
+	// application in the ResponseCheckTx response.
+	nonce uint64
+
 	// timestamp is the time at which the node first received the transaction from
 	// a peer. It is used as a second dimension is prioritizing transactions when
 	// two transactions have the same priority.
@@ -47,6 +51,19 @@ type WrappedTx struct {
 	// gossipEl references the linked-list element in the gossip index
 	gossipEl *clist.CElement
 
+	// lane defines the lane of the transaction, as assigned by the application
+	// in the ResponseCheckTx response.
+	lane *txLane
+
+	// laneEl references the linked-list element in the gossip index of the lane
+	laneEl *clist.CElement
+
+	// recheckKeys are the keys of the application state the validity of the
+	// transaction depends on, as specified by the application in the
+	// ResponseCheckTx response. If empty, the transaction is rechecked after
+	// every block.
+	recheckKeys [][]byte
+
 	// removed marks the transaction as removed from the mempool. This is set
 	// during RemoveTx and is needed due to the fact that a given existing
 	// transaction in the mempool can be evicted when it is simultaneously having
@@ -58,6 +75,43 @@ func (wtx *WrappedTx) Size() int {
 	return len(wtx.tx)
 }
 
+// unaffectedBy returns true if the transaction has recheck keys and none of
+// them is in the given set of keys updated by a block, in which case it does
+// not need to be rechecked.
+func (wtx *WrappedTx) unaffectedBy(updatedKeys map[string]struct{}) bool {
+	if len(wtx.recheckKeys) == 0 {
+		return false
+	}
+
+	for _, key := range wtx.recheckKeys {
+		if _, ok := updatedKeys[string(key)]; ok {
+			return false
+		}
+	}
+	return true
+}
+
+func newTxRecord(wtx *WrappedTx, status mempool.TxStatus) mempool.TxRecord {
+	return mempool.TxRecord{
+		Tx:        wtx.tx,
+		Status:    status,
+		Priority:  wtx.priority,
+		Sender:    wtx.sender,
+		GasWanted: wtx.gasWanted,
+		Height:    wtx.height,
+		Timestamp: wtx.timestamp,
+	}
+}
+
+func newEventDataMempoolTx(wtx *WrappedTx) types.EventDataMempoolTx {
+	return types.EventDataMempoolTx{
+		Tx:       wtx.tx,
+		Priority: wtx.priority,
+		Sender:   wtx.sender,
+		Height:   wtx.height,
+	}
+}
+
 // TxStore implements a thread-safe mapping of valid transaction(s).
 //
 // NOTE:
@@ -67,12 +121,12 @@ func (wtx *WrappedTx) Size() int {
 type TxStore struct {
 	mtx       tmsync.RWMutex
 	hashTxs   map[[mempool.TxKeySize]byte]*WrappedTx // primary index
-	senderTxs map[string]*WrappedTx                  // sender is defined by the ABCI application
+	senderTxs map[string][]*WrappedTx                // sender is defined by the ABCI application, ordered by nonce
 }
 
 func NewTxStore() *TxStore {
 	return &TxStore{
-		senderTxs: make(map[string]*WrappedTx),
+		senderTxs: make(map[string][]*WrappedTx),
 		hashTxs:   make(map[[mempool.TxKeySize]byte]*WrappedTx),
 	}
 }
@@ -100,13 +154,54 @@ func (txs *TxStore) GetAllTxs() []*WrappedTx {
 	return wTxs
 }
 
-// GetTxBySender returns a *WrappedTx by the transaction's sender property
-// defined by the ABCI application.
-func (txs *TxStore) GetTxBySender(sender string) *WrappedTx {
+// GetTxBySender returns a *WrappedTx by the transaction's sender and nonce
+// properties defined by the ABCI application.
+func (txs *TxStore) GetTxBySender(sender string, nonce uint64) *WrappedTx {
 	txs.mtx.RLock()
 	defer txs.mtx.RUnlock()
 
-	return txs.senderTxs[sender]
+	senderTxs := txs.senderTxs[sender]
+	i := searchNonce(senderTxs, nonce)
+	if i < len(senderTxs) && senderTxs[i].nonce == nonce {
+		return senderTxs[i]
+	}
+
+	return nil
+}
+
+// GetTxsBySender returns all the transactions of the given sender, in ascending
+// nonce order.
+func (txs *TxStore) GetTxsBySender(sender string) []*WrappedTx {
+	txs.mtx.RLock()
+	defer txs.mtx.RUnlock()
+
+	senderTxs := make([]*WrappedTx, len(txs.senderTxs[sender]))
+	copy(senderTxs, txs.senderTxs[sender])
+
+	return senderTxs
+}
+
+// GetDependentTxs returns the transactions of the same sender as the given
+// transaction with a higher nonce, in ascending nonce order. They depend on the
+// given transaction being executed first.
+func (txs *TxStore) GetDependentTxs(wtx *WrappedTx) []*WrappedTx {
+	if len(wtx.sender) == 0 {
+		return nil
+	}
+
+	txs.mtx.RLock()
+	defer txs.mtx.RUnlock()
+
+	senderTxs := txs.senderTxs[wtx.sender]
+	i := searchNonce(senderTxs, wtx.nonce+1)
+	if i == len(senderTxs) {
+		return nil
+	}
+
+	dependents := make([]*WrappedTx, len(senderTxs)-i)
+	copy(dependents, senderTxs[i:])
+
+	return dependents
 }
 
 // GetTxByHash returns a *WrappedTx by the transaction's hash.
@@ -117,29 +212,38 @@ func (txs *TxStore) GetTxByHash(hash [mempool.TxKeySize]byte) *WrappedTx {
 	return txs.hashTxs[hash]
 }
 
-// IsTxRemoved returns true if a transaction by hash is marked as removed and
-// false otherwise.
-func (txs *TxStore) IsTxRemoved(hash [mempool.TxKeySize]byte) bool {
+// IsTxRemoved returns true if a transaction is marked as removed and false
+// otherwise.
+func (txs *TxStore) IsTxRemoved(wtx *WrappedTx) bool {
 	txs.mtx.RLock()
 	defer txs.mtx.RUnlock()
 
-	wtx, ok := txs.hashTxs[hash]
-	if ok {
-		return wtx.removed
-	}
-
-	return false
+	return wtx.removed
 }
 
 // SetTx stores a *WrappedTx by it's hash. If the transaction also contains a
-// non-empty sender, we additionally store the transaction by the sender as
-// defined by the ABCI application.
+// non-empty sender, we additionally store the transaction by the sender, in
+// nonce order, as defined by the ABCI application. A transaction of the same
+// sender with the same nonce is replaced.
 func (txs *TxStore) SetTx(wtx *WrappedTx) {
 	txs.mtx.Lock()
 	defer txs.mtx.Unlock()
 
 	if len(wtx.sender) > 0 {
-		txs.senderTxs[wtx.sender] = wtx
+		senderTxs := txs.senderTxs[wtx.sender]
+		i := searchNonce(senderTxs, wtx.nonce)
+
+		switch {
+		case i < len(senderTxs) && senderTxs[i].nonce == wtx.nonce:
+			senderTxs[i] = wtx
+
+		default:
+			senderTxs = append(senderTxs, nil)
+			copy(senderTxs[i+1:], senderTxs[i:])
+			senderTxs[i] = wtx
+		}
+
+		txs.senderTxs[wtx.sender] = senderTxs
 	}
 
 	txs.hashTxs[mempool.TxKey(wtx.tx)] = wtx
@@ -152,7 +256,18 @@ func (txs *TxStore) RemoveTx(wtx *WrappedTx) {
 	defer txs.mtx.Unlock()
 
 	if len(wtx.sender) > 0 {
-		delete(txs.senderTxs, wtx.sender)
+		senderTxs := txs.senderTxs[wtx.sender]
+		i := searchNonce(senderTxs, wtx.nonce)
+
+		if i < len(senderTxs) && senderTxs[i] == wtx {
+			senderTxs = append(senderTxs[:i], senderTxs[i+1:]...)
+		}
+
+		if len(senderTxs) == 0 {
+			delete(txs.senderTxs, wtx.sender)
+		} else {
+			txs.senderTxs[wtx.sender] = senderTxs
+		}
 	}
 
 	delete(txs.hashTxs, mempool.TxKey(wtx.tx))
@@ -200,6 +315,14 @@ func (txs *TxStore) GetOrSetPeerByTxHash(hash [mempool.TxKeySize]byte, peerID ui
 	return wtx, false
 }
 
+// searchNonce returns the index of the first transaction in the given nonce
+// ordered list with a nonce greater than or equal to the given one.
+func searchNonce(wtxs []*WrappedTx, nonce uint64) int {
+	return sort.Search(len(wtxs), func(i int) bool {
+		return wtxs[i].nonce >= nonce
+	})
+}
+
 // WrappedTxList implements a thread-safe list of *WrappedTx objects that can be
 // used to build generic transaction indexes in the mempool. It accepts a
 // comparator function, less(a, b *WrappedTx) bool, that compares two WrappedTx
@@ -280,3 +403,65 @@ func (wtl *WrappedTxList) Remove(wtx *WrappedTx) {
 		i++
 	}
 }
+
+// TxHistory implements a thread-safe, fixed-size record of the transactions
+// which recently left the mempool. Once full, the oldest record is dropped for
+// each new record.
+type TxHistory struct {
+	mtx     tmsync.RWMutex
+	records map[[mempool.TxKeySize]byte]mempool.TxRecord
+	keys    [][mempool.TxKeySize]byte // ring buffer of the keys in insertion order
+	next    int
+}
+
+func NewTxHistory(size int) *TxHistory {
+	return &TxHistory{
+		records: make(map[[mempool.TxKeySize]byte]mempool.TxRecord, size),
+		keys:    make([][mempool.TxKeySize]byte, 0, size),
+	}
+}
+
+// Add records a transaction which left the mempool, replacing any existing
+// record of the transaction.
+func (th *TxHistory) Add(record mempool.TxRecord) {
+	th.mtx.Lock()
+	defer th.mtx.Unlock()
+
+	if cap(th.keys) == 0 {
+		return
+	}
+
+	key := mempool.TxKey(record.Tx)
+	if _, ok := th.records[key]; ok {
+		th.records[key] = record
+		return
+	}
+
+	if len(th.keys) < cap(th.keys) {
+		th.keys = append(th.keys, key)
+	} else {
+		delete(th.records, th.keys[th.next])
+		th.keys[th.next] = key
+		th.next = (th.next + 1) % len(th.keys)
+	}
+	th.records[key] = record
+}
+
+// Get returns the record of the transaction with the given key, if any.
+func (th *TxHistory) Get(key [mempool.TxKeySize]byte) (mempool.TxRecord, bool) {
+	th.mtx.RLock()
+	defer th.mtx.RUnlock()
+
+	record, ok := th.records[key]
+	return record, ok
+}
+
+// Reset removes all the records.
+func (th *TxHistory) Reset() {
+	th.mtx.Lock()
+	defer th.mtx.Unlock()
+
+	th.records = make(map[[mempool.TxKeySize]byte]mempool.TxRecord, cap(th.keys))
+	th.keys = th.keys[:0]
+	th.next = 0
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/internal/mempool"
+	"github.com/tendermint/tendermint/types"
 )
 
 func TestTxStore_GetTxBySender(t *testing.T) {
@@ -20,14 +21,53 @@ func TestTxStore_GetTxBySender(t *testing.T) {
 		timestamp: time.Now(),
 	}
 
-	res := txs.GetTxBySender(wtx.sender)
+	res := txs.GetTxBySender(wtx.sender, wtx.nonce)
 	require.Nil(t, res)
 
 	txs.SetTx(wtx)
 
-	res = txs.GetTxBySender(wtx.sender)
+	res = txs.GetTxBySender(wtx.sender, wtx.nonce)
 	require.NotNil(t, res)
 	require.Equal(t, wtx, res)
+
+	res = txs.GetTxBySender(wtx.sender, wtx.nonce+1)
+	require.Nil(t, res)
+}
+
+func TestTxStore_GetTxsBySender(t *testing.T) {
+	txs := NewTxStore()
+
+	// insert the transactions of a sender out of nonce order
+	nonces := []uint64{3, 0, 5, 1}
+	wtxs := make(map[uint64]*WrappedTx, len(nonces))
+	for _, nonce := range nonces {
+		wtx := &WrappedTx{
+			tx:        []byte(fmt.Sprintf("test_tx_%d", nonce)),
+			sender:    "foo",
+			nonce:     nonce,
+			timestamp: time.Now(),
+		}
+		wtxs[nonce] = wtx
+		txs.SetTx(wtx)
+	}
+
+	res := txs.GetTxsBySender("foo")
+	require.Equal(t, []*WrappedTx{wtxs[0], wtxs[1], wtxs[3], wtxs[5]}, res)
+	require.Empty(t, txs.GetTxsBySender("bar"))
+
+	require.Equal(t, []*WrappedTx{wtxs[3], wtxs[5]}, txs.GetDependentTxs(wtxs[1]))
+	require.Empty(t, txs.GetDependentTxs(wtxs[5]))
+
+	txs.RemoveTx(wtxs[1])
+	require.True(t, txs.IsTxRemoved(wtxs[1]))
+	require.Equal(t, []*WrappedTx{wtxs[0], wtxs[3], wtxs[5]}, txs.GetTxsBySender("foo"))
+	require.Nil(t, txs.GetTxBySender("foo", 1))
+
+	for _, nonce := range []uint64{0, 3, 5} {
+		txs.RemoveTx(wtxs[nonce])
+	}
+	require.Empty(t, txs.GetTxsBySender("foo"))
+	require.Empty(t, txs.senderTxs)
 }
 
 func TestTxStore_GetTxByHash(t *testing.T) {
@@ -228,3 +268,41 @@ func TestWrappedTxList_Remove(t *testing.T) {
 	sort.Ints(expected)
 	require.Equal(t, expected, got)
 }
+
+func TestTxHistory(t *testing.T) {
+	th := NewTxHistory(3)
+
+	for i := 0; i < 5; i++ {
+		th.Add(mempool.TxRecord{
+			Tx:     types.Tx(fmt.Sprintf("tx-%d", i)),
+			Status: mempool.TxStatusEvicted,
+		})
+	}
+
+	// only the last records are kept
+	for i := 0; i < 5; i++ {
+		record, ok := th.Get(mempool.TxKey(types.Tx(fmt.Sprintf("tx-%d", i))))
+		require.Equal(t, i >= 2, ok)
+		if ok {
+			require.Equal(t, mempool.TxStatusEvicted, record.Status)
+		}
+	}
+
+	// a record of the same transaction replaces the existing one, but keeps its
+	// place in the history
+	th.Add(mempool.TxRecord{Tx: types.Tx("tx-2"), Status: mempool.TxStatusCommitted})
+	record, ok := th.Get(mempool.TxKey(types.Tx("tx-2")))
+	require.True(t, ok)
+	require.Equal(t, mempool.TxStatusCommitted, record.Status)
+
+	th.Add(mempool.TxRecord{Tx: types.Tx("tx-5"), Status: mempool.TxStatusExpired})
+	_, ok = th.Get(mempool.TxKey(types.Tx("tx-2")))
+	require.False(t, ok)
+	record, ok = th.Get(mempool.TxKey(types.Tx("tx-5")))
+	require.True(t, ok)
+	require.Equal(t, mempool.TxStatusExpired, record.Status)
+
+	th.Reset()
+	_, ok = th.Get(mempool.TxKey(types.Tx("tx-5")))
+	require.False(t, ok)
+}
@@ -36,6 +36,7 @@ type chunkQueue struct {
 	chunkSenders   map[uint32]types.NodeID    // the peer who sent the given chunk
 	chunkAllocated map[uint32]bool            // chunks that have been allocated via Allocate()
 	chunkReturned  map[uint32]bool            // chunks returned via Next()
+	chunkSkipped   map[uint32]bool            // chunks already applied when resuming, never fetched
 	waiters        map[uint32][]chan<- uint32 // signals WaitFor() waiters about chunk arrival
 }
 
@@ -57,6 +58,7 @@ func newChunkQueue(snapshot *snapshot, tempDir string) (*chunkQueue, error) {
 		chunkSenders:   make(map[uint32]types.NodeID, snapshot.Chunks),
 		chunkAllocated: make(map[uint32]bool, snapshot.Chunks),
 		chunkReturned:  make(map[uint32]bool, snapshot.Chunks),
+		chunkSkipped:   make(map[uint32]bool),
 		waiters:        make(map[uint32][]chan<- uint32),
 	}, nil
 }
@@ -156,8 +158,8 @@ func (q *chunkQueue) Close() error {
 }
 
 // Discard discards a chunk. It will be removed from the queue, available for allocation, and can
-// be added and returned via Next() again. If the chunk is not already in the queue this does
-// nothing, to avoid it being allocated to multiple fetchers.
+// be added and returned via Next() again. If the chunk is not already in the queue or skipped this
+// does nothing, to avoid it being allocated to multiple fetchers.
 func (q *chunkQueue) Discard(index uint32) error {
 	q.Lock()
 	defer q.Unlock()
@@ -170,6 +172,13 @@ func (q *chunkQueue) discard(index uint32) error {
 		return nil
 	}
 
+	if q.chunkSkipped[index] {
+		delete(q.chunkSkipped, index)
+		delete(q.chunkReturned, index)
+		delete(q.chunkAllocated, index)
+		return nil
+	}
+
 	path := q.chunkFiles[index]
 	if path == "" {
 		return nil
@@ -307,11 +316,38 @@ func (q *chunkQueue) Retry(index uint32) {
 	delete(q.chunkReturned, index)
 }
 
-// RetryAll schedules all chunks to be retried, without refetching them.
+// RetryAll schedules all chunks to be retried, without refetching them. Skipped chunks, which
+// were never fetched, are scheduled for fetching.
 func (q *chunkQueue) RetryAll() {
 	q.Lock()
 	defer q.Unlock()
 	q.chunkReturned = make(map[uint32]bool)
+	for index := range q.chunkSkipped {
+		delete(q.chunkAllocated, index)
+	}
+	q.chunkSkipped = make(map[uint32]bool)
+}
+
+// Skip skips a chunk already applied to the app before the restore was resumed: it is neither
+// fetched nor returned via Next(), unless discarded.
+func (q *chunkQueue) Skip(index uint32) error {
+	q.Lock()
+	defer q.Unlock()
+
+	if q.snapshot == nil {
+		return nil
+	}
+	if index >= q.snapshot.Chunks {
+		return fmt.Errorf("cannot skip unexpected chunk %v", index)
+	}
+	if q.chunkAllocated[index] {
+		return fmt.Errorf("cannot skip chunk %v, which is already allocated", index)
+	}
+
+	q.chunkAllocated[index] = true
+	q.chunkReturned[index] = true
+	q.chunkSkipped[index] = true
+	return nil
 }
 
 // Size returns the total number of chunks for the snapshot and queue, or 0 when closed.
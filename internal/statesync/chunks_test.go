@@ -482,6 +482,61 @@ func TestChunkQueue_RetryAll(t *testing.T) {
 	assert.Equal(t, errDone, err)
 }
 
+func TestChunkQueue_Skip(t *testing.T) {
+	queue, teardown := setupChunkQueue(t)
+	defer teardown()
+
+	// Skipped chunks are neither allocated nor returned
+	require.NoError(t, queue.Skip(0))
+	require.NoError(t, queue.Skip(2))
+	require.Error(t, queue.Skip(5))
+
+	for _, expected := range []uint32{1, 3, 4} {
+		index, err := queue.Allocate()
+		require.NoError(t, err)
+		assert.Equal(t, expected, index)
+		_, err = queue.Add(&chunk{Height: 3, Format: 1, Index: index, Chunk: []byte{byte(index)}})
+		require.NoError(t, err)
+	}
+	_, err := queue.Allocate()
+	assert.Equal(t, errDone, err)
+	require.Error(t, queue.Skip(1))
+
+	for _, expected := range []uint32{1, 3, 4} {
+		c, err := queue.Next()
+		require.NoError(t, err)
+		assert.Equal(t, expected, c.Index)
+	}
+	_, err = queue.Next()
+	assert.Equal(t, errDone, err)
+
+	// A discarded skipped chunk is fetched and returned
+	require.NoError(t, queue.Discard(2))
+	index, err := queue.Allocate()
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, index)
+	_, err = queue.Add(&chunk{Height: 3, Format: 1, Index: 2, Chunk: []byte{2}})
+	require.NoError(t, err)
+	c, err := queue.Next()
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, c.Index)
+
+	// Retrying all chunks fetches the skipped ones, which are then returned in order
+	queue.RetryAll()
+	index, err = queue.Allocate()
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, index)
+	_, err = queue.Allocate()
+	assert.Equal(t, errDone, err)
+	_, err = queue.Add(&chunk{Height: 3, Format: 1, Index: 0, Chunk: []byte{0}})
+	require.NoError(t, err)
+	for i := uint32(0); i < queue.Size(); i++ {
+		c, err := queue.Next()
+		require.NoError(t, err)
+		assert.Equal(t, i, c.Index)
+	}
+}
+
 func TestChunkQueue_Size(t *testing.T) {
 	queue, teardown := setupChunkQueue(t)
 	defer teardown()
@@ -0,0 +1,341 @@
+package statesync
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/tendermint/tendermint/config"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/proxy"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	// localManifestFile is the manifest of a local snapshot, in its directory.
+	localManifestFile = "snapshot.json"
+	// localChunksDir holds the chunks of a local snapshot, one file per chunk
+	// named after its index.
+	localChunksDir = "chunks"
+)
+
+// LocalSnapshot is a snapshot stored in a local directory, e.g. a backup
+// copied from an object storage. The directory holds the manifest,
+// snapshot.json, and the chunks, in chunks/<index>.
+//
+// Along with the snapshot, the manifest holds the light blocks and the
+// consensus parameters needed to build the state at the snapshot height, so
+// that the snapshot can be restored from a trusted hash without any peer.
+type LocalSnapshot struct {
+	Height   uint64           `json:"height"`
+	Format   uint32           `json:"format"`
+	Chunks   uint32           `json:"chunks"`
+	Hash     tmbytes.HexBytes `json:"hash"`
+	Metadata []byte           `json:"metadata"`
+	// ChunkHashes holds the SHA-256 checksums of the chunks, if any, which
+	// are checked as the chunks are read.
+	ChunkHashes []tmbytes.HexBytes `json:"chunk_hashes,omitempty"`
+
+	// LightBlocks holds the light blocks at the snapshot height, and at the
+	// two following heights.
+	LightBlocks []*types.LightBlock `json:"light_blocks"`
+	// ConsensusParams holds the consensus parameters at the height following
+	// the snapshot height.
+	ConsensusParams types.ConsensusParams `json:"consensus_params"`
+
+	dir string
+}
+
+// LoadLocalSnapshot loads the snapshot stored in the given directory. It
+// checks that all its chunks are present.
+func LoadLocalSnapshot(dir string) (*LocalSnapshot, error) {
+	bz, err := ioutil.ReadFile(filepath.Join(dir, localManifestFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot manifest: %w", err)
+	}
+	s := &LocalSnapshot{}
+	if err := tmjson.Unmarshal(bz, s); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot manifest: %w", err)
+	}
+	s.dir = dir
+
+	if s.Height == 0 {
+		return nil, errors.New("snapshot has no height")
+	}
+	if s.Chunks == 0 {
+		return nil, errors.New("snapshot has no chunks")
+	}
+	if len(s.ChunkHashes) > 0 && len(s.ChunkHashes) != int(s.Chunks) {
+		return nil, fmt.Errorf("snapshot has %d chunks, but %d chunk checksums", s.Chunks, len(s.ChunkHashes))
+	}
+	for i := uint32(0); i < s.Chunks; i++ {
+		if _, err := os.Stat(s.chunkPath(i)); err != nil {
+			return nil, fmt.Errorf("missing chunk %d: %w", i, err)
+		}
+	}
+	return s, nil
+}
+
+// Chunk returns the chunk of the given index, checking its checksum if the
+// manifest has one.
+func (s *LocalSnapshot) Chunk(index uint32) ([]byte, error) {
+	if index >= s.Chunks {
+		return nil, fmt.Errorf("snapshot has no chunk %d, it has %d chunks", index, s.Chunks)
+	}
+	bz, err := ioutil.ReadFile(s.chunkPath(index))
+	if err != nil {
+		return nil, err
+	}
+	if len(s.ChunkHashes) > 0 {
+		if hash := sha256.Sum256(bz); !bytes.Equal(hash[:], s.ChunkHashes[index]) {
+			return nil, fmt.Errorf("chunk %d does not match its checksum %X", index, s.ChunkHashes[index])
+		}
+	}
+	return bz, nil
+}
+
+func (s *LocalSnapshot) chunkPath(index uint32) string {
+	return filepath.Join(s.dir, localChunksDir, strconv.FormatUint(uint64(index), 10))
+}
+
+func (s *LocalSnapshot) snapshot() *snapshot {
+	return &snapshot{
+		Height:   s.Height,
+		Format:   s.Format,
+		Chunks:   s.Chunks,
+		Hash:     s.Hash,
+		Metadata: s.Metadata,
+	}
+}
+
+// loadChunks adds the chunks allocated by the queue, until the context is
+// done.
+func (s *LocalSnapshot) loadChunks(ctx context.Context, chunks *chunkQueue) error {
+	for {
+		index, err := chunks.Allocate()
+		if errors.Is(err, errDone) {
+			// Keep checking until the context is canceled (restore is done), in case any
+			// chunks need to be applied again.
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(time.Second):
+				continue
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		bz, err := s.Chunk(index)
+		if err != nil {
+			return fmt.Errorf("failed to load chunk %d: %w", index, err)
+		}
+		if _, err := chunks.Add(&chunk{Height: s.Height, Format: s.Format, Index: index, Chunk: bz}); err != nil {
+			return err
+		}
+	}
+}
+
+// localStateProvider is a state provider using the light blocks and consensus
+// parameters of a local snapshot, verified from a trusted hash.
+type localStateProvider struct {
+	height  uint64
+	appHash []byte
+	commit  *types.Commit
+	state   sm.State
+}
+
+// NewLocalStateProvider creates a new StateProvider from the light blocks and
+// consensus parameters of a local snapshot. The light block at the snapshot
+// height must match the trusted hash, and the light blocks at the two
+// following heights are verified from it.
+func NewLocalStateProvider(
+	chainID string,
+	version sm.Version,
+	initialHeight int64,
+	s *LocalSnapshot,
+	trustedHash []byte,
+) (StateProvider, error) {
+	if len(s.LightBlocks) != 3 {
+		return nil, fmt.Errorf("expected the light blocks at heights %d to %d, got %d light blocks",
+			s.Height, s.Height+2, len(s.LightBlocks))
+	}
+	for i, lb := range s.LightBlocks {
+		if lb == nil || lb.SignedHeader == nil || lb.Height != int64(s.Height)+int64(i) {
+			return nil, fmt.Errorf("expected the light block at height %d", int64(s.Height)+int64(i))
+		}
+		if err := lb.ValidateBasic(chainID); err != nil {
+			return nil, fmt.Errorf("invalid light block at height %d: %w", lb.Height, err)
+		}
+	}
+
+	lastLightBlock, currentLightBlock, nextLightBlock := s.LightBlocks[0], s.LightBlocks[1], s.LightBlocks[2]
+	if !bytes.Equal(lastLightBlock.Hash(), trustedHash) {
+		return nil, fmt.Errorf("expected the header at height %d to have the trusted hash %X, got %X",
+			lastLightBlock.Height, trustedHash, lastLightBlock.Hash())
+	}
+	for i := 1; i < len(s.LightBlocks); i++ {
+		trusted, lb := s.LightBlocks[i-1], s.LightBlocks[i]
+		if !bytes.Equal(lb.LastBlockID.Hash, trusted.Hash()) {
+			return nil, fmt.Errorf("light block at height %d does not follow the light block at height %d",
+				lb.Height, trusted.Height)
+		}
+		// The light blocks come from the block store of a node, and the
+		// trusted hash is not subject to a trusting period, so only the
+		// validator sets and the commits are verified, not the header times.
+		if !bytes.Equal(lb.ValidatorsHash, trusted.NextValidatorsHash) {
+			return nil, fmt.Errorf("validators hash %X of light block at height %d does not match "+
+				"the next validators hash %X of the light block at height %d",
+				lb.ValidatorsHash, lb.Height, trusted.NextValidatorsHash, trusted.Height)
+		}
+		err := lb.ValidatorSet.VerifyCommitLight(chainID, lb.Commit.BlockID, lb.Height, lb.Commit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify light block at height %d: %w", lb.Height, err)
+		}
+	}
+
+	params := s.ConsensusParams
+	if !bytes.Equal(params.HashConsensusParams(), currentLightBlock.ConsensusHash) {
+		return nil, fmt.Errorf("consensus parameters do not match the consensus hash %X of height %d",
+			currentLightBlock.ConsensusHash, currentLightBlock.Height)
+	}
+
+	state := sm.State{
+		ChainID:       chainID,
+		Version:       version,
+		InitialHeight: initialHeight,
+	}
+	if state.InitialHeight == 0 {
+		state.InitialHeight = 1
+	}
+
+	// The heights of the light blocks map onto the state as in lightClientStateProvider.State.
+	state.LastBlockHeight = lastLightBlock.Height
+	state.LastBlockTime = lastLightBlock.Time
+	state.LastBlockID = lastLightBlock.Commit.BlockID
+	state.AppHash = currentLightBlock.AppHash
+	state.LastResultsHash = currentLightBlock.LastResultsHash
+	state.LastValidators = lastLightBlock.ValidatorSet
+	state.Validators = currentLightBlock.ValidatorSet
+	state.NextValidators = nextLightBlock.ValidatorSet
+	state.LastHeightValidatorsChanged = nextLightBlock.Height
+	state.ConsensusParams = params
+	state.LastHeightConsensusParamsChanged = currentLightBlock.Height
+
+	return &localStateProvider{
+		height:  s.Height,
+		appHash: currentLightBlock.AppHash,
+		commit:  lastLightBlock.Commit,
+		state:   state,
+	}, nil
+}
+
+// AppHash implements StateProvider.
+func (s *localStateProvider) AppHash(ctx context.Context, height uint64) ([]byte, error) {
+	if height != s.height {
+		return nil, fmt.Errorf("no app hash at height %d, only at the snapshot height %d", height, s.height)
+	}
+	return s.appHash, nil
+}
+
+// Commit implements StateProvider.
+func (s *localStateProvider) Commit(ctx context.Context, height uint64) (*types.Commit, error) {
+	if height != s.height {
+		return nil, fmt.Errorf("no commit at height %d, only at the snapshot height %d", height, s.height)
+	}
+	return s.commit, nil
+}
+
+// State implements StateProvider.
+func (s *localStateProvider) State(ctx context.Context, height uint64) (sm.State, error) {
+	if height != s.height {
+		return sm.State{}, fmt.Errorf("no state at height %d, only at the snapshot height %d", height, s.height)
+	}
+	return s.state, nil
+}
+
+// Restore restores the snapshot stored in the given directory into the app,
+// verified by the state provider, and bootstraps the state and block stores
+// with the state at the snapshot height. Unlike Reactor.Sync, it needs no
+// peers: the chunks are read from the directory. Like Reactor.Sync, it records
+// its progress to the progress file, and resumes an interrupted restore of the
+// snapshot if enabled.
+func Restore(
+	ctx context.Context,
+	cfg config.StateSyncConfig,
+	logger log.Logger,
+	conn proxy.AppConnSnapshot,
+	connQuery proxy.AppConnQuery,
+	stateProvider StateProvider,
+	stateStore sm.Store,
+	blockStore *store.BlockStore,
+	dir string,
+) (sm.State, error) {
+	local, err := LoadLocalSnapshot(dir)
+	if err != nil {
+		return sm.State{}, err
+	}
+
+	snapshot := local.snapshot()
+	snapshot.trustedAppHash, err = stateProvider.AppHash(ctx, snapshot.Height)
+	if err != nil {
+		return sm.State{}, fmt.Errorf("failed to get the app hash at height %d: %w", snapshot.Height, err)
+	}
+
+	chunks, err := newChunkQueue(snapshot, cfg.TempDir)
+	if err != nil {
+		return sm.State{}, err
+	}
+	defer chunks.Close()
+
+	// the chunks are loaded from the directory instead of being fetched from peers
+	cfg.Fetchers = 0
+	syncer := newSyncer(cfg, logger, conn, connQuery, stateProvider, nil, nil, cfg.TempDir, NopMetrics())
+	syncer.loadResumed()
+
+	loadCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	loadErr := make(chan error, 1)
+	go func() {
+		err := local.loadChunks(loadCtx, chunks)
+		if err != nil {
+			// unblock the restore waiting for the next chunk
+			chunks.Close()
+		}
+		loadErr <- err
+	}()
+
+	state, commit, err := syncer.Sync(ctx, snapshot, chunks)
+	cancel()
+	if err := <-loadErr; err != nil {
+		return sm.State{}, err
+	}
+	if err != nil {
+		return sm.State{}, err
+	}
+	syncer.removeProgress()
+
+	err = stateStore.Bootstrap(state)
+	if err != nil {
+		return sm.State{}, fmt.Errorf("failed to bootstrap node with new state: %w", err)
+	}
+
+	err = blockStore.SaveSeenCommit(state.LastBlockHeight, commit)
+	if err != nil {
+		return sm.State{}, fmt.Errorf("failed to store last seen commit: %w", err)
+	}
+
+	return state, nil
+}
@@ -0,0 +1,201 @@
+package statesync
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/test/factory"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/proxy"
+	proxymocks "github.com/tendermint/tendermint/proxy/mocks"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestNewLocalStateProvider(t *testing.T) {
+	s := makeLocalSnapshot(t, t.TempDir(), 10, [][]byte{{1}})
+	trustedHash := s.LightBlocks[0].Hash()
+
+	sp, err := NewLocalStateProvider(factory.DefaultTestChainID, sm.Version{}, 1, s, trustedHash)
+	require.NoError(t, err)
+
+	appHash, err := sp.AppHash(ctx, 10)
+	require.NoError(t, err)
+	require.EqualValues(t, s.LightBlocks[1].AppHash, appHash)
+	_, err = sp.AppHash(ctx, 11)
+	require.Error(t, err)
+
+	commit, err := sp.Commit(ctx, 10)
+	require.NoError(t, err)
+	require.Equal(t, s.LightBlocks[0].Commit, commit)
+
+	state, err := sp.State(ctx, 10)
+	require.NoError(t, err)
+	require.EqualValues(t, 10, state.LastBlockHeight)
+	require.Equal(t, s.LightBlocks[0].Commit.BlockID, state.LastBlockID)
+	require.EqualValues(t, s.LightBlocks[1].AppHash, state.AppHash)
+	require.Equal(t, s.LightBlocks[2].ValidatorSet.Hash(), state.NextValidators.Hash())
+	require.Equal(t, s.ConsensusParams, state.ConsensusParams)
+
+	testCases := map[string]func(s *LocalSnapshot) []byte{
+		"untrusted hash": func(s *LocalSnapshot) []byte {
+			return factory.RandomHash()
+		},
+		"missing light block": func(s *LocalSnapshot) []byte {
+			s.LightBlocks = s.LightBlocks[:2]
+			return trustedHash
+		},
+		"unverifiable light block": func(s *LocalSnapshot) []byte {
+			vals, _ := factory.RandValidatorSet(3, 10)
+			s.LightBlocks[2] = &types.LightBlock{SignedHeader: s.LightBlocks[2].SignedHeader, ValidatorSet: vals}
+			return trustedHash
+		},
+		"invalid commit": func(s *LocalSnapshot) []byte {
+			s.LightBlocks[2].Commit.Signatures[0].Signature[0] ^= 0xFF
+			s.LightBlocks[2].Commit.Signatures[1].Signature[0] ^= 0xFF
+			return trustedHash
+		},
+		"invalid consensus params": func(s *LocalSnapshot) []byte {
+			s.ConsensusParams.Block.MaxGas = 1000
+			return trustedHash
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			s := makeLocalSnapshot(t, t.TempDir(), 10, [][]byte{{1}})
+			trustedHash = s.LightBlocks[0].Hash()
+			_, err := NewLocalStateProvider(factory.DefaultTestChainID, sm.Version{}, 1, s, tc(s))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestRestore(t *testing.T) {
+	dir := t.TempDir()
+	chunks := [][]byte{{1, 1, 0}, {1, 1, 1}, {1, 1, 2}}
+	s := makeLocalSnapshot(t, dir, 10, chunks)
+	sp, err := NewLocalStateProvider(factory.DefaultTestChainID, sm.Version{}, 1, s, s.LightBlocks[0].Hash())
+	require.NoError(t, err)
+	appHash := s.LightBlocks[1].AppHash
+
+	connSnapshot := &proxymocks.AppConnSnapshot{}
+	connQuery := &proxymocks.AppConnQuery{}
+	connSnapshot.On("OfferSnapshotSync", mock.Anything, abci.RequestOfferSnapshot{
+		Snapshot: &abci.Snapshot{Height: 10, Format: 1, Chunks: 3, Hash: s.Hash},
+		AppHash:  appHash,
+	}).Return(&abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_ACCEPT}, nil)
+	// the app asks for chunk 0 to be applied again
+	connSnapshot.On("ApplySnapshotChunkSync", mock.Anything, abci.RequestApplySnapshotChunk{
+		Index: 1, Chunk: chunks[1],
+	}).Once().Return(&abci.ResponseApplySnapshotChunk{
+		Result:        abci.ResponseApplySnapshotChunk_ACCEPT,
+		RefetchChunks: []uint32{0},
+	}, nil)
+	connSnapshot.On("ApplySnapshotChunkSync", mock.Anything, abci.RequestApplySnapshotChunk{
+		Index: 0, Chunk: chunks[0],
+	}).Times(2).Return(&abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_ACCEPT}, nil)
+	connSnapshot.On("ApplySnapshotChunkSync", mock.Anything, abci.RequestApplySnapshotChunk{
+		Index: 2, Chunk: chunks[2],
+	}).Once().Return(&abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_ACCEPT}, nil)
+	connQuery.On("InfoSync", mock.Anything, proxy.RequestInfo).Return(&abci.ResponseInfo{
+		AppVersion:       9,
+		LastBlockHeight:  10,
+		LastBlockAppHash: appHash,
+	}, nil)
+
+	stateStore := sm.NewStore(dbm.NewMemDB())
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	cfg := config.DefaultStateSyncConfig()
+	cfg.TempDir = t.TempDir()
+	cfg.ProgressPath = filepath.Join(t.TempDir(), "statesync-progress.json")
+
+	state, err := Restore(ctx, *cfg, log.TestingLogger(), connSnapshot, connQuery, sp, stateStore, blockStore, dir)
+	require.NoError(t, err)
+	require.EqualValues(t, 10, state.LastBlockHeight)
+	require.EqualValues(t, 9, state.Version.Consensus.App)
+
+	stored, err := stateStore.Load()
+	require.NoError(t, err)
+	require.Equal(t, state.LastBlockID, stored.LastBlockID)
+	require.Equal(t, s.LightBlocks[0].Commit.Hash(), blockStore.LoadSeenCommit().Hash())
+
+	connSnapshot.AssertExpectations(t)
+	connQuery.AssertExpectations(t)
+
+	// a snapshot missing chunks can't be restored
+	require.NoError(t, os.Remove(filepath.Join(dir, localChunksDir, "2")))
+	_, err = Restore(ctx, *cfg, log.TestingLogger(), connSnapshot, connQuery, sp, stateStore, blockStore, dir)
+	require.Error(t, err)
+}
+
+// makeLocalSnapshot writes a snapshot at the given height with the given
+// chunks to dir, along with the light blocks and consensus params needed to
+// restore it, and returns it.
+func makeLocalSnapshot(t *testing.T, dir string, height uint64, chunks [][]byte) *LocalSnapshot {
+	t.Helper()
+
+	params := *types.DefaultConsensusParams()
+	vals, privVals := factory.RandValidatorSet(3, 10)
+	lastBlockID := factory.MakeBlockID()
+	// the blocks are an hour apart, which doesn't prevent their verification
+	blockTime := time.Now().Add(-3 * time.Hour)
+
+	lightBlocks := make([]*types.LightBlock, 3)
+	for i := range lightBlocks {
+		h := int64(height) + int64(i)
+		header, err := factory.MakeHeader(&types.Header{
+			Height:             h,
+			LastBlockID:        lastBlockID,
+			Time:               blockTime.Add(time.Duration(i) * time.Hour),
+			ValidatorsHash:     vals.Hash(),
+			NextValidatorsHash: vals.Hash(),
+			ConsensusHash:      params.HashConsensusParams(),
+			ProposerAddress:    vals.Proposer.Address,
+		})
+		require.NoError(t, err)
+		blockID := factory.MakeBlockIDWithHash(header.Hash())
+		voteSet := types.NewVoteSet(factory.DefaultTestChainID, h, 0, tmproto.PrecommitType, vals)
+		commit, err := factory.MakeCommit(blockID, h, 0, voteSet, privVals, header.Time)
+		require.NoError(t, err)
+
+		lightBlocks[i] = &types.LightBlock{
+			SignedHeader: &types.SignedHeader{Header: header, Commit: commit},
+			ValidatorSet: vals,
+		}
+		lastBlockID = blockID
+	}
+
+	s := &LocalSnapshot{
+		Height:          height,
+		Format:          1,
+		Chunks:          uint32(len(chunks)),
+		Hash:            factory.RandomHash(),
+		LightBlocks:     lightBlocks,
+		ConsensusParams: params,
+	}
+	bz, err := tmjson.Marshal(s)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, localManifestFile), bz, 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, localChunksDir), 0755))
+	for i, chunk := range chunks {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, localChunksDir, strconv.Itoa(i)), chunk, 0644))
+	}
+
+	s, err = LoadLocalSnapshot(dir)
+	require.NoError(t, err)
+	return s
+}
@@ -0,0 +1,91 @@
+package statesync
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "statesync"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Height of the snapshot being restored.
+	SnapshotHeight metrics.Gauge
+	// Number of chunks of the snapshot being restored.
+	SnapshotChunks metrics.Gauge
+	// Number of chunks of the snapshot fetched from peers.
+	ChunksFetched metrics.Gauge
+	// Number of chunks of the snapshot applied to the app, including those
+	// applied before the restore was resumed.
+	ChunksApplied metrics.Gauge
+	// Number of bytes of chunks received from a given peer.
+	ChunkBytesReceived metrics.Counter
+	// Estimated time left to restore the snapshot, in seconds.
+	RemainingTime metrics.Gauge
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		SnapshotHeight: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "snapshot_height",
+			Help:      "Height of the snapshot being restored.",
+		}, labels).With(labelsAndValues...),
+		SnapshotChunks: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "snapshot_chunks",
+			Help:      "Number of chunks of the snapshot being restored.",
+		}, labels).With(labelsAndValues...),
+		ChunksFetched: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "chunks_fetched",
+			Help:      "Number of chunks of the snapshot fetched from peers.",
+		}, labels).With(labelsAndValues...),
+		ChunksApplied: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "chunks_applied",
+			Help:      "Number of chunks of the snapshot applied to the app.",
+		}, labels).With(labelsAndValues...),
+		ChunkBytesReceived: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "chunk_bytes_received_total",
+			Help:      "Number of bytes of chunks received from a given peer.",
+		}, append(labels, "peer_id")).With(labelsAndValues...),
+		RemainingTime: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "remaining_time_seconds",
+			Help:      "Estimated time left to restore the snapshot, in seconds.",
+		}, labels).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		SnapshotHeight:     discard.NewGauge(),
+		SnapshotChunks:     discard.NewGauge(),
+		ChunksFetched:      discard.NewGauge(),
+		ChunksApplied:      discard.NewGauge(),
+		ChunkBytesReceived: discard.NewCounter(),
+		RemainingTime:      discard.NewGauge(),
+	}
+}
@@ -0,0 +1,301 @@
+package statesync
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"time"
+
+	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
+	"github.com/tendermint/tendermint/internal/libs/tempfile"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"github.com/tendermint/tendermint/types"
+)
+
+// Progress is the progress of the restore of a snapshot.
+type Progress struct {
+	SnapshotHeight uint64
+	SnapshotFormat uint32
+	Chunks         uint32
+
+	// ChunksFetched and ChunksApplied include the chunks applied before the
+	// restore was resumed, counted by ChunksResumed.
+	ChunksFetched uint32
+	ChunksApplied uint32
+	ChunksResumed uint32
+
+	Elapsed time.Duration
+	// RemainingTime is estimated from the chunks applied since the restore
+	// started, or 0 until one is.
+	RemainingTime time.Duration
+
+	Peers []PeerProgress
+}
+
+// PeerProgress is the progress of the chunks received from a peer.
+type PeerProgress struct {
+	PeerID types.NodeID
+	Chunks uint32
+	Bytes  uint64
+	// Throughput is in bytes per second since the restore started.
+	Throughput float64
+}
+
+// savedProgress is the progress of a restore persisted to the progress file:
+// the snapshot, and the chunks applied to the app.
+type savedProgress struct {
+	Height   uint64           `json:"height"`
+	Format   uint32           `json:"format"`
+	Chunks   uint32           `json:"chunks"`
+	Hash     tmbytes.HexBytes `json:"hash"`
+	Metadata tmbytes.HexBytes `json:"metadata"`
+	Applied  []uint32         `json:"applied"`
+}
+
+// matches returns true if the progress is the progress of the snapshot.
+func (p *savedProgress) matches(s *snapshot) bool {
+	saved := &snapshot{
+		Height:   p.Height,
+		Format:   p.Format,
+		Chunks:   p.Chunks,
+		Hash:     p.Hash,
+		Metadata: p.Metadata,
+	}
+	return saved.Key() == s.Key()
+}
+
+// loadProgress loads the progress persisted to the given file, or nil if there
+// is none.
+func loadProgress(file string) (*savedProgress, error) {
+	bz, err := ioutil.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	p := &savedProgress{}
+	if err := tmjson.Unmarshal(bz, p); err != nil {
+		return nil, fmt.Errorf("invalid progress file %s: %w", file, err)
+	}
+	for _, index := range p.Applied {
+		if index >= p.Chunks {
+			return nil, fmt.Errorf("invalid progress file %s: chunk %d out of %d", file, index, p.Chunks)
+		}
+	}
+	return p, nil
+}
+
+// removeProgress removes the progress file, if any.
+func removeProgress(file string) error {
+	if err := os.Remove(file); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// peerProgress is the chunks received from a peer.
+type peerProgress struct {
+	chunks uint32
+	bytes  uint64
+}
+
+// syncProgress tracks the progress of the restore of a snapshot, reports it to
+// the metrics, and persists the chunks applied to the app to the progress
+// file. A nil syncProgress tracks nothing.
+type syncProgress struct {
+	tmsync.Mutex
+	snapshot *snapshot
+	file     string
+	metrics  *Metrics
+	started  time.Time
+	fetched  map[uint32]bool
+	applied  map[uint32]bool
+	resumed  map[uint32]bool
+	peers    map[types.NodeID]*peerProgress
+}
+
+// newSyncProgress starts tracking the progress of the restore of a snapshot.
+func newSyncProgress(snapshot *snapshot, file string, metrics *Metrics) *syncProgress {
+	metrics.SnapshotHeight.Set(float64(snapshot.Height))
+	metrics.SnapshotChunks.Set(float64(snapshot.Chunks))
+	p := &syncProgress{
+		snapshot: snapshot,
+		file:     file,
+		metrics:  metrics,
+		started:  time.Now(),
+		fetched:  make(map[uint32]bool, snapshot.Chunks),
+		applied:  make(map[uint32]bool, snapshot.Chunks),
+		resumed:  make(map[uint32]bool),
+		peers:    make(map[types.NodeID]*peerProgress),
+	}
+	p.report()
+	return p
+}
+
+// Resume records a chunk applied before the restore was resumed.
+func (p *syncProgress) Resume(index uint32) {
+	if p == nil {
+		return
+	}
+	p.Lock()
+	defer p.Unlock()
+	p.fetched[index] = true
+	p.applied[index] = true
+	p.resumed[index] = true
+	p.report()
+}
+
+// Fetched records a chunk received from a peer.
+func (p *syncProgress) Fetched(index uint32, peerID types.NodeID, size int) {
+	if p == nil {
+		return
+	}
+	p.Lock()
+	defer p.Unlock()
+	p.fetched[index] = true
+	peer, ok := p.peers[peerID]
+	if !ok {
+		peer = &peerProgress{}
+		p.peers[peerID] = peer
+	}
+	peer.chunks++
+	peer.bytes += uint64(size)
+	p.metrics.ChunkBytesReceived.With("peer_id", string(peerID)).Add(float64(size))
+	p.report()
+}
+
+// Applied records a chunk applied to the app, and persists the progress.
+func (p *syncProgress) Applied(index uint32) error {
+	if p == nil {
+		return nil
+	}
+	p.Lock()
+	defer p.Unlock()
+	p.applied[index] = true
+	p.report()
+	return p.save()
+}
+
+// Discarded records a chunk discarded to be fetched and applied again, and
+// persists the progress.
+func (p *syncProgress) Discarded(index uint32) error {
+	if p == nil {
+		return nil
+	}
+	p.Lock()
+	defer p.Unlock()
+	delete(p.fetched, index)
+	delete(p.applied, index)
+	delete(p.resumed, index)
+	p.report()
+	return p.save()
+}
+
+// Reset records that the app restarts the restore of the snapshot from
+// scratch, with the chunks already fetched, and persists the progress.
+func (p *syncProgress) Reset() error {
+	if p == nil {
+		return nil
+	}
+	p.Lock()
+	defer p.Unlock()
+	// the chunks applied before the restore was resumed must be fetched
+	for index := range p.resumed {
+		delete(p.fetched, index)
+	}
+	p.applied = make(map[uint32]bool, p.snapshot.Chunks)
+	p.resumed = make(map[uint32]bool)
+	p.started = time.Now()
+	p.report()
+	return p.save()
+}
+
+// Save persists the progress.
+func (p *syncProgress) Save() error {
+	if p == nil {
+		return nil
+	}
+	p.Lock()
+	defer p.Unlock()
+	return p.save()
+}
+
+// Progress returns the progress of the restore.
+func (p *syncProgress) Progress() *Progress {
+	p.Lock()
+	defer p.Unlock()
+
+	elapsed := time.Since(p.started)
+	progress := &Progress{
+		SnapshotHeight: p.snapshot.Height,
+		SnapshotFormat: p.snapshot.Format,
+		Chunks:         p.snapshot.Chunks,
+		ChunksFetched:  uint32(len(p.fetched)),
+		ChunksApplied:  uint32(len(p.applied)),
+		ChunksResumed:  uint32(len(p.resumed)),
+		Elapsed:        elapsed,
+		RemainingTime:  p.remainingTime(elapsed),
+		Peers:          make([]PeerProgress, 0, len(p.peers)),
+	}
+	for peerID, peer := range p.peers {
+		progress.Peers = append(progress.Peers, PeerProgress{
+			PeerID:     peerID,
+			Chunks:     peer.chunks,
+			Bytes:      peer.bytes,
+			Throughput: float64(peer.bytes) / elapsed.Seconds(),
+		})
+	}
+	sort.Slice(progress.Peers, func(i, j int) bool {
+		return progress.Peers[i].PeerID < progress.Peers[j].PeerID
+	})
+	return progress
+}
+
+// remainingTime estimates the time left to apply the remaining chunks, at the
+// pace of the chunks applied since the restore started. The caller must hold
+// the mutex lock.
+func (p *syncProgress) remainingTime(elapsed time.Duration) time.Duration {
+	applied, resumed := len(p.applied), len(p.resumed)
+	if applied <= resumed {
+		return 0
+	}
+	return elapsed / time.Duration(applied-resumed) * time.Duration(int(p.snapshot.Chunks)-applied)
+}
+
+// report reports the progress to the metrics. The caller must hold the mutex
+// lock.
+func (p *syncProgress) report() {
+	p.metrics.ChunksFetched.Set(float64(len(p.fetched)))
+	p.metrics.ChunksApplied.Set(float64(len(p.applied)))
+	p.metrics.RemainingTime.Set(p.remainingTime(time.Since(p.started)).Seconds())
+}
+
+// save persists the progress to the progress file. The caller must hold the
+// mutex lock.
+func (p *syncProgress) save() error {
+	saved := savedProgress{
+		Height:   p.snapshot.Height,
+		Format:   p.snapshot.Format,
+		Chunks:   p.snapshot.Chunks,
+		Hash:     p.snapshot.Hash,
+		Metadata: p.snapshot.Metadata,
+		Applied:  make([]uint32, 0, len(p.applied)),
+	}
+	for index := range p.applied {
+		saved.Applied = append(saved.Applied, index)
+	}
+	sort.Slice(saved.Applied, func(i, j int) bool { return saved.Applied[i] < saved.Applied[j] })
+
+	bz, err := tmjson.Marshal(saved)
+	if err != nil {
+		return err
+	}
+	if err := tempfile.WriteFileAtomic(p.file, bz, 0600); err != nil {
+		return fmt.Errorf("failed to save state sync progress to %s: %w", p.file, err)
+	}
+	return nil
+}
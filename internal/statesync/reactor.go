@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"reflect"
 	"runtime/debug"
 	"sort"
@@ -16,6 +17,7 @@ import (
 	"github.com/tendermint/tendermint/internal/p2p"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/libs/service"
+	lightp2p "github.com/tendermint/tendermint/light/provider/p2p"
 	ssproto "github.com/tendermint/tendermint/proto/tendermint/statesync"
 	"github.com/tendermint/tendermint/proxy"
 	sm "github.com/tendermint/tendermint/state"
@@ -119,14 +121,16 @@ type Reactor struct {
 
 	conn        proxy.AppConnSnapshot
 	connQuery   proxy.AppConnQuery
+	snapshots   *SnapshotStore
 	tempDir     string
+	metrics     *Metrics
 	snapshotCh  *p2p.Channel
 	chunkCh     *p2p.Channel
 	blockCh     *p2p.Channel
 	peerUpdates *p2p.PeerUpdates
 	closeCh     chan struct{}
 
-	dispatcher *dispatcher
+	dispatcher *lightp2p.Dispatcher
 
 	// This will only be set when a state sync is in progress. It is used to feed
 	// received snapshots and chunks into the sync.
@@ -138,6 +142,11 @@ type Reactor struct {
 // the service.Service interface. It accepts a logger, connections for snapshots
 // and querying, references to p2p Channels and a channel to listen for peer
 // updates on. Note, the reactor will close all p2p Channels when stopping.
+//
+// The snapshots of the snapshot store, if any, are served along with the
+// snapshots of the app. Without connections to the app, the reactor only
+// serves the snapshots of the store and the light blocks of the node, and
+// can't sync.
 func NewReactor(
 	cfg config.StateSyncConfig,
 	logger log.Logger,
@@ -147,19 +156,23 @@ func NewReactor(
 	peerUpdates *p2p.PeerUpdates,
 	stateStore sm.Store,
 	blockStore *store.BlockStore,
+	snapshots *SnapshotStore,
 	tempDir string,
+	metrics *Metrics,
 ) *Reactor {
 	r := &Reactor{
 		cfg:         cfg,
 		conn:        conn,
 		connQuery:   connQuery,
+		snapshots:   snapshots,
 		snapshotCh:  snapshotCh,
 		chunkCh:     chunkCh,
 		blockCh:     blockCh,
 		peerUpdates: peerUpdates,
 		closeCh:     make(chan struct{}),
 		tempDir:     tempDir,
-		dispatcher:  newDispatcher(blockCh.Out, lightBlockResponseTimeout),
+		metrics:     metrics,
+		dispatcher:  lightp2p.NewDispatcher(blockCh.Out, lightBlockResponseTimeout),
 		stateStore:  stateStore,
 		blockStore:  blockStore,
 	}
@@ -189,7 +202,7 @@ func (r *Reactor) OnStart() error {
 
 	go r.processPeerUpdates()
 
-	r.dispatcher.start()
+	r.dispatcher.Start()
 
 	return nil
 }
@@ -198,7 +211,7 @@ func (r *Reactor) OnStart() error {
 // blocking until they all exit.
 func (r *Reactor) OnStop() {
 	// tell the dispatcher to stop sending any more requests
-	r.dispatcher.stop()
+	r.dispatcher.Stop()
 
 	// Close closeCh to signal to all spawned goroutines to gracefully exit. All
 	// p2p Channels should execute Close().
@@ -242,6 +255,7 @@ func (r *Reactor) Sync(
 		r.snapshotCh.Out,
 		r.chunkCh.Out,
 		r.tempDir,
+		r.metrics,
 	)
 	r.mtx.Unlock()
 
@@ -275,6 +289,17 @@ func (r *Reactor) Sync(
 	return state, nil
 }
 
+// Progress returns the progress of the restore of a snapshot, or nil if the
+// reactor is not restoring one.
+func (r *Reactor) Progress() *Progress {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	if r.syncer == nil {
+		return nil
+	}
+	return r.syncer.Progress()
+}
+
 // Backfill sequentially fetches, verifies and stores light blocks in reverse
 // order. It does not stop verifying blocks until reaching a block with a height
 // and time that is less or equal to the stopHeight and stopTime. The
@@ -337,7 +362,7 @@ func (r *Reactor) backfill(
 					}
 					if err != nil {
 						queue.retry(height)
-						if errors.Is(err, errNoConnectedPeers) {
+						if errors.Is(err, lightp2p.ErrNoConnectedPeers) {
 							r.Logger.Info("backfill: no connected peers to fetch light blocks from; sleeping...",
 								"sleepTime", sleepTime)
 							time.Sleep(sleepTime)
@@ -353,7 +378,7 @@ func (r *Reactor) backfill(
 						queue.retry(height)
 						// As we are fetching blocks backwards, if this node doesn't have the block it likely doesn't
 						// have any prior ones, thus we remove it from the peer list.
-						r.dispatcher.removePeer(peer)
+						r.dispatcher.RemovePeer(peer)
 						continue
 					}
 
@@ -452,7 +477,7 @@ func (r *Reactor) backfill(
 
 // Dispatcher exposes the dispatcher so that a state provider can use it for
 // light client verification
-func (r *Reactor) Dispatcher() *dispatcher { //nolint:golint
+func (r *Reactor) Dispatcher() *lightp2p.Dispatcher {
 	return r.dispatcher
 }
 
@@ -537,11 +562,7 @@ func (r *Reactor) handleChunkMessage(envelope p2p.Envelope) error {
 			"chunk", msg.Index,
 			"peer", envelope.From,
 		)
-		resp, err := r.conn.LoadSnapshotChunkSync(context.Background(), abci.RequestLoadSnapshotChunk{
-			Height: msg.Height,
-			Format: msg.Format,
-			Chunk:  msg.Index,
-		})
+		resp, err := r.loadChunk(msg.Height, msg.Format, msg.Index)
 		if err != nil {
 			r.Logger.Error(
 				"failed to load chunk",
@@ -640,7 +661,7 @@ func (r *Reactor) handleLightBlockMessage(envelope p2p.Envelope) error {
 		}
 
 	case *ssproto.LightBlockResponse:
-		if err := r.dispatcher.respond(msg.LightBlock, envelope.From); err != nil {
+		if err := r.dispatcher.Respond(msg.LightBlock, envelope.From); err != nil {
 			r.Logger.Error("error processing light block response", "err", err)
 		}
 
@@ -742,13 +763,13 @@ func (r *Reactor) processPeerUpdate(peerUpdate p2p.PeerUpdate) {
 		if r.syncer != nil {
 			r.syncer.AddPeer(peerUpdate.NodeID)
 		}
-		r.dispatcher.addPeer(peerUpdate.NodeID)
+		r.dispatcher.AddPeer(peerUpdate.NodeID)
 
 	case p2p.PeerStatusDown:
 		if r.syncer != nil {
 			r.syncer.RemovePeer(peerUpdate.NodeID)
 		}
-		r.dispatcher.removePeer(peerUpdate.NodeID)
+		r.dispatcher.RemovePeer(peerUpdate.NodeID)
 	}
 }
 
@@ -770,16 +791,46 @@ func (r *Reactor) processPeerUpdates() {
 	}
 }
 
-// recentSnapshots fetches the n most recent snapshots from the app
+// recentSnapshots fetches the n most recent snapshots from the app and the
+// snapshot store. The snapshots of the app take precedence over the snapshots
+// of the store at the same height and in the same format.
 func (r *Reactor) recentSnapshots(n uint32) ([]*snapshot, error) {
-	resp, err := r.conn.ListSnapshotsSync(context.Background(), abci.RequestListSnapshots{})
-	if err != nil {
-		return nil, err
+	var snapshots []*snapshot
+	if r.conn != nil {
+		resp, err := r.conn.ListSnapshotsSync(context.Background(), abci.RequestListSnapshots{})
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range resp.Snapshots {
+			snapshots = append(snapshots, &snapshot{
+				Height:   s.Height,
+				Format:   s.Format,
+				Chunks:   s.Chunks,
+				Hash:     s.Hash,
+				Metadata: s.Metadata,
+			})
+		}
 	}
 
-	sort.Slice(resp.Snapshots, func(i, j int) bool {
-		a := resp.Snapshots[i]
-		b := resp.Snapshots[j]
+	if r.snapshots != nil {
+		stored, err := r.snapshots.List()
+		if err != nil {
+			return nil, err
+		}
+	STORED:
+		for _, local := range stored {
+			for _, s := range snapshots {
+				if s.Height == local.Height && s.Format == local.Format {
+					continue STORED
+				}
+			}
+			snapshots = append(snapshots, local.snapshot())
+		}
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		a := snapshots[i]
+		b := snapshots[j]
 
 		switch {
 		case a.Height > b.Height:
@@ -791,40 +842,77 @@ func (r *Reactor) recentSnapshots(n uint32) ([]*snapshot, error) {
 		}
 	})
 
-	snapshots := make([]*snapshot, 0, n)
-	for i, s := range resp.Snapshots {
-		if i >= recentSnapshots {
-			break
-		}
+	if len(snapshots) > int(n) {
+		snapshots = snapshots[:n]
+	}
+
+	return snapshots, nil
+}
 
-		snapshots = append(snapshots, &snapshot{
-			Height:   s.Height,
-			Format:   s.Format,
-			Chunks:   s.Chunks,
-			Hash:     s.Hash,
-			Metadata: s.Metadata,
+// loadChunk loads a chunk from the app, or from the snapshot store if the app
+// doesn't have it, e.g. once it pruned the snapshot. A nil chunk is returned
+// if neither has it.
+func (r *Reactor) loadChunk(height uint64, format, index uint32) (*abci.ResponseLoadSnapshotChunk, error) {
+	if r.conn != nil {
+		resp, err := r.conn.LoadSnapshotChunkSync(context.Background(), abci.RequestLoadSnapshotChunk{
+			Height: height,
+			Format: format,
+			Chunk:  index,
 		})
+		if err != nil || resp.Chunk != nil || r.snapshots == nil {
+			return resp, err
+		}
 	}
 
-	return snapshots, nil
+	if r.snapshots == nil {
+		return &abci.ResponseLoadSnapshotChunk{}, nil
+	}
+	local, err := r.snapshots.Load(height, format)
+	if errors.Is(err, os.ErrNotExist) {
+		return &abci.ResponseLoadSnapshotChunk{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	bz, err := local.Chunk(index)
+	if err != nil {
+		return nil, err
+	}
+	return &abci.ResponseLoadSnapshotChunk{Chunk: bz}, nil
 }
 
 // fetchLightBlock works out whether the node has a light block at a particular
 // height and if so returns it so it can be gossiped to peers
 func (r *Reactor) fetchLightBlock(height uint64) (*types.LightBlock, error) {
-	h := int64(height)
+	return loadLightBlock(r.stateStore, r.blockStore, int64(height))
+}
 
-	blockMeta := r.blockStore.LoadBlockMeta(h)
+// loadLightBlock loads the light block at the given height, or the latest one
+// if the height is 0, from the block and state stores. It returns nil if the
+// stores don't hold it.
+func loadLightBlock(stateStore sm.Store, blockStore *store.BlockStore, h int64) (*types.LightBlock, error) {
+	if h == 0 {
+		h = blockStore.Height()
+	}
+
+	blockMeta := blockStore.LoadBlockMeta(h)
 	if blockMeta == nil {
 		return nil, nil
 	}
 
-	commit := r.blockStore.LoadBlockCommit(h)
+	// the latest light block is committed by the seen commit until the next
+	// block is stored
+	commit := blockStore.LoadBlockCommit(h)
+	if commit == nil {
+		if seenCommit := blockStore.LoadSeenCommit(); seenCommit != nil && seenCommit.Height == h {
+			commit = seenCommit
+		}
+	}
 	if commit == nil {
 		return nil, nil
 	}
 
-	vals, err := r.stateStore.LoadValidators(h)
+	vals, err := stateStore.LoadValidators(h)
 	if err != nil {
 		return nil, err
 	}
@@ -839,5 +927,4 @@ func (r *Reactor) fetchLightBlock(height uint64) (*types.LightBlock, error) {
 		},
 		ValidatorSet: vals,
 	}, nil
-
 }
@@ -3,6 +3,8 @@ package statesync
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -19,6 +21,7 @@ import (
 	"github.com/tendermint/tendermint/internal/test/factory"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/light/provider"
+	lightp2p "github.com/tendermint/tendermint/light/provider/p2p"
 	ssproto "github.com/tendermint/tendermint/proto/tendermint/statesync"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	proxymocks "github.com/tendermint/tendermint/proxy/mocks"
@@ -55,6 +58,7 @@ type reactorTestSuite struct {
 
 	stateStore *smmocks.Store
 	blockStore *store.BlockStore
+	snapshots  *SnapshotStore
 }
 
 func setup(
@@ -120,8 +124,12 @@ func setup(
 
 	rts.stateStore = &smmocks.Store{}
 	rts.blockStore = store.NewBlockStore(dbm.NewMemDB())
+	snapshots, err := NewSnapshotStore(t.TempDir())
+	require.NoError(t, err)
+	rts.snapshots = snapshots
 
 	cfg := config.DefaultStateSyncConfig()
+	cfg.ProgressPath = filepath.Join(t.TempDir(), "statesync-progress.json")
 
 	rts.reactor = NewReactor(
 		*cfg,
@@ -134,11 +142,13 @@ func setup(
 		rts.peerUpdates,
 		rts.stateStore,
 		rts.blockStore,
+		rts.snapshots,
 		"",
+		NopMetrics(),
 	)
 
 	// override the dispatcher with one with a shorter timeout
-	rts.reactor.dispatcher = newDispatcher(rts.blockChannel.Out, 1*time.Second)
+	rts.reactor.dispatcher = lightp2p.NewDispatcher(rts.blockChannel.Out, 1*time.Second)
 
 	rts.syncer = newSyncer(
 		*cfg,
@@ -149,6 +159,7 @@ func setup(
 		rts.snapshotOutCh,
 		rts.chunkOutCh,
 		"",
+		NopMetrics(),
 	)
 
 	require.NoError(t, rts.reactor.Start())
@@ -317,6 +328,42 @@ func TestReactor_SnapshotsRequest(t *testing.T) {
 	}
 }
 
+func TestReactor_SnapshotStore(t *testing.T) {
+	// the app pruned the snapshot at height 5, which was exported to the store
+	conn := &proxymocks.AppConnSnapshot{}
+	conn.On("ListSnapshotsSync", context.Background(), abci.RequestListSnapshots{}).Return(&abci.ResponseListSnapshots{
+		Snapshots: []*abci.Snapshot{{Height: 3, Format: 1, Chunks: 2, Hash: []byte{3, 1}}},
+	}, nil)
+	conn.On("LoadSnapshotChunkSync", context.Background(), abci.RequestLoadSnapshotChunk{
+		Height: 5, Format: 1, Chunk: 1,
+	}).Return(&abci.ResponseLoadSnapshotChunk{}, nil)
+
+	rts := setup(t, conn, nil, nil, 2)
+	dir := filepath.Join(rts.snapshots.dir, snapshotDirName(5, 1))
+	require.NoError(t, os.Mkdir(dir, 0755))
+	s := makeLocalSnapshot(t, dir, 5, [][]byte{{5, 0}, {5, 1}})
+	rts.snapshots.add(s)
+
+	rts.snapshotInCh <- p2p.Envelope{
+		From:    types.NodeID("aa"),
+		Message: &ssproto.SnapshotsRequest{},
+	}
+	retryUntil(t, func() bool { return len(rts.snapshotOutCh) == 2 }, time.Second)
+	require.Equal(t, &ssproto.SnapshotsResponse{Height: 5, Format: 1, Chunks: 2, Hash: s.Hash},
+		(<-rts.snapshotOutCh).Message)
+	require.Equal(t, &ssproto.SnapshotsResponse{Height: 3, Format: 1, Chunks: 2, Hash: []byte{3, 1}},
+		(<-rts.snapshotOutCh).Message)
+
+	rts.chunkInCh <- p2p.Envelope{
+		From:    types.NodeID("aa"),
+		Message: &ssproto.ChunkRequest{Height: 5, Format: 1, Index: 1},
+	}
+	response := <-rts.chunkOutCh
+	require.Equal(t, &ssproto.ChunkResponse{Height: 5, Format: 1, Index: 1, Chunk: []byte{5, 1}}, response.Message)
+
+	conn.AssertExpectations(t)
+}
+
 func TestReactor_LightBlockResponse(t *testing.T) {
 	rts := setup(t, nil, nil, nil, 2)
 
@@ -13,6 +13,7 @@ import (
 	"github.com/tendermint/tendermint/light"
 	lightprovider "github.com/tendermint/tendermint/light/provider"
 	lighthttp "github.com/tendermint/tendermint/light/provider/http"
+	lightp2p "github.com/tendermint/tendermint/light/provider/p2p"
 	lightrpc "github.com/tendermint/tendermint/light/rpc"
 	lightdb "github.com/tendermint/tendermint/light/store/db"
 	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
@@ -90,7 +91,7 @@ func NewLightClientStateProviderFromDispatcher(
 	chainID string,
 	version sm.Version,
 	initialHeight int64,
-	dispatcher *dispatcher,
+	dispatcher *lightp2p.Dispatcher,
 	trustOptions light.TrustOptions,
 	logger log.Logger,
 ) (StateProvider, error) {
@@ -101,7 +102,7 @@ func NewLightClientStateProviderFromDispatcher(
 
 	providersMap := make(map[lightprovider.Provider]string)
 	for _, p := range providers {
-		providersMap[p] = p.(*blockProvider).String()
+		providersMap[p] = p.(*lightp2p.BlockProvider).String()
 	}
 
 	lc, err := light.NewClient(ctx, chainID, trustOptions, providers[0], providers[1:],
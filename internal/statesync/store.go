@@ -0,0 +1,239 @@
+package statesync
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"github.com/tendermint/tendermint/proxy"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
+	"github.com/tendermint/tendermint/types"
+)
+
+// SnapshotStore is a directory of snapshots exported from the app, each in a
+// subdirectory named <height>-<format> in the format of LocalSnapshot. The node
+// serves them to its peers along with the snapshots of the app, so that they
+// remain available once the app has pruned them.
+//
+// The manifests of the snapshots are loaded when opening the store, and kept
+// in memory, so that the requests of peers don't read them from disk.
+type SnapshotStore struct {
+	dir string
+
+	mtx       tmsync.RWMutex
+	snapshots []*LocalSnapshot // by descending height and format
+}
+
+// NewSnapshotStore opens the snapshot store in the given directory, which is
+// created by the first export, loading the manifests of its snapshots.
+func NewSnapshotStore(dir string) (*SnapshotStore, error) {
+	snapshots, err := loadSnapshots(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &SnapshotStore{dir: dir, snapshots: snapshots}, nil
+}
+
+// List returns the snapshots of the store, by descending height and format.
+func (s *SnapshotStore) List() ([]*LocalSnapshot, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	return append([]*LocalSnapshot(nil), s.snapshots...), nil
+}
+
+// Load returns the snapshot of the given height and format. An error wrapping
+// os.ErrNotExist is returned if the store doesn't have it.
+func (s *SnapshotStore) Load(height uint64, format uint32) (*LocalSnapshot, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	for _, local := range s.snapshots {
+		if local.Height == height && local.Format == format {
+			return local, nil
+		}
+	}
+	return nil, fmt.Errorf("snapshot at height %d in format %d: %w", height, format, os.ErrNotExist)
+}
+
+// add adds the given snapshot, just exported, to the snapshots of the store.
+func (s *SnapshotStore) add(local *LocalSnapshot) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.snapshots = append(s.snapshots, local)
+	sortSnapshots(s.snapshots)
+}
+
+// loadSnapshots loads the snapshots of the store in the given directory, by
+// descending height and format.
+func loadSnapshots(dir string) ([]*LocalSnapshot, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]*LocalSnapshot, 0, len(entries))
+	for _, entry := range entries {
+		var (
+			height uint64
+			format uint32
+		)
+		// skip anything but snapshots, e.g. exports in progress
+		_, err := fmt.Sscanf(entry.Name(), "%d-%d", &height, &format)
+		if err != nil || !entry.IsDir() || entry.Name() != snapshotDirName(height, format) {
+			continue
+		}
+
+		local, err := LoadLocalSnapshot(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("invalid snapshot %s: %w", entry.Name(), err)
+		}
+		snapshots = append(snapshots, local)
+	}
+
+	sortSnapshots(snapshots)
+	return snapshots, nil
+}
+
+// sortSnapshots sorts the given snapshots by descending height and format.
+func sortSnapshots(snapshots []*LocalSnapshot) {
+	sort.Slice(snapshots, func(i, j int) bool {
+		a, b := snapshots[i], snapshots[j]
+		return a.Height > b.Height || (a.Height == b.Height && a.Format > b.Format)
+	})
+}
+
+// Export asks the app for its snapshot at the given height, or for its latest
+// snapshot if the height is 0, and writes it to the store, along with the
+// checksums of its chunks, and the light blocks and the consensus parameters
+// needed to restore it. The block and state stores must hold the two blocks
+// following the snapshot height.
+func (s *SnapshotStore) Export(
+	ctx context.Context,
+	conn proxy.AppConnSnapshot,
+	stateStore sm.Store,
+	blockStore *store.BlockStore,
+	height uint64,
+) (*LocalSnapshot, error) {
+	resp, err := conn.ListSnapshotsSync(ctx, abci.RequestListSnapshots{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the snapshots of the app: %w", err)
+	}
+	var snapshot *abci.Snapshot
+	for _, as := range resp.Snapshots {
+		if height != 0 && as.Height != height {
+			continue
+		}
+		if snapshot == nil || as.Height > snapshot.Height ||
+			(as.Height == snapshot.Height && as.Format > snapshot.Format) {
+			snapshot = as
+		}
+	}
+	switch {
+	case snapshot == nil && height == 0:
+		return nil, errors.New("the app has no snapshot")
+	case snapshot == nil:
+		return nil, fmt.Errorf("the app has no snapshot at height %d", height)
+	case snapshot.Chunks == 0:
+		return nil, fmt.Errorf("snapshot at height %d has no chunks", snapshot.Height)
+	}
+
+	dir := filepath.Join(s.dir, snapshotDirName(snapshot.Height, snapshot.Format))
+	if _, err := os.Stat(dir); err == nil {
+		return nil, fmt.Errorf("snapshot at height %d in format %d was already exported",
+			snapshot.Height, snapshot.Format)
+	}
+
+	local := &LocalSnapshot{
+		Height:      snapshot.Height,
+		Format:      snapshot.Format,
+		Chunks:      snapshot.Chunks,
+		Hash:        snapshot.Hash,
+		Metadata:    snapshot.Metadata,
+		ChunkHashes: make([]tmbytes.HexBytes, snapshot.Chunks),
+		LightBlocks: make([]*types.LightBlock, 3),
+	}
+	for i := range local.LightBlocks {
+		h := int64(snapshot.Height) + int64(i)
+		lb, err := loadLightBlock(stateStore, blockStore, h)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the light block at height %d: %w", h, err)
+		}
+		if lb == nil {
+			return nil, fmt.Errorf("no light block at height %d, the node must be synced up to height %d",
+				h, snapshot.Height+2)
+		}
+		local.LightBlocks[i] = lb
+	}
+	local.ConsensusParams, err = stateStore.LoadConsensusParams(int64(snapshot.Height) + 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the consensus params at height %d: %w", snapshot.Height+1, err)
+	}
+
+	// the snapshot is written to a temporary directory, and moved into the
+	// store once complete
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return nil, err
+	}
+	tempDir, err := ioutil.TempDir(s.dir, ".export-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tempDir)
+	if err := os.Mkdir(filepath.Join(tempDir, localChunksDir), 0755); err != nil {
+		return nil, err
+	}
+	local.dir = tempDir
+
+	for i := uint32(0); i < snapshot.Chunks; i++ {
+		resp, err := conn.LoadSnapshotChunkSync(ctx, abci.RequestLoadSnapshotChunk{
+			Height: snapshot.Height,
+			Format: snapshot.Format,
+			Chunk:  i,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load chunk %d: %w", i, err)
+		}
+		if resp.Chunk == nil {
+			return nil, fmt.Errorf("the app has no chunk %d, the snapshot may have been pruned", i)
+		}
+		hash := sha256.Sum256(resp.Chunk)
+		local.ChunkHashes[i] = hash[:]
+		if err := ioutil.WriteFile(local.chunkPath(i), resp.Chunk, 0644); err != nil {
+			return nil, err
+		}
+	}
+
+	bz, err := tmjson.MarshalIndent(local, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(filepath.Join(tempDir, localManifestFile), bz, 0644); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tempDir, dir); err != nil {
+		return nil, err
+	}
+	local.dir = dir
+	s.add(local)
+	return local, nil
+}
+
+// snapshotDirName returns the name of the directory of a snapshot in the store.
+func snapshotDirName(height uint64, format uint32) string {
+	return fmt.Sprintf("%d-%d", height, format)
+}
@@ -0,0 +1,150 @@
+package statesync
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/internal/test/factory"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	proxymocks "github.com/tendermint/tendermint/proxy/mocks"
+	sm "github.com/tendermint/tendermint/state"
+	smmocks "github.com/tendermint/tendermint/state/mocks"
+	"github.com/tendermint/tendermint/store"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestSnapshotStore_Export(t *testing.T) {
+	params := *types.DefaultConsensusParams()
+	vals, privVals := factory.RandValidatorSet(3, 10)
+	blockStore := makeBlockStore(t, vals, privVals, params, 10, 13)
+	stateStore := &smmocks.Store{}
+	stateStore.On("LoadValidators", mock.Anything).Return(vals, nil)
+	stateStore.On("LoadConsensusParams", mock.Anything).Return(params, nil)
+
+	chunks := [][]byte{{1, 0}, {1, 1}}
+	conn := &proxymocks.AppConnSnapshot{}
+	conn.On("ListSnapshotsSync", ctx, abci.RequestListSnapshots{}).Return(&abci.ResponseListSnapshots{
+		Snapshots: []*abci.Snapshot{
+			{Height: 5, Format: 1, Chunks: 1, Hash: []byte{5}},
+			{Height: 10, Format: 1, Chunks: 2, Hash: []byte{10, 1}},
+			{Height: 10, Format: 2, Chunks: 2, Hash: []byte{10, 2}},
+			{Height: 11, Format: 1, Chunks: 1, Hash: []byte{11}},
+		},
+	}, nil)
+	for i, chunk := range chunks {
+		conn.On("LoadSnapshotChunkSync", ctx, abci.RequestLoadSnapshotChunk{
+			Height: 10, Format: 2, Chunk: uint32(i),
+		}).Return(&abci.ResponseLoadSnapshotChunk{Chunk: chunk}, nil)
+	}
+	conn.On("LoadSnapshotChunkSync", ctx, abci.RequestLoadSnapshotChunk{
+		Height: 11, Format: 1, Chunk: 0,
+	}).Return(&abci.ResponseLoadSnapshotChunk{}, nil)
+
+	dir := filepath.Join(t.TempDir(), "snapshots")
+	snapshots, err := NewSnapshotStore(dir)
+	require.NoError(t, err)
+	listed, err := snapshots.List()
+	require.NoError(t, err)
+	require.Empty(t, listed)
+
+	_, err = snapshots.Export(ctx, conn, stateStore, blockStore, 0)
+	require.Error(t, err, "pruned snapshot")
+	_, err = snapshots.Export(ctx, conn, stateStore, blockStore, 5)
+	require.Error(t, err, "missing blocks")
+	_, err = snapshots.Export(ctx, conn, stateStore, blockStore, 7)
+	require.Error(t, err, "no snapshot")
+
+	// the highest format is exported
+	exported, err := snapshots.Export(ctx, conn, stateStore, blockStore, 10)
+	require.NoError(t, err)
+	require.EqualValues(t, 10, exported.Height)
+	require.EqualValues(t, 2, exported.Format)
+	require.Len(t, exported.ChunkHashes, 2)
+
+	_, err = snapshots.Export(ctx, conn, stateStore, blockStore, 10)
+	require.Error(t, err, "already exported")
+
+	listed, err = snapshots.List()
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	s, err := snapshots.Load(10, 2)
+	require.NoError(t, err)
+	require.Equal(t, exported.Hash, s.Hash)
+	_, err = snapshots.Load(10, 1)
+	require.True(t, errors.Is(err, os.ErrNotExist), err)
+
+	// the exported snapshot is loaded when reopening the store
+	reopened, err := NewSnapshotStore(dir)
+	require.NoError(t, err)
+	listed, err = reopened.List()
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	require.Equal(t, exported.Hash, listed[0].Hash)
+	for i, chunk := range chunks {
+		bz, err := s.Chunk(uint32(i))
+		require.NoError(t, err)
+		require.Equal(t, chunk, bz)
+	}
+
+	// the exported snapshot can be restored from the hash of the header at its height
+	_, err = NewLocalStateProvider(factory.DefaultTestChainID, sm.Version{}, 1, s,
+		blockStore.LoadBlockMeta(10).BlockID.Hash)
+	require.NoError(t, err)
+
+	// a corrupted chunk is detected
+	require.NoError(t, ioutil.WriteFile(s.chunkPath(1), []byte{1, 2}, 0644))
+	_, err = s.Chunk(1)
+	require.Error(t, err)
+
+	// a missing chunk makes the store fail to open, instead of being skipped
+	require.NoError(t, os.Remove(s.chunkPath(1)))
+	_, err = NewSnapshotStore(dir)
+	require.Error(t, err)
+
+	conn.AssertExpectations(t)
+}
+
+// makeBlockStore returns a block store holding the blocks of the given heights,
+// committed by the validators.
+func makeBlockStore(
+	t *testing.T,
+	vals *types.ValidatorSet,
+	privVals []types.PrivValidator,
+	params types.ConsensusParams,
+	from, to int64,
+) *store.BlockStore {
+	t.Helper()
+
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	lastBlockID := factory.MakeBlockID()
+	lastCommit := &types.Commit{Height: from - 1, BlockID: lastBlockID}
+	for h := from; h <= to; h++ {
+		block := types.MakeBlock(h, nil, lastCommit, nil)
+		block.ChainID = factory.DefaultTestChainID
+		block.Time = time.Now().Add(time.Duration(h-to) * time.Minute)
+		block.LastBlockID = lastBlockID
+		block.ValidatorsHash = vals.Hash()
+		block.NextValidatorsHash = vals.Hash()
+		block.ConsensusHash = params.HashConsensusParams()
+		block.ProposerAddress = vals.Proposer.Address
+
+		parts := block.MakePartSet(types.BlockPartSizeBytes)
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+		voteSet := types.NewVoteSet(factory.DefaultTestChainID, h, 0, tmproto.PrecommitType, vals)
+		commit, err := factory.MakeCommit(blockID, h, 0, voteSet, privVals, block.Time)
+		require.NoError(t, err)
+
+		blockStore.SaveBlock(block, parts, commit)
+		lastBlockID, lastCommit = blockID, commit
+	}
+	return blockStore
+}
@@ -64,9 +64,17 @@ type syncer struct {
 	tempDir       string
 	fetchers      int32
 	retryTimeout  time.Duration
+	progressFile  string
+	resume        bool
+	metrics       *Metrics
 
-	mtx    tmsync.RWMutex
-	chunks *chunkQueue
+	// resumed is the progress of an interrupted restore loaded by SyncAny(),
+	// until the restore of its snapshot is resumed.
+	resumed *savedProgress
+
+	mtx      tmsync.RWMutex
+	chunks   *chunkQueue
+	progress *syncProgress
 }
 
 // newSyncer creates a new syncer.
@@ -78,6 +86,7 @@ func newSyncer(
 	stateProvider StateProvider,
 	snapshotCh, chunkCh chan<- p2p.Envelope,
 	tempDir string,
+	metrics *Metrics,
 ) *syncer {
 	return &syncer{
 		logger:        logger,
@@ -90,6 +99,9 @@ func newSyncer(
 		tempDir:       tempDir,
 		fetchers:      cfg.Fetchers,
 		retryTimeout:  cfg.ChunkRequestTimeout,
+		progressFile:  cfg.ProgressFile(),
+		resume:        cfg.Resume,
+		metrics:       metrics,
 	}
 }
 
@@ -106,6 +118,7 @@ func (s *syncer) AddChunk(chunk *chunk) (bool, error) {
 		return false, err
 	}
 	if added {
+		s.progress.Fetched(chunk.Index, chunk.Sender, len(chunk.Chunk))
 		s.logger.Debug("Added chunk to queue", "height", chunk.Height, "format", chunk.Format,
 			"chunk", chunk.Index)
 	} else {
@@ -139,6 +152,16 @@ func (s *syncer) AddPeer(peerID types.NodeID) {
 	}
 }
 
+// Progress returns the progress of the restore in progress, or nil if there is none.
+func (s *syncer) Progress() *Progress {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	if s.progress == nil {
+		return nil
+	}
+	return s.progress.Progress()
+}
+
 // RemovePeer removes a peer from the pool.
 func (s *syncer) RemovePeer(peerID types.NodeID) {
 	s.logger.Debug("Removing peer from sync", "peer", peerID)
@@ -158,6 +181,8 @@ func (s *syncer) SyncAny(
 		discoveryTime = minimumDiscoveryTime
 	}
 
+	s.loadResumed()
+
 	if discoveryTime > 0 {
 		requestSnapshots()
 		s.logger.Info(fmt.Sprintf("Discovering snapshots for %v", discoveryTime))
@@ -174,7 +199,7 @@ func (s *syncer) SyncAny(
 	for {
 		// If not nil, we're going to retry restoration of the same snapshot.
 		if snapshot == nil {
-			snapshot = s.snapshots.Best()
+			snapshot = s.bestSnapshot()
 			chunks = nil
 		}
 		if snapshot == nil {
@@ -197,9 +222,11 @@ func (s *syncer) SyncAny(
 		newState, commit, err := s.Sync(ctx, snapshot, chunks)
 		switch {
 		case err == nil:
+			s.removeProgress()
 			return newState, commit, nil
 
 		case errors.Is(err, errAbort):
+			s.removeProgress()
 			return sm.State{}, nil, err
 
 		case errors.Is(err, errRetrySnapshot):
@@ -243,6 +270,7 @@ func (s *syncer) SyncAny(
 		if err != nil {
 			s.logger.Error("Failed to clean up chunk queue", "err", err)
 		}
+		s.removeProgress()
 		snapshot = nil
 		chunks = nil
 	}
@@ -270,6 +298,21 @@ func (s *syncer) Sync(ctx context.Context, snapshot *snapshot, chunks *chunkQueu
 		return sm.State{}, nil, err
 	}
 
+	// Track the progress of the restore, skipping the chunks already applied
+	// if it is resumed.
+	progress, err := s.startProgress(snapshot, chunks)
+	if err != nil {
+		return sm.State{}, nil, err
+	}
+	s.mtx.Lock()
+	s.progress = progress
+	s.mtx.Unlock()
+	defer func() {
+		s.mtx.Lock()
+		s.progress = nil
+		s.mtx.Unlock()
+	}()
+
 	// Spawn chunk fetchers. They will terminate when the chunk queue is closed or context canceled.
 	fetchCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
@@ -320,6 +363,74 @@ func (s *syncer) Sync(ctx context.Context, snapshot *snapshot, chunks *chunkQueu
 	return state, commit, nil
 }
 
+// loadResumed loads the progress of an interrupted restore from the progress file, to be resumed
+// if enabled. Otherwise, the progress file is removed, since the restore starts from scratch.
+func (s *syncer) loadResumed() {
+	resumed, err := loadProgress(s.progressFile)
+	switch {
+	case err != nil:
+		s.logger.Error("Failed to load state sync progress, restoring from scratch", "err", err)
+		s.removeProgress()
+	case resumed == nil:
+	case !s.resume:
+		s.logger.Info("Found an interrupted restore, but resume is disabled, restoring from scratch",
+			"height", resumed.Height, "format", resumed.Format, "applied", len(resumed.Applied))
+		s.removeProgress()
+	default:
+		s.logger.Info("Found an interrupted restore to resume", "height", resumed.Height,
+			"format", resumed.Format, "applied", len(resumed.Applied), "chunks", resumed.Chunks)
+		s.resumed = resumed
+	}
+}
+
+// bestSnapshot returns the snapshot of the interrupted restore to resume, if discovered and not
+// rejected, or else the best snapshot of the pool.
+func (s *syncer) bestSnapshot() *snapshot {
+	if s.resumed != nil {
+		for _, snapshot := range s.snapshots.Ranked() {
+			if s.resumed.matches(snapshot) {
+				return snapshot
+			}
+		}
+	}
+	return s.snapshots.Best()
+}
+
+// startProgress starts tracking the progress of the restore of a snapshot accepted by the app. If
+// it is the snapshot of the interrupted restore, the chunks already applied are skipped, once.
+func (s *syncer) startProgress(snapshot *snapshot, chunks *chunkQueue) (*syncProgress, error) {
+	progress := newSyncProgress(snapshot, s.progressFile, s.metrics)
+	if s.resumed != nil && s.resumed.matches(snapshot) {
+		for _, index := range s.resumed.Applied {
+			if err := chunks.Skip(index); err != nil {
+				return nil, fmt.Errorf("failed to resume restore: %w", err)
+			}
+			progress.Resume(index)
+		}
+		s.logger.Info("Resuming restore", "height", snapshot.Height, "format", snapshot.Format,
+			"applied", len(s.resumed.Applied), "chunks", snapshot.Chunks)
+		s.resumed = nil
+	}
+	if err := progress.Save(); err != nil {
+		s.logger.Error("Failed to save state sync progress", "err", err)
+	}
+	return progress, nil
+}
+
+// getProgress returns the progress of the restore in progress, or nil if there is none.
+func (s *syncer) getProgress() *syncProgress {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return s.progress
+}
+
+// removeProgress removes the progress file, once the restore is complete or can't be resumed.
+func (s *syncer) removeProgress() {
+	if err := removeProgress(s.progressFile); err != nil {
+		s.logger.Error("Failed to remove state sync progress", "err", err)
+	}
+}
+
 // offerSnapshot offers a snapshot to the app. It returns various errors depending on the app's
 // response, or nil if the snapshot was accepted.
 func (s *syncer) offerSnapshot(ctx context.Context, snapshot *snapshot) error {
@@ -378,12 +489,22 @@ func (s *syncer) applyChunks(ctx context.Context, chunks *chunkQueue) error {
 		s.logger.Info("Applied snapshot chunk to ABCI app", "height", chunk.Height,
 			"format", chunk.Format, "chunk", chunk.Index, "total", chunks.Size())
 
+		// Record the chunk as applied before any refetch, which may include it
+		if resp.Result == abci.ResponseApplySnapshotChunk_ACCEPT {
+			if err := s.getProgress().Applied(chunk.Index); err != nil {
+				s.logger.Error("Failed to save state sync progress", "err", err)
+			}
+		}
+
 		// Discard and refetch any chunks as requested by the app
 		for _, index := range resp.RefetchChunks {
 			err := chunks.Discard(index)
 			if err != nil {
 				return fmt.Errorf("failed to discard chunk %v: %w", index, err)
 			}
+			if err := s.getProgress().Discarded(index); err != nil {
+				s.logger.Error("Failed to save state sync progress", "err", err)
+			}
 		}
 
 		// Reject any senders as requested by the app
@@ -405,6 +526,9 @@ func (s *syncer) applyChunks(ctx context.Context, chunks *chunkQueue) error {
 		case abci.ResponseApplySnapshotChunk_RETRY:
 			chunks.Retry(chunk.Index)
 		case abci.ResponseApplySnapshotChunk_RETRY_SNAPSHOT:
+			if err := s.getProgress().Reset(); err != nil {
+				s.logger.Error("Failed to save state sync progress", "err", err)
+			}
 			return errRetrySnapshot
 		case abci.ResponseApplySnapshotChunk_REJECT_SNAPSHOT:
 			return errRejectSnapshot
@@ -373,6 +373,138 @@ func TestSyncer_SyncAny_abciError(t *testing.T) {
 	rts.conn.AssertExpectations(t)
 }
 
+func TestSyncer_SyncAny_resume(t *testing.T) {
+	state := sm.State{ChainID: "chain", LastBlockHeight: 1, AppHash: []byte("app_hash")}
+	commit := &types.Commit{BlockID: types.BlockID{Hash: []byte("blockhash")}}
+	chunks := []*chunk{
+		{Height: 1, Format: 1, Index: 0, Chunk: []byte{1, 1, 0}},
+		{Height: 1, Format: 1, Index: 1, Chunk: []byte{1, 1, 1}},
+		{Height: 1, Format: 1, Index: 2, Chunk: []byte{1, 1, 2}},
+	}
+	s := &snapshot{Height: 1, Format: 1, Chunks: 3, Hash: []byte{1, 2, 3}}
+	peerID := types.NodeID("aa")
+
+	stateProvider := &mocks.StateProvider{}
+	stateProvider.On("AppHash", mock.Anything, uint64(1)).Return(state.AppHash, nil)
+	stateProvider.On("AppHash", mock.Anything, uint64(2)).Return([]byte("app_hash_2"), nil)
+	stateProvider.On("Commit", mock.Anything, uint64(1)).Return(commit, nil)
+	stateProvider.On("State", mock.Anything, uint64(1)).Return(state, nil)
+
+	rts := setup(t, nil, nil, stateProvider, 3)
+
+	var (
+		chunkRequests    = make(map[uint32]int)
+		chunkRequestsMtx tmsync.Mutex
+	)
+	go func() {
+		for e := range rts.chunkOutCh {
+			msg, ok := e.Message.(*ssproto.ChunkRequest)
+			assert.True(t, ok)
+
+			_, err := rts.syncer.AddChunk(&chunk{
+				Height: 1, Format: 1, Index: msg.Index, Chunk: chunks[msg.Index].Chunk, Sender: peerID,
+			})
+			assert.NoError(t, err)
+
+			chunkRequestsMtx.Lock()
+			chunkRequests[msg.Index]++
+			chunkRequestsMtx.Unlock()
+		}
+	}()
+
+	_, err := rts.syncer.AddSnapshot(peerID, s)
+	require.NoError(t, err)
+	rts.conn.On("OfferSnapshotSync", ctx, abci.RequestOfferSnapshot{
+		Snapshot: toABCI(s), AppHash: []byte("app_hash"),
+	}).Times(2).Return(&abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_ACCEPT}, nil)
+
+	// The restore fails after applying the first two chunks, which are recorded in the progress file
+	rts.conn.On("ApplySnapshotChunkSync", ctx, abci.RequestApplySnapshotChunk{
+		Index: 0, Chunk: chunks[0].Chunk, Sender: string(peerID),
+	}).Once().Return(&abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_ACCEPT}, nil)
+	rts.conn.On("ApplySnapshotChunkSync", ctx, abci.RequestApplySnapshotChunk{
+		Index: 1, Chunk: chunks[1].Chunk, Sender: string(peerID),
+	}).Once().Return(&abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_ACCEPT}, nil)
+	rts.conn.On("ApplySnapshotChunkSync", ctx, abci.RequestApplySnapshotChunk{
+		Index: 2, Chunk: chunks[2].Chunk, Sender: string(peerID),
+	}).Once().Return(nil, errors.New("boom"))
+
+	_, _, err = rts.syncer.SyncAny(ctx, 0, func() {})
+	require.Error(t, err)
+	require.Nil(t, rts.syncer.Progress())
+
+	saved, err := loadProgress(rts.syncer.progressFile)
+	require.NoError(t, err)
+	require.True(t, saved.matches(s))
+	require.Equal(t, []uint32{0, 1}, saved.Applied)
+
+	// Once resumed, the restore of the snapshot continues from the last chunk, even though a
+	// better snapshot was discovered in the meantime
+	rts.syncer.resume = true
+	s2 := &snapshot{Height: 2, Format: 1, Chunks: 3, Hash: []byte{1}}
+	_, err = rts.syncer.AddSnapshot(peerID, s2)
+	require.NoError(t, err)
+
+	var progress *Progress
+	rts.conn.On("ApplySnapshotChunkSync", ctx, abci.RequestApplySnapshotChunk{
+		Index: 2, Chunk: chunks[2].Chunk, Sender: string(peerID),
+	}).Once().Run(func(args mock.Arguments) {
+		progress = rts.syncer.Progress()
+	}).Return(&abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_ACCEPT}, nil)
+	rts.connQuery.On("InfoSync", ctx, proxy.RequestInfo).Return(&abci.ResponseInfo{
+		AppVersion:       9,
+		LastBlockHeight:  1,
+		LastBlockAppHash: []byte("app_hash"),
+	}, nil)
+
+	_, _, err = rts.syncer.SyncAny(ctx, 0, func() {})
+	require.NoError(t, err)
+
+	chunkRequestsMtx.Lock()
+	require.Equal(t, map[uint32]int{0: 1, 1: 1, 2: 2}, chunkRequests)
+	chunkRequestsMtx.Unlock()
+
+	require.NotNil(t, progress)
+	require.EqualValues(t, 1, progress.SnapshotHeight)
+	require.EqualValues(t, 3, progress.Chunks)
+	require.EqualValues(t, 3, progress.ChunksFetched)
+	require.EqualValues(t, 2, progress.ChunksApplied)
+	require.EqualValues(t, 2, progress.ChunksResumed)
+	require.Len(t, progress.Peers, 1)
+	require.Equal(t, peerID, progress.Peers[0].PeerID)
+	require.EqualValues(t, 1, progress.Peers[0].Chunks)
+	require.EqualValues(t, len(chunks[2].Chunk), progress.Peers[0].Bytes)
+
+	// The progress file is removed once the snapshot is restored
+	saved, err = loadProgress(rts.syncer.progressFile)
+	require.NoError(t, err)
+	require.Nil(t, saved)
+
+	rts.conn.AssertExpectations(t)
+	rts.connQuery.AssertExpectations(t)
+}
+
+func TestSyncer_SyncAny_resumeDisabled(t *testing.T) {
+	rts := setup(t, nil, nil, nil, 2)
+
+	s := &snapshot{Height: 1, Format: 1, Chunks: 3, Hash: []byte{1, 2, 3}}
+	progress := newSyncProgress(s, rts.syncer.progressFile, NopMetrics())
+	require.NoError(t, progress.Applied(0))
+
+	// The progress of an interrupted restore is discarded, unless resume is enabled
+	rts.syncer.loadResumed()
+	require.Nil(t, rts.syncer.resumed)
+	saved, err := loadProgress(rts.syncer.progressFile)
+	require.NoError(t, err)
+	require.Nil(t, saved)
+
+	require.NoError(t, progress.Applied(1))
+	rts.syncer.resume = true
+	rts.syncer.loadResumed()
+	require.NotNil(t, rts.syncer.resumed)
+	require.Equal(t, []uint32{0, 1}, rts.syncer.resumed.Applied)
+}
+
 func TestSyncer_offerSnapshot(t *testing.T) {
 	unknownErr := errors.New("unknown error")
 	boom := errors.New("boom")
@@ -0,0 +1,178 @@
+package light
+
+import (
+	"container/list"
+	"context"
+	"errors"
+
+	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
+	"github.com/tendermint/tendermint/light/provider"
+	"github.com/tendermint/tendermint/types"
+)
+
+// blockCache keeps the light blocks recently fetched from the providers, so
+// that a light block is fetched only once from a provider, e.g. when it is
+// verified by VerifyLightBlockAtHeight after being fetched by Update, or when
+// it has been prefetched ahead of verifySkipping. Concurrent requests of the
+// same light block share a single fetch. Errors are not cached.
+//
+// When the cache is full, the light blocks added first are evicted. A cache of
+// size 0 fetches every light block from the provider.
+type blockCache struct {
+	size int
+
+	mtx     tmsync.Mutex
+	entries map[blockCacheKey]*blockCacheEntry
+	order   *list.List // of blockCacheKey, in order of addition
+}
+
+// blockCacheKey identifies a light block of a provider. Providers must be
+// comparable, which is the case of pointers.
+type blockCacheKey struct {
+	provider provider.Provider
+	height   int64
+}
+
+type blockCacheEntry struct {
+	done chan struct{} // closed once fetched
+	lb   *types.LightBlock
+	err  error
+
+	elem *list.Element
+}
+
+func newBlockCache(size int) *blockCache {
+	return &blockCache{
+		size:    size,
+		entries: make(map[blockCacheKey]*blockCacheEntry),
+		order:   list.New(),
+	}
+}
+
+// lightBlock returns the light block of the given height from the provider,
+// fetching it unless it is cached or being fetched. The latest light block,
+// requested with a height of 0, is always fetched, then cached at its height.
+func (bc *blockCache) lightBlock(ctx context.Context, p provider.Provider, height int64) (*types.LightBlock, error) {
+	if bc.size == 0 {
+		return p.LightBlock(ctx, height)
+	}
+	if height == 0 {
+		lb, err := p.LightBlock(ctx, height)
+		if err == nil {
+			bc.add(p, lb)
+		}
+		return lb, err
+	}
+
+	for {
+		e, fetch := bc.entry(p, height)
+		if fetch {
+			bc.fetch(ctx, p, height, e)
+		}
+
+		select {
+		case <-e.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		// the light block was being fetched by another caller, whose context
+		// is done: fetch it again
+		if !fetch && isContextErr(e.err) && ctx.Err() == nil {
+			continue
+		}
+		return e.lb, e.err
+	}
+}
+
+// prefetch fetches the light block of the given height from the provider in
+// the background, unless it is cached or being fetched.
+func (bc *blockCache) prefetch(ctx context.Context, p provider.Provider, height int64) {
+	if bc.size == 0 || height <= 0 {
+		return
+	}
+	if e, fetch := bc.entry(p, height); fetch {
+		go bc.fetch(ctx, p, height, e)
+	}
+}
+
+// remove evicts all the light blocks of the provider, e.g. once it is no
+// longer used.
+func (bc *blockCache) remove(p provider.Provider) {
+	bc.mtx.Lock()
+	defer bc.mtx.Unlock()
+
+	for key, e := range bc.entries {
+		if key.provider == p {
+			bc.delete(key, e)
+		}
+	}
+}
+
+// entry returns the cache entry of the light block, and true if it was just
+// created, in which case the caller must fetch the light block.
+func (bc *blockCache) entry(p provider.Provider, height int64) (*blockCacheEntry, bool) {
+	bc.mtx.Lock()
+	defer bc.mtx.Unlock()
+
+	key := blockCacheKey{provider: p, height: height}
+	if e, ok := bc.entries[key]; ok {
+		return e, false
+	}
+
+	e := &blockCacheEntry{done: make(chan struct{})}
+	bc.insert(key, e)
+	return e, true
+}
+
+func (bc *blockCache) fetch(ctx context.Context, p provider.Provider, height int64, e *blockCacheEntry) {
+	lb, err := p.LightBlock(ctx, height)
+
+	bc.mtx.Lock()
+	defer bc.mtx.Unlock()
+
+	e.lb, e.err = lb, err
+	if err != nil {
+		key := blockCacheKey{provider: p, height: height}
+		if bc.entries[key] == e {
+			bc.delete(key, e)
+		}
+	}
+	close(e.done)
+}
+
+// add caches a light block fetched from the provider.
+func (bc *blockCache) add(p provider.Provider, lb *types.LightBlock) {
+	bc.mtx.Lock()
+	defer bc.mtx.Unlock()
+
+	key := blockCacheKey{provider: p, height: lb.Height}
+	if _, ok := bc.entries[key]; ok {
+		return
+	}
+
+	e := &blockCacheEntry{done: make(chan struct{}), lb: lb}
+	close(e.done)
+	bc.insert(key, e)
+}
+
+// NOTE: requires a mtx lock
+func (bc *blockCache) insert(key blockCacheKey, e *blockCacheEntry) {
+	e.elem = bc.order.PushBack(key)
+	bc.entries[key] = e
+
+	for len(bc.entries) > bc.size {
+		oldest := bc.order.Front().Value.(blockCacheKey)
+		bc.delete(oldest, bc.entries[oldest])
+	}
+}
+
+// NOTE: requires a mtx lock
+func (bc *blockCache) delete(key blockCacheKey, e *blockCacheEntry) {
+	bc.order.Remove(e.elem)
+	delete(bc.entries, key)
+}
+
+func isContextErr(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
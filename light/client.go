@@ -25,6 +25,9 @@ const (
 
 	defaultPruningSize = 1000
 
+	defaultLightBlockCacheSize = 100
+	defaultPrefetchDepth       = 0
+
 	// For verifySkipping, we need an algorithm to find what height to check
 	// next to see if it has sufficient validator set overlap. The most
 	// intuitive method is to take the halfway point i.e. if you trusted block
@@ -92,6 +95,27 @@ func PruningSize(h uint16) Option {
 	}
 }
 
+// LightBlockCacheSize option sets the maximum amount of light blocks fetched
+// from the providers that the light client keeps in memory, so that they are
+// not requested again, e.g. by VerifyLightBlockAtHeight after Update.
+// Default: 100. A size of 0 disables the cache, and prefetching.
+func LightBlockCacheSize(n uint16) Option {
+	return func(c *Client) {
+		c.lightBlockCacheSize = n
+	}
+}
+
+// PrefetchDepth option sets how many light blocks the light client requests
+// ahead of verification. When skipping verification fails to verify a light
+// block, the next n pivot heights it may bisect to are requested in parallel.
+// Sequential verification requests the next n heights. Default: 0, which
+// disables prefetching.
+func PrefetchDepth(n uint16) Option {
+	return func(c *Client) {
+		c.prefetchDepth = n
+	}
+}
+
 // Logger option can be used to set a logger for the client.
 func Logger(l log.Logger) Option {
 	return func(c *Client) {
@@ -121,6 +145,21 @@ func MaxBlockLag(d time.Duration) Option {
 	}
 }
 
+// DivergenceHook is called with each divergence detected between the primary
+// and a witness, once saved in the trusted store.
+type DivergenceHook func(ctx context.Context, d *store.Divergence) error
+
+// OnDivergence option adds a hook called with each divergence detected between
+// the primary and a witness, e.g. to alert the operator of an attack. The hooks
+// are called in order, each within divergenceHookTimeout, in the background so
+// that they don't delay the verification. Up to divergenceQueueSize divergences
+// are queued for the hooks; the later ones are only saved in the trusted store.
+func OnDivergence(hook DivergenceHook) Option {
+	return func(c *Client) {
+		c.divergenceHooks = append(c.divergenceHooks, hook)
+	}
+}
+
 // Client represents a light client, connected to a single chain, which gets
 // light blocks from a primary provider, verifies them either sequentially or by
 // skipping some and stores them in a trusted store (usually, a local FS).
@@ -149,6 +188,20 @@ type Client struct {
 	// See PruningSize option
 	pruningSize uint16
 
+	// Light blocks recently fetched from the providers.
+	blocks *blockCache
+	// See LightBlockCacheSize option
+	lightBlockCacheSize uint16
+	// See PrefetchDepth option
+	prefetchDepth uint16
+
+	// See OnDivergence option
+	divergenceHooks []DivergenceHook
+	// Divergences queued for the hooks, which run while hooksRunning is set.
+	divergenceMtx   tmsync.Mutex
+	divergenceQueue []*store.Divergence
+	hooksRunning    bool
+
 	logger log.Logger
 }
 
@@ -156,6 +209,10 @@ type Client struct {
 // obtain the light block from the primary or they are invalid (e.g. trust
 // hash does not match with the one from the headers).
 //
+// If TrustOptions.Quorum is set, the client bootstraps from the light block
+// agreed on by a quorum of the primary and witnesses instead of a trusted hash,
+// and records its provenance in the trusted store.
+//
 // Witnesses are providers, which will be used for cross-checking the primary
 // provider. At least one witness must be given when skipping verification is
 // used (default). A witness can become a primary iff the current primary is
@@ -192,30 +249,46 @@ func NewClient(
 	if len(witnesses) < 1 {
 		return nil, ErrNoWitnesses
 	}
+	if trustOptions.Quorum > len(witnesses)+1 {
+		return nil, fmt.Errorf("quorum of %d providers, but only %d providers given",
+			trustOptions.Quorum, len(witnesses)+1)
+	}
 
 	c := &Client{
-		chainID:          chainID,
-		trustingPeriod:   trustOptions.Period,
-		verificationMode: skipping,
-		trustLevel:       DefaultTrustLevel,
-		maxClockDrift:    defaultMaxClockDrift,
-		maxBlockLag:      defaultMaxBlockLag,
-		primary:          primary,
-		witnesses:        witnesses,
-		trustedStore:     trustedStore,
-		pruningSize:      defaultPruningSize,
-		logger:           log.NewNopLogger(),
+		chainID:             chainID,
+		trustingPeriod:      trustOptions.Period,
+		verificationMode:    skipping,
+		trustLevel:          DefaultTrustLevel,
+		maxClockDrift:       defaultMaxClockDrift,
+		maxBlockLag:         defaultMaxBlockLag,
+		primary:             primary,
+		witnesses:           witnesses,
+		trustedStore:        trustedStore,
+		pruningSize:         defaultPruningSize,
+		lightBlockCacheSize: defaultLightBlockCacheSize,
+		prefetchDepth:       defaultPrefetchDepth,
+		logger:              log.NewNopLogger(),
 	}
 
 	for _, o := range options {
 		o(c)
 	}
 
+	c.blocks = newBlockCache(int(c.lightBlockCacheSize))
+
 	// Validate trust level.
 	if err := ValidateTrustLevel(c.trustLevel); err != nil {
 		return nil, err
 	}
 
+	// Use a quorum of the providers to agree on the first weakly-trusted block
+	if trustOptions.Quorum > 0 {
+		if err := c.initializeWithQuorum(ctx, trustOptions); err != nil {
+			return nil, err
+		}
+		return c, nil
+	}
+
 	// Use the trusted hash and height to fetch the first weakly-trusted block
 	// from the primary provider. Assert that all the witnesses have the same block
 	if err := c.initializeWithTrustOptions(ctx, trustOptions); err != nil {
@@ -236,23 +309,27 @@ func NewClientFromTrustedStore(
 	options ...Option) (*Client, error) {
 
 	c := &Client{
-		chainID:          chainID,
-		trustingPeriod:   trustingPeriod,
-		verificationMode: skipping,
-		trustLevel:       DefaultTrustLevel,
-		maxClockDrift:    defaultMaxClockDrift,
-		maxBlockLag:      defaultMaxBlockLag,
-		primary:          primary,
-		witnesses:        witnesses,
-		trustedStore:     trustedStore,
-		pruningSize:      defaultPruningSize,
-		logger:           log.NewNopLogger(),
+		chainID:             chainID,
+		trustingPeriod:      trustingPeriod,
+		verificationMode:    skipping,
+		trustLevel:          DefaultTrustLevel,
+		maxClockDrift:       defaultMaxClockDrift,
+		maxBlockLag:         defaultMaxBlockLag,
+		primary:             primary,
+		witnesses:           witnesses,
+		trustedStore:        trustedStore,
+		pruningSize:         defaultPruningSize,
+		lightBlockCacheSize: defaultLightBlockCacheSize,
+		prefetchDepth:       defaultPrefetchDepth,
+		logger:              log.NewNopLogger(),
 	}
 
 	for _, o := range options {
 		o(c)
 	}
 
+	c.blocks = newBlockCache(int(c.lightBlockCacheSize))
+
 	// Validate the number of witnesses.
 	if len(c.witnesses) < 1 {
 		return nil, ErrNoWitnesses
@@ -314,7 +391,7 @@ func (c *Client) initializeWithTrustOptions(ctx context.Context, options TrustOp
 	}
 
 	// 4) Cross-verify with witnesses to ensure everybody has the same state.
-	if err := c.compareFirstHeaderWithWitnesses(ctx, l.SignedHeader); err != nil {
+	if _, err := c.compareFirstHeaderWithWitnesses(ctx, l.SignedHeader); err != nil {
 		return err
 	}
 
@@ -322,6 +399,56 @@ func (c *Client) initializeWithTrustOptions(ctx context.Context, options TrustOp
 	return c.updateTrustedLightBlock(l)
 }
 
+// initializeWithQuorum fetches the weakly-trusted light block at the given
+// height, or the latest one, from the primary provider and asserts that a
+// quorum of distinct providers, the primary included, have the same light
+// block. It then sets it as the lastTrustedBlock and records its provenance.
+func (c *Client) initializeWithQuorum(ctx context.Context, options TrustOptions) error {
+	// 1) Fetch the light block. Note that we do not verify the time of the first block
+	l, err := c.lightBlockFromPrimary(ctx, options.Height)
+	if err != nil {
+		return err
+	}
+
+	// 2) Ensure that +2/3 of validators signed correctly. This also sanity checks that the
+	// chain ID is the same.
+	err = l.ValidatorSet.VerifyCommitLight(c.chainID, l.Commit.BlockID, l.Height, l.Commit)
+	if err != nil {
+		return fmt.Errorf("invalid commit: %w", err)
+	}
+
+	// 3) Cross-verify with witnesses, none of which may have a different
+	// header, and count the distinct providers which have the same one.
+	agreed, err := c.compareFirstHeaderWithWitnesses(ctx, l.SignedHeader)
+	if err != nil {
+		return err
+	}
+
+	c.providerMutex.Lock()
+	providers := distinctProviderIDs(append([]provider.Provider{c.primary}, agreed...))
+	c.providerMutex.Unlock()
+
+	if len(providers) < options.Quorum {
+		return ErrQuorumNotReached{Quorum: options.Quorum, Agreed: len(providers)}
+	}
+
+	// 4) Persist the light block along with its provenance and continue.
+	if err := c.updateTrustedLightBlock(l); err != nil {
+		return err
+	}
+
+	c.logger.Info("bootstrapped from a quorum of providers",
+		"height", l.Height, "hash", l.Hash(), "providers", providers)
+
+	return c.trustedStore.SaveProvenance(&store.Provenance{
+		Height:    l.Height,
+		Hash:      l.Hash(),
+		Quorum:    options.Quorum,
+		Providers: providers,
+		Time:      time.Now().UTC(),
+	})
+}
+
 // TrustedLightBlock returns a trusted light block at the given height (0 - the latest).
 //
 // It returns an error if:
@@ -559,6 +686,9 @@ func (c *Client) verifySequential(
 		if height == newLightBlock.Height { // last light block
 			interimBlock = newLightBlock
 		} else { // intermediate light blocks
+			for i := int64(1); i <= int64(c.prefetchDepth) && height+i < newLightBlock.Height; i++ {
+				c.prefetchFromPrimary(ctx, height+i)
+			}
 			interimBlock, err = c.lightBlockFromPrimary(ctx, height)
 			if err != nil {
 				return ErrVerificationFailed{From: verifiedBlock.Height, To: height, Reason: err}
@@ -693,7 +823,16 @@ func (c *Client) verifySkipping(
 			if depth == len(blockCache)-1 {
 				// schedule what the next height we need to fetch is
 				pivotHeight := c.schedule(verifiedBlock.Height, blockCache[depth].Height)
-				interimBlock, providerErr := source.LightBlock(ctx, pivotHeight)
+				// prefetch the next pivot heights, in case the pivot block can't
+				// be verified either
+				for i, h := 0, pivotHeight; i < int(c.prefetchDepth); i++ {
+					h = c.schedule(verifiedBlock.Height, h)
+					if h <= verifiedBlock.Height {
+						break
+					}
+					c.blocks.prefetch(ctx, source, h)
+				}
+				interimBlock, providerErr := c.blocks.lightBlock(ctx, source, pivotHeight)
 				if providerErr != nil {
 					return nil, ErrVerificationFailed{From: verifiedBlock.Height, To: pivotHeight, Reason: providerErr}
 				}
@@ -722,6 +861,10 @@ func (c *Client) verifySkippingAgainstPrimary(
 	newLightBlock *types.LightBlock,
 	now time.Time) error {
 
+	// the witnesses are requested the new light block while it is verified, to
+	// compare it with theirs once verified
+	c.prefetchFromWitnesses(ctx, newLightBlock.Height)
+
 	trace, err := c.verifySkipping(ctx, c.primary, trustedBlock, newLightBlock, now)
 	if err == nil {
 		// Success! Now compare the header with the witnesses to ensure it's not a fork.
@@ -806,6 +949,14 @@ func (c *Client) FirstTrustedHeight() (int64, error) {
 	return c.trustedStore.FirstLightBlockHeight()
 }
 
+// Divergences returns the divergences detected between the primary and the
+// witnesses, which halted the light client, in the order they were detected.
+//
+// Safe for concurrent use by multiple goroutines.
+func (c *Client) Divergences() ([]*store.Divergence, error) {
+	return c.trustedStore.Divergences()
+}
+
 // ChainID returns the chain ID the light client was configured with.
 //
 // Safe for concurrent use by multiple goroutines.
@@ -930,8 +1081,9 @@ func (c *Client) backwards(
 //    any other error, the primary is permanently dropped and is replaced by a witness.
 func (c *Client) lightBlockFromPrimary(ctx context.Context, height int64) (*types.LightBlock, error) {
 	c.providerMutex.Lock()
-	l, err := c.primary.LightBlock(ctx, height)
+	primary := c.primary
 	c.providerMutex.Unlock()
+	l, err := c.blocks.lightBlock(ctx, primary, height)
 
 	switch err {
 	case nil:
@@ -957,6 +1109,33 @@ func (c *Client) lightBlockFromPrimary(ctx context.Context, height int64) (*type
 	}
 }
 
+// prefetchFromPrimary requests the light block at the given height from the
+// primary in the background, so that it is cached once needed.
+func (c *Client) prefetchFromPrimary(ctx context.Context, height int64) {
+	if c.prefetchDepth == 0 {
+		return
+	}
+
+	c.providerMutex.Lock()
+	primary := c.primary
+	c.providerMutex.Unlock()
+	c.blocks.prefetch(ctx, primary, height)
+}
+
+// prefetchFromWitnesses requests the light block at the given height from all
+// the witnesses in the background, so that it is cached once needed.
+func (c *Client) prefetchFromWitnesses(ctx context.Context, height int64) {
+	if c.prefetchDepth == 0 {
+		return
+	}
+
+	c.providerMutex.Lock()
+	defer c.providerMutex.Unlock()
+	for _, witness := range c.witnesses {
+		c.blocks.prefetch(ctx, witness, height)
+	}
+}
+
 // NOTE: requires a providerMutex lock
 func (c *Client) removeWitnesses(indexes []int) error {
 	// check that we will still have witnesses remaining
@@ -968,6 +1147,10 @@ func (c *Client) removeWitnesses(indexes []int) error {
 	// order so as to not affect the indexes themselves
 	sort.Ints(indexes)
 	for i := len(indexes) - 1; i >= 0; i-- {
+		// the light blocks of a promoted witness are kept
+		if witness := c.witnesses[indexes[i]]; witness != c.primary {
+			c.blocks.remove(witness)
+		}
 		c.witnesses[indexes[i]] = c.witnesses[len(c.witnesses)-1]
 		c.witnesses = c.witnesses[:len(c.witnesses)-1]
 	}
@@ -1008,7 +1191,7 @@ func (c *Client) findNewPrimary(ctx context.Context, height int64, remove bool)
 		go func(witnessIndex int, witnessResponsesC chan witnessResponse) {
 			defer wg.Done()
 
-			lb, err := c.witnesses[witnessIndex].LightBlock(subctx, height)
+			lb, err := c.blocks.lightBlock(subctx, c.witnesses[witnessIndex], height)
 			witnessResponsesC <- witnessResponse{lb, witnessIndex, err}
 		}(index, witnessResponsesC)
 	}
@@ -1026,6 +1209,8 @@ func (c *Client) findNewPrimary(ctx context.Context, height int64, remove bool)
 			// if we are not intending on removing the primary then append the old primary to the end of the witness slice
 			if !remove {
 				c.witnesses = append(c.witnesses, c.primary)
+			} else {
+				c.blocks.remove(c.primary)
 			}
 
 			// promote respondent as the new primary
@@ -1067,9 +1252,19 @@ func (c *Client) findNewPrimary(ctx context.Context, height int64, remove bool)
 	return nil, lastError
 }
 
+// witnessComparison is the result of the comparison of a header with a witness.
+type witnessComparison struct {
+	witnessIndex int
+	err          error
+}
+
 // compareFirstHeaderWithWitnesses concurrently compares h with all witnesses. If any
-// witness reports a different header than h, the function returns an error.
-func (c *Client) compareFirstHeaderWithWitnesses(ctx context.Context, h *types.SignedHeader) error {
+// witness reports a different header than h, the function returns an error. It
+// returns the witnesses which reported the same header as h.
+func (c *Client) compareFirstHeaderWithWitnesses(
+	ctx context.Context,
+	h *types.SignedHeader,
+) ([]provider.Provider, error) {
 	compareCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
@@ -1077,28 +1272,36 @@ func (c *Client) compareFirstHeaderWithWitnesses(ctx context.Context, h *types.S
 	defer c.providerMutex.Unlock()
 
 	if len(c.witnesses) < 1 {
-		return ErrNoWitnesses
+		return nil, ErrNoWitnesses
 	}
 
-	errc := make(chan error, len(c.witnesses))
+	comparisonc := make(chan witnessComparison, len(c.witnesses))
 	for i, witness := range c.witnesses {
-		go c.compareNewHeaderWithWitness(compareCtx, errc, h, witness, i)
+		go func(witnessIndex int, witness provider.Provider) {
+			errc := make(chan error, 1)
+			c.compareNewHeaderWithWitness(compareCtx, errc, h, witness, witnessIndex)
+			comparisonc <- witnessComparison{witnessIndex: witnessIndex, err: <-errc}
+		}(i, witness)
 	}
 
-	witnessesToRemove := make([]int, 0, len(c.witnesses))
+	var (
+		witnessesToRemove = make([]int, 0, len(c.witnesses))
+		agreed            []provider.Provider
+	)
 
 	// handle errors from the header comparisons as they come in
-	for i := 0; i < cap(errc); i++ {
-		err := <-errc
+	for i := 0; i < cap(comparisonc); i++ {
+		comparison := <-comparisonc
+		err := comparison.err
 
 		switch e := err.(type) {
 		case nil:
-			continue
+			agreed = append(agreed, c.witnesses[comparison.witnessIndex])
 		case errConflictingHeaders:
 			c.logger.Error(`witness has a different header. Please check primary is
 correct and remove witness. Otherwise, use a different primary`,
 				"Witness", c.witnesses[e.WitnessIndex], "ExpHeader", h.Hash(), "GotHeader", e.Block.Hash())
-			return err
+			return nil, err
 		case errBadWitness:
 			// If witness sent us an invalid header, then remove it
 			c.logger.Info("witness returned an error, removing...",
@@ -1107,7 +1310,7 @@ correct and remove witness. Otherwise, use a different primary`,
 		default:
 			// check for canceled contexts or deadlines
 			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
-				return err
+				return nil, err
 			}
 
 			// the witness either didn't respond or didn't have the block. We ignore it.
@@ -1118,7 +1321,34 @@ correct and remove witness. Otherwise, use a different primary`,
 	}
 
 	// remove all witnesses that misbehaved
-	return c.removeWitnesses(witnessesToRemove)
+	return agreed, c.removeWitnesses(witnessesToRemove)
+}
+
+// distinctProviderIDs returns the identifiers of the given providers, without
+// duplicates, so that the same provider given twice is counted once.
+func distinctProviderIDs(providers []provider.Provider) []string {
+	var (
+		ids  = make([]string, 0, len(providers))
+		seen = make(map[string]struct{}, len(providers))
+	)
+	for _, p := range providers {
+		id := providerID(p)
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// providerID returns the identifier of the given provider: its address for the
+// providers implementing fmt.Stringer, such as the HTTP provider.
+func providerID(p provider.Provider) string {
+	if s, ok := p.(fmt.Stringer); ok {
+		return s.String()
+	}
+	return fmt.Sprintf("%p", p)
 }
 
 // providerShouldBeRemoved analyzes the nature of the error and whether the provider
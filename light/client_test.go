@@ -97,6 +97,29 @@ func TestValidateTrustOptions(t *testing.T) {
 				Hash:   []byte("incorrect hash"),
 			},
 		},
+		{
+			false,
+			light.TrustOptions{
+				Period: 1 * time.Hour,
+				Quorum: 2,
+			},
+		},
+		{
+			true,
+			light.TrustOptions{
+				Period: 1 * time.Hour,
+				Quorum: 1,
+			},
+		},
+		{
+			true,
+			light.TrustOptions{
+				Period: 1 * time.Hour,
+				Height: 1,
+				Hash:   h1.Hash(),
+				Quorum: 2,
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -406,6 +429,92 @@ func TestClientLargeBisectionVerification(t *testing.T) {
 	mockNode.AssertExpectations(t)
 }
 
+// slowProvider serves the light blocks of a provider after some latency,
+// recording the maximum number of requests served concurrently.
+type slowProvider struct {
+	provider.Provider
+	latency time.Duration
+
+	mtx         sync.Mutex
+	inFlight    int
+	maxInFlight int
+}
+
+func (p *slowProvider) LightBlock(ctx context.Context, height int64) (*types.LightBlock, error) {
+	p.mtx.Lock()
+	p.inFlight++
+	if p.inFlight > p.maxInFlight {
+		p.maxInFlight = p.inFlight
+	}
+	p.mtx.Unlock()
+
+	defer func() {
+		p.mtx.Lock()
+		p.inFlight--
+		p.mtx.Unlock()
+	}()
+
+	time.Sleep(p.latency)
+	return p.Provider.LightBlock(ctx, height)
+}
+
+func TestClientPrefetch(t *testing.T) {
+	numBlocks := int64(50)
+	headers, vals, _ := genLightBlocksWithKeys(chainID, numBlocks, 10, 3, bTime)
+
+	testCases := []struct {
+		name     string
+		mode     light.Option
+		prefetch uint16
+	}{
+		{"sequential", light.SequentialVerification(), 0},
+		{"sequential with prefetch", light.SequentialVerification(), 3},
+		{"skipping", light.SkippingVerification(light.DefaultTrustLevel), 0},
+		{"skipping with prefetch", light.SkippingVerification(light.DefaultTrustLevel), 3},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			primary := &slowProvider{Provider: newProviderBenchmarkImpl(headers, vals), latency: time.Millisecond}
+			witness := newProviderBenchmarkImpl(headers, vals)
+			trustedBlock, err := witness.LightBlock(ctx, 1)
+			require.NoError(t, err)
+
+			c, err := light.NewClient(
+				ctx,
+				chainID,
+				light.TrustOptions{
+					Period: 4 * time.Hour,
+					Height: 1,
+					Hash:   trustedBlock.Hash(),
+				},
+				primary,
+				[]provider.Provider{witness},
+				dbs.New(dbm.NewMemDB()),
+				light.Logger(log.TestingLogger()),
+				tc.mode,
+				light.PrefetchDepth(tc.prefetch),
+			)
+			require.NoError(t, err)
+
+			l, err := c.VerifyLightBlockAtHeight(ctx, numBlocks, bTime.Add(2*time.Hour))
+			require.NoError(t, err)
+			assert.Equal(t, headers[numBlocks].Hash(), l.Hash())
+
+			// the light blocks are requested ahead of verification only if
+			// prefetching
+			primary.mtx.Lock()
+			defer primary.mtx.Unlock()
+			if tc.prefetch > 0 {
+				assert.Greater(t, primary.maxInFlight, 1)
+			} else {
+				assert.Equal(t, 1, primary.maxInFlight)
+			}
+		})
+	}
+}
+
 func TestClientBisectionBetweenTrustedHeaders(t *testing.T) {
 	mockFullNode := mockNodeFromHeadersAndVals(headerSet, valSet)
 	c, err := light.NewClient(
@@ -531,7 +640,8 @@ func TestClient_Update(t *testing.T) {
 	mockFullNode := &provider_mocks.Provider{}
 	mockFullNode.On("LightBlock", mock.Anything, int64(0)).Return(l3, nil)
 	mockFullNode.On("LightBlock", mock.Anything, int64(1)).Return(l1, nil)
-	mockFullNode.On("LightBlock", mock.Anything, int64(3)).Return(l3, nil)
+	// the witness is the primary, so the light block is served from the cache
+	mockFullNode.On("LightBlock", mock.Anything, int64(3)).Return(l3, nil).Maybe()
 	c, err := light.NewClient(
 		ctx,
 		chainID,
@@ -795,6 +905,99 @@ func TestClient_NewClientFromTrustedStore(t *testing.T) {
 	mockNode.AssertExpectations(t)
 }
 
+func TestClient_BootstrapWithQuorum(t *testing.T) {
+	quorumOptions := light.TrustOptions{Period: trustPeriod, Quorum: 3}
+
+	fullNode := func() *provider_mocks.Provider {
+		mockNode := mockNodeFromHeadersAndVals(headerSet, valSet)
+		mockNode.On("LightBlock", mock.Anything, int64(0)).Return(l3, nil)
+		return mockNode
+	}
+	deadNode := &provider_mocks.Provider{}
+	deadNode.On("LightBlock", mock.Anything, mock.Anything).Return(nil, provider.ErrNoResponse)
+
+	// the latest light block is trusted once the quorum agrees on it, and its
+	// provenance is recorded
+	db := dbs.New(dbm.NewMemDB())
+	c, err := light.NewClient(
+		ctx,
+		chainID,
+		quorumOptions,
+		fullNode(),
+		[]provider.Provider{fullNode(), deadNode, fullNode()},
+		db,
+		light.Logger(log.TestingLogger()),
+	)
+	require.NoError(t, err)
+
+	h, err := c.TrustedLightBlock(0)
+	require.NoError(t, err)
+	assert.EqualValues(t, l3.Height, h.Height)
+
+	provenance, err := db.Provenance()
+	require.NoError(t, err)
+	assert.EqualValues(t, l3.Height, provenance.Height)
+	assert.EqualValues(t, l3.Hash(), provenance.Hash)
+	assert.Equal(t, 3, provenance.Quorum)
+	assert.Len(t, provenance.Providers, 3)
+
+	// a given height can be trusted too
+	c, err = light.NewClient(
+		ctx,
+		chainID,
+		light.TrustOptions{Period: trustPeriod, Height: 2, Quorum: 2},
+		fullNode(),
+		[]provider.Provider{fullNode()},
+		dbs.New(dbm.NewMemDB()),
+		light.Logger(log.TestingLogger()),
+	)
+	require.NoError(t, err)
+
+	h, err = c.TrustedLightBlock(0)
+	require.NoError(t, err)
+	assert.EqualValues(t, l2.Height, h.Height)
+
+	// the same provider given twice is counted once
+	witness := fullNode()
+	_, err = light.NewClient(
+		ctx,
+		chainID,
+		quorumOptions,
+		fullNode(),
+		[]provider.Provider{witness, witness, deadNode},
+		dbs.New(dbm.NewMemDB()),
+		light.Logger(log.TestingLogger()),
+	)
+	require.Equal(t, light.ErrQuorumNotReached{Quorum: 3, Agreed: 2}, err)
+
+	// a witness with a different header prevents the bootstrap
+	conflictingHeader := keys.GenSignedHeaderLastBlockID(chainID, 3, bTime.Add(1*time.Hour), nil, vals, vals,
+		hash("other_app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(keys), types.BlockID{Hash: h2.Hash()})
+	conflictingNode := mockNodeFromHeadersAndVals(map[int64]*types.SignedHeader{3: conflictingHeader}, valSet)
+	_, err = light.NewClient(
+		ctx,
+		chainID,
+		quorumOptions,
+		fullNode(),
+		[]provider.Provider{fullNode(), fullNode(), conflictingNode},
+		dbs.New(dbm.NewMemDB()),
+		light.Logger(log.TestingLogger()),
+	)
+	require.Error(t, err)
+
+	// the quorum can't exceed the number of providers
+	_, err = light.NewClient(
+		ctx,
+		chainID,
+		quorumOptions,
+		fullNode(),
+		[]provider.Provider{fullNode()},
+		dbs.New(dbm.NewMemDB()),
+		light.Logger(log.TestingLogger()),
+	)
+	require.Error(t, err)
+}
+
 func TestClientRemovesWitnessIfItSendsUsIncorrectHeader(t *testing.T) {
 	// different headers hash then primary plus less than 1/3 signed (no fork)
 	headers1 := map[int64]*types.SignedHeader{
@@ -924,6 +1127,7 @@ func TestClientPrunesHeadersAndValidatorSets(t *testing.T) {
 		dbs.New(dbm.NewMemDB()),
 		light.Logger(log.TestingLogger()),
 		light.PruningSize(1),
+		light.LightBlockCacheSize(0),
 	)
 	require.NoError(t, err)
 	_, err = c.TrustedLightBlock(1)
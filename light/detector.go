@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/tendermint/tendermint/light/provider"
+	"github.com/tendermint/tendermint/light/store"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -110,7 +111,7 @@ func (c *Client) detectDivergence(ctx context.Context, primaryTrace []*types.Lig
 func (c *Client) compareNewHeaderWithWitness(ctx context.Context, errc chan error, h *types.SignedHeader,
 	witness provider.Provider, witnessIndex int) {
 
-	lightBlock, err := witness.LightBlock(ctx, h.Height)
+	lightBlock, err := c.blocks.lightBlock(ctx, witness, h.Height)
 	switch err {
 	// no error means we move on to checking the hash of the two headers
 	case nil:
@@ -196,6 +197,7 @@ func (c *Client) compareNewHeaderWithWitness(ctx context.Context, errc chan erro
 
 	if !bytes.Equal(h.Header.Hash(), lightBlock.Header.Hash()) {
 		errc <- errConflictingHeaders{Block: lightBlock, WitnessIndex: witnessIndex}
+		return
 	}
 
 	c.logger.Debug("matching header received by witness", "height", h.Height, "witness", witnessIndex)
@@ -240,6 +242,16 @@ func (c *Client) handleConflictingHeaders(
 		"primary", c.primary, "witness", supportingWitness)
 	c.sendEvidence(ctx, evidenceAgainstPrimary, supportingWitness)
 
+	divergence := &store.Divergence{
+		Height:                 primaryTrace[len(primaryTrace)-1].Height,
+		Primary:                providerID(c.primary),
+		Witness:                providerID(supportingWitness),
+		PrimaryTrace:           primaryTrace,
+		WitnessTrace:           witnessTrace,
+		EvidenceAgainstPrimary: evidenceAgainstPrimary,
+		Time:                   now,
+	}
+
 	if primaryBlock.Commit.Round != witnessTrace[len(witnessTrace)-1].Commit.Round {
 		c.logger.Info("The light client has detected, and prevented, an attempted amnesia attack." +
 			" We think this attack is pretty unlikely, so if you see it, that's interesting to us." +
@@ -258,6 +270,7 @@ func (c *Client) handleConflictingHeaders(
 	)
 	if err != nil {
 		c.logger.Info("Error validating primary's divergent header", "primary", c.primary, "err", err)
+		c.recordDivergence(divergence)
 		return ErrLightClientAttack
 	}
 
@@ -267,10 +280,24 @@ func (c *Client) handleConflictingHeaders(
 	c.logger.Error("Sending evidence against witness by primary", "ev", evidenceAgainstWitness,
 		"primary", c.primary, "witness", supportingWitness)
 	c.sendEvidence(ctx, evidenceAgainstWitness, c.primary)
+	divergence.EvidenceAgainstWitness = evidenceAgainstWitness
+	c.recordDivergence(divergence)
 	// We return the error and don't process anymore witnesses
 	return ErrLightClientAttack
 }
 
+// recordDivergence saves the given divergence in the trusted store and queues
+// it for the divergence hooks, logging any error.
+func (c *Client) recordDivergence(d *store.Divergence) {
+	if err := c.trustedStore.SaveDivergence(d); err != nil {
+		c.logger.Error("failed to save divergence", "height", d.Height, "err", err)
+	}
+
+	if len(c.divergenceHooks) > 0 {
+		c.queueDivergence(d)
+	}
+}
+
 // examineConflictingHeaderAgainstTrace takes a trace from one provider and a divergent header that
 // it has received from another and preforms verifySkipping at the heights of each of the intermediate
 // headers in the trace until it reaches the divergentHeader. 1 of 2 things can happen.
@@ -331,7 +358,7 @@ func (c *Client) examineConflictingHeaderAgainstTrace(
 		if traceBlock.Height == targetBlock.Height {
 			sourceBlock = targetBlock
 		} else {
-			sourceBlock, err = source.LightBlock(ctx, traceBlock.Height)
+			sourceBlock, err = c.blocks.lightBlock(ctx, source, traceBlock.Height)
 			if err != nil {
 				return nil, nil, fmt.Errorf("failed to examine trace: %w", err)
 			}
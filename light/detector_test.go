@@ -2,6 +2,7 @@ package light_test
 
 import (
 	"bytes"
+	"context"
 	"testing"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/tendermint/tendermint/light"
 	"github.com/tendermint/tendermint/light/provider"
 	provider_mocks "github.com/tendermint/tendermint/light/provider/mocks"
+	"github.com/tendermint/tendermint/light/store"
 	dbs "github.com/tendermint/tendermint/light/store/db"
 	"github.com/tendermint/tendermint/types"
 )
@@ -77,6 +79,11 @@ func TestLightClientAttackEvidence_Lunatic(t *testing.T) {
 		return bytes.Equal(evidence.Hash(), evAgainstWitness.Hash())
 	})).Return(nil)
 
+	// the hook blocks until released, which doesn't delay the verification
+	var (
+		hooked  = make(chan *store.Divergence, 1)
+		release = make(chan struct{})
+	)
 	c, err := light.NewClient(
 		ctx,
 		chainID,
@@ -89,6 +96,11 @@ func TestLightClientAttackEvidence_Lunatic(t *testing.T) {
 		[]provider.Provider{mockWitness},
 		dbs.New(dbm.NewMemDB()),
 		light.Logger(log.TestingLogger()),
+		light.OnDivergence(func(_ context.Context, d *store.Divergence) error {
+			<-release
+			hooked <- d
+			return nil
+		}),
 	)
 	require.NoError(t, err)
 
@@ -98,6 +110,25 @@ func TestLightClientAttackEvidence_Lunatic(t *testing.T) {
 		assert.Equal(t, light.ErrLightClientAttack, err)
 	}
 
+	// Check the divergence was recorded with the traces and evidence.
+	divergences, err := c.Divergences()
+	require.NoError(t, err)
+	require.Len(t, divergences, 1)
+	d := divergences[0]
+	assert.EqualValues(t, 1, d.ID)
+	assert.Equal(t, latestHeight, d.Height)
+	assert.Equal(t, primaryHeaders[latestHeight].Hash(), d.PrimaryTrace[len(d.PrimaryTrace)-1].Hash())
+	assert.Equal(t, witnessHeaders[latestHeight].Hash(), d.WitnessTrace[len(d.WitnessTrace)-1].Hash())
+	assert.NotNil(t, d.EvidenceAgainstPrimary)
+	assert.NotNil(t, d.EvidenceAgainstWitness)
+	close(release)
+	select {
+	case h := <-hooked:
+		assert.Equal(t, d.ID, h.ID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("divergence hook not called")
+	}
+
 	mockWitness.AssertExpectations(t)
 	mockPrimary.AssertExpectations(t)
 }
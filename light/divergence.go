@@ -0,0 +1,114 @@
+package light
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"time"
+
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"github.com/tendermint/tendermint/light/store"
+)
+
+const (
+	// divergenceHookTimeout defines how long each divergence hook can take.
+	divergenceHookTimeout = 30 * time.Second
+
+	// divergenceQueueSize defines how many divergences can wait for the
+	// divergence hooks.
+	divergenceQueueSize = 100
+)
+
+// queueDivergence queues the given divergence for the divergence hooks, running
+// them in the background unless they are already running. The divergence is
+// dropped if the queue is full.
+func (c *Client) queueDivergence(d *store.Divergence) {
+	c.divergenceMtx.Lock()
+	defer c.divergenceMtx.Unlock()
+
+	if len(c.divergenceQueue) >= divergenceQueueSize {
+		c.logger.Error("divergence hooks queue is full, dropping divergence", "id", d.ID, "height", d.Height)
+		return
+	}
+	c.divergenceQueue = append(c.divergenceQueue, d)
+
+	if !c.hooksRunning {
+		c.hooksRunning = true
+		go c.runDivergenceHooks()
+	}
+}
+
+// runDivergenceHooks calls the divergence hooks with each queued divergence,
+// logging any error, until the queue is empty.
+func (c *Client) runDivergenceHooks() {
+	for {
+		c.divergenceMtx.Lock()
+		if len(c.divergenceQueue) == 0 {
+			c.hooksRunning = false
+			c.divergenceMtx.Unlock()
+			return
+		}
+		d := c.divergenceQueue[0]
+		c.divergenceQueue[0] = nil
+		c.divergenceQueue = c.divergenceQueue[1:]
+		c.divergenceMtx.Unlock()
+
+		for _, hook := range c.divergenceHooks {
+			ctx, cancel := context.WithTimeout(context.Background(), divergenceHookTimeout)
+			if err := hook(ctx, d); err != nil {
+				c.logger.Error("divergence hook failed", "id", d.ID, "height", d.Height, "err", err)
+			}
+			cancel()
+		}
+	}
+}
+
+// WebhookDivergenceHook returns a DivergenceHook, which posts each divergence,
+// JSON-encoded, to the given URL. Any response status other than 2xx is an
+// error.
+func WebhookDivergenceHook(url string) DivergenceHook {
+	return func(ctx context.Context, d *store.Divergence) error {
+		bz, err := tmjson.Marshal(d)
+		if err != nil {
+			return fmt.Errorf("marshaling Divergence: %w", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(bz))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("posting divergence to %s: %w", url, err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("posting divergence to %s: unexpected status %s", url, resp.Status)
+		}
+		return nil
+	}
+}
+
+// ExecDivergenceHook returns a DivergenceHook, which runs the given command
+// with each divergence, JSON-encoded, on its standard input. A non-zero exit
+// status is an error.
+func ExecDivergenceHook(name string, args ...string) DivergenceHook {
+	return func(ctx context.Context, d *store.Divergence) error {
+		bz, err := tmjson.Marshal(d)
+		if err != nil {
+			return fmt.Errorf("marshaling Divergence: %w", err)
+		}
+
+		cmd := exec.CommandContext(ctx, name, args...)
+		cmd.Stdin = bytes.NewReader(bz)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("running %s: %w: %s", name, err, out)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,44 @@
+package light_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"github.com/tendermint/tendermint/light"
+	"github.com/tendermint/tendermint/light/store"
+)
+
+func TestWebhookDivergenceHook(t *testing.T) {
+	var (
+		d        = &store.Divergence{ID: 1, Height: 3, Primary: "primary", Witness: "witness"}
+		received = make(chan *store.Divergence, 1)
+		status   = http.StatusOK
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		bz, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		rd := new(store.Divergence)
+		require.NoError(t, tmjson.Unmarshal(bz, rd))
+		received <- rd
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	hook := light.WebhookDivergenceHook(server.URL)
+	require.NoError(t, hook(context.Background(), d))
+	assert.Equal(t, d, <-received)
+
+	// a failed delivery is an error
+	status = http.StatusInternalServerError
+	require.Error(t, hook(context.Background(), d))
+	<-received
+}
@@ -76,6 +76,17 @@ var ErrLightClientAttack = errors.New(`attempted attack detected.
 // continue running the light client.
 var ErrNoWitnesses = errors.New("no witnesses connected. please reset light client")
 
+// ErrQuorumNotReached means that fewer providers than the quorum agreed on the
+// header to bootstrap the light client from.
+type ErrQuorumNotReached struct {
+	Quorum int
+	Agreed int
+}
+
+func (e ErrQuorumNotReached) Error() string {
+	return fmt.Sprintf("only %d providers agreed on the header, quorum is %d", e.Agreed, e.Quorum)
+}
+
 // ----------------------------- INTERNAL ERRORS ---------------------------------
 
 // ErrConflictingHeaders is thrown when two conflicting headers are discovered.
@@ -0,0 +1,320 @@
+package light
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tendermint/tendermint/light/provider"
+	"github.com/tendermint/tendermint/light/store"
+	"github.com/tendermint/tendermint/types"
+)
+
+// Header is a verified header in the form expected by the Tendermint light
+// clients running on other chains, e.g. IBC clients: the signed header and its
+// validator set, along with the height of a header the on-chain client already
+// trusts and the validator set following it, from which the header can be
+// verified.
+type Header struct {
+	SignedHeader *types.SignedHeader `json:"signed_header"`
+	ValidatorSet *types.ValidatorSet `json:"validator_set"`
+
+	// TrustedHeight defines the height of the header the on-chain client
+	// trusts, and TrustedValidators its next validator set, i.e. the
+	// validator set at TrustedHeight+1.
+	TrustedHeight     int64               `json:"trusted_height"`
+	TrustedValidators *types.ValidatorSet `json:"trusted_validators"`
+}
+
+// NewHeader returns the header of the light block lb, verifiable from the
+// trusted light block, whose next validator set is trustedNextVals.
+func NewHeader(lb, trusted *types.LightBlock, trustedNextVals *types.ValidatorSet) (*Header, error) {
+	if trusted.Height >= lb.Height {
+		return nil, fmt.Errorf("trusted height %d must be below the header height %d", trusted.Height, lb.Height)
+	}
+	if !bytes.Equal(trustedNextVals.Hash(), trusted.NextValidatorsHash) {
+		return nil, fmt.Errorf("trusted next validators hash %X does not match the one of the trusted header %X",
+			trustedNextVals.Hash(), trusted.NextValidatorsHash)
+	}
+
+	h := &Header{
+		SignedHeader:      lb.SignedHeader,
+		ValidatorSet:      lb.ValidatorSet,
+		TrustedHeight:     trusted.Height,
+		TrustedValidators: trustedNextVals,
+	}
+	return h, h.ValidateBasic(lb.ChainID)
+}
+
+// Height returns the height of the header.
+func (h *Header) Height() int64 {
+	return h.SignedHeader.Height
+}
+
+// ValidateBasic performs basic validation of the header.
+func (h *Header) ValidateBasic(chainID string) error {
+	if h.SignedHeader == nil {
+		return errors.New("missing signed header")
+	}
+	if h.ValidatorSet == nil {
+		return errors.New("missing validator set")
+	}
+	lb := types.LightBlock{SignedHeader: h.SignedHeader, ValidatorSet: h.ValidatorSet}
+	if err := lb.ValidateBasic(chainID); err != nil {
+		return err
+	}
+
+	if h.TrustedHeight <= 0 || h.TrustedHeight >= h.Height() {
+		return fmt.Errorf("trusted height %d must be positive and below the header height %d",
+			h.TrustedHeight, h.Height())
+	}
+	if h.TrustedValidators == nil {
+		return errors.New("missing trusted validators")
+	}
+	if err := h.TrustedValidators.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid trusted validators: %w", err)
+	}
+	return nil
+}
+
+// Misbehaviour is the evidence of a light client attack in the form expected
+// by the Tendermint light clients running on other chains, e.g. IBC clients:
+// two conflicting headers at the same height, each verifiable from a header
+// the on-chain client trusts.
+type Misbehaviour struct {
+	Header1 *Header `json:"header_1"`
+	Header2 *Header `json:"header_2"`
+}
+
+// NewMisbehaviour packages the evidence of a light client attack into a
+// misbehaviour: the conflicting block of the evidence, and the light block
+// conflicting with it at the same height, both verifiable from the common
+// light block, whose next validator set is commonNextVals. The common light
+// block is the light block the two chains diverged from, which is below the
+// common height of the evidence in case of equivocation or amnesia.
+func NewMisbehaviour(
+	ev *types.LightClientAttackEvidence,
+	conflicting *types.LightBlock,
+	common *types.LightBlock,
+	commonNextVals *types.ValidatorSet,
+) (*Misbehaviour, error) {
+	if ev.ConflictingBlock == nil {
+		return nil, errors.New("evidence has no conflicting block")
+	}
+
+	header1, err := NewHeader(ev.ConflictingBlock, common, commonNextVals)
+	if err != nil {
+		return nil, fmt.Errorf("conflicting block of the evidence: %w", err)
+	}
+	header2, err := NewHeader(conflicting, common, commonNextVals)
+	if err != nil {
+		return nil, fmt.Errorf("conflicting light block: %w", err)
+	}
+
+	m := &Misbehaviour{Header1: header1, Header2: header2}
+	return m, m.ValidateBasic(common.ChainID)
+}
+
+// ValidateBasic performs basic validation of the misbehaviour: both headers
+// must be valid, at the same height, and different.
+func (m *Misbehaviour) ValidateBasic(chainID string) error {
+	if m.Header1 == nil || m.Header2 == nil {
+		return errors.New("missing header")
+	}
+	if err := m.Header1.ValidateBasic(chainID); err != nil {
+		return fmt.Errorf("invalid header 1: %w", err)
+	}
+	if err := m.Header2.ValidateBasic(chainID); err != nil {
+		return fmt.Errorf("invalid header 2: %w", err)
+	}
+
+	if m.Header1.Height() != m.Header2.Height() {
+		return fmt.Errorf("headers are at different heights %d and %d", m.Header1.Height(), m.Header2.Height())
+	}
+	if bytes.Equal(m.Header1.SignedHeader.Hash(), m.Header2.SignedHeader.Hash()) {
+		return errors.New("headers do not conflict")
+	}
+	return nil
+}
+
+// Header verifies the light block at the given height, and returns its header
+// verifiable from the trusted light block at trustedHeight, so that an on-chain
+// client trusting the header at trustedHeight can be updated to it.
+//
+// trustedHeight must be below height, and the light block at trustedHeight
+// must have been verified already. An error is returned if the light block
+// can't be verified from the trusted light block in a single skip, with the
+// trust level of the light client: HeaderUpdates then returns the headers
+// updating the on-chain client in several steps.
+func (c *Client) Header(ctx context.Context, trustedHeight, height int64, now time.Time) (*Header, error) {
+	if trustedHeight >= height {
+		return nil, fmt.Errorf("trusted height %d must be below the height %d", trustedHeight, height)
+	}
+	trusted, err := c.TrustedLightBlock(trustedHeight)
+	if err != nil {
+		return nil, fmt.Errorf("can't get the trusted light block at height %d: %w", trustedHeight, err)
+	}
+
+	lb, err := c.VerifyLightBlockAtHeight(ctx, height, now)
+	if err != nil {
+		return nil, err
+	}
+
+	next, err := c.nextLightBlock(ctx, trusted)
+	if err != nil {
+		return nil, err
+	}
+
+	// verify the light block the way on-chain clients do: from the next
+	// validator set of the trusted light block
+	if err := Verify(trusted.SignedHeader, next.ValidatorSet, lb.SignedHeader, lb.ValidatorSet,
+		c.trustingPeriod, now, c.maxClockDrift, c.trustLevel); err != nil {
+		return nil, fmt.Errorf("the light block at height %d can't be verified from the trusted light block "+
+			"at height %d in a single skip, use HeaderUpdates: %w", height, trustedHeight, err)
+	}
+	return NewHeader(lb, trusted, next.ValidatorSet)
+}
+
+// HeaderUpdates verifies the light block at the given height, and returns the
+// headers updating an on-chain client trusting the header at trustedHeight up
+// to it, in ascending height order. Each header is verifiable from the
+// previous one, or from the header at trustedHeight for the first, with the
+// trust level of the light client: there are as many headers as validator set
+// transitions the on-chain client cannot skip over, which are picked among the
+// light blocks verified by the light client.
+//
+// trustedHeight must be below height, and the light block at trustedHeight
+// must have been verified already.
+func (c *Client) HeaderUpdates(ctx context.Context, trustedHeight, height int64, now time.Time) ([]*Header, error) {
+	if trustedHeight >= height {
+		return nil, fmt.Errorf("trusted height %d must be below the height %d", trustedHeight, height)
+	}
+	trusted, err := c.TrustedLightBlock(trustedHeight)
+	if err != nil {
+		return nil, fmt.Errorf("can't get the trusted light block at height %d: %w", trustedHeight, err)
+	}
+
+	if _, err := c.VerifyLightBlockAtHeight(ctx, height, now); err != nil {
+		return nil, err
+	}
+
+	// the light blocks verified between the trusted height and the height, in
+	// descending height order
+	var verified []*types.LightBlock
+	for h := height + 1; h > trustedHeight+1; {
+		lb, err := c.trustedStore.LightBlockBefore(h)
+		if err != nil {
+			return nil, err
+		}
+		if lb.Height <= trustedHeight {
+			break
+		}
+		verified = append(verified, lb)
+		h = lb.Height
+	}
+
+	var headers []*Header
+	for trusted.Height < height {
+		adjacent, err := c.nextLightBlock(ctx, trusted)
+		if err != nil {
+			return nil, err
+		}
+		nextVals := adjacent.ValidatorSet
+
+		// skip to the highest light block verifiable from the trusted one, the
+		// way on-chain clients verify it: from its next validator set
+		var next *types.LightBlock
+		for _, lb := range verified {
+			if lb.Height <= trusted.Height {
+				break
+			}
+			err := Verify(trusted.SignedHeader, nextVals, lb.SignedHeader, lb.ValidatorSet,
+				c.trustingPeriod, now, c.maxClockDrift, c.trustLevel)
+			if err == nil {
+				next = lb
+				break
+			}
+		}
+		// the validator set changed too much to be skipped over: update to the
+		// next height
+		if next == nil {
+			if err := VerifyAdjacent(trusted.SignedHeader, adjacent.SignedHeader, nextVals,
+				c.trustingPeriod, now, c.maxClockDrift); err != nil {
+				return nil, fmt.Errorf("can't verify the light block at height %d: %w", adjacent.Height, err)
+			}
+			next = adjacent
+		}
+
+		header, err := NewHeader(next, trusted, nextVals)
+		if err != nil {
+			return nil, err
+		}
+		headers = append(headers, header)
+		trusted = next
+	}
+
+	return headers, nil
+}
+
+// Misbehaviour packages the evidence of the light client attack recorded by
+// the given divergence into a misbehaviour, for on-chain clients trusting the
+// light block the primary and the witness diverged from.
+func (c *Client) Misbehaviour(ctx context.Context, d *store.Divergence) (*Misbehaviour, error) {
+	if d.EvidenceAgainstPrimary == nil {
+		return nil, errors.New("divergence has no evidence")
+	}
+	if len(d.WitnessTrace) < 2 {
+		return nil, errors.New("divergence has no witness trace")
+	}
+
+	common, conflicting := d.WitnessTrace[0], d.WitnessTrace[len(d.WitnessTrace)-1]
+	next := d.WitnessTrace[1]
+	if next.Height != common.Height+1 {
+		var err error
+		if next, err = c.nextLightBlock(ctx, common); err != nil {
+			return nil, err
+		}
+	}
+
+	return NewMisbehaviour(d.EvidenceAgainstPrimary, conflicting, common, next.ValidatorSet)
+}
+
+// nextLightBlock returns the light block following the trusted one: the
+// trusted light block at the next height, or else the light block of the first
+// provider whose validator set matches the next validators hash of the trusted
+// light block, as the primary may not after an attack. Only the validator set
+// of the latter is verified.
+func (c *Client) nextLightBlock(ctx context.Context, trusted *types.LightBlock) (*types.LightBlock, error) {
+	height := trusted.Height + 1
+	next, err := c.trustedStore.LightBlock(height)
+	switch {
+	case err == nil:
+		return next, nil
+	case !errors.Is(err, store.ErrLightBlockNotFound):
+		return nil, err
+	}
+
+	c.providerMutex.Lock()
+	providers := append([]provider.Provider{c.primary}, c.witnesses...)
+	c.providerMutex.Unlock()
+
+	for _, p := range providers {
+		next, err := c.blocks.lightBlock(ctx, p, height)
+		if err != nil {
+			if isContextErr(err) {
+				return nil, err
+			}
+			c.logger.Debug("failed to get the next validator set", "height", height, "provider", p, "err", err)
+			continue
+		}
+		if bytes.Equal(next.ValidatorSet.Hash(), trusted.NextValidatorsHash) {
+			return next, nil
+		}
+		c.logger.Info("validator set does not match the next validators hash of the trusted light block",
+			"height", height, "provider", p)
+	}
+	return nil, fmt.Errorf("no provider returned the validator set at height %d matching the next validators hash %X",
+		height, trusted.NextValidatorsHash)
+}
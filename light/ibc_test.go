@@ -0,0 +1,152 @@
+package light_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/light"
+	"github.com/tendermint/tendermint/light/provider"
+	dbs "github.com/tendermint/tendermint/light/store/db"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestClientHeaderUpdates(t *testing.T) {
+	const latestHeight = int64(10)
+	// 2 out of 5 validators change at each height, so that the on-chain client
+	// can't skip from the first header to the last
+	headers, vals, _ := genLightBlocksWithKeys(chainID, latestHeight+1, 5, 2, bTime)
+	primary := mockNodeFromHeadersAndVals(headers, vals)
+	witness := mockNodeFromHeadersAndVals(headers, vals)
+
+	c, err := light.NewClient(
+		ctx,
+		chainID,
+		light.TrustOptions{
+			Period: 4 * time.Hour,
+			Height: 1,
+			Hash:   headers[1].Hash(),
+		},
+		primary,
+		[]provider.Provider{witness},
+		dbs.New(dbm.NewMemDB()),
+		light.Logger(log.TestingLogger()),
+	)
+	require.NoError(t, err)
+	now := bTime.Add(time.Hour)
+
+	// the last header can't be verified from the first one in a single skip
+	_, err = c.Header(ctx, 1, latestHeight, now)
+	assert.Error(t, err)
+
+	header, err := c.Header(ctx, 1, 2, now)
+	require.NoError(t, err)
+	assert.Equal(t, headers[2].Hash(), header.SignedHeader.Hash())
+	assert.EqualValues(t, 1, header.TrustedHeight)
+	assert.Equal(t, vals[2].Hash(), header.TrustedValidators.Hash())
+	require.NoError(t, header.ValidateBasic(chainID))
+
+	_, err = c.Header(ctx, latestHeight, 1, now)
+	assert.Error(t, err)
+
+	updates, err := c.HeaderUpdates(ctx, 1, latestHeight, now)
+	require.NoError(t, err)
+	require.Greater(t, len(updates), 1)
+	assert.EqualValues(t, 1, updates[0].TrustedHeight)
+	assert.Equal(t, latestHeight, updates[len(updates)-1].Height())
+
+	// each header is verifiable by an on-chain client trusting the previous one
+	trusted := headers[1]
+	for _, h := range updates {
+		require.NoError(t, h.ValidateBasic(chainID))
+		assert.Equal(t, trusted.Height, h.TrustedHeight)
+		assert.Equal(t, trusted.NextValidatorsHash.Bytes(), h.TrustedValidators.Hash())
+		err := light.Verify(trusted, h.TrustedValidators, h.SignedHeader, h.ValidatorSet,
+			4*time.Hour, now, 10*time.Second, light.DefaultTrustLevel)
+		require.NoError(t, err, "height %d from height %d", h.Height(), h.TrustedHeight)
+		trusted = h.SignedHeader
+	}
+}
+
+func TestClientMisbehaviour(t *testing.T) {
+	// primary performs a lunatic attack from height 2
+	var (
+		latestHeight      = int64(3)
+		valSize           = 5
+		divergenceHeight  = int64(2)
+		primaryHeaders    = make(map[int64]*types.SignedHeader, latestHeight)
+		primaryValidators = make(map[int64]*types.ValidatorSet, latestHeight)
+	)
+
+	witnessHeaders, witnessValidators, chainKeys := genLightBlocksWithKeys(chainID, latestHeight, valSize, 2, bTime)
+
+	forgedKeys := chainKeys[divergenceHeight-1].ChangeKeys(3) // we change 3 out of the 5 validators (still 2/5 remain)
+	forgedVals := forgedKeys.ToValidators(2, 0)
+
+	for height := int64(1); height <= latestHeight; height++ {
+		if height < divergenceHeight {
+			primaryHeaders[height] = witnessHeaders[height]
+			primaryValidators[height] = witnessValidators[height]
+			continue
+		}
+		primaryHeaders[height] = forgedKeys.GenSignedHeader(chainID, height, bTime.Add(time.Duration(height)*time.Minute),
+			nil, forgedVals, forgedVals, hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(forgedKeys))
+		primaryValidators[height] = forgedVals
+	}
+
+	mockWitness := mockNodeFromHeadersAndVals(witnessHeaders, witnessValidators)
+	mockPrimary := mockNodeFromHeadersAndVals(primaryHeaders, primaryValidators)
+	mockWitness.On("ReportEvidence", mock.Anything, mock.Anything).Return(nil)
+	mockPrimary.On("ReportEvidence", mock.Anything, mock.Anything).Return(nil)
+
+	c, err := light.NewClient(
+		ctx,
+		chainID,
+		light.TrustOptions{
+			Period: 4 * time.Hour,
+			Height: 1,
+			Hash:   primaryHeaders[1].Hash(),
+		},
+		mockPrimary,
+		[]provider.Provider{mockWitness},
+		dbs.New(dbm.NewMemDB()),
+		light.Logger(log.TestingLogger()),
+	)
+	require.NoError(t, err)
+
+	_, err = c.VerifyLightBlockAtHeight(ctx, latestHeight, bTime.Add(1*time.Hour))
+	require.Equal(t, light.ErrLightClientAttack, err)
+
+	divergences, err := c.Divergences()
+	require.NoError(t, err)
+	require.Len(t, divergences, 1)
+	d := divergences[0]
+
+	m, err := c.Misbehaviour(ctx, d)
+	require.NoError(t, err)
+	require.NoError(t, m.ValidateBasic(chainID))
+	assert.Equal(t, primaryHeaders[latestHeight].Hash(), m.Header1.SignedHeader.Hash())
+	assert.Equal(t, witnessHeaders[latestHeight].Hash(), m.Header2.SignedHeader.Hash())
+	for _, h := range []*light.Header{m.Header1, m.Header2} {
+		// both headers are verifiable from the header the chains diverged from,
+		// with the validator set the primary forged at height 2 rejected
+		assert.EqualValues(t, 1, h.TrustedHeight)
+		assert.Equal(t, witnessValidators[2].Hash(), h.TrustedValidators.Hash())
+	}
+
+	// the packaged light blocks must conflict
+	same := &light.Misbehaviour{Header1: m.Header2, Header2: m.Header2}
+	assert.Error(t, same.ValidateBasic(chainID))
+	_, err = light.NewMisbehaviour(d.EvidenceAgainstPrimary, d.EvidenceAgainstPrimary.ConflictingBlock,
+		d.WitnessTrace[0], witnessValidators[2])
+	assert.Error(t, err)
+	_, err = light.NewMisbehaviour(d.EvidenceAgainstPrimary, d.WitnessTrace[len(d.WitnessTrace)-1],
+		d.WitnessTrace[0], forgedVals)
+	assert.Error(t, err)
+}
@@ -1,4 +1,4 @@
-package statesync
+package p2p
 
 import (
 	"context"
@@ -15,17 +15,22 @@ import (
 )
 
 var (
-	errNoConnectedPeers    = errors.New("no available peers to dispatch request to")
+	// ErrNoConnectedPeers is returned by Dispatcher.LightBlock when the
+	// dispatcher is connected to no peer.
+	ErrNoConnectedPeers = errors.New("no available peers to dispatch request to")
+
 	errUnsolicitedResponse = errors.New("unsolicited light block response")
 	errNoResponse          = errors.New("peer failed to respond within timeout")
 	errPeerAlreadyBusy     = errors.New("peer is already processing a request")
 	errDisconnected        = errors.New("dispatcher has been disconnected")
 )
 
-// dispatcher keeps a list of peers and allows concurrent requests for light
-// blocks. NOTE: It is not the responsibility of the dispatcher to verify the
-// light blocks.
-type dispatcher struct {
+// Dispatcher keeps a list of peers and allows concurrent requests for light
+// blocks, sent on the light block channel of the p2p network. The reactor
+// owning the channel must pass the responses to Respond and the peer updates
+// to AddPeer and RemovePeer. NOTE: It is not the responsibility of the
+// dispatcher to verify the light blocks.
+type Dispatcher struct {
 	availablePeers *peerlist
 	requestCh      chan<- p2p.Envelope
 	timeout        time.Duration
@@ -35,8 +40,10 @@ type dispatcher struct {
 	running bool
 }
 
-func newDispatcher(requestCh chan<- p2p.Envelope, timeout time.Duration) *dispatcher {
-	return &dispatcher{
+// NewDispatcher returns a new Dispatcher, sending the requests on requestCh
+// and waiting for each response up to the given timeout.
+func NewDispatcher(requestCh chan<- p2p.Envelope, timeout time.Duration) *Dispatcher {
+	return &Dispatcher{
 		availablePeers: newPeerList(),
 		timeout:        timeout,
 		requestCh:      requestCh,
@@ -47,12 +54,12 @@ func newDispatcher(requestCh chan<- p2p.Envelope, timeout time.Duration) *dispat
 
 // LightBlock uses the request channel to fetch a light block from the next peer
 // in a list, tracks the call and waits for the reactor to pass along the response
-func (d *dispatcher) LightBlock(ctx context.Context, height int64) (*types.LightBlock, types.NodeID, error) {
+func (d *Dispatcher) LightBlock(ctx context.Context, height int64) (*types.LightBlock, types.NodeID, error) {
 	d.mtx.Lock()
 	// check to see that the dispatcher is connected to at least one peer
 	if d.availablePeers.Len() == 0 && len(d.calls) == 0 {
 		d.mtx.Unlock()
-		return nil, "", errNoConnectedPeers
+		return nil, "", ErrNoConnectedPeers
 	}
 	d.mtx.Unlock()
 
@@ -65,24 +72,21 @@ func (d *dispatcher) LightBlock(ctx context.Context, height int64) (*types.Light
 
 // Providers turns the dispatcher into a set of providers (per peer) which can
 // be used by a light client
-func (d *dispatcher) Providers(chainID string, timeout time.Duration) []provider.Provider {
+func (d *Dispatcher) Providers(chainID string, timeout time.Duration) []provider.Provider {
 	d.mtx.Lock()
 	defer d.mtx.Unlock()
 
 	providers := make([]provider.Provider, d.availablePeers.Len())
 	peers := d.availablePeers.Peers()
 	for index, peer := range peers {
-		providers[index] = &blockProvider{
-			peer:       peer,
-			dispatcher: d,
-			chainID:    chainID,
-			timeout:    timeout,
-		}
+		providers[index] = NewBlockProvider(peer, chainID, timeout, d)
 	}
 	return providers
 }
 
-func (d *dispatcher) stop() {
+// Stop stops the dispatcher from sending any more requests, and cancels the
+// pending ones.
+func (d *Dispatcher) Stop() {
 	d.mtx.Lock()
 	defer d.mtx.Unlock()
 	d.running = false
@@ -92,13 +96,14 @@ func (d *dispatcher) stop() {
 	}
 }
 
-func (d *dispatcher) start() {
+// Start allows the dispatcher to send requests again after Stop.
+func (d *Dispatcher) Start() {
 	d.mtx.Lock()
 	defer d.mtx.Unlock()
 	d.running = true
 }
 
-func (d *dispatcher) lightBlock(ctx context.Context, height int64, peer types.NodeID) (*types.LightBlock, error) {
+func (d *Dispatcher) lightBlock(ctx context.Context, height int64, peer types.NodeID) (*types.LightBlock, error) {
 	// dispatch the request to the peer
 	callCh, err := d.dispatch(peer, height)
 	if err != nil {
@@ -120,9 +125,9 @@ func (d *dispatcher) lightBlock(ctx context.Context, height int64, peer types.No
 	}
 }
 
-// respond allows the underlying process which receives requests on the
+// Respond allows the underlying process which receives requests on the
 // requestCh to respond with the respective light block
-func (d *dispatcher) respond(lb *proto.LightBlock, peer types.NodeID) error {
+func (d *Dispatcher) Respond(lb *proto.LightBlock, peer types.NodeID) error {
 	d.mtx.Lock()
 	defer d.mtx.Unlock()
 
@@ -144,19 +149,20 @@ func (d *dispatcher) respond(lb *proto.LightBlock, peer types.NodeID) error {
 
 	block, err := types.LightBlockFromProto(lb)
 	if err != nil {
-		fmt.Println("error with converting light block")
-		return err
+		return fmt.Errorf("invalid light block: %w", err)
 	}
 
 	answerCh <- block
 	return nil
 }
 
-func (d *dispatcher) addPeer(peer types.NodeID) {
+// AddPeer adds a peer the dispatcher can send requests to.
+func (d *Dispatcher) AddPeer(peer types.NodeID) {
 	d.availablePeers.Append(peer)
 }
 
-func (d *dispatcher) removePeer(peer types.NodeID) {
+// RemovePeer removes a peer the dispatcher can send requests to.
+func (d *Dispatcher) RemovePeer(peer types.NodeID) {
 	d.mtx.Lock()
 	defer d.mtx.Unlock()
 	if _, ok := d.calls[peer]; ok {
@@ -168,7 +174,7 @@ func (d *dispatcher) removePeer(peer types.NodeID) {
 
 // dispatch takes a peer and allocates it a channel so long as it's not already
 // busy and the receiving channel is still running. It then dispatches the message
-func (d *dispatcher) dispatch(peer types.NodeID, height int64) (chan *types.LightBlock, error) {
+func (d *Dispatcher) dispatch(peer types.NodeID, height int64) (chan *types.LightBlock, error) {
 	d.mtx.Lock()
 	defer d.mtx.Unlock()
 	ch := make(chan *types.LightBlock, 1)
@@ -198,7 +204,7 @@ func (d *dispatcher) dispatch(peer types.NodeID, height int64) (chan *types.Ligh
 
 // release appends the peer back to the list and deletes the allocated call so
 // that a new call can be made to that peer
-func (d *dispatcher) release(peer types.NodeID) {
+func (d *Dispatcher) release(peer types.NodeID) {
 	d.mtx.Lock()
 	defer d.mtx.Unlock()
 	if call, ok := d.calls[peer]; ok {
@@ -210,50 +216,6 @@ func (d *dispatcher) release(peer types.NodeID) {
 
 //----------------------------------------------------------------
 
-// blockProvider is a p2p based light provider which uses a dispatcher connected
-// to the state sync reactor to serve light blocks to the light client
-//
-// TODO: This should probably be moved over to the light package but as we're
-// not yet officially supporting p2p light clients we'll leave this here for now.
-type blockProvider struct {
-	peer       types.NodeID
-	chainID    string
-	timeout    time.Duration
-	dispatcher *dispatcher
-}
-
-func (p *blockProvider) LightBlock(ctx context.Context, height int64) (*types.LightBlock, error) {
-	// FIXME: The provider doesn't know if the dispatcher is still connected to
-	// that peer. If the connection is dropped for whatever reason the
-	// dispatcher needs to be able to relay this back to the provider so it can
-	// return ErrConnectionClosed instead of ErrNoResponse
-	ctx, cancel := context.WithTimeout(ctx, p.timeout)
-	defer cancel()
-	lb, _ := p.dispatcher.lightBlock(ctx, height, p.peer)
-	if lb == nil {
-		return nil, provider.ErrNoResponse
-	}
-
-	if err := lb.ValidateBasic(p.chainID); err != nil {
-		return nil, provider.ErrBadLightBlock{Reason: err}
-	}
-
-	return lb, nil
-}
-
-// ReportEvidence should allow for the light client to report any light client
-// attacks. This is a no op as there currently isn't a way to wire this up to
-// the evidence reactor (we should endeavor to do this in the future but for now
-// it's not critical for backwards verification)
-func (p *blockProvider) ReportEvidence(ctx context.Context, ev types.Evidence) error {
-	return nil
-}
-
-// String implements stringer interface
-func (p *blockProvider) String() string { return string(p.peer) }
-
-//----------------------------------------------------------------
-
 // peerList is a rolling list of peers. This is used to distribute the load of
 // retrieving blocks over all the peers the reactor is connected to
 type peerlist struct {
@@ -299,6 +261,8 @@ func (l *peerlist) Pop(ctx context.Context) types.NodeID {
 	return peer
 }
 
+// Append adds the peer at the end of the list, unless it is already in it,
+// which is the case of the peers released after a request of a BlockProvider.
 func (l *peerlist) Append(peer types.NodeID) {
 	l.mtx.Lock()
 	defer l.mtx.Unlock()
@@ -307,9 +271,15 @@ func (l *peerlist) Append(peer types.NodeID) {
 		l.waiting = l.waiting[1:]
 		wait <- peer
 		close(wait)
-	} else {
-		l.peers = append(l.peers, peer)
+		return
+	}
+
+	for _, p := range l.peers {
+		if p == peer {
+			return
+		}
 	}
+	l.peers = append(l.peers, peer)
 }
 
 func (l *peerlist) Remove(peer types.NodeID) {
@@ -1,4 +1,4 @@
-package statesync
+package p2p
 
 import (
 	"context"
@@ -13,10 +13,14 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/internal/p2p"
+	"github.com/tendermint/tendermint/internal/test/factory"
 	ssproto "github.com/tendermint/tendermint/proto/tendermint/statesync"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/types"
 )
 
+var ctx = context.Background()
+
 func TestDispatcherBasic(t *testing.T) {
 	t.Cleanup(leaktest.Check(t))
 
@@ -24,13 +28,13 @@ func TestDispatcherBasic(t *testing.T) {
 	closeCh := make(chan struct{})
 	defer close(closeCh)
 
-	d := newDispatcher(ch, 1*time.Second)
+	d := NewDispatcher(ch, 1*time.Second)
 
 	go handleRequests(t, d, ch, closeCh)
 
 	peers := createPeerSet(5)
 	for _, peer := range peers {
-		d.addPeer(peer)
+		d.AddPeer(peer)
 	}
 
 	wg := sync.WaitGroup{}
@@ -54,14 +58,14 @@ func TestDispatcherBasic(t *testing.T) {
 func TestDispatcherReturnsNoBlock(t *testing.T) {
 	t.Cleanup(leaktest.Check(t))
 	ch := make(chan p2p.Envelope, 100)
-	d := newDispatcher(ch, 1*time.Second)
+	d := NewDispatcher(ch, 1*time.Second)
 	peerFromSet := createPeerSet(1)[0]
-	d.addPeer(peerFromSet)
+	d.AddPeer(peerFromSet)
 	doneCh := make(chan struct{})
 
 	go func() {
 		<-ch
-		require.NoError(t, d.respond(nil, peerFromSet))
+		require.NoError(t, d.Respond(nil, peerFromSet))
 		close(doneCh)
 	}()
 
@@ -76,21 +80,21 @@ func TestDispatcherReturnsNoBlock(t *testing.T) {
 func TestDispatcherErrorsWhenNoPeers(t *testing.T) {
 	t.Cleanup(leaktest.Check(t))
 	ch := make(chan p2p.Envelope, 100)
-	d := newDispatcher(ch, 1*time.Second)
+	d := NewDispatcher(ch, 1*time.Second)
 
 	lb, peerResult, err := d.LightBlock(context.Background(), 1)
 
 	require.Nil(t, lb)
 	require.Empty(t, peerResult)
-	require.Equal(t, errNoConnectedPeers, err)
+	require.Equal(t, ErrNoConnectedPeers, err)
 }
 
 func TestDispatcherReturnsBlockOncePeerAvailable(t *testing.T) {
 	t.Cleanup(leaktest.Check(t))
 	dispatcherRequestCh := make(chan p2p.Envelope, 100)
-	d := newDispatcher(dispatcherRequestCh, 1*time.Second)
+	d := NewDispatcher(dispatcherRequestCh, 1*time.Second)
 	peerFromSet := createPeerSet(1)[0]
-	d.addPeer(peerFromSet)
+	d.AddPeer(peerFromSet)
 	ctx := context.Background()
 	wrapped, cancelFunc := context.WithCancel(ctx)
 
@@ -115,7 +119,7 @@ func TestDispatcherReturnsBlockOncePeerAvailable(t *testing.T) {
 		lb := &types.LightBlock{}
 		asProto, err := lb.ToProto()
 		require.Nil(t, err)
-		err = d.respond(asProto, peerFromSet)
+		err = d.Respond(asProto, peerFromSet)
 		require.Nil(t, err)
 	}()
 
@@ -134,19 +138,19 @@ func TestDispatcherProviders(t *testing.T) {
 	closeCh := make(chan struct{})
 	defer close(closeCh)
 
-	d := newDispatcher(ch, 1*time.Second)
+	d := NewDispatcher(ch, 1*time.Second)
 
 	go handleRequests(t, d, ch, closeCh)
 
 	peers := createPeerSet(5)
 	for _, peer := range peers {
-		d.addPeer(peer)
+		d.AddPeer(peer)
 	}
 
 	providers := d.Providers(chainID, 5*time.Second)
 	require.Len(t, providers, 5)
 	for i, p := range providers {
-		bp, ok := p.(*blockProvider)
+		bp, ok := p.(*BlockProvider)
 		require.True(t, ok)
 		assert.Equal(t, bp.String(), string(peers[i]))
 		lb, err := p.LightBlock(context.Background(), 10)
@@ -172,6 +176,10 @@ func TestPeerListBasic(t *testing.T) {
 
 	assert.Equal(t, numPeers, peerList.Len())
 
+	// a peer is only listed once
+	peerList.Append(peerSet[0])
+	assert.Equal(t, numPeers, peerList.Len())
+
 	half := numPeers / 2
 	for i := 0; i < half; i++ {
 		assert.Equal(t, peerSet[i], peerList.Pop(ctx))
@@ -279,16 +287,15 @@ func TestPeerListConcurrent(t *testing.T) {
 
 // handleRequests is a helper function usually run in a separate go routine to
 // imitate the expected responses of the reactor wired to the dispatcher
-func handleRequests(t *testing.T, d *dispatcher, ch chan p2p.Envelope, closeCh chan struct{}) {
+func handleRequests(t *testing.T, d *Dispatcher, ch chan p2p.Envelope, closeCh chan struct{}) {
 	t.Helper()
 	for {
 		select {
 		case request := <-ch:
 			height := request.Message.(*ssproto.LightBlockRequest).Height
 			peer := request.To
-			resp := mockLBResp(t, peer, int64(height), time.Now())
-			block, _ := resp.block.ToProto()
-			require.NoError(t, d.respond(block, resp.peer))
+			block, _ := mockLB(t, int64(height), time.Now()).ToProto()
+			require.NoError(t, d.Respond(block, peer))
 		case <-closeCh:
 			return
 		}
@@ -302,3 +309,24 @@ func createPeerSet(num int) []types.NodeID {
 	}
 	return peers
 }
+
+func mockLB(t *testing.T, height int64, time time.Time) *types.LightBlock {
+	header, err := factory.MakeHeader(&types.Header{
+		Height: height,
+		Time:   time,
+	})
+	require.NoError(t, err)
+	vals, pv := factory.RandValidatorSet(3, 10)
+	header.ValidatorsHash = vals.Hash()
+	blockID := factory.MakeBlockIDWithHash(header.Hash())
+	voteSet := types.NewVoteSet(factory.DefaultTestChainID, height, 0, tmproto.PrecommitType, vals)
+	commit, err := factory.MakeCommit(blockID, height, 0, voteSet, pv, time)
+	require.NoError(t, err)
+	return &types.LightBlock{
+		SignedHeader: &types.SignedHeader{
+			Header: header,
+			Commit: commit,
+		},
+		ValidatorSet: vals,
+	}
+}
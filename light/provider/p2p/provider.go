@@ -0,0 +1,112 @@
+package p2p
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/internal/p2p"
+	"github.com/tendermint/tendermint/light/provider"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// BlockProvider is a p2p based light provider, which uses a Dispatcher to
+// request the light blocks from a single peer. Requests are sent one at a
+// time, as a peer only serves one request of a dispatcher at a time.
+type BlockProvider struct {
+	peer       types.NodeID
+	chainID    string
+	timeout    time.Duration
+	dispatcher *Dispatcher
+
+	// evidenceCh is used to report evidence to the peer. If nil, evidence is
+	// not reported.
+	evidenceCh chan<- p2p.Envelope
+
+	mtx sync.Mutex
+}
+
+var _ provider.Provider = (*BlockProvider)(nil)
+
+// NewBlockProvider returns a new BlockProvider requesting the light blocks of
+// the given chain from the given peer, waiting for each up to the timeout.
+func NewBlockProvider(
+	peer types.NodeID,
+	chainID string,
+	timeout time.Duration,
+	dispatcher *Dispatcher,
+) *BlockProvider {
+	return &BlockProvider{
+		peer:       peer,
+		chainID:    chainID,
+		timeout:    timeout,
+		dispatcher: dispatcher,
+	}
+}
+
+// LightBlock implements provider.Provider. A height of 0 requests the latest
+// light block of the peer. ErrLightBlockNotFound is returned if the peer
+// doesn't have the light block, and ErrNoResponse if it failed to respond
+// within the timeout.
+func (p *BlockProvider) LightBlock(ctx context.Context, height int64) (*types.LightBlock, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	// FIXME: The provider doesn't know if the dispatcher is still connected to
+	// that peer. If the connection is dropped for whatever reason the
+	// dispatcher needs to be able to relay this back to the provider so it can
+	// return ErrConnectionClosed instead of ErrNoResponse
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	lb, err := p.dispatcher.lightBlock(ctx, height, p.peer)
+	switch {
+	case errors.Is(err, errDisconnected):
+		return nil, provider.ErrConnectionClosed
+	case err != nil || ctx.Err() != nil:
+		return nil, provider.ErrNoResponse
+	case lb == nil:
+		return nil, provider.ErrLightBlockNotFound
+	}
+
+	if err := lb.ValidateBasic(p.chainID); err != nil {
+		return nil, provider.ErrBadLightBlock{Reason: err}
+	}
+	if height != 0 && lb.Height != height {
+		return nil, provider.ErrBadLightBlock{
+			Reason: fmt.Errorf("height %d responded doesn't match height %d requested", lb.Height, height),
+		}
+	}
+
+	return lb, nil
+}
+
+// ReportEvidence implements provider.Provider, sending the evidence to the
+// peer on the evidence channel on a best effort basis. It is a no op if the
+// provider has no evidence channel, which is the case of the providers
+// returned by Dispatcher.Providers.
+func (p *BlockProvider) ReportEvidence(ctx context.Context, ev types.Evidence) error {
+	if p.evidenceCh == nil {
+		return nil
+	}
+
+	evpb, err := types.EvidenceToProto(ev)
+	if err != nil {
+		return fmt.Errorf("failed to convert evidence to proto: %w", err)
+	}
+
+	select {
+	case p.evidenceCh <- p2p.Envelope{
+		To:      p.peer,
+		Message: &tmproto.EvidenceList{Evidence: []tmproto.Evidence{*evpb}},
+	}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// String implements stringer interface
+func (p *BlockProvider) String() string { return string(p.peer) }
@@ -0,0 +1,225 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
+	"github.com/tendermint/tendermint/internal/p2p"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/service"
+	ssproto "github.com/tendermint/tendermint/proto/tendermint/statesync"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+var _ service.Service = (*Reactor)(nil)
+
+// Reactor connects the BlockProviders of a standalone light client to full
+// nodes over the p2p network. It requests light blocks on the light block
+// channel of the state sync reactor of the full nodes, and reports evidence on
+// the channel of their evidence reactor.
+//
+// The light client doesn't serve light blocks: it responds to light block
+// requests with no light block, and ignores the evidence gossiped to it.
+type Reactor struct {
+	service.BaseService
+
+	chainID     string
+	timeout     time.Duration
+	blockCh     *p2p.Channel
+	evidenceCh  *p2p.Channel
+	peerUpdates *p2p.PeerUpdates
+	dispatcher  *Dispatcher
+	closeCh     chan struct{}
+
+	mtx   tmsync.Mutex
+	peers map[types.NodeID]chan struct{} // closed once the peer is up
+}
+
+// NewReactor returns a new Reactor for the light blocks of the given chain,
+// requested on blockCh and waited for up to the timeout, with evidence
+// reported on evidenceCh. Note, the reactor will close the p2p Channels when
+// stopping.
+func NewReactor(
+	logger log.Logger,
+	chainID string,
+	blockCh, evidenceCh *p2p.Channel,
+	peerUpdates *p2p.PeerUpdates,
+	timeout time.Duration,
+) *Reactor {
+	r := &Reactor{
+		chainID:     chainID,
+		timeout:     timeout,
+		blockCh:     blockCh,
+		evidenceCh:  evidenceCh,
+		peerUpdates: peerUpdates,
+		dispatcher:  NewDispatcher(blockCh.Out, timeout),
+		closeCh:     make(chan struct{}),
+		peers:       make(map[types.NodeID]chan struct{}),
+	}
+
+	r.BaseService = *service.NewBaseService(logger, "LightP2P", r)
+	return r
+}
+
+// OnStart starts separate go routines for each p2p Channel and for the peer
+// updates.
+func (r *Reactor) OnStart() error {
+	go r.processBlockCh()
+	go r.processEvidenceCh()
+	go r.processPeerUpdates()
+
+	r.dispatcher.Start()
+
+	return nil
+}
+
+// OnStop stops the reactor by signaling to all spawned goroutines to exit and
+// blocking until they all exit.
+func (r *Reactor) OnStop() {
+	r.dispatcher.Stop()
+
+	close(r.closeCh)
+
+	<-r.blockCh.Done()
+	<-r.evidenceCh.Done()
+	<-r.peerUpdates.Done()
+}
+
+// Provider returns a BlockProvider requesting light blocks from the given
+// peer, and reporting evidence to it.
+func (r *Reactor) Provider(peer types.NodeID) *BlockProvider {
+	p := NewBlockProvider(peer, r.chainID, r.timeout, r.dispatcher)
+	p.evidenceCh = r.evidenceCh.Out
+	return p
+}
+
+// WaitForPeer blocks until the given peer is connected, or the context is
+// done.
+func (r *Reactor) WaitForPeer(ctx context.Context, peer types.NodeID) error {
+	select {
+	case <-r.peerUp(peer):
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for peer %v: %w", peer, ctx.Err())
+	}
+}
+
+// peerUp returns a channel closed once the given peer is up.
+func (r *Reactor) peerUp(peer types.NodeID) chan struct{} {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	up, ok := r.peers[peer]
+	if !ok {
+		up = make(chan struct{})
+		r.peers[peer] = up
+	}
+	return up
+}
+
+func (r *Reactor) processBlockCh() {
+	defer r.blockCh.Close()
+
+	for {
+		select {
+		case envelope := <-r.blockCh.In:
+			if err := r.handleLightBlockMessage(envelope); err != nil {
+				r.Logger.Error("failed to process light block message", "envelope", envelope, "err", err)
+				r.blockCh.Error <- p2p.PeerError{
+					NodeID: envelope.From,
+					Err:    err,
+				}
+			}
+
+		case <-r.closeCh:
+			r.Logger.Debug("stopped listening on light block channel; closing...")
+			return
+		}
+	}
+}
+
+func (r *Reactor) handleLightBlockMessage(envelope p2p.Envelope) error {
+	switch msg := envelope.Message.(type) {
+	case *ssproto.LightBlockRequest:
+		// we don't serve light blocks
+		r.blockCh.Out <- p2p.Envelope{
+			To:      envelope.From,
+			Message: &ssproto.LightBlockResponse{},
+		}
+
+	case *ssproto.LightBlockResponse:
+		if err := r.dispatcher.Respond(msg.LightBlock, envelope.From); err != nil {
+			r.Logger.Debug("error processing light block response", "peer", envelope.From, "err", err)
+		}
+
+	default:
+		return fmt.Errorf("received unknown message: %T", msg)
+	}
+
+	return nil
+}
+
+func (r *Reactor) processEvidenceCh() {
+	defer r.evidenceCh.Close()
+
+	for {
+		select {
+		case envelope := <-r.evidenceCh.In:
+			// evidence gossiped by the full nodes is not verified by the
+			// light client, which has no evidence pool
+			if _, ok := envelope.Message.(*tmproto.EvidenceList); !ok {
+				r.evidenceCh.Error <- p2p.PeerError{
+					NodeID: envelope.From,
+					Err:    fmt.Errorf("received unknown message: %T", envelope.Message),
+				}
+			}
+
+		case <-r.closeCh:
+			r.Logger.Debug("stopped listening on evidence channel; closing...")
+			return
+		}
+	}
+}
+
+func (r *Reactor) processPeerUpdates() {
+	defer r.peerUpdates.Close()
+
+	for {
+		select {
+		case peerUpdate := <-r.peerUpdates.Updates():
+			r.processPeerUpdate(peerUpdate)
+
+		case <-r.closeCh:
+			r.Logger.Debug("stopped listening on peer updates channel; closing...")
+			return
+		}
+	}
+}
+
+func (r *Reactor) processPeerUpdate(peerUpdate p2p.PeerUpdate) {
+	r.Logger.Debug("received peer update", "peer", peerUpdate.NodeID, "status", peerUpdate.Status)
+
+	switch peerUpdate.Status {
+	case p2p.PeerStatusUp:
+		r.dispatcher.AddPeer(peerUpdate.NodeID)
+
+		up := r.peerUp(peerUpdate.NodeID)
+		r.mtx.Lock()
+		select {
+		case <-up:
+		default:
+			close(up)
+		}
+		r.mtx.Unlock()
+
+	case p2p.PeerStatusDown:
+		r.dispatcher.RemovePeer(peerUpdate.NodeID)
+
+		r.mtx.Lock()
+		delete(r.peers, peerUpdate.NodeID)
+		r.mtx.Unlock()
+	}
+}
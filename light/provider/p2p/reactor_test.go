@@ -0,0 +1,129 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fortytw2/leaktest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/internal/p2p"
+	"github.com/tendermint/tendermint/internal/test/factory"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/light/provider"
+	ssproto "github.com/tendermint/tendermint/proto/tendermint/statesync"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+type reactorTestSuite struct {
+	reactor *Reactor
+
+	blockInCh     chan p2p.Envelope
+	blockOutCh    chan p2p.Envelope
+	evidenceInCh  chan p2p.Envelope
+	evidenceOutCh chan p2p.Envelope
+	peerUpdateCh  chan p2p.PeerUpdate
+}
+
+func setup(t *testing.T) *reactorTestSuite {
+	t.Helper()
+
+	rts := &reactorTestSuite{
+		blockInCh:     make(chan p2p.Envelope, 10),
+		blockOutCh:    make(chan p2p.Envelope, 10),
+		evidenceInCh:  make(chan p2p.Envelope, 10),
+		evidenceOutCh: make(chan p2p.Envelope, 10),
+		peerUpdateCh:  make(chan p2p.PeerUpdate, 10),
+	}
+
+	blockCh := p2p.NewChannel(0x62, new(ssproto.Message), rts.blockInCh, rts.blockOutCh,
+		make(chan p2p.PeerError, 10))
+	evidenceCh := p2p.NewChannel(0x38, new(tmproto.EvidenceList), rts.evidenceInCh, rts.evidenceOutCh,
+		make(chan p2p.PeerError, 10))
+	peerUpdates := p2p.NewPeerUpdates(rts.peerUpdateCh, 10)
+
+	rts.reactor = NewReactor(log.TestingLogger(), factory.DefaultTestChainID, blockCh, evidenceCh,
+		peerUpdates, time.Second)
+	require.NoError(t, rts.reactor.Start())
+	t.Cleanup(func() {
+		require.NoError(t, rts.reactor.Stop())
+	})
+
+	return rts
+}
+
+func TestReactor_Provider(t *testing.T) {
+	t.Cleanup(leaktest.Check(t))
+	rts := setup(t)
+	peer := createPeerSet(1)[0]
+
+	// wait for the peer to connect
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.Error(t, rts.reactor.WaitForPeer(ctx, peer))
+
+	rts.peerUpdateCh <- p2p.PeerUpdate{NodeID: peer, Status: p2p.PeerStatusUp}
+	require.NoError(t, rts.reactor.WaitForPeer(context.Background(), peer))
+
+	p := rts.reactor.Provider(peer)
+	assert.Equal(t, string(peer), p.String())
+
+	// the provider returns the light block responded by the peer
+	lb := mockLB(t, 5, time.Now())
+	go func() {
+		request := <-rts.blockOutCh
+		assert.Equal(t, peer, request.To)
+		assert.EqualValues(t, 5, request.Message.(*ssproto.LightBlockRequest).Height)
+
+		lbpb, err := lb.ToProto()
+		assert.NoError(t, err)
+		rts.blockInCh <- p2p.Envelope{From: peer, Message: &ssproto.LightBlockResponse{LightBlock: lbpb}}
+	}()
+	resp, err := p.LightBlock(context.Background(), 5)
+	require.NoError(t, err)
+	assert.Equal(t, lb.Hash(), resp.Hash())
+
+	// a light block of another height is bad
+	go func() {
+		<-rts.blockOutCh
+		lbpb, err := lb.ToProto()
+		assert.NoError(t, err)
+		rts.blockInCh <- p2p.Envelope{From: peer, Message: &ssproto.LightBlockResponse{LightBlock: lbpb}}
+	}()
+	_, err = p.LightBlock(context.Background(), 6)
+	assert.IsType(t, provider.ErrBadLightBlock{}, err)
+
+	// no light block means the peer doesn't have it
+	go func() {
+		<-rts.blockOutCh
+		rts.blockInCh <- p2p.Envelope{From: peer, Message: &ssproto.LightBlockResponse{}}
+	}()
+	_, err = p.LightBlock(context.Background(), 7)
+	assert.Equal(t, provider.ErrLightBlockNotFound, err)
+
+	// no response within the timeout
+	_, err = p.LightBlock(context.Background(), 8)
+	assert.Equal(t, provider.ErrNoResponse, err)
+	<-rts.blockOutCh
+
+	// evidence is reported to the peer
+	ev := types.NewMockDuplicateVoteEvidence(1, time.Now(), factory.DefaultTestChainID)
+	require.NoError(t, p.ReportEvidence(context.Background(), ev))
+	report := <-rts.evidenceOutCh
+	assert.Equal(t, peer, report.To)
+	require.Len(t, report.Message.(*tmproto.EvidenceList).Evidence, 1)
+}
+
+func TestReactor_DoesNotServeLightBlocks(t *testing.T) {
+	t.Cleanup(leaktest.Check(t))
+	rts := setup(t)
+	peer := createPeerSet(1)[0]
+
+	rts.blockInCh <- p2p.Envelope{From: peer, Message: &ssproto.LightBlockRequest{Height: 1}}
+	response := <-rts.blockOutCh
+	assert.Equal(t, peer, response.To)
+	assert.Nil(t, response.Message.(*ssproto.LightBlockResponse).LightBlock)
+}
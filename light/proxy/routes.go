@@ -1,7 +1,10 @@
 package proxy
 
 import (
+	"encoding/json"
+
 	"github.com/tendermint/tendermint/libs/bytes"
+	tmjson "github.com/tendermint/tendermint/libs/json"
 	lrpc "github.com/tendermint/tendermint/light/rpc"
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
@@ -23,11 +26,12 @@ func RPCRoutes(c *lrpc.Client) map[string]*rpcserver.RPCFunc {
 		"net_info":             rpcserver.NewRPCFunc(makeNetInfoFunc(c), "", false),
 		"blockchain":           rpcserver.NewRPCFunc(makeBlockchainInfoFunc(c), "minHeight,maxHeight", true),
 		"genesis":              rpcserver.NewRPCFunc(makeGenesisFunc(c), "", true),
-		"genesis_chunked":      rpcserver.NewRPCFunc(makeGenesisChunkedFunc(c), "", true),
+		"genesis_chunked":      rpcserver.NewRPCFunc(makeGenesisChunkedFunc(c), "chunk", true),
 		"block":                rpcserver.NewRPCFunc(makeBlockFunc(c), "height", true),
 		"block_by_hash":        rpcserver.NewRPCFunc(makeBlockByHashFunc(c), "hash", true),
 		"block_results":        rpcserver.NewRPCFunc(makeBlockResultsFunc(c), "height", true),
 		"commit":               rpcserver.NewRPCFunc(makeCommitFunc(c), "height", true),
+		"check_tx":             rpcserver.NewRPCFunc(makeCheckTxFunc(c), "tx", true),
 		"tx":                   rpcserver.NewRPCFunc(makeTxFunc(c), "hash,prove", true),
 		"tx_search":            rpcserver.NewRPCFunc(makeTxSearchFunc(c), "query,prove,page,per_page,order_by", false),
 		"block_search":         rpcserver.NewRPCFunc(makeBlockSearchFunc(c), "query,page,per_page,order_by", false),
@@ -37,6 +41,9 @@ func RPCRoutes(c *lrpc.Client) map[string]*rpcserver.RPCFunc {
 		"consensus_params":     rpcserver.NewRPCFunc(makeConsensusParamsFunc(c), "height", true),
 		"unconfirmed_txs":      rpcserver.NewRPCFunc(makeUnconfirmedTxsFunc(c), "limit", false),
 		"num_unconfirmed_txs":  rpcserver.NewRPCFunc(makeNumUnconfirmedTxsFunc(c), "", false),
+		"mempool_tx":           rpcserver.NewRPCFunc(makeMempoolTxFunc(c), "hash", false),
+		"mempool_txs": rpcserver.NewRPCFunc(makeMempoolTxsFunc(c),
+			"sender,min_priority,max_priority,page,per_page,sort_by,order_by", false),
 
 		// tx broadcast API
 		"broadcast_tx_commit": rpcserver.NewRPCFunc(makeBroadcastTxCommitFunc(c), "tx", false),
@@ -49,31 +56,34 @@ func RPCRoutes(c *lrpc.Client) map[string]*rpcserver.RPCFunc {
 
 		// evidence API
 		"broadcast_evidence": rpcserver.NewRPCFunc(makeBroadcastEvidenceFunc(c), "evidence", false),
+
+		// light client API
+		"divergences": rpcserver.NewRPCFunc(makeDivergencesFunc(c), "", false),
 	}
 }
 
-type rpcHealthFunc func(ctx *rpctypes.Context) (*ctypes.ResultHealth, error)
+type rpcHealthFunc func(ctx *rpctypes.Context) (*lrpc.ResultUnverified, error)
 
 func makeHealthFunc(c *lrpc.Client) rpcHealthFunc {
-	return func(ctx *rpctypes.Context) (*ctypes.ResultHealth, error) {
-		return c.Health(ctx.Context())
+	return func(ctx *rpctypes.Context) (*lrpc.ResultUnverified, error) {
+		return lrpc.Unverified(c.Health(ctx.Context()))
 	}
 }
 
-type rpcStatusFunc func(ctx *rpctypes.Context) (*ctypes.ResultStatus, error)
+type rpcStatusFunc func(ctx *rpctypes.Context) (*lrpc.ResultUnverified, error)
 
 // nolint: interfacer
 func makeStatusFunc(c *lrpc.Client) rpcStatusFunc {
-	return func(ctx *rpctypes.Context) (*ctypes.ResultStatus, error) {
-		return c.Status(ctx.Context())
+	return func(ctx *rpctypes.Context) (*lrpc.ResultUnverified, error) {
+		return lrpc.Unverified(c.Status(ctx.Context()))
 	}
 }
 
-type rpcNetInfoFunc func(ctx *rpctypes.Context) (*ctypes.ResultNetInfo, error)
+type rpcNetInfoFunc func(ctx *rpctypes.Context) (*lrpc.ResultUnverified, error)
 
 func makeNetInfoFunc(c *lrpc.Client) rpcNetInfoFunc {
-	return func(ctx *rpctypes.Context) (*ctypes.ResultNetInfo, error) {
-		return c.NetInfo(ctx.Context())
+	return func(ctx *rpctypes.Context) (*lrpc.ResultUnverified, error) {
+		return lrpc.Unverified(c.NetInfo(ctx.Context()))
 	}
 }
 
@@ -85,19 +95,19 @@ func makeBlockchainInfoFunc(c *lrpc.Client) rpcBlockchainInfoFunc {
 	}
 }
 
-type rpcGenesisFunc func(ctx *rpctypes.Context) (*ctypes.ResultGenesis, error)
+type rpcGenesisFunc func(ctx *rpctypes.Context) (*lrpc.ResultUnverified, error)
 
 func makeGenesisFunc(c *lrpc.Client) rpcGenesisFunc {
-	return func(ctx *rpctypes.Context) (*ctypes.ResultGenesis, error) {
-		return c.Genesis(ctx.Context())
+	return func(ctx *rpctypes.Context) (*lrpc.ResultUnverified, error) {
+		return lrpc.Unverified(c.Genesis(ctx.Context()))
 	}
 }
 
-type rpcGenesisChunkedFunc func(ctx *rpctypes.Context, chunk uint) (*ctypes.ResultGenesisChunk, error)
+type rpcGenesisChunkedFunc func(ctx *rpctypes.Context, chunk uint) (*lrpc.ResultUnverified, error)
 
 func makeGenesisChunkedFunc(c *lrpc.Client) rpcGenesisChunkedFunc {
-	return func(ctx *rpctypes.Context, chunk uint) (*ctypes.ResultGenesisChunk, error) {
-		return c.GenesisChunked(ctx.Context(), chunk)
+	return func(ctx *rpctypes.Context, chunk uint) (*lrpc.ResultUnverified, error) {
+		return lrpc.Unverified(c.GenesisChunked(ctx.Context(), chunk))
 	}
 }
 
@@ -133,6 +143,14 @@ func makeCommitFunc(c *lrpc.Client) rpcCommitFunc {
 	}
 }
 
+type rpcCheckTxFunc func(ctx *rpctypes.Context, tx types.Tx) (*lrpc.ResultUnverified, error)
+
+func makeCheckTxFunc(c *lrpc.Client) rpcCheckTxFunc {
+	return func(ctx *rpctypes.Context, tx types.Tx) (*lrpc.ResultUnverified, error) {
+		return lrpc.Unverified(c.CheckTx(ctx.Context(), tx))
+	}
+}
+
 type rpcTxFunc func(ctx *rpctypes.Context, hash []byte, prove bool) (*ctypes.ResultTx, error)
 
 func makeTxFunc(c *lrpc.Client) rpcTxFunc {
@@ -164,7 +182,6 @@ func makeTxSearchFunc(c *lrpc.Client) rpcTxSearchFunc {
 type rpcBlockSearchFunc func(
 	ctx *rpctypes.Context,
 	query string,
-	prove bool,
 	page, perPage *int,
 	orderBy string,
 ) (*ctypes.ResultBlockSearch, error)
@@ -173,7 +190,6 @@ func makeBlockSearchFunc(c *lrpc.Client) rpcBlockSearchFunc {
 	return func(
 		ctx *rpctypes.Context,
 		query string,
-		prove bool,
 		page, perPage *int,
 		orderBy string,
 	) (*ctypes.ResultBlockSearch, error) {
@@ -190,19 +206,19 @@ func makeValidatorsFunc(c *lrpc.Client) rpcValidatorsFunc {
 	}
 }
 
-type rpcDumpConsensusStateFunc func(ctx *rpctypes.Context) (*ctypes.ResultDumpConsensusState, error)
+type rpcDumpConsensusStateFunc func(ctx *rpctypes.Context) (*lrpc.ResultUnverified, error)
 
 func makeDumpConsensusStateFunc(c *lrpc.Client) rpcDumpConsensusStateFunc {
-	return func(ctx *rpctypes.Context) (*ctypes.ResultDumpConsensusState, error) {
-		return c.DumpConsensusState(ctx.Context())
+	return func(ctx *rpctypes.Context) (*lrpc.ResultUnverified, error) {
+		return lrpc.Unverified(c.DumpConsensusState(ctx.Context()))
 	}
 }
 
-type rpcConsensusStateFunc func(ctx *rpctypes.Context) (*ctypes.ResultConsensusState, error)
+type rpcConsensusStateFunc func(ctx *rpctypes.Context) (*lrpc.ResultUnverified, error)
 
 func makeConsensusStateFunc(c *lrpc.Client) rpcConsensusStateFunc {
-	return func(ctx *rpctypes.Context) (*ctypes.ResultConsensusState, error) {
-		return c.ConsensusState(ctx.Context())
+	return func(ctx *rpctypes.Context) (*lrpc.ResultUnverified, error) {
+		return lrpc.Unverified(c.ConsensusState(ctx.Context()))
 	}
 }
 
@@ -214,43 +230,81 @@ func makeConsensusParamsFunc(c *lrpc.Client) rpcConsensusParamsFunc {
 	}
 }
 
-type rpcUnconfirmedTxsFunc func(ctx *rpctypes.Context, limit *int) (*ctypes.ResultUnconfirmedTxs, error)
+type rpcUnconfirmedTxsFunc func(ctx *rpctypes.Context, limit *int) (*lrpc.ResultUnverified, error)
 
 func makeUnconfirmedTxsFunc(c *lrpc.Client) rpcUnconfirmedTxsFunc {
-	return func(ctx *rpctypes.Context, limit *int) (*ctypes.ResultUnconfirmedTxs, error) {
-		return c.UnconfirmedTxs(ctx.Context(), limit)
+	return func(ctx *rpctypes.Context, limit *int) (*lrpc.ResultUnverified, error) {
+		return lrpc.Unverified(c.UnconfirmedTxs(ctx.Context(), limit))
 	}
 }
 
-type rpcNumUnconfirmedTxsFunc func(ctx *rpctypes.Context) (*ctypes.ResultUnconfirmedTxs, error)
+type rpcNumUnconfirmedTxsFunc func(ctx *rpctypes.Context) (*lrpc.ResultUnverified, error)
 
 func makeNumUnconfirmedTxsFunc(c *lrpc.Client) rpcNumUnconfirmedTxsFunc {
-	return func(ctx *rpctypes.Context) (*ctypes.ResultUnconfirmedTxs, error) {
-		return c.NumUnconfirmedTxs(ctx.Context())
+	return func(ctx *rpctypes.Context) (*lrpc.ResultUnverified, error) {
+		return lrpc.Unverified(c.NumUnconfirmedTxs(ctx.Context()))
 	}
 }
 
-type rpcBroadcastTxCommitFunc func(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error)
+type rpcMempoolTxFunc func(ctx *rpctypes.Context, hash []byte) (*lrpc.ResultUnverified, error)
+
+func makeMempoolTxFunc(c *lrpc.Client) rpcMempoolTxFunc {
+	return func(ctx *rpctypes.Context, hash []byte) (*lrpc.ResultUnverified, error) {
+		return lrpc.Unverified(c.MempoolTx(ctx.Context(), hash))
+	}
+}
 
+type rpcMempoolTxsFunc func(
+	ctx *rpctypes.Context,
+	sender string,
+	minPriority, maxPriority *int64,
+	page, perPage *int,
+	sortBy, orderBy string,
+) (*lrpc.ResultUnverified, error)
+
+func makeMempoolTxsFunc(c *lrpc.Client) rpcMempoolTxsFunc {
+	return func(
+		ctx *rpctypes.Context,
+		sender string,
+		minPriority, maxPriority *int64,
+		page, perPage *int,
+		sortBy, orderBy string,
+	) (*lrpc.ResultUnverified, error) {
+		return lrpc.Unverified(c.MempoolTxs(ctx.Context(), sender, minPriority, maxPriority, page, perPage, sortBy, orderBy))
+	}
+}
+
+type rpcBroadcastTxCommitFunc func(ctx *rpctypes.Context, tx types.Tx) (json.RawMessage, error)
+
+// makeBroadcastTxCommitFunc marks the result unverified unless the tx was
+// committed, in which case its inclusion and result were verified. The result
+// is encoded here since its type depends on whether it was verified.
 func makeBroadcastTxCommitFunc(c *lrpc.Client) rpcBroadcastTxCommitFunc {
-	return func(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
-		return c.BroadcastTxCommit(ctx.Context(), tx)
+	return func(ctx *rpctypes.Context, tx types.Tx) (json.RawMessage, error) {
+		res, err := c.BroadcastTxCommit(ctx.Context(), tx)
+		if err != nil {
+			return nil, err
+		}
+		if res.Height == 0 {
+			return tmjson.Marshal(lrpc.ResultUnverified{Result: res})
+		}
+		return tmjson.Marshal(res)
 	}
 }
 
-type rpcBroadcastTxSyncFunc func(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error)
+type rpcBroadcastTxSyncFunc func(ctx *rpctypes.Context, tx types.Tx) (*lrpc.ResultUnverified, error)
 
 func makeBroadcastTxSyncFunc(c *lrpc.Client) rpcBroadcastTxSyncFunc {
-	return func(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
-		return c.BroadcastTxSync(ctx.Context(), tx)
+	return func(ctx *rpctypes.Context, tx types.Tx) (*lrpc.ResultUnverified, error) {
+		return lrpc.Unverified(c.BroadcastTxSync(ctx.Context(), tx))
 	}
 }
 
-type rpcBroadcastTxAsyncFunc func(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error)
+type rpcBroadcastTxAsyncFunc func(ctx *rpctypes.Context, tx types.Tx) (*lrpc.ResultUnverified, error)
 
 func makeBroadcastTxAsyncFunc(c *lrpc.Client) rpcBroadcastTxAsyncFunc {
-	return func(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
-		return c.BroadcastTxAsync(ctx.Context(), tx)
+	return func(ctx *rpctypes.Context, tx types.Tx) (*lrpc.ResultUnverified, error) {
+		return lrpc.Unverified(c.BroadcastTxAsync(ctx.Context(), tx))
 	}
 }
 
@@ -268,19 +322,27 @@ func makeABCIQueryFunc(c *lrpc.Client) rpcABCIQueryFunc {
 	}
 }
 
-type rpcABCIInfoFunc func(ctx *rpctypes.Context) (*ctypes.ResultABCIInfo, error)
+type rpcABCIInfoFunc func(ctx *rpctypes.Context) (*lrpc.ResultUnverified, error)
 
 func makeABCIInfoFunc(c *lrpc.Client) rpcABCIInfoFunc {
-	return func(ctx *rpctypes.Context) (*ctypes.ResultABCIInfo, error) {
-		return c.ABCIInfo(ctx.Context())
+	return func(ctx *rpctypes.Context) (*lrpc.ResultUnverified, error) {
+		return lrpc.Unverified(c.ABCIInfo(ctx.Context()))
 	}
 }
 
-type rpcBroadcastEvidenceFunc func(ctx *rpctypes.Context, ev types.Evidence) (*ctypes.ResultBroadcastEvidence, error)
+type rpcBroadcastEvidenceFunc func(ctx *rpctypes.Context, ev types.Evidence) (*lrpc.ResultUnverified, error)
 
 // nolint: interfacer
 func makeBroadcastEvidenceFunc(c *lrpc.Client) rpcBroadcastEvidenceFunc {
-	return func(ctx *rpctypes.Context, ev types.Evidence) (*ctypes.ResultBroadcastEvidence, error) {
-		return c.BroadcastEvidence(ctx.Context(), ev)
+	return func(ctx *rpctypes.Context, ev types.Evidence) (*lrpc.ResultUnverified, error) {
+		return lrpc.Unverified(c.BroadcastEvidence(ctx.Context(), ev))
+	}
+}
+
+type rpcDivergencesFunc func(ctx *rpctypes.Context) (*lrpc.ResultDivergences, error)
+
+func makeDivergencesFunc(c *lrpc.Client) rpcDivergencesFunc {
+	return func(ctx *rpctypes.Context) (*lrpc.ResultDivergences, error) {
+		return c.Divergences(ctx.Context())
 	}
 }
@@ -15,6 +15,7 @@ import (
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 	tmmath "github.com/tendermint/tendermint/libs/math"
 	service "github.com/tendermint/tendermint/libs/service"
+	"github.com/tendermint/tendermint/light/store"
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
@@ -31,6 +32,7 @@ type LightClient interface {
 	Update(ctx context.Context, now time.Time) (*types.LightBlock, error)
 	VerifyLightBlockAtHeight(ctx context.Context, height int64, now time.Time) (*types.LightBlock, error)
 	TrustedLightBlock(height int64) (*types.LightBlock, error)
+	Divergences() ([]*store.Divergence, error)
 }
 
 var _ rpcclient.Client = (*Client)(nil)
@@ -113,10 +115,12 @@ func (c *Client) OnStop() {
 	}
 }
 
+// Status calls rpcclient#Status. The result is not verified.
 func (c *Client) Status(ctx context.Context) (*ctypes.ResultStatus, error) {
 	return c.next.Status(ctx)
 }
 
+// ABCIInfo calls rpcclient#ABCIInfo. The result is not verified.
 func (c *Client) ABCIInfo(ctx context.Context) (*ctypes.ResultABCIInfo, error) {
 	return c.next.ABCIInfo(ctx)
 }
@@ -188,42 +192,105 @@ func (c *Client) ABCIQueryWithOptions(ctx context.Context, path string, data tmb
 	return &ctypes.ResultABCIQuery{Response: resp}, nil
 }
 
+// BroadcastTxCommit calls rpcclient#BroadcastTxCommit and then, if the tx was
+// committed, verifies that the tx is included in the block at the returned
+// height and that its DeliverTx result matches the block results, waiting for
+// the next block, whose header commits to these results. The CheckTx result is
+// not verified.
 func (c *Client) BroadcastTxCommit(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
-	return c.next.BroadcastTxCommit(ctx, tx)
+	res, err := c.next.BroadcastTxCommit(ctx, tx)
+	if err != nil || res.Height == 0 {
+		return res, err
+	}
+
+	// Validate res.
+	if res.Height < 0 {
+		return nil, ctypes.ErrZeroOrNegativeHeight
+	}
+	if !bytes.Equal(res.Hash, tx.Hash()) {
+		return nil, fmt.Errorf("tx hash %X does not match with tx %X", res.Hash, tx.Hash())
+	}
+
+	// Verify the block includes the tx.
+	block, err := c.Block(ctx, &res.Height)
+	if err != nil {
+		return nil, err
+	}
+	index := block.Block.Data.Txs.Index(tx)
+	if index < 0 {
+		return nil, fmt.Errorf("tx %X is not included in block %d", res.Hash, res.Height)
+	}
+
+	// Verify the result of the tx.
+	err = c.verifyTxResult(ctx, res.Height, uint32(index), &res.DeliverTx, make(blockResultsCache))
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
 }
 
+// BroadcastTxAsync calls rpcclient#BroadcastTxAsync. The result is not
+// verified.
 func (c *Client) BroadcastTxAsync(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
 	return c.next.BroadcastTxAsync(ctx, tx)
 }
 
+// BroadcastTxSync calls rpcclient#BroadcastTxSync. The result is not verified.
 func (c *Client) BroadcastTxSync(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
 	return c.next.BroadcastTxSync(ctx, tx)
 }
 
+// UnconfirmedTxs calls rpcclient#UnconfirmedTxs. The result is not verified.
 func (c *Client) UnconfirmedTxs(ctx context.Context, limit *int) (*ctypes.ResultUnconfirmedTxs, error) {
 	return c.next.UnconfirmedTxs(ctx, limit)
 }
 
+// NumUnconfirmedTxs calls rpcclient#NumUnconfirmedTxs. The result is not
+// verified.
 func (c *Client) NumUnconfirmedTxs(ctx context.Context) (*ctypes.ResultUnconfirmedTxs, error) {
 	return c.next.NumUnconfirmedTxs(ctx)
 }
 
+// MempoolTx calls rpcclient#MempoolTx. The result is not verified.
+func (c *Client) MempoolTx(ctx context.Context, hash []byte) (*ctypes.ResultMempoolTx, error) {
+	return c.next.MempoolTx(ctx, hash)
+}
+
+// MempoolTxs calls rpcclient#MempoolTxs. The result is not verified.
+func (c *Client) MempoolTxs(
+	ctx context.Context,
+	sender string,
+	minPriority, maxPriority *int64,
+	page, perPage *int,
+	sortBy, orderBy string,
+) (*ctypes.ResultMempoolTxs, error) {
+	return c.next.MempoolTxs(ctx, sender, minPriority, maxPriority, page, perPage, sortBy, orderBy)
+}
+
+// CheckTx calls rpcclient#CheckTx. The result is not verified.
 func (c *Client) CheckTx(ctx context.Context, tx types.Tx) (*ctypes.ResultCheckTx, error) {
 	return c.next.CheckTx(ctx, tx)
 }
 
+// NetInfo calls rpcclient#NetInfo. The result is not verified.
 func (c *Client) NetInfo(ctx context.Context) (*ctypes.ResultNetInfo, error) {
 	return c.next.NetInfo(ctx)
 }
 
+// DumpConsensusState calls rpcclient#DumpConsensusState. The result is not
+// verified.
 func (c *Client) DumpConsensusState(ctx context.Context) (*ctypes.ResultDumpConsensusState, error) {
 	return c.next.DumpConsensusState(ctx)
 }
 
+// ConsensusState calls rpcclient#ConsensusState. The result is not verified.
 func (c *Client) ConsensusState(ctx context.Context) (*ctypes.ResultConsensusState, error) {
 	return c.next.ConsensusState(ctx)
 }
 
+// ConsensusParams calls rpcclient#ConsensusParams and then verifies the hash
+// of the params against the trusted header.
 func (c *Client) ConsensusParams(ctx context.Context, height *int64) (*ctypes.ResultConsensusParams, error) {
 	res, err := c.next.ConsensusParams(ctx, height)
 	if err != nil {
@@ -253,6 +320,7 @@ func (c *Client) ConsensusParams(ctx context.Context, height *int64) (*ctypes.Re
 	return res, nil
 }
 
+// Health calls rpcclient#Health. The result is not verified.
 func (c *Client) Health(ctx context.Context) (*ctypes.ResultHealth, error) {
 	return c.next.Health(ctx)
 }
@@ -298,10 +366,12 @@ func (c *Client) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64)
 	return res, nil
 }
 
+// Genesis calls rpcclient#Genesis. The result is not verified.
 func (c *Client) Genesis(ctx context.Context) (*ctypes.ResultGenesis, error) {
 	return c.next.Genesis(ctx)
 }
 
+// GenesisChunked calls rpcclient#GenesisChunked. The result is not verified.
 func (c *Client) GenesisChunked(ctx context.Context, id uint) (*ctypes.ResultGenesisChunk, error) {
 	return c.next.GenesisChunked(ctx, id)
 }
@@ -313,31 +383,41 @@ func (c *Client) Block(ctx context.Context, height *int64) (*ctypes.ResultBlock,
 		return nil, err
 	}
 
+	if err := c.verifyBlock(ctx, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// verifyBlock verifies the given block against the trusted header at its
+// height.
+func (c *Client) verifyBlock(ctx context.Context, res *ctypes.ResultBlock) error {
 	// Validate res.
 	if err := res.BlockID.ValidateBasic(); err != nil {
-		return nil, err
+		return err
 	}
 	if err := res.Block.ValidateBasic(); err != nil {
-		return nil, err
+		return err
 	}
 	if bmH, bH := res.BlockID.Hash, res.Block.Hash(); !bytes.Equal(bmH, bH) {
-		return nil, fmt.Errorf("blockID %X does not match with block %X",
+		return fmt.Errorf("blockID %X does not match with block %X",
 			bmH, bH)
 	}
 
 	// Update the light client if we're behind.
 	l, err := c.updateLightClientIfNeededTo(ctx, &res.Block.Height)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// Verify block.
 	if bH, tH := res.Block.Hash(), l.Hash(); !bytes.Equal(bH, tH) {
-		return nil, fmt.Errorf("block header %X does not match with trusted header %X",
+		return fmt.Errorf("block header %X does not match with trusted header %X",
 			bH, tH)
 	}
 
-	return res, nil
+	return nil
 }
 
 // BlockByHash calls rpcclient#BlockByHash and then verifies the result.
@@ -347,28 +427,11 @@ func (c *Client) BlockByHash(ctx context.Context, hash []byte) (*ctypes.ResultBl
 		return nil, err
 	}
 
-	// Validate res.
-	if err := res.BlockID.ValidateBasic(); err != nil {
-		return nil, err
-	}
-	if err := res.Block.ValidateBasic(); err != nil {
-		return nil, err
-	}
-	if bmH, bH := res.BlockID.Hash, res.Block.Hash(); !bytes.Equal(bmH, bH) {
-		return nil, fmt.Errorf("blockID %X does not match with block %X",
-			bmH, bH)
-	}
-
-	// Update the light client if we're behind.
-	l, err := c.updateLightClientIfNeededTo(ctx, &res.Block.Height)
-	if err != nil {
+	if err := c.verifyBlock(ctx, res); err != nil {
 		return nil, err
 	}
-
-	// Verify block.
-	if bH, tH := res.Block.Hash(), l.Hash(); !bytes.Equal(bH, tH) {
-		return nil, fmt.Errorf("block header %X does not match with trusted header %X",
-			bH, tH)
+	if !bytes.Equal(res.BlockID.Hash, hash) {
+		return nil, fmt.Errorf("block %X does not match with requested hash %X", res.BlockID.Hash, hash)
 	}
 
 	return res, nil
@@ -452,29 +515,32 @@ func (c *Client) Commit(ctx context.Context, height *int64) (*ctypes.ResultCommi
 	}, nil
 }
 
-// Tx calls rpcclient#Tx method and then verifies the proof if such was
-// requested.
+// Tx calls rpcclient#Tx method and then verifies the inclusion proof of the tx,
+// which is always requested, and its result. The proof is only returned if
+// such was requested.
 func (c *Client) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error) {
-	res, err := c.next.Tx(ctx, hash, prove)
-	if err != nil || !prove {
-		return res, err
+	res, err := c.next.Tx(ctx, hash, true)
+	if err != nil {
+		return nil, err
 	}
 
-	// Validate res.
-	if res.Height <= 0 {
-		return nil, ctypes.ErrZeroOrNegativeHeight
+	if !bytes.Equal(res.Hash, hash) {
+		return nil, fmt.Errorf("tx hash %X does not match with requested hash %X", res.Hash, hash)
 	}
-
-	// Update the light client if we're behind.
-	l, err := c.updateLightClientIfNeededTo(ctx, &res.Height)
-	if err != nil {
+	if err := c.verifyTx(ctx, res, make(blockResultsCache)); err != nil {
 		return nil, err
 	}
 
-	// Validate the proof.
-	return res, res.Proof.Validate(l.DataHash)
+	if !prove {
+		res.Proof = types.TxProof{}
+	}
+	return res, nil
 }
 
+// TxSearch calls rpcclient#TxSearch method and then verifies the inclusion
+// proof of each tx, which is always requested, and its result. The proofs are
+// only returned if such was requested. Note, there is no way to verify that no
+// tx matching the query was omitted.
 func (c *Client) TxSearch(
 	ctx context.Context,
 	query string,
@@ -482,16 +548,128 @@ func (c *Client) TxSearch(
 	page, perPage *int,
 	orderBy string,
 ) (*ctypes.ResultTxSearch, error) {
-	return c.next.TxSearch(ctx, query, prove, page, perPage, orderBy)
+	res, err := c.next.TxSearch(ctx, query, true, page, perPage, orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(blockResultsCache)
+	for _, tx := range res.Txs {
+		if tx == nil {
+			return nil, errors.New("nil tx")
+		}
+		if err := c.verifyTx(ctx, tx, results); err != nil {
+			return nil, err
+		}
+
+		if !prove {
+			tx.Proof = types.TxProof{}
+		}
+	}
+
+	return res, nil
 }
 
+// BlockSearch calls rpcclient#BlockSearch method and then verifies each block
+// against the trusted header at its height. Note, there is no way to verify
+// that no block matching the query was omitted.
 func (c *Client) BlockSearch(
 	ctx context.Context,
 	query string,
 	page, perPage *int,
 	orderBy string,
 ) (*ctypes.ResultBlockSearch, error) {
-	return c.next.BlockSearch(ctx, query, page, perPage, orderBy)
+	res, err := c.next.BlockSearch(ctx, query, page, perPage, orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, block := range res.Blocks {
+		if block == nil {
+			return nil, errors.New("nil block")
+		}
+		if err := c.verifyBlock(ctx, block); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// blockResultsCache holds the verified results of the blocks, by height.
+type blockResultsCache map[int64]*ctypes.ResultBlockResults
+
+// verifyTx verifies the inclusion proof of the given tx against the trusted
+// header at its height, and its result against the results of the block.
+func (c *Client) verifyTx(ctx context.Context, res *ctypes.ResultTx, results blockResultsCache) error {
+	// Validate res.
+	if res.Height <= 0 {
+		return ctypes.ErrZeroOrNegativeHeight
+	}
+	if !bytes.Equal(res.Hash, res.Tx.Hash()) {
+		return fmt.Errorf("tx hash %X does not match with tx %X", res.Hash, res.Tx.Hash())
+	}
+	if !bytes.Equal(res.Tx, res.Proof.Data) {
+		return errors.New("tx does not match with the proof data")
+	}
+	if int64(res.Index) != res.Proof.Proof.Index {
+		return fmt.Errorf("tx index %d does not match with the proof index %d", res.Index, res.Proof.Proof.Index)
+	}
+
+	// Update the light client if we're behind.
+	l, err := c.updateLightClientIfNeededTo(ctx, &res.Height)
+	if err != nil {
+		return err
+	}
+
+	// Validate the proof.
+	if err := res.Proof.Validate(l.DataHash); err != nil {
+		return err
+	}
+
+	// Verify the result of the tx.
+	return c.verifyTxResult(ctx, res.Height, res.Index, &res.TxResult, results)
+}
+
+// verifyTxResult verifies the result of the tx at the given index of the block
+// at the given height against the results of the block, committed to by the
+// header of the next block, which it waits for. The verified block results are
+// cached in results.
+func (c *Client) verifyTxResult(
+	ctx context.Context,
+	height int64,
+	index uint32,
+	result *abci.ResponseDeliverTx,
+	results blockResultsCache,
+) error {
+	blockResults, ok := results[height]
+	if !ok {
+		if err := rpcclient.WaitForHeightContext(ctx, c.next, height+1, nil); err != nil {
+			return fmt.Errorf("can't wait for block %d: %w", height+1, err)
+		}
+
+		var err error
+		blockResults, err = c.BlockResults(ctx, &height)
+		if err != nil {
+			return err
+		}
+		results[height] = blockResults
+	}
+
+	if int(index) >= len(blockResults.TxsResults) {
+		return fmt.Errorf("tx index %d out of the %d results of block %d",
+			index, len(blockResults.TxsResults), height)
+	}
+
+	// Compare the deterministic fields of the results, which the results hash
+	// commits to.
+	rH := types.NewResults([]*abci.ResponseDeliverTx{result}).Hash()
+	tH := types.NewResults(blockResults.TxsResults[index : index+1]).Hash()
+	if !bytes.Equal(rH, tH) {
+		return fmt.Errorf("tx result %X does not match with trusted tx result %X", rH, tH)
+	}
+
+	return nil
 }
 
 // Validators fetches and verifies validators.
@@ -525,6 +703,8 @@ func (c *Client) Validators(
 		Total:       totalCount}, nil
 }
 
+// BroadcastEvidence calls rpcclient#BroadcastEvidence. The result is not
+// verified.
 func (c *Client) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*ctypes.ResultBroadcastEvidence, error) {
 	return c.next.BroadcastEvidence(ctx, ev)
 }
@@ -558,12 +738,28 @@ func (c *Client) updateLightClientIfNeededTo(ctx context.Context, height *int64)
 	return l, nil
 }
 
+// ResultDivergences lists the divergences detected by the light client.
+type ResultDivergences struct {
+	Divergences []*store.Divergence `json:"divergences"`
+}
+
+// Divergences returns the divergences detected between the primary and the
+// witnesses of the light client, which halted it.
+func (c *Client) Divergences(ctx context.Context) (*ResultDivergences, error) {
+	divergences, err := c.lc.Divergences()
+	if err != nil {
+		return nil, err
+	}
+	return &ResultDivergences{Divergences: divergences}, nil
+}
+
 func (c *Client) RegisterOpDecoder(typ string, dec merkle.OpDecoder) {
 	c.prt.RegisterOpDecoder(typ, dec)
 }
 
 // SubscribeWS subscribes for events using the given query and remote address as
-// a subscriber, but does not verify responses (UNSAFE)!
+// a subscriber, but does not verify responses (UNSAFE)! The events are marked
+// unverified.
 // TODO: verify data
 func (c *Client) SubscribeWS(ctx *rpctypes.Context, query string) (*ctypes.ResultSubscribe, error) {
 	out, err := c.next.Subscribe(context.Background(), ctx.RemoteAddr(), query)
@@ -580,7 +776,7 @@ func (c *Client) SubscribeWS(ctx *rpctypes.Context, query string) (*ctypes.Resul
 				ctx.WSConn.TryWriteRPCResponse(
 					rpctypes.NewRPCSuccessResponse(
 						rpctypes.JSONRPCStringID(fmt.Sprintf("%v#event", ctx.JSONReq.ID)),
-						resultEvent,
+						ResultUnverified{Result: resultEvent},
 					))
 			case <-c.Quit():
 				return
@@ -0,0 +1,184 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	lcmock "github.com/tendermint/tendermint/light/rpc/mocks"
+	rpcmock "github.com/tendermint/tendermint/rpc/client/mocks"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestTxSearch(t *testing.T) {
+	var (
+		txs     = types.Txs{types.Tx("a=1"), types.Tx("b=2")}
+		results = []*abci.ResponseDeliverTx{{Code: 0, Data: []byte("a")}, {Code: 1, Data: []byte("b")}}
+		l1      = &types.LightBlock{SignedHeader: &types.SignedHeader{Header: &types.Header{Height: 1}}}
+		l2      = &types.LightBlock{SignedHeader: &types.SignedHeader{Header: &types.Header{Height: 2}}}
+	)
+	l1.DataHash = txs.Hash()
+	l2.LastResultsHash = blockResultsHash(t, results)
+
+	resultTx := func(index int, result abci.ResponseDeliverTx) *ctypes.ResultTx {
+		return &ctypes.ResultTx{
+			Hash:     txs[index].Hash(),
+			Height:   1,
+			Index:    uint32(index),
+			TxResult: result,
+			Tx:       txs[index],
+			Proof:    txs.Proof(index),
+		}
+	}
+
+	testCases := []struct {
+		name    string
+		txs     []*ctypes.ResultTx
+		prove   bool
+		wantErr bool
+	}{
+		{"valid", []*ctypes.ResultTx{resultTx(0, *results[0]), resultTx(1, *results[1])}, false, false},
+		{"valid with proofs", []*ctypes.ResultTx{resultTx(1, *results[1])}, true, false},
+		{"wrong result", []*ctypes.ResultTx{resultTx(1, *results[0])}, false, true},
+		{"wrong tx", []*ctypes.ResultTx{func() *ctypes.ResultTx {
+			res := resultTx(0, *results[0])
+			res.Tx = types.Tx("c=3")
+			return res
+		}()}, false, true},
+		{"wrong index", []*ctypes.ResultTx{func() *ctypes.ResultTx {
+			res := resultTx(0, *results[0])
+			res.Index = 1
+			return res
+		}()}, false, true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			next := &rpcmock.Client{}
+			next.On("TxSearch", mock.Anything, "tx.height=1", true, (*int)(nil), (*int)(nil), "").
+				Return(&ctypes.ResultTxSearch{Txs: tc.txs, TotalCount: len(tc.txs)}, nil)
+			next.On("Status", mock.Anything).
+				Return(&ctypes.ResultStatus{SyncInfo: ctypes.SyncInfo{LatestBlockHeight: 2}}, nil)
+			next.On("BlockResults", mock.Anything, mock.Anything).
+				Return(&ctypes.ResultBlockResults{Height: 1, TxsResults: results}, nil)
+
+			lc := &lcmock.LightClient{}
+			lc.On("VerifyLightBlockAtHeight", mock.Anything, int64(1), mock.Anything).Return(l1, nil)
+			lc.On("VerifyLightBlockAtHeight", mock.Anything, int64(2), mock.Anything).Return(l2, nil)
+
+			c := NewClient(next, lc)
+			res, err := c.TxSearch(context.Background(), "tx.height=1", tc.prove, nil, nil, "")
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, res.Txs, len(tc.txs))
+			for _, tx := range res.Txs {
+				if tc.prove {
+					assert.NotEmpty(t, tx.Proof.Data)
+				} else {
+					assert.Equal(t, types.TxProof{}, tx.Proof)
+				}
+			}
+		})
+	}
+}
+
+func TestBlockSearch(t *testing.T) {
+	block := types.MakeBlock(1, types.Txs{types.Tx("a=1")}, &types.Commit{}, nil)
+	block.ProposerAddress = crypto.AddressHash([]byte("proposer"))
+	block.ValidatorsHash = tmhash.Sum([]byte("validators"))
+	blockID := types.BlockID{Hash: block.Hash()}
+
+	trusted := &types.LightBlock{SignedHeader: &types.SignedHeader{Header: &block.Header}}
+	untrusted := &types.LightBlock{SignedHeader: &types.SignedHeader{Header: &types.Header{Height: 1}}}
+
+	testCases := []struct {
+		name    string
+		trusted *types.LightBlock
+		wantErr bool
+	}{
+		{"matching header", trusted, false},
+		{"conflicting header", untrusted, true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			next := &rpcmock.Client{}
+			next.On("BlockSearch", mock.Anything, "block.height=1", (*int)(nil), (*int)(nil), "").
+				Return(&ctypes.ResultBlockSearch{
+					Blocks:     []*ctypes.ResultBlock{{BlockID: blockID, Block: block}},
+					TotalCount: 1,
+				}, nil)
+
+			lc := &lcmock.LightClient{}
+			lc.On("VerifyLightBlockAtHeight", mock.Anything, int64(1), mock.Anything).Return(tc.trusted, nil)
+
+			c := NewClient(next, lc)
+			res, err := c.BlockSearch(context.Background(), "block.height=1", nil, nil, "")
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, res.Blocks, 1)
+		})
+	}
+}
+
+func TestResultUnverified_MarshalJSON(t *testing.T) {
+	testCases := []struct {
+		name   string
+		result interface{}
+		want   string
+	}{
+		{"object", &ctypes.ResultUnconfirmedTxs{Count: 1, Total: 2}, `{"verified":false,"n_txs":"1","total":"2",`},
+		{"empty object", &ctypes.ResultHealth{}, `{"verified":false}`},
+		{"not an object", []int{1}, `["1"]`},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := Unverified(tc.result, nil)
+			require.NoError(t, err)
+
+			bz, err := tmjson.Marshal(res)
+			require.NoError(t, err)
+			assert.Contains(t, string(bz), tc.want)
+		})
+	}
+
+	// the marked result can still be decoded as usual
+	bz, err := tmjson.Marshal(ResultUnverified{Result: &ctypes.ResultUnconfirmedTxs{Count: 1, Total: 2}})
+	require.NoError(t, err)
+	var res ctypes.ResultUnconfirmedTxs
+	require.NoError(t, tmjson.Unmarshal(bz, &res))
+	assert.Equal(t, 2, res.Total)
+}
+
+// blockResultsHash returns the hash of the block results with the given
+// DeliverTx results and no BeginBlock or EndBlock events.
+func blockResultsHash(t *testing.T, results []*abci.ResponseDeliverTx) []byte {
+	t.Helper()
+
+	bbeBytes, err := proto.Marshal(&abci.ResponseBeginBlock{})
+	require.NoError(t, err)
+	ebeBytes, err := proto.Marshal(&abci.ResponseEndBlock{})
+	require.NoError(t, err)
+
+	return merkle.HashFromByteSlices([][]byte{bbeBytes, types.NewResults(results).Hash(), ebeBytes})
+}
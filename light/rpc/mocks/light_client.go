@@ -7,6 +7,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	store "github.com/tendermint/tendermint/light/store"
+
 	time "time"
 
 	types "github.com/tendermint/tendermint/types"
@@ -31,6 +33,29 @@ func (_m *LightClient) ChainID() string {
 	return r0
 }
 
+// Divergences provides a mock function with given fields:
+func (_m *LightClient) Divergences() ([]*store.Divergence, error) {
+	ret := _m.Called()
+
+	var r0 []*store.Divergence
+	if rf, ok := ret.Get(0).(func() []*store.Divergence); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*store.Divergence)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // TrustedLightBlock provides a mock function with given fields: height
 func (_m *LightClient) TrustedLightBlock(height int64) (*types.LightBlock, error) {
 	ret := _m.Called(height)
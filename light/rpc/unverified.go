@@ -0,0 +1,44 @@
+package rpc
+
+import (
+	"bytes"
+
+	tmjson "github.com/tendermint/tendermint/libs/json"
+)
+
+// ResultUnverified wraps the result of a method which cannot be verified
+// against the headers trusted by the light client, such as the state of the
+// node's mempool, peers or consensus. It is encoded as the wrapped result with
+// an additional "verified": false field, so that the clients decoding the
+// result as usual keep working while the others can tell it is not verified.
+type ResultUnverified struct {
+	Result interface{}
+}
+
+// Unverified wraps the given result, unless an error is given, in which case
+// the error is returned.
+func Unverified(result interface{}, err error) (*ResultUnverified, error) {
+	if err != nil {
+		return nil, err
+	}
+	return &ResultUnverified{Result: result}, nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r ResultUnverified) MarshalJSON() ([]byte, error) {
+	bz, err := tmjson.Marshal(r.Result)
+	if err != nil {
+		return nil, err
+	}
+
+	// only results encoded as objects can be marked
+	if !bytes.HasPrefix(bz, []byte("{")) {
+		return bz, nil
+	}
+
+	marked := []byte(`{"verified":false`)
+	if !bytes.Equal(bz, []byte("{}")) {
+		marked = append(marked, ',')
+	}
+	return append(marked, bz[1:]...), nil
+}
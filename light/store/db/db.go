@@ -8,6 +8,7 @@ import (
 	dbm "github.com/tendermint/tm-db"
 
 	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
+	tmjson "github.com/tendermint/tendermint/libs/json"
 	"github.com/tendermint/tendermint/light/store"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/types"
@@ -16,6 +17,8 @@ import (
 const (
 	prefixLightBlock = int64(11)
 	prefixSize       = int64(12)
+	prefixProvenance = int64(13)
+	prefixDivergence = int64(14)
 )
 
 type dbs struct {
@@ -257,6 +260,104 @@ func (s *dbs) Size() uint16 {
 	return s.size
 }
 
+// SaveProvenance persists the provenance of the first trusted LightBlock to
+// the db.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *dbs) SaveProvenance(p *store.Provenance) error {
+	bz, err := tmjson.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("marshaling Provenance: %w", err)
+	}
+
+	return s.db.SetSync(s.provenanceKey(), bz)
+}
+
+// Provenance retrieves the provenance of the first trusted LightBlock.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *dbs) Provenance() (*store.Provenance, error) {
+	bz, err := s.db.Get(s.provenanceKey())
+	if err != nil {
+		panic(err)
+	}
+	if len(bz) == 0 {
+		return nil, store.ErrProvenanceNotFound
+	}
+
+	p := new(store.Provenance)
+	if err := tmjson.Unmarshal(bz, p); err != nil {
+		return nil, fmt.Errorf("unmarshal error: %w", err)
+	}
+
+	return p, nil
+}
+
+// SaveDivergence persists the given divergence to the db, assigning it the
+// ID following the last saved one.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *dbs) SaveDivergence(d *store.Divergence) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	itr, err := s.db.ReverseIterator(
+		s.divergenceKey(0),
+		append(s.divergenceKey(1<<63-1), byte(0x00)),
+	)
+	if err != nil {
+		panic(err)
+	}
+	defer itr.Close()
+
+	d.ID = 1
+	if itr.Valid() {
+		id, err := s.decodeDivergenceKey(itr.Key())
+		if err != nil {
+			return err
+		}
+		d.ID = id + 1
+	}
+	if err := itr.Error(); err != nil {
+		return err
+	}
+
+	bz, err := tmjson.Marshal(d)
+	if err != nil {
+		return fmt.Errorf("marshaling Divergence: %w", err)
+	}
+
+	return s.db.SetSync(s.divergenceKey(d.ID), bz)
+}
+
+// Divergences retrieves all the divergences, in ascending ID order.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *dbs) Divergences() ([]*store.Divergence, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	itr, err := s.db.Iterator(
+		s.divergenceKey(0),
+		append(s.divergenceKey(1<<63-1), byte(0x00)),
+	)
+	if err != nil {
+		panic(err)
+	}
+	defer itr.Close()
+
+	divergences := make([]*store.Divergence, 0)
+	for ; itr.Valid(); itr.Next() {
+		d := new(store.Divergence)
+		if err := tmjson.Unmarshal(itr.Value(), d); err != nil {
+			return nil, fmt.Errorf("unmarshal error: %w", err)
+		}
+		divergences = append(divergences, d)
+	}
+
+	return divergences, itr.Error()
+}
+
 func (s *dbs) batchDelete(batch dbm.Batch, numToPrune uint16) error {
 	itr, err := s.db.Iterator(
 		s.lbKey(1),
@@ -286,6 +387,40 @@ func (s *dbs) sizeKey() []byte {
 	return key
 }
 
+func (s *dbs) provenanceKey() []byte {
+	key, err := orderedcode.Append(nil, prefixProvenance)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+func (s *dbs) divergenceKey(id uint64) []byte {
+	key, err := orderedcode.Append(nil, prefixDivergence, int64(id))
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+func (s *dbs) decodeDivergenceKey(key []byte) (uint64, error) {
+	var (
+		prefix int64
+		id     int64
+	)
+	remaining, err := orderedcode.Parse(string(key), &prefix, &id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse divergence key: %w", err)
+	}
+	if len(remaining) != 0 {
+		return 0, fmt.Errorf("expected no remainder when parsing divergence key but got: %s", remaining)
+	}
+	if prefix != prefixDivergence {
+		return 0, fmt.Errorf("expected divergence prefix but got: %d", prefix)
+	}
+	return uint64(id), nil
+}
+
 func (s *dbs) lbKey(height int64) []byte {
 	key, err := orderedcode.Append(nil, prefixLightBlock, height)
 	if err != nil {
@@ -14,6 +14,7 @@ import (
 	"github.com/tendermint/tendermint/crypto/tmhash"
 	"github.com/tendermint/tendermint/internal/test/factory"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
+	"github.com/tendermint/tendermint/light/store"
 	"github.com/tendermint/tendermint/types"
 	"github.com/tendermint/tendermint/version"
 )
@@ -73,6 +74,82 @@ func Test_SaveLightBlock(t *testing.T) {
 
 }
 
+func Test_SaveProvenance(t *testing.T) {
+	dbStore := New(dbm.NewMemDB())
+
+	// Empty store
+	_, err := dbStore.Provenance()
+	require.ErrorIs(t, err, store.ErrProvenanceNotFound)
+
+	provenance := &store.Provenance{
+		Height:    10,
+		Hash:      tmhash.Sum([]byte("header")),
+		Quorum:    2,
+		Providers: []string{"http{localhost:26657}", "http{localhost:36657}"},
+		Time:      time.Now().UTC().Round(0),
+	}
+	require.NoError(t, dbStore.SaveProvenance(provenance))
+
+	p, err := dbStore.Provenance()
+	require.NoError(t, err)
+	assert.Equal(t, provenance, p)
+
+	// the provenance is kept when pruning the light blocks
+	require.NoError(t, dbStore.SaveLightBlock(randLightBlock(1)))
+	require.NoError(t, dbStore.Prune(0))
+
+	p, err = dbStore.Provenance()
+	require.NoError(t, err)
+	assert.Equal(t, provenance, p)
+}
+
+func Test_SaveDivergence(t *testing.T) {
+	dbStore := New(dbm.NewMemDB())
+
+	// Empty store
+	divergences, err := dbStore.Divergences()
+	require.NoError(t, err)
+	assert.Empty(t, divergences)
+
+	conflicting, trusted := randLightBlock(3), randLightBlock(3)
+	d1 := &store.Divergence{
+		Height:       3,
+		Primary:      "http{localhost:26657}",
+		Witness:      "http{localhost:36657}",
+		PrimaryTrace: []*types.LightBlock{randLightBlock(1), conflicting},
+		WitnessTrace: []*types.LightBlock{randLightBlock(1), trusted},
+		EvidenceAgainstPrimary: &types.LightClientAttackEvidence{
+			ConflictingBlock: conflicting,
+			CommonHeight:     1,
+			Timestamp:        time.Now().UTC().Round(0),
+		},
+		Time: time.Now().UTC().Round(0),
+	}
+	d2 := &store.Divergence{Height: 5, Time: time.Now().UTC().Round(0)}
+	require.NoError(t, dbStore.SaveDivergence(d1))
+	require.NoError(t, dbStore.SaveDivergence(d2))
+	assert.EqualValues(t, 1, d1.ID)
+	assert.EqualValues(t, 2, d2.ID)
+
+	divergences, err = dbStore.Divergences()
+	require.NoError(t, err)
+	require.Len(t, divergences, 2)
+	assert.Equal(t, d1.ID, divergences[0].ID)
+	assert.Equal(t, d1.PrimaryTrace[1].Hash(), divergences[0].PrimaryTrace[1].Hash())
+	assert.Equal(t, d1.WitnessTrace[1].Hash(), divergences[0].WitnessTrace[1].Hash())
+	assert.Equal(t, d1.EvidenceAgainstPrimary.Hash(), divergences[0].EvidenceAgainstPrimary.Hash())
+	assert.Nil(t, divergences[0].EvidenceAgainstWitness)
+	assert.Equal(t, d2, divergences[1])
+
+	// the divergences are kept when pruning the light blocks
+	require.NoError(t, dbStore.SaveLightBlock(randLightBlock(1)))
+	require.NoError(t, dbStore.Prune(0))
+
+	divergences, err = dbStore.Divergences()
+	require.NoError(t, err)
+	assert.Len(t, divergences, 2)
+}
+
 func Test_LightBlockBefore(t *testing.T) {
 	dbStore := New(dbm.NewMemDB())
 
@@ -6,4 +6,8 @@ var (
 	// ErrLightBlockNotFound is returned when a store does not have the
 	// requested header.
 	ErrLightBlockNotFound = errors.New("light block not found")
+
+	// ErrProvenanceNotFound is returned when a store does not have the
+	// provenance of the first trusted light block.
+	ErrProvenanceNotFound = errors.New("provenance not found")
 )
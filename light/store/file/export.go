@@ -0,0 +1,118 @@
+package file
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/tendermint/tendermint/light/store"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// Export writes the light blocks of the store from height from up to height to
+// included, in ascending height order, to w. The export uses the record format
+// of the log of the Store, with light block records only, and can be imported
+// into any store with Import. It returns the light blocks exported.
+func Export(w io.Writer, s store.Store, from, to int64) ([]*types.LightBlock, error) {
+	if from <= 0 || to < from {
+		return nil, fmt.Errorf("invalid range of heights [%d, %d]", from, to)
+	}
+
+	last, err := s.LastLightBlockHeight()
+	if err != nil {
+		return nil, err
+	}
+	if last < to {
+		to = last
+	}
+
+	// walk the light blocks down from the top of the range
+	var lbs []*types.LightBlock
+	for height := to + 1; height > from; {
+		lb, err := s.LightBlockBefore(height)
+		if errors.Is(err, store.ErrLightBlockNotFound) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if lb.Height < from {
+			break
+		}
+		lbs = append(lbs, lb)
+		height = lb.Height
+	}
+
+	bw := bufio.NewWriter(w)
+	if _, err := bw.Write(magic); err != nil {
+		return nil, err
+	}
+	exported := make([]*types.LightBlock, 0, len(lbs))
+	for i := len(lbs) - 1; i >= 0; i-- {
+		lbpb, err := lbs[i].ToProto()
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert light block to protobuf: %w", err)
+		}
+		lbBz, err := lbpb.Marshal()
+		if err != nil {
+			return nil, fmt.Errorf("marshaling LightBlock: %w", err)
+		}
+		bz, err := record{typ: recordLightBlock, key: lbs[i].Height, data: lbBz}.encode()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := bw.Write(bz); err != nil {
+			return nil, err
+		}
+		exported = append(exported, lbs[i])
+	}
+
+	return exported, bw.Flush()
+}
+
+// Import reads the light blocks exported to r by Export, and saves them to the
+// store, in the order they were exported. Each light block is passed to verify
+// before being saved: if it returns an error, the import is aborted. It
+// returns the number of light blocks imported.
+func Import(r io.Reader, s store.Store, verify func(lb *types.LightBlock) error) (int, error) {
+	br := bufio.NewReader(r)
+	if err := readMagic(br); err != nil {
+		return 0, err
+	}
+
+	imported := 0
+	for {
+		rec, _, err := readRecord(br)
+		if err == io.EOF {
+			return imported, nil
+		}
+		if err != nil {
+			return imported, err
+		}
+		if rec.typ != recordLightBlock {
+			return imported, fmt.Errorf("unexpected record of type %d", rec.typ)
+		}
+
+		var lbpb tmproto.LightBlock
+		if err := lbpb.Unmarshal(rec.data); err != nil {
+			return imported, fmt.Errorf("unmarshal error: %w", err)
+		}
+		lb, err := types.LightBlockFromProto(&lbpb)
+		if err != nil {
+			return imported, fmt.Errorf("proto conversion error: %w", err)
+		}
+		if lb.Height != rec.key {
+			return imported, fmt.Errorf("light block of height %d recorded at height %d", lb.Height, rec.key)
+		}
+
+		if err := verify(lb); err != nil {
+			return imported, fmt.Errorf("light block at height %d: %w", lb.Height, err)
+		}
+		if err := s.SaveLightBlock(lb); err != nil {
+			return imported, err
+		}
+		imported++
+	}
+}
@@ -0,0 +1,683 @@
+package file
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
+	"github.com/tendermint/tendermint/internal/libs/tempfile"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"github.com/tendermint/tendermint/light/store"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	logFileName   = "light.log"
+	indexFileName = "light.idx"
+
+	// indexInterval is the number of records appended to the log between two
+	// snapshots of the index.
+	indexInterval = 100
+)
+
+// Store is a store.Store keeping the light blocks, provenance and divergences
+// in an append-only log file, with an index of the log kept in memory.
+//
+// Each record of the log is checksummed. On opening, the index is restored
+// from its last snapshot, and the records appended after it are replayed. A
+// record left incomplete by a crash ends the log, which is truncated before
+// it, while a corrupt record followed by others fails the opening. The records
+// removed or superseded are dropped from the log when it is compacted, e.g.
+// after pruning.
+type Store struct {
+	dir  string
+	file *os.File
+
+	mtx tmsync.RWMutex
+	end int64 // offset of the end of the log
+	idx index
+	// number of records appended since the last snapshot of the index
+	sinceSnapshot int
+}
+
+var _ store.Store = (*Store)(nil)
+
+// index locates the live records of the log.
+type index struct {
+	// Offset is the end of the log covered by the index.
+	Offset int64 `json:"offset"`
+
+	Blocks           map[int64]recordRef `json:"blocks"`
+	Provenance       *recordRef          `json:"provenance,omitempty"`
+	Divergences      []recordRef         `json:"divergences"`
+	LastDivergenceID uint64              `json:"last_divergence_id"`
+
+	// Garbage is the size of the records removed or superseded.
+	Garbage int64 `json:"garbage"`
+
+	// heights of Blocks, in ascending order
+	heights []int64
+}
+
+// recordRef locates a record in the log.
+type recordRef struct {
+	Offset int64 `json:"offset"`
+	Size   int64 `json:"size"`
+}
+
+// New opens the Store in the given directory, creating it if needed, and
+// recovers it from any crash.
+func New(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	s := &Store{dir: dir}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// SaveLightBlock appends the LightBlock to the log.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *Store) SaveLightBlock(lb *types.LightBlock) error {
+	if lb.Height <= 0 {
+		panic("negative or zero height")
+	}
+
+	lbpb, err := lb.ToProto()
+	if err != nil {
+		return fmt.Errorf("unable to convert light block to protobuf: %w", err)
+	}
+
+	lbBz, err := lbpb.Marshal()
+	if err != nil {
+		return fmt.Errorf("marshaling LightBlock: %w", err)
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.append(record{typ: recordLightBlock, key: lb.Height, data: lbBz})
+}
+
+// DeleteLightBlock appends the deletion of the LightBlock to the log.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *Store) DeleteLightBlock(height int64) error {
+	if height <= 0 {
+		panic("negative or zero height")
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if _, ok := s.idx.Blocks[height]; !ok {
+		return nil
+	}
+	return s.append(record{typ: recordDelete, key: height})
+}
+
+// LightBlock retrieves the LightBlock at the given height.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *Store) LightBlock(height int64) (*types.LightBlock, error) {
+	if height <= 0 {
+		panic("negative or zero height")
+	}
+
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	ref, ok := s.idx.Blocks[height]
+	if !ok {
+		return nil, store.ErrLightBlockNotFound
+	}
+	return s.readLightBlock(ref)
+}
+
+// LastLightBlockHeight returns the last LightBlock height stored.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *Store) LastLightBlockHeight() (int64, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	if len(s.idx.heights) == 0 {
+		return -1, nil
+	}
+	return s.idx.heights[len(s.idx.heights)-1], nil
+}
+
+// FirstLightBlockHeight returns the first LightBlock height stored.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *Store) FirstLightBlockHeight() (int64, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	if len(s.idx.heights) == 0 {
+		return -1, nil
+	}
+	return s.idx.heights[0], nil
+}
+
+// LightBlockBefore returns the LightBlock with the highest height below the
+// given height. It returns ErrLightBlockNotFound if no such block exists.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *Store) LightBlockBefore(height int64) (*types.LightBlock, error) {
+	if height <= 0 {
+		panic("negative or zero height")
+	}
+
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	i := sort.Search(len(s.idx.heights), func(i int) bool { return s.idx.heights[i] >= height })
+	if i == 0 {
+		return nil, store.ErrLightBlockNotFound
+	}
+	return s.readLightBlock(s.idx.Blocks[s.idx.heights[i-1]])
+}
+
+// Prune appends the removal of the oldest light blocks to the log, until
+// there are only size light blocks left. The log is compacted once the records
+// removed or superseded outweigh the live ones.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *Store) Prune(size uint16) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if len(s.idx.heights) <= int(size) {
+		return nil
+	}
+
+	// the lowest height kept
+	lowest := s.idx.heights[len(s.idx.heights)-1] + 1
+	if size > 0 {
+		lowest = s.idx.heights[len(s.idx.heights)-int(size)]
+	}
+	if err := s.append(record{typ: recordPrune, key: lowest}); err != nil {
+		return err
+	}
+
+	if s.idx.Garbage > s.end-int64(len(magic))-s.idx.Garbage {
+		return s.compact()
+	}
+	return nil
+}
+
+// Size returns the number of light blocks.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *Store) Size() uint16 {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return uint16(len(s.idx.heights))
+}
+
+// SaveProvenance appends the provenance of the first trusted LightBlock to
+// the log.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *Store) SaveProvenance(p *store.Provenance) error {
+	bz, err := tmjson.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("marshaling Provenance: %w", err)
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.append(record{typ: recordProvenance, data: bz})
+}
+
+// Provenance retrieves the provenance of the first trusted LightBlock.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *Store) Provenance() (*store.Provenance, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	if s.idx.Provenance == nil {
+		return nil, store.ErrProvenanceNotFound
+	}
+
+	rec, err := s.read(*s.idx.Provenance)
+	if err != nil {
+		return nil, err
+	}
+	p := new(store.Provenance)
+	if err := tmjson.Unmarshal(rec.data, p); err != nil {
+		return nil, fmt.Errorf("unmarshal error: %w", err)
+	}
+	return p, nil
+}
+
+// SaveDivergence appends the given divergence to the log, assigning it the
+// ID following the last saved one.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *Store) SaveDivergence(d *store.Divergence) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	d.ID = s.idx.LastDivergenceID + 1
+	bz, err := tmjson.Marshal(d)
+	if err != nil {
+		return fmt.Errorf("marshaling Divergence: %w", err)
+	}
+
+	return s.append(record{typ: recordDivergence, key: int64(d.ID), data: bz})
+}
+
+// Divergences retrieves all the divergences, in ascending ID order.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *Store) Divergences() ([]*store.Divergence, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	divergences := make([]*store.Divergence, 0, len(s.idx.Divergences))
+	for _, ref := range s.idx.Divergences {
+		rec, err := s.read(ref)
+		if err != nil {
+			return nil, err
+		}
+		d := new(store.Divergence)
+		if err := tmjson.Unmarshal(rec.data, d); err != nil {
+			return nil, fmt.Errorf("unmarshal error: %w", err)
+		}
+		divergences = append(divergences, d)
+	}
+	return divergences, nil
+}
+
+// Compact rewrites the log with only its live records.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *Store) Compact() error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.compact()
+}
+
+// Close snapshots the index and closes the log. The Store must not be used
+// afterwards.
+func (s *Store) Close() error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if err := s.snapshot(); err != nil {
+		return err
+	}
+	return s.file.Close()
+}
+
+func (s *Store) logPath() string   { return filepath.Join(s.dir, logFileName) }
+func (s *Store) indexPath() string { return filepath.Join(s.dir, indexFileName) }
+
+// open opens the log, restoring the index from its snapshot and replaying the
+// records appended after it. The log is truncated before a record torn by a
+// crash at its end, and an error is returned for a corrupt record in the
+// middle of the log.
+func (s *Store) open() error {
+	f, err := os.OpenFile(s.logPath(), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open log: %w", err)
+	}
+	s.file = f
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	// the log is new, or crashed while being created
+	if info.Size() < int64(len(magic)) {
+		if err := f.Truncate(0); err != nil {
+			return err
+		}
+		if _, err := f.WriteAt(magic, 0); err != nil {
+			return fmt.Errorf("failed to write log: %w", err)
+		}
+		if err := f.Sync(); err != nil {
+			return err
+		}
+	} else if err := readMagic(io.NewSectionReader(f, 0, info.Size())); err != nil {
+		return fmt.Errorf("invalid log %v: %w", s.logPath(), err)
+	}
+
+	if info, err = f.Stat(); err != nil {
+		return err
+	}
+
+	s.idx = s.loadSnapshot(info.Size())
+	if s.idx.Offset < int64(len(magic)) {
+		s.idx = newIndex()
+		s.idx.Offset = int64(len(magic))
+	}
+
+	// replay the records appended after the snapshot
+	offset := s.idx.Offset
+	r := bufio.NewReader(io.NewSectionReader(f, offset, info.Size()-offset))
+	for {
+		rec, size, err := readRecord(r)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if !errors.Is(err, errCorruptRecord) {
+				return err
+			}
+			torn, tornErr := isTornRecord(f, offset, info.Size())
+			if tornErr != nil {
+				return tornErr
+			}
+			if !torn {
+				return fmt.Errorf("log %v is corrupt at offset %d: %w", s.logPath(), offset, err)
+			}
+			// the record was being written when crashing: drop it
+			if err := f.Truncate(offset); err != nil {
+				return fmt.Errorf("failed to truncate log: %w", err)
+			}
+			if err := f.Sync(); err != nil {
+				return err
+			}
+			break
+		}
+
+		s.idx.apply(rec, recordRef{Offset: offset, Size: size})
+		offset += size
+	}
+	s.end = offset
+
+	return nil
+}
+
+// isTornRecord returns whether the corrupt record at offset was being written
+// to the end of the log of the given size when crashing: it runs past the end
+// of the log, or ends with it, or the log is zeroed from it on, having been
+// extended without its data being written.
+func isTornRecord(f *os.File, offset, size int64) (bool, error) {
+	if size-offset < recordHeaderSize {
+		return true, nil
+	}
+
+	header := make([]byte, recordHeaderSize)
+	if _, err := f.ReadAt(header, offset); err != nil {
+		return false, fmt.Errorf("failed to read log: %w", err)
+	}
+	length := int64(binary.BigEndian.Uint32(header[4:8]))
+	if length >= recordDataHeaderSize && length <= maxRecordSize {
+		return offset+recordHeaderSize+length >= size, nil
+	}
+
+	// the length is invalid, so the record end is unknown
+	r := bufio.NewReader(io.NewSectionReader(f, offset, size-offset))
+	for {
+		b, err := r.ReadByte()
+		if err == io.EOF {
+			return true, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to read log: %w", err)
+		}
+		if b != 0 {
+			return false, nil
+		}
+	}
+}
+
+// append appends the record to the log and applies it to the index.
+//
+// NOTE: requires a mtx lock
+func (s *Store) append(rec record) error {
+	bz, err := rec.encode()
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.file.WriteAt(bz, s.end); err != nil {
+		return fmt.Errorf("failed to write log: %w", err)
+	}
+	if err := s.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync log: %w", err)
+	}
+
+	s.idx.apply(rec, recordRef{Offset: s.end, Size: int64(len(bz))})
+	s.end += int64(len(bz))
+
+	s.sinceSnapshot++
+	if s.sinceSnapshot >= indexInterval {
+		return s.snapshot()
+	}
+	return nil
+}
+
+// read reads the record at ref, verifying its checksum.
+func (s *Store) read(ref recordRef) (record, error) {
+	rec, _, err := readRecord(io.NewSectionReader(s.file, ref.Offset, ref.Size))
+	if err != nil {
+		return record{}, fmt.Errorf("failed to read log at offset %d: %w", ref.Offset, err)
+	}
+	return rec, nil
+}
+
+func (s *Store) readLightBlock(ref recordRef) (*types.LightBlock, error) {
+	rec, err := s.read(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	var lbpb tmproto.LightBlock
+	if err := lbpb.Unmarshal(rec.data); err != nil {
+		return nil, fmt.Errorf("unmarshal error: %w", err)
+	}
+
+	lightBlock, err := types.LightBlockFromProto(&lbpb)
+	if err != nil {
+		return nil, fmt.Errorf("proto conversion error: %w", err)
+	}
+	return lightBlock, nil
+}
+
+// compact writes the live records to a new log, in their order, and replaces
+// the log with it.
+//
+// NOTE: requires a mtx lock
+func (s *Store) compact() error {
+	// copy the references of the live records, to update them once written
+	var (
+		refs        = make([]*recordRef, 0, len(s.idx.Blocks)+len(s.idx.Divergences)+1)
+		blocks      = make(map[int64]*recordRef, len(s.idx.Blocks))
+		divergences = append([]recordRef(nil), s.idx.Divergences...)
+		provenance  *recordRef
+	)
+	for height, ref := range s.idx.Blocks {
+		ref := ref
+		blocks[height] = &ref
+		refs = append(refs, &ref)
+	}
+	for i := range divergences {
+		refs = append(refs, &divergences[i])
+	}
+	if s.idx.Provenance != nil {
+		ref := *s.idx.Provenance
+		provenance = &ref
+		refs = append(refs, provenance)
+	}
+	sort.Slice(refs, func(i, j int) bool { return refs[i].Offset < refs[j].Offset })
+
+	tmpPath := s.logPath() + ".compact"
+	f, err := os.OpenFile(tmpPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create compacted log: %w", err)
+	}
+	fail := func(err error) error {
+		f.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if _, err := f.WriteAt(magic, 0); err != nil {
+		return fail(fmt.Errorf("failed to write compacted log: %w", err))
+	}
+	offset := int64(len(magic))
+	for _, ref := range refs {
+		bz := make([]byte, ref.Size)
+		if _, err := s.file.ReadAt(bz, ref.Offset); err != nil {
+			return fail(fmt.Errorf("failed to read log at offset %d: %w", ref.Offset, err))
+		}
+		if _, err := f.WriteAt(bz, offset); err != nil {
+			return fail(fmt.Errorf("failed to write compacted log: %w", err))
+		}
+		ref.Offset = offset
+		offset += ref.Size
+	}
+	if err := f.Sync(); err != nil {
+		return fail(err)
+	}
+
+	// the snapshot of the index doesn't match the compacted log: the index is
+	// restored by replaying the log if crashing before the next snapshot
+	if err := os.Remove(s.indexPath()); err != nil && !os.IsNotExist(err) {
+		return fail(fmt.Errorf("failed to remove index: %w", err))
+	}
+	if err := syncDir(s.dir); err != nil {
+		return fail(err)
+	}
+	if err := os.Rename(tmpPath, s.logPath()); err != nil {
+		return fail(fmt.Errorf("failed to replace log: %w", err))
+	}
+	s.file.Close()
+	s.file = f
+
+	for height, ref := range blocks {
+		s.idx.Blocks[height] = *ref
+	}
+	s.idx.Divergences = divergences
+	s.idx.Provenance = provenance
+	s.idx.Garbage = 0
+	s.end = offset
+
+	// persist the rename, which a crash would otherwise lose
+	if err := syncDir(s.dir); err != nil {
+		return err
+	}
+	return s.snapshot()
+}
+
+// syncDir syncs the directory, persisting the files created, renamed or removed
+// in it.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return fmt.Errorf("failed to open directory: %w", err)
+	}
+	defer d.Close()
+
+	if err := d.Sync(); err != nil {
+		return fmt.Errorf("failed to sync directory %v: %w", dir, err)
+	}
+	return nil
+}
+
+// loadSnapshot returns the index saved by the last snapshot, or an empty
+// index if none is valid for a log of the given size.
+func (s *Store) loadSnapshot(logSize int64) index {
+	bz, err := os.ReadFile(s.indexPath())
+	if err != nil {
+		return newIndex()
+	}
+
+	idx := newIndex()
+	if err := json.Unmarshal(bz, &idx); err != nil || idx.Offset > logSize || idx.Blocks == nil {
+		return newIndex()
+	}
+	for height := range idx.Blocks {
+		idx.heights = append(idx.heights, height)
+	}
+	sort.Slice(idx.heights, func(i, j int) bool { return idx.heights[i] < idx.heights[j] })
+	return idx
+}
+
+// snapshot saves the index, covering the log up to its end.
+//
+// NOTE: requires a mtx lock
+func (s *Store) snapshot() error {
+	s.idx.Offset = s.end
+	bz, err := json.Marshal(s.idx)
+	if err != nil {
+		return fmt.Errorf("marshaling index: %w", err)
+	}
+	if err := tempfile.WriteFileAtomic(s.indexPath(), bz, 0600); err != nil {
+		return fmt.Errorf("failed to save index: %w", err)
+	}
+	s.sinceSnapshot = 0
+	return nil
+}
+
+func newIndex() index {
+	return index{
+		Blocks:      make(map[int64]recordRef),
+		Divergences: make([]recordRef, 0),
+	}
+}
+
+// apply updates the index with the record appended to the log at ref.
+func (idx *index) apply(rec record, ref recordRef) {
+	switch rec.typ {
+	case recordLightBlock:
+		if old, ok := idx.Blocks[rec.key]; ok {
+			idx.Garbage += old.Size
+		} else {
+			i := sort.Search(len(idx.heights), func(i int) bool { return idx.heights[i] >= rec.key })
+			idx.heights = append(idx.heights, 0)
+			copy(idx.heights[i+1:], idx.heights[i:])
+			idx.heights[i] = rec.key
+		}
+		idx.Blocks[rec.key] = ref
+
+	case recordDelete:
+		if old, ok := idx.Blocks[rec.key]; ok {
+			idx.Garbage += old.Size
+			delete(idx.Blocks, rec.key)
+			i := sort.Search(len(idx.heights), func(i int) bool { return idx.heights[i] >= rec.key })
+			idx.heights = append(idx.heights[:i], idx.heights[i+1:]...)
+		}
+		idx.Garbage += ref.Size
+
+	case recordPrune:
+		i := sort.Search(len(idx.heights), func(i int) bool { return idx.heights[i] >= rec.key })
+		for _, height := range idx.heights[:i] {
+			idx.Garbage += idx.Blocks[height].Size
+			delete(idx.Blocks, height)
+		}
+		idx.heights = append(idx.heights[:0], idx.heights[i:]...)
+		idx.Garbage += ref.Size
+
+	case recordProvenance:
+		if idx.Provenance != nil {
+			idx.Garbage += idx.Provenance.Size
+		}
+		idx.Provenance = &ref
+
+	case recordDivergence:
+		idx.Divergences = append(idx.Divergences, ref)
+		idx.LastDivergenceID = uint64(rec.key)
+
+	default:
+		// records of unknown types are skipped
+		idx.Garbage += ref.Size
+	}
+}
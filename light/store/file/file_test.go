@@ -0,0 +1,374 @@
+package file
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	"github.com/tendermint/tendermint/internal/test/factory"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+	"github.com/tendermint/tendermint/light/store"
+	"github.com/tendermint/tendermint/types"
+	"github.com/tendermint/tendermint/version"
+)
+
+func newStore(t *testing.T, dir string) *Store {
+	t.Helper()
+
+	s, err := New(dir)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = s.file.Close() })
+	return s
+}
+
+func Test_SaveLightBlock(t *testing.T) {
+	s := newStore(t, t.TempDir())
+
+	// Empty store
+	h, err := s.LightBlock(1)
+	require.ErrorIs(t, err, store.ErrLightBlockNotFound)
+	assert.Nil(t, h)
+
+	height, err := s.LastLightBlockHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, -1, height)
+
+	// Out of order heights
+	for _, height := range []int64{3, 1, 2} {
+		require.NoError(t, s.SaveLightBlock(randLightBlock(height)))
+	}
+	assert.EqualValues(t, 3, s.Size())
+
+	height, err = s.FirstLightBlockHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, height)
+	height, err = s.LastLightBlockHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, height)
+
+	// Overwriting a light block doesn't change the size
+	lb := randLightBlock(2)
+	require.NoError(t, s.SaveLightBlock(lb))
+	assert.EqualValues(t, 3, s.Size())
+
+	h, err = s.LightBlock(2)
+	require.NoError(t, err)
+	assert.Equal(t, lb.Hash(), h.Hash())
+
+	require.NoError(t, s.DeleteLightBlock(2))
+	_, err = s.LightBlock(2)
+	require.ErrorIs(t, err, store.ErrLightBlockNotFound)
+	assert.EqualValues(t, 2, s.Size())
+}
+
+func Test_LightBlockBefore(t *testing.T) {
+	s := newStore(t, t.TempDir())
+
+	assert.Panics(t, func() {
+		_, _ = s.LightBlockBefore(0)
+	})
+
+	require.NoError(t, s.SaveLightBlock(randLightBlock(2)))
+	require.NoError(t, s.SaveLightBlock(randLightBlock(5)))
+
+	h, err := s.LightBlockBefore(5)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, h.Height)
+
+	h, err = s.LightBlockBefore(100)
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, h.Height)
+
+	_, err = s.LightBlockBefore(2)
+	require.ErrorIs(t, err, store.ErrLightBlockNotFound)
+}
+
+func Test_Prune(t *testing.T) {
+	s := newStore(t, t.TempDir())
+
+	// Empty store
+	require.NoError(t, s.Prune(0))
+
+	provenance := &store.Provenance{
+		Height: 1,
+		Hash:   tmhash.Sum([]byte("header")),
+		Quorum: 2,
+		Time:   time.Now().UTC().Round(0),
+	}
+	require.NoError(t, s.SaveProvenance(provenance))
+	divergence := &store.Divergence{Height: 5, Time: time.Now().UTC().Round(0)}
+	require.NoError(t, s.SaveDivergence(divergence))
+
+	for i := int64(1); i <= 10; i++ {
+		require.NoError(t, s.SaveLightBlock(randLightBlock(i)))
+	}
+
+	require.NoError(t, s.Prune(11))
+	assert.EqualValues(t, 10, s.Size())
+
+	// pruning most of the light blocks compacts the log
+	before := s.end
+	require.NoError(t, s.Prune(3))
+	assert.EqualValues(t, 3, s.Size())
+	assert.Less(t, s.end, before)
+	assert.Zero(t, s.idx.Garbage)
+
+	height, err := s.FirstLightBlockHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, 8, height)
+	for i := int64(8); i <= 10; i++ {
+		_, err := s.LightBlock(i)
+		require.NoError(t, err)
+	}
+
+	// the provenance and divergences are kept
+	p, err := s.Provenance()
+	require.NoError(t, err)
+	assert.Equal(t, provenance, p)
+	divergences, err := s.Divergences()
+	require.NoError(t, err)
+	assert.Equal(t, []*store.Divergence{divergence}, divergences)
+
+	require.NoError(t, s.Prune(0))
+	assert.EqualValues(t, 0, s.Size())
+}
+
+func Test_Reopen(t *testing.T) {
+	dir := t.TempDir()
+
+	s, err := New(dir)
+	require.NoError(t, err)
+	lbs := make(map[int64]*types.LightBlock)
+	for i := int64(1); i <= indexInterval+10; i++ {
+		lbs[i] = randLightBlock(i)
+		require.NoError(t, s.SaveLightBlock(lbs[i]))
+	}
+	require.NoError(t, s.DeleteLightBlock(1))
+	require.NoError(t, s.SaveDivergence(&store.Divergence{Height: 3}))
+
+	testCases := []struct {
+		name  string
+		index func(t *testing.T)
+	}{
+		// the records appended after the last snapshot are replayed
+		{"from snapshot", func(t *testing.T) {}},
+		// the whole log is replayed
+		{"without snapshot", func(t *testing.T) {
+			require.NoError(t, os.Remove(filepath.Join(dir, indexFileName)))
+		}},
+		{"with corrupt snapshot", func(t *testing.T) {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, indexFileName), []byte("{"), 0600))
+		}},
+	}
+
+	for i, tc := range testCases {
+		i, tc := i, tc
+		t.Run(tc.name, func(t *testing.T) {
+			tc.index(t)
+
+			s := newStore(t, dir)
+			assert.EqualValues(t, indexInterval+9, s.Size())
+			_, err := s.LightBlock(1)
+			require.ErrorIs(t, err, store.ErrLightBlockNotFound)
+			for i := int64(2); i <= indexInterval+10; i++ {
+				lb, err := s.LightBlock(i)
+				require.NoError(t, err)
+				assert.Equal(t, lbs[i].Hash(), lb.Hash())
+			}
+
+			// the IDs of the divergences follow the ones saved before
+			d := &store.Divergence{Height: 4}
+			require.NoError(t, s.SaveDivergence(d))
+			assert.EqualValues(t, i+2, d.ID)
+			require.NoError(t, s.Close())
+		})
+	}
+	require.NoError(t, s.file.Close())
+}
+
+func Test_CrashRecovery(t *testing.T) {
+	dir := t.TempDir()
+
+	s, err := New(dir)
+	require.NoError(t, err)
+	lb := randLightBlock(1)
+	require.NoError(t, s.SaveLightBlock(lb))
+	require.NoError(t, s.Close())
+
+	// crash while appending a light block
+	bz, err := record{typ: recordLightBlock, key: 2, data: []byte("light block")}.encode()
+	require.NoError(t, err)
+	f, err := os.OpenFile(filepath.Join(dir, logFileName), os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = f.Write(bz[:len(bz)-3])
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	s = newStore(t, dir)
+	assert.EqualValues(t, 1, s.Size())
+	h, err := s.LightBlock(1)
+	require.NoError(t, err)
+	assert.Equal(t, lb.Hash(), h.Hash())
+
+	// the incomplete record was dropped
+	info, err := os.Stat(filepath.Join(dir, logFileName))
+	require.NoError(t, err)
+	assert.Equal(t, s.end, info.Size())
+	require.NoError(t, s.SaveLightBlock(randLightBlock(2)))
+	assert.EqualValues(t, 2, s.Size())
+
+	// a corrupt light block fails to be read
+	_, err = s.file.WriteAt([]byte{0xFF}, s.idx.Blocks[1].Offset+recordHeaderSize+recordDataHeaderSize)
+	require.NoError(t, err)
+	_, err = s.LightBlock(1)
+	require.True(t, errors.Is(err, errCorruptRecord), err)
+
+	// the log must be a light store log
+	other := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(other, logFileName), []byte("not a light store"), 0600))
+	_, err = New(other)
+	require.Error(t, err)
+}
+
+func Test_CorruptLog(t *testing.T) {
+	dir := t.TempDir()
+
+	s, err := New(dir)
+	require.NoError(t, err)
+	require.NoError(t, s.SaveLightBlock(randLightBlock(1)))
+	require.NoError(t, s.SaveLightBlock(randLightBlock(2)))
+	first, end := s.idx.Blocks[1], s.end
+	require.NoError(t, s.Close())
+
+	// the log was extended, but not written, when crashing
+	f, err := os.OpenFile(filepath.Join(dir, logFileName), os.O_RDWR, 0600)
+	require.NoError(t, err)
+	require.NoError(t, f.Truncate(end+100))
+	require.NoError(t, f.Close())
+
+	s = newStore(t, dir)
+	assert.EqualValues(t, 2, s.Size())
+	assert.Equal(t, end, s.end)
+	require.NoError(t, s.Close())
+
+	// a corrupt record followed by others isn't dropped with them, when
+	// replayed without the snapshot of the index
+	require.NoError(t, os.Remove(filepath.Join(dir, indexFileName)))
+	f, err = os.OpenFile(filepath.Join(dir, logFileName), os.O_RDWR, 0600)
+	require.NoError(t, err)
+	_, err = f.WriteAt([]byte{0xFF}, first.Offset+recordHeaderSize+recordDataHeaderSize)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	_, err = New(dir)
+	require.True(t, errors.Is(err, errCorruptRecord), err)
+	info, err := os.Stat(filepath.Join(dir, logFileName))
+	require.NoError(t, err)
+	assert.Equal(t, end, info.Size())
+}
+
+func Test_ExportImport(t *testing.T) {
+	s := newStore(t, t.TempDir())
+	for _, height := range []int64{1, 2, 4, 8, 9} {
+		require.NoError(t, s.SaveLightBlock(randLightBlock(height)))
+	}
+
+	var buf bytes.Buffer
+	exported, err := Export(&buf, s, 2, 8)
+	require.NoError(t, err)
+	require.Len(t, exported, 3)
+	assert.EqualValues(t, 2, exported[0].Height)
+	assert.EqualValues(t, 8, exported[2].Height)
+
+	// the import stops at the first light block failing verification
+	dst := newStore(t, t.TempDir())
+	n, err := Import(bytes.NewReader(buf.Bytes()), dst, func(lb *types.LightBlock) error {
+		if lb.Height == 8 {
+			return errors.New("unverified")
+		}
+		return nil
+	})
+	require.Error(t, err)
+	assert.Equal(t, 2, n)
+	assert.EqualValues(t, 2, dst.Size())
+
+	dst = newStore(t, t.TempDir())
+	n, err = Import(bytes.NewReader(buf.Bytes()), dst, func(lb *types.LightBlock) error { return nil })
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	for _, lb := range exported {
+		h, err := dst.LightBlock(lb.Height)
+		require.NoError(t, err)
+		assert.Equal(t, lb.Hash(), h.Hash())
+	}
+
+	// a truncated export fails to be imported
+	_, err = Import(bytes.NewReader(buf.Bytes()[:buf.Len()-1]), newStore(t, t.TempDir()),
+		func(lb *types.LightBlock) error { return nil })
+	require.Error(t, err)
+}
+
+func Test_Concurrency(t *testing.T) {
+	s := newStore(t, t.TempDir())
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 100; i++ {
+		wg.Add(1)
+		go func(i int64) {
+			defer wg.Done()
+
+			require.NoError(t, s.SaveLightBlock(randLightBlock(i)))
+
+			_, _ = s.LightBlock(i)
+			if i > 2 {
+				_, _ = s.LightBlockBefore(i - 1)
+			}
+			_, _ = s.LastLightBlockHeight()
+			_, _ = s.FirstLightBlockHeight()
+
+			require.NoError(t, s.Prune(3))
+			_ = s.Size()
+
+			if i > 2 && i%2 == 0 {
+				require.NoError(t, s.DeleteLightBlock(i-1))
+			}
+		}(int64(i))
+	}
+
+	wg.Wait()
+}
+
+func randLightBlock(height int64) *types.LightBlock {
+	vals, _ := factory.RandValidatorSet(2, 1)
+	return &types.LightBlock{
+		SignedHeader: &types.SignedHeader{
+			Header: &types.Header{
+				Version:            version.Consensus{Block: version.BlockProtocol, App: 0},
+				ChainID:            tmrand.Str(12),
+				Height:             height,
+				Time:               time.Now(),
+				LastBlockID:        types.BlockID{},
+				LastCommitHash:     crypto.CRandBytes(tmhash.Size),
+				DataHash:           crypto.CRandBytes(tmhash.Size),
+				ValidatorsHash:     crypto.CRandBytes(tmhash.Size),
+				NextValidatorsHash: crypto.CRandBytes(tmhash.Size),
+				ConsensusHash:      crypto.CRandBytes(tmhash.Size),
+				AppHash:            crypto.CRandBytes(tmhash.Size),
+				LastResultsHash:    crypto.CRandBytes(tmhash.Size),
+				EvidenceHash:       crypto.CRandBytes(tmhash.Size),
+				ProposerAddress:    crypto.CRandBytes(crypto.AddressSize),
+			},
+			Commit: &types.Commit{},
+		},
+		ValidatorSet: vals,
+	}
+}
@@ -0,0 +1,115 @@
+package file
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+)
+
+// Record types.
+const (
+	recordLightBlock byte = iota + 1 // key: height, data: LightBlock proto
+	recordDelete                     // key: height
+	recordPrune                      // key: lowest height kept
+	recordProvenance                 // data: Provenance json
+	recordDivergence                 // key: ID, data: Divergence json
+)
+
+const (
+	// crc32c and length of the record data
+	recordHeaderSize = 8
+	// type and key of the record
+	recordDataHeaderSize = 9
+
+	// maxRecordSize is the maximum size of the record data. Light blocks
+	// are well below, even with large validator sets.
+	maxRecordSize = 64 << 20
+)
+
+var (
+	// magic starts the log and export files, versioning their format.
+	magic = []byte("TMLIGHT\x01")
+
+	crc32c = crc32.MakeTable(crc32.Castagnoli)
+
+	// errCorruptRecord is returned when a record is incomplete or its
+	// checksum doesn't match, e.g. after a crash during a write.
+	errCorruptRecord = errors.New("corrupt record")
+)
+
+// record is the unit of the log and export files. It is encoded as:
+//
+//	crc32c (4 bytes) | length (4 bytes) | type (1 byte) | key (8 bytes) | data
+//
+// where the checksum and the length, in big endian, cover the type, key and
+// data.
+type record struct {
+	typ  byte
+	key  int64
+	data []byte
+}
+
+func (r record) encode() ([]byte, error) {
+	length := recordDataHeaderSize + len(r.data)
+	if length > maxRecordSize {
+		return nil, fmt.Errorf("record is too big: %d bytes, max: %d bytes", length, maxRecordSize)
+	}
+
+	bz := make([]byte, recordHeaderSize+length)
+	bz[recordHeaderSize] = r.typ
+	binary.BigEndian.PutUint64(bz[recordHeaderSize+1:], uint64(r.key))
+	copy(bz[recordHeaderSize+recordDataHeaderSize:], r.data)
+
+	binary.BigEndian.PutUint32(bz[0:4], crc32.Checksum(bz[recordHeaderSize:], crc32c))
+	binary.BigEndian.PutUint32(bz[4:8], uint32(length))
+	return bz, nil
+}
+
+// readRecord reads the next record from r, returning it along with its
+// encoded size. io.EOF is returned if r is at its end, and errCorruptRecord
+// if the record is incomplete or corrupt.
+func readRecord(r io.Reader) (record, int64, error) {
+	header := make([]byte, recordHeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		if err == io.EOF {
+			return record{}, 0, err
+		}
+		return record{}, 0, fmt.Errorf("%w: failed to read header: %v", errCorruptRecord, err)
+	}
+
+	crc := binary.BigEndian.Uint32(header[0:4])
+	length := binary.BigEndian.Uint32(header[4:8])
+	if length < recordDataHeaderSize || length > maxRecordSize {
+		return record{}, 0, fmt.Errorf("%w: invalid length %d", errCorruptRecord, length)
+	}
+
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return record{}, 0, fmt.Errorf("%w: failed to read data: %v", errCorruptRecord, err)
+	}
+	if actual := crc32.Checksum(data, crc32c); actual != crc {
+		return record{}, 0, fmt.Errorf("%w: checksums do not match: read: %v, actual: %v",
+			errCorruptRecord, crc, actual)
+	}
+
+	rec := record{
+		typ:  data[0],
+		key:  int64(binary.BigEndian.Uint64(data[1:recordDataHeaderSize])),
+		data: data[recordDataHeaderSize:],
+	}
+	return rec, int64(recordHeaderSize + length), nil
+}
+
+// readMagic checks that r starts with the magic of the file format.
+func readMagic(r io.Reader) error {
+	bz := make([]byte, len(magic))
+	if _, err := io.ReadFull(r, bz); err != nil {
+		return fmt.Errorf("failed to read the file format: %w", err)
+	}
+	if string(bz) != string(magic) {
+		return fmt.Errorf("unknown file format %q", bz)
+	}
+	return nil
+}
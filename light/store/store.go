@@ -1,6 +1,11 @@
 package store
 
-import "github.com/tendermint/tendermint/types"
+import (
+	"time"
+
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/types"
+)
 
 // Store is anything that can persistently store headers.
 type Store interface {
@@ -45,4 +50,65 @@ type Store interface {
 
 	// Size returns a number of currently existing header & validator set pairs.
 	Size() uint16
+
+	// SaveProvenance saves the provenance of the first trusted LightBlock.
+	SaveProvenance(p *Provenance) error
+
+	// Provenance returns the provenance of the first trusted LightBlock.
+	//
+	// If no provenance was saved, ErrProvenanceNotFound is returned.
+	Provenance() (*Provenance, error)
+
+	// SaveDivergence saves a divergence detected between the primary and a
+	// witness, assigning it the next ID.
+	SaveDivergence(d *Divergence) error
+
+	// Divergences returns all the saved divergences, in ascending ID order.
+	Divergences() ([]*Divergence, error)
+}
+
+// Provenance records how the first trusted LightBlock of a light client was
+// obtained, when bootstrapped from a quorum of providers instead of a trusted
+// hash.
+type Provenance struct {
+	Height int64            `json:"height"`
+	Hash   tmbytes.HexBytes `json:"hash"`
+
+	// Quorum defines the number of providers required to agree on the block.
+	Quorum int `json:"quorum"`
+
+	// Providers lists the providers which agreed on the block.
+	Providers []string `json:"providers"`
+
+	// Time defines when the block was trusted.
+	Time time.Time `json:"time"`
+}
+
+// Divergence records a conflicting header detected between the primary and a
+// witness, which led the light client to halt, along with the traces of both
+// providers and the evidence sent to them.
+type Divergence struct {
+	// ID defines the sequence number of the divergence, assigned when saved.
+	ID uint64 `json:"id"`
+
+	// Height defines the height of the header being verified.
+	Height int64 `json:"height"`
+
+	Primary string `json:"primary"`
+	Witness string `json:"witness"`
+
+	// PrimaryTrace and WitnessTrace hold the light blocks verified from the
+	// primary and from the witness respectively, from the common block up to
+	// the conflicting one.
+	PrimaryTrace []*types.LightBlock `json:"primary_trace"`
+	WitnessTrace []*types.LightBlock `json:"witness_trace"`
+
+	// EvidenceAgainstPrimary was sent to the witness. EvidenceAgainstWitness
+	// was sent to the primary, and is nil if the primary could not verify the
+	// conflicting header of the witness.
+	EvidenceAgainstPrimary *types.LightClientAttackEvidence `json:"evidence_against_primary"`
+	EvidenceAgainstWitness *types.LightClientAttackEvidence `json:"evidence_against_witness"`
+
+	// Time defines when the divergence was detected.
+	Time time.Time `json:"time"`
 }
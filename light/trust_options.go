@@ -33,6 +33,12 @@ type TrustOptions struct {
 	// particular header.
 	Height int64
 	Hash   []byte
+
+	// Quorum, if non-zero, lets the light client bootstrap without a Hash: the
+	// header at Height, or the latest header if Height is zero, is fetched from
+	// the primary and all the witnesses, and trusted if at least Quorum
+	// distinct providers, the primary included, agree on it.
+	Quorum int
 }
 
 // ValidateBasic performs basic validation.
@@ -40,6 +46,18 @@ func (opts TrustOptions) ValidateBasic() error {
 	if opts.Period <= 0 {
 		return errors.New("negative or zero period")
 	}
+	if opts.Quorum != 0 {
+		if opts.Quorum < 2 {
+			return errors.New("quorum must be at least 2 providers")
+		}
+		if opts.Height < 0 {
+			return errors.New("negative height")
+		}
+		if len(opts.Hash) != 0 {
+			return errors.New("hash can't be set with a quorum")
+		}
+		return nil
+	}
 	if opts.Height <= 0 {
 		return errors.New("negative or zero height")
 	}
@@ -69,6 +69,7 @@ type nodeImpl struct {
 	bcReactor        service.Service   // for block-syncing
 	mempoolReactor   service.Service   // for gossipping transactions
 	mempool          mempool.Mempool
+	mempoolJournal   *mempool.Journal   // persisting the mempool, if enabled
 	stateSync        bool               // whether the node should state sync on startup
 	stateSyncReactor *statesync.Reactor // for hosting and restoring state sync snapshots
 	consensusState   *cs.State          // latest consensus state
@@ -246,7 +247,8 @@ func makeNode(config *cfg.Config,
 		return nil, fmt.Errorf("failed to create peer manager: %w", err)
 	}
 
-	csMetrics, p2pMetrics, memplMetrics, smMetrics := defaultMetricsProvider(config.Instrumentation)(genDoc.ChainID)
+	csMetrics, p2pMetrics, memplMetrics, smMetrics, ssMetrics :=
+		defaultMetricsProvider(config.Instrumentation)(genDoc.ChainID)
 
 	router, err := createRouter(p2pLogger, p2pMetrics, nodeInfo, nodeKey.PrivKey,
 		peerManager, transport, getRouterConfig(config, proxyApp))
@@ -254,8 +256,19 @@ func makeNode(config *cfg.Config,
 		return nil, fmt.Errorf("failed to create router: %w", err)
 	}
 
+	var (
+		mpJournal        *mempool.Journal
+		mpJournalEntries []mempool.JournalEntry
+	)
+	if config.Mempool.Journal {
+		mpJournal, mpJournalEntries, err = mempool.OpenJournal(config.Mempool.JournalFile())
+		if err != nil {
+			return nil, fmt.Errorf("failed to open mempool journal: %w", err)
+		}
+	}
+
 	mpReactorShim, mpReactor, mp, err := createMempoolReactor(
-		config, proxyApp, state, memplMetrics, peerManager, router, logger,
+		config, proxyApp, state, memplMetrics, peerManager, router, mpJournal, mpJournalEntries, eventBus, logger,
 	)
 	if err != nil {
 		return nil, err
@@ -333,6 +346,11 @@ func makeNode(config *cfg.Config,
 		peerUpdates = stateSyncReactorShim.PeerUpdates
 	}
 
+	snapshotStore, err := statesync.NewSnapshotStore(config.StateSync.SnapshotDir())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the snapshot store: %w", err)
+	}
+
 	stateSyncReactor = statesync.NewReactor(
 		*config.StateSync,
 		stateSyncReactorShim.Logger,
@@ -344,7 +362,9 @@ func makeNode(config *cfg.Config,
 		peerUpdates,
 		stateStore,
 		blockStore,
+		snapshotStore,
 		config.StateSync.TempDir,
+		ssMetrics,
 	)
 
 	// add the channel descriptors to both the transports
@@ -438,6 +458,7 @@ func makeNode(config *cfg.Config,
 		bcReactor:        bcReactor,
 		mempoolReactor:   mpReactor,
 		mempool:          mp,
+		mempoolJournal:   mpJournal,
 		consensusState:   csState,
 		consensusReactor: csReactor,
 		stateSyncReactor: stateSyncReactor,
@@ -717,6 +738,12 @@ func (n *nodeImpl) OnStop() {
 			n.Logger.Error("failed to stop the mempool reactor", "err", err)
 		}
 
+		if n.mempoolJournal != nil {
+			if err := n.mempoolJournal.Close(); err != nil {
+				n.Logger.Error("failed to close the mempool journal", "err", err)
+			}
+		}
+
 		// Stop the real evidence reactor separately since the switch uses the shim.
 		if err := n.evidenceReactor.Stop(); err != nil {
 			n.Logger.Error("failed to stop the evidence reactor", "err", err)
@@ -790,6 +817,7 @@ func (n *nodeImpl) ConfigureRPC() (*rpccore.Environment, error) {
 
 		Config:           *n.config.RPC,
 		BlockSyncReactor: n.bcReactor.(cs.BlockSyncReactor),
+		StateSyncReactor: n.stateSyncReactor,
 	}
 	if n.config.Mode == cfg.ModeValidator {
 		pubKey, err := n.privValidator.GetPubKey(context.TODO())
@@ -1107,20 +1135,22 @@ func defaultGenesisDocProviderFunc(config *cfg.Config) genesisDocProvider {
 	}
 }
 
-// metricsProvider returns a consensus, p2p and mempool Metrics.
-type metricsProvider func(chainID string) (*cs.Metrics, *p2p.Metrics, *mempool.Metrics, *sm.Metrics)
+// metricsProvider returns a consensus, p2p, mempool, state and state sync Metrics.
+type metricsProvider func(chainID string) (*cs.Metrics, *p2p.Metrics, *mempool.Metrics, *sm.Metrics,
+	*statesync.Metrics)
 
 // defaultMetricsProvider returns Metrics build using Prometheus client library
 // if Prometheus is enabled. Otherwise, it returns no-op Metrics.
 func defaultMetricsProvider(config *cfg.InstrumentationConfig) metricsProvider {
-	return func(chainID string) (*cs.Metrics, *p2p.Metrics, *mempool.Metrics, *sm.Metrics) {
+	return func(chainID string) (*cs.Metrics, *p2p.Metrics, *mempool.Metrics, *sm.Metrics, *statesync.Metrics) {
 		if config.Prometheus {
 			return cs.PrometheusMetrics(config.Namespace, "chain_id", chainID),
 				p2p.PrometheusMetrics(config.Namespace, "chain_id", chainID),
 				mempool.PrometheusMetrics(config.Namespace, "chain_id", chainID),
-				sm.PrometheusMetrics(config.Namespace, "chain_id", chainID)
+				sm.PrometheusMetrics(config.Namespace, "chain_id", chainID),
+				statesync.PrometheusMetrics(config.Namespace, "chain_id", chainID)
 		}
-		return cs.NopMetrics(), p2p.NopMetrics(), mempool.NopMetrics(), sm.NopMetrics()
+		return cs.NopMetrics(), p2p.NopMetrics(), mempool.NopMetrics(), sm.NopMetrics(), statesync.NopMetrics()
 	}
 }
 
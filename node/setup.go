@@ -204,6 +204,9 @@ func createMempoolReactor(
 	memplMetrics *mempool.Metrics,
 	peerManager *p2p.PeerManager,
 	router *p2p.Router,
+	journal *mempool.Journal,
+	journalEntries []mempool.JournalEntry,
+	eventBus *types.EventBus,
 	logger log.Logger,
 ) (*p2p.ReactorShim, service.Service, mempool.Mempool, error) {
 
@@ -233,6 +236,7 @@ func createMempoolReactor(
 			mempoolv0.WithMetrics(memplMetrics),
 			mempoolv0.WithPreCheck(sm.TxPreCheck(state)),
 			mempoolv0.WithPostCheck(sm.TxPostCheck(state)),
+			mempoolv0.WithJournal(journal),
 		)
 
 		mp.SetLogger(logger)
@@ -250,6 +254,12 @@ func createMempoolReactor(
 			mp.EnableTxsAvailable()
 		}
 
+		if journal != nil {
+			if err := mp.ReplayJournal(journalEntries); err != nil {
+				return nil, nil, nil, fmt.Errorf("failed to replay mempool journal: %w", err)
+			}
+		}
+
 		return reactorShim, reactor, mp, nil
 
 	case cfg.MempoolV1:
@@ -261,6 +271,8 @@ func createMempoolReactor(
 			mempoolv1.WithMetrics(memplMetrics),
 			mempoolv1.WithPreCheck(sm.TxPreCheck(state)),
 			mempoolv1.WithPostCheck(sm.TxPostCheck(state)),
+			mempoolv1.WithJournal(journal),
+			mempoolv1.WithEventBus(eventBus),
 		)
 
 		reactor := mempoolv1.NewReactor(
@@ -276,6 +288,12 @@ func createMempoolReactor(
 			mp.EnableTxsAvailable()
 		}
 
+		if journal != nil {
+			if err := mp.ReplayJournal(journalEntries); err != nil {
+				return nil, nil, nil, fmt.Errorf("failed to replay mempool journal: %w", err)
+			}
+		}
+
 		return reactorShim, reactor, mp, nil
 
 	default:
@@ -434,6 +452,7 @@ func createConsensusReactor(
 		peerUpdates,
 		waitSync,
 		cs.ReactorMetrics(csMetrics),
+		cs.ReactorMempool(mp),
 	)
 
 	// Services which will be publishing and/or subscribing for messages (events)
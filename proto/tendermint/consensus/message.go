@@ -36,6 +36,18 @@ func (m *Message) Wrap(pb proto.Message) error {
 	case *VoteSetBits:
 		m.Sum = &Message_VoteSetBits{VoteSetBits: msg}
 
+	case *CompactBlock:
+		m.Sum = &Message_CompactBlock{CompactBlock: msg}
+
+	case *CompactBlockStatus:
+		m.Sum = &Message_CompactBlockStatus{CompactBlockStatus: msg}
+
+	case *CompactBlockTxs:
+		m.Sum = &Message_CompactBlockTxs{CompactBlockTxs: msg}
+
+	case *CompactBlockSupport:
+		m.Sum = &Message_CompactBlockSupport{CompactBlockSupport: msg}
+
 	default:
 		return fmt.Errorf("unknown message: %T", msg)
 	}
@@ -74,6 +86,18 @@ func (m *Message) Unwrap() (proto.Message, error) {
 	case *Message_VoteSetBits:
 		return m.GetVoteSetBits(), nil
 
+	case *Message_CompactBlock:
+		return m.GetCompactBlock(), nil
+
+	case *Message_CompactBlockStatus:
+		return m.GetCompactBlockStatus(), nil
+
+	case *Message_CompactBlockTxs:
+		return m.GetCompactBlockTxs(), nil
+
+	case *Message_CompactBlockSupport:
+		return m.GetCompactBlockSupport(), nil
+
 	default:
 		return nil, fmt.Errorf("unknown message: %T", msg)
 	}
@@ -12,6 +12,15 @@ func (m *Message) Wrap(pb proto.Message) error {
 	case *Txs:
 		m.Sum = &Message_Txs{Txs: msg}
 
+	case *GossipMode:
+		m.Sum = &Message_GossipMode{GossipMode: msg}
+
+	case *TxAnnouncement:
+		m.Sum = &Message_TxAnnouncement{TxAnnouncement: msg}
+
+	case *TxRequest:
+		m.Sum = &Message_TxRequest{TxRequest: msg}
+
 	default:
 		return fmt.Errorf("unknown message: %T", msg)
 	}
@@ -26,6 +35,15 @@ func (m *Message) Unwrap() (proto.Message, error) {
 	case *Message_Txs:
 		return m.GetTxs(), nil
 
+	case *Message_GossipMode:
+		return m.GetGossipMode(), nil
+
+	case *Message_TxAnnouncement:
+		return m.GetTxAnnouncement(), nil
+
+	case *Message_TxRequest:
+		return m.GetTxRequest(), nil
+
 	default:
 		return nil, fmt.Errorf("unknown message: %T", msg)
 	}
@@ -83,6 +83,8 @@ func (m *CanonicalBlockID) GetPartSetHeader() CanonicalPartSetHeader {
 type CanonicalPartSetHeader struct {
 	Total uint32 `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
 	Hash  []byte `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	// number of data parts of an erasure coded part set, 0 if not erasure coded
+	DataParts uint32 `protobuf:"varint,3,opt,name=data_parts,json=dataParts,proto3" json:"data_parts,omitempty"`
 }
 
 func (m *CanonicalPartSetHeader) Reset()         { *m = CanonicalPartSetHeader{} }
@@ -132,6 +134,13 @@ func (m *CanonicalPartSetHeader) GetHash() []byte {
 	return nil
 }
 
+func (m *CanonicalPartSetHeader) GetDataParts() uint32 {
+	if m != nil {
+		return m.DataParts
+	}
+	return 0
+}
+
 type CanonicalProposal struct {
 	Type      SignedMsgType     `protobuf:"varint,1,opt,name=type,proto3,enum=tendermint.types.SignedMsgType" json:"type,omitempty"`
 	Height    int64             `protobuf:"fixed64,2,opt,name=height,proto3" json:"height,omitempty"`
@@ -318,38 +327,39 @@ func init() {
 func init() { proto.RegisterFile("tendermint/types/canonical.proto", fileDescriptor_8d1a1a84ff7267ed) }
 
 var fileDescriptor_8d1a1a84ff7267ed = []byte{
-	// 487 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x53, 0x3d, 0x6f, 0xd3, 0x40,
-	0x18, 0xce, 0xa5, 0x4e, 0xe2, 0x5c, 0x1b, 0x08, 0xa7, 0xaa, 0xb2, 0x22, 0x64, 0x5b, 0x1e, 0x90,
-	0x59, 0x6c, 0xa9, 0x1d, 0xd8, 0x5d, 0x06, 0x82, 0x40, 0x94, 0x6b, 0xd5, 0x81, 0x25, 0xba, 0xd8,
-	0x87, 0x6d, 0xe1, 0xf8, 0x4e, 0xf6, 0x65, 0xe8, 0xc2, 0x6f, 0xe8, 0xef, 0xe0, 0x97, 0x74, 0xec,
-	0x08, 0x4b, 0x40, 0xce, 0x1f, 0x41, 0x77, 0x4e, 0xec, 0xa8, 0x01, 0x16, 0x10, 0xcb, 0xe9, 0xfd,
-	0x78, 0xee, 0x79, 0x1f, 0x3d, 0xaf, 0x5e, 0x68, 0x0b, 0x9a, 0x47, 0xb4, 0x58, 0xa4, 0xb9, 0xf0,
-	0xc5, 0x0d, 0xa7, 0xa5, 0x1f, 0x92, 0x9c, 0xe5, 0x69, 0x48, 0x32, 0x8f, 0x17, 0x4c, 0x30, 0x34,
-	0x6e, 0x11, 0x9e, 0x42, 0x4c, 0x8e, 0x63, 0x16, 0x33, 0xd5, 0xf4, 0x65, 0x54, 0xe3, 0x26, 0x4f,
-	0xf7, 0x98, 0xd4, 0xbb, 0xe9, 0x5a, 0x31, 0x63, 0x71, 0x46, 0x7d, 0x95, 0xcd, 0x97, 0x1f, 0x7d,
-	0x91, 0x2e, 0x68, 0x29, 0xc8, 0x82, 0xd7, 0x00, 0xe7, 0x33, 0x1c, 0x9f, 0x6f, 0x27, 0x07, 0x19,
-	0x0b, 0x3f, 0x4d, 0x5f, 0x22, 0x04, 0xb5, 0x84, 0x94, 0x89, 0x01, 0x6c, 0xe0, 0x1e, 0x61, 0x15,
-	0xa3, 0x6b, 0xf8, 0x98, 0x93, 0x42, 0xcc, 0x4a, 0x2a, 0x66, 0x09, 0x25, 0x11, 0x2d, 0x8c, 0xae,
-	0x0d, 0xdc, 0xc3, 0x53, 0xd7, 0x7b, 0x28, 0xd4, 0x6b, 0x08, 0x2f, 0x48, 0x21, 0x2e, 0xa9, 0x78,
-	0xa5, 0xf0, 0x81, 0x76, 0xb7, 0xb2, 0x3a, 0x78, 0xc4, 0x77, 0x8b, 0x4e, 0x00, 0x4f, 0x7e, 0x0d,
-	0x47, 0xc7, 0xb0, 0x27, 0x98, 0x20, 0x99, 0x92, 0x31, 0xc2, 0x75, 0xd2, 0x68, 0xeb, 0xb6, 0xda,
-	0x9c, 0x6f, 0x5d, 0xf8, 0xa4, 0x25, 0x29, 0x18, 0x67, 0x25, 0xc9, 0xd0, 0x19, 0xd4, 0xa4, 0x1c,
-	0xf5, 0xfd, 0xd1, 0xa9, 0xb5, 0x2f, 0xf3, 0x32, 0x8d, 0x73, 0x1a, 0xbd, 0x2d, 0xe3, 0xab, 0x1b,
-	0x4e, 0xb1, 0x02, 0xa3, 0x13, 0xd8, 0x4f, 0x68, 0x1a, 0x27, 0x42, 0x0d, 0x18, 0xe3, 0x4d, 0x26,
-	0xc5, 0x14, 0x6c, 0x99, 0x47, 0xc6, 0x81, 0x2a, 0xd7, 0x09, 0x7a, 0x0e, 0x87, 0x9c, 0x65, 0xb3,
-	0xba, 0xa3, 0xd9, 0xc0, 0x3d, 0x08, 0x8e, 0xaa, 0x95, 0xa5, 0x5f, 0xbc, 0x7b, 0x83, 0x65, 0x0d,
-	0xeb, 0x9c, 0x65, 0x2a, 0x42, 0xaf, 0xa1, 0x3e, 0x97, 0xf6, 0xce, 0xd2, 0xc8, 0xe8, 0x29, 0xe3,
-	0x9c, 0x3f, 0x18, 0xb7, 0xd9, 0x44, 0x70, 0x58, 0xad, 0xac, 0xc1, 0x26, 0xc1, 0x03, 0x45, 0x30,
-	0x8d, 0x50, 0x00, 0x87, 0xcd, 0x1a, 0x8d, 0xbe, 0x22, 0x9b, 0x78, 0xf5, 0xa2, 0xbd, 0xed, 0xa2,
-	0xbd, 0xab, 0x2d, 0x22, 0xd0, 0xa5, 0xef, 0xb7, 0xdf, 0x2d, 0x80, 0xdb, 0x6f, 0xe8, 0x19, 0xd4,
-	0xc3, 0x84, 0xa4, 0xb9, 0xd4, 0x33, 0xb0, 0x81, 0x3b, 0xac, 0x67, 0x9d, 0xcb, 0x9a, 0x9c, 0xa5,
-	0x9a, 0xd3, 0xc8, 0xf9, 0xd2, 0x85, 0xa3, 0x46, 0xd6, 0x35, 0x13, 0xf4, 0x7f, 0xf8, 0xba, 0x6b,
-	0x96, 0xf6, 0x2f, 0xcd, 0xea, 0xfd, 0xbd, 0x59, 0xfd, 0xdf, 0x9b, 0x15, 0xbc, 0xbf, 0xab, 0x4c,
-	0x70, 0x5f, 0x99, 0xe0, 0x47, 0x65, 0x82, 0xdb, 0xb5, 0xd9, 0xb9, 0x5f, 0x9b, 0x9d, 0xaf, 0x6b,
-	0xb3, 0xf3, 0xe1, 0x45, 0x9c, 0x8a, 0x64, 0x39, 0xf7, 0x42, 0xb6, 0xf0, 0x77, 0x0f, 0xb6, 0x0d,
-	0xeb, 0xc3, 0x7e, 0x78, 0xcc, 0xf3, 0xbe, 0xaa, 0x9f, 0xfd, 0x0c, 0x00, 0x00, 0xff, 0xff, 0x6d,
-	0xdd, 0x12, 0x5d, 0x31, 0x04, 0x00, 0x00,
+	// 501 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x53, 0x3f, 0x6f, 0xd3, 0x40,
+	0x1c, 0x8d, 0xd3, 0xfc, 0x71, 0xae, 0x0d, 0x84, 0x53, 0x55, 0x59, 0x11, 0xd8, 0x96, 0x07, 0x64,
+	0x16, 0x5b, 0x6a, 0x07, 0x76, 0x97, 0x81, 0x20, 0x10, 0xe5, 0x5a, 0x75, 0x60, 0xb1, 0x2e, 0xf6,
+	0x61, 0x5b, 0x38, 0xbe, 0x93, 0x7d, 0x19, 0xba, 0xf0, 0x19, 0xfa, 0x39, 0xf8, 0x24, 0x1d, 0x3b,
+	0xc2, 0x12, 0x90, 0xf3, 0x45, 0xd0, 0xfd, 0x9c, 0x7f, 0x6a, 0x81, 0x05, 0xd4, 0x25, 0xfa, 0xfd,
+	0x79, 0x79, 0xef, 0xf9, 0xdd, 0x1d, 0xb2, 0x25, 0x2b, 0x62, 0x56, 0xce, 0xb2, 0x42, 0xfa, 0xf2,
+	0x4a, 0xb0, 0xca, 0x8f, 0x68, 0xc1, 0x8b, 0x2c, 0xa2, 0xb9, 0x27, 0x4a, 0x2e, 0x39, 0x1e, 0x6d,
+	0x11, 0x1e, 0x20, 0xc6, 0x87, 0x09, 0x4f, 0x38, 0x2c, 0x7d, 0x55, 0x35, 0xb8, 0xf1, 0xd3, 0x7b,
+	0x4c, 0xf0, 0xbb, 0xda, 0x5a, 0x09, 0xe7, 0x49, 0xce, 0x7c, 0xe8, 0xa6, 0xf3, 0x4f, 0xbe, 0xcc,
+	0x66, 0xac, 0x92, 0x74, 0x26, 0x1a, 0x80, 0xf3, 0x05, 0x8d, 0x4e, 0xd7, 0xca, 0x41, 0xce, 0xa3,
+	0xcf, 0x93, 0x57, 0x18, 0xa3, 0x4e, 0x4a, 0xab, 0xd4, 0xd0, 0x6c, 0xcd, 0x3d, 0x20, 0x50, 0xe3,
+	0x4b, 0xf4, 0x58, 0xd0, 0x52, 0x86, 0x15, 0x93, 0x61, 0xca, 0x68, 0xcc, 0x4a, 0xa3, 0x6d, 0x6b,
+	0xee, 0xfe, 0xb1, 0xeb, 0xdd, 0x35, 0xea, 0x6d, 0x08, 0xcf, 0x68, 0x29, 0xcf, 0x99, 0x7c, 0x0d,
+	0xf8, 0xa0, 0x73, 0xb3, 0xb0, 0x5a, 0x64, 0x28, 0x76, 0x87, 0x0e, 0x45, 0x47, 0xbf, 0x87, 0xe3,
+	0x43, 0xd4, 0x95, 0x5c, 0xd2, 0x1c, 0x6c, 0x0c, 0x49, 0xd3, 0x6c, 0xbc, 0xb5, 0x77, 0xbc, 0x3d,
+	0x43, 0x28, 0xa6, 0x92, 0x86, 0x8a, 0xb9, 0x32, 0xf6, 0x00, 0x3e, 0x50, 0x13, 0x45, 0x58, 0x39,
+	0xdf, 0xdb, 0xe8, 0xc9, 0x56, 0xa3, 0xe4, 0x82, 0x57, 0x34, 0xc7, 0x27, 0xa8, 0xa3, 0xdc, 0x02,
+	0xfb, 0xa3, 0x63, 0xeb, 0xfe, 0x57, 0x9c, 0x67, 0x49, 0xc1, 0xe2, 0x77, 0x55, 0x72, 0x71, 0x25,
+	0x18, 0x01, 0x30, 0x3e, 0x42, 0xbd, 0x94, 0x65, 0x49, 0x2a, 0x41, 0x7f, 0x44, 0x56, 0x9d, 0xf2,
+	0x5a, 0xf2, 0x79, 0x11, 0x83, 0xf8, 0x88, 0x34, 0x0d, 0x7e, 0x81, 0x06, 0x82, 0xe7, 0x61, 0xb3,
+	0xe9, 0xd8, 0x9a, 0xbb, 0x17, 0x1c, 0xd4, 0x0b, 0x4b, 0x3f, 0x7b, 0xff, 0x96, 0xa8, 0x19, 0xd1,
+	0x05, 0xcf, 0xa1, 0xc2, 0x6f, 0x90, 0x3e, 0x55, 0xe9, 0x87, 0x59, 0x6c, 0x74, 0x21, 0x57, 0xe7,
+	0x2f, 0xb9, 0xae, 0x0e, 0x2a, 0xd8, 0xaf, 0x17, 0x56, 0x7f, 0xd5, 0x90, 0x3e, 0x10, 0x4c, 0x62,
+	0x1c, 0xa0, 0xc1, 0xe6, 0x94, 0x8d, 0x1e, 0x90, 0x8d, 0xbd, 0xe6, 0x1e, 0x78, 0xeb, 0x7b, 0xe0,
+	0x5d, 0xac, 0x11, 0x81, 0xae, 0x8e, 0xe5, 0xfa, 0x87, 0xa5, 0x91, 0xed, 0xdf, 0xf0, 0x73, 0xa4,
+	0x47, 0x29, 0xcd, 0x0a, 0xe5, 0xa7, 0x6f, 0x6b, 0xee, 0xa0, 0xd1, 0x3a, 0x55, 0x33, 0xa5, 0x05,
+	0xcb, 0x49, 0xec, 0x7c, 0x6d, 0xa3, 0xe1, 0xc6, 0xd6, 0x25, 0x97, 0xec, 0x21, 0x72, 0xdd, 0x0d,
+	0xab, 0xf3, 0x3f, 0xc3, 0xea, 0xfe, 0x7b, 0x58, 0xbd, 0x3f, 0x87, 0x15, 0x7c, 0xb8, 0xa9, 0x4d,
+	0xed, 0xb6, 0x36, 0xb5, 0x9f, 0xb5, 0xa9, 0x5d, 0x2f, 0xcd, 0xd6, 0xed, 0xd2, 0x6c, 0x7d, 0x5b,
+	0x9a, 0xad, 0x8f, 0x2f, 0x93, 0x4c, 0xa6, 0xf3, 0xa9, 0x17, 0xf1, 0x99, 0xbf, 0xfb, 0x9e, 0xb7,
+	0x65, 0xf3, 0xee, 0xef, 0xbe, 0xf5, 0x69, 0x0f, 0xe6, 0x27, 0xbf, 0x06, 0x00, 0xb1, 0xbb, 0xf4,
+	0x8c, 0x50, 0x04, 0x00, 0x00,
 }
 
 func (m *CanonicalBlockID) Marshal() (dAtA []byte, err error) {
@@ -412,6 +422,11 @@ func (m *CanonicalPartSetHeader) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	_ = i
 	var l int
 	_ = l
+	if m.DataParts != 0 {
+		i = encodeVarintCanonical(dAtA, i, uint64(m.DataParts))
+		i--
+		dAtA[i] = 0x18
+	}
 	if len(m.Hash) > 0 {
 		i -= len(m.Hash)
 		copy(dAtA[i:], m.Hash)
@@ -605,6 +620,9 @@ func (m *CanonicalPartSetHeader) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovCanonical(uint64(l))
 	}
+	if m.DataParts != 0 {
+		n += 1 + sovCanonical(uint64(m.DataParts))
+	}
 	return n
 }
 
@@ -872,6 +890,25 @@ func (m *CanonicalPartSetHeader) Unmarshal(dAtA []byte) error {
 				m.Hash = []byte{}
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DataParts", wireType)
+			}
+			m.DataParts = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCanonical
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DataParts |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCanonical(dAtA[iNdEx:])
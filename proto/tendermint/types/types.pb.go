@@ -99,6 +99,8 @@ func (SignedMsgType) EnumDescriptor() ([]byte, []int) {
 type PartSetHeader struct {
 	Total uint32 `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
 	Hash  []byte `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	// number of data parts of an erasure coded part set, 0 if not erasure coded
+	DataParts uint32 `protobuf:"varint,3,opt,name=data_parts,json=dataParts,proto3" json:"data_parts,omitempty"`
 }
 
 func (m *PartSetHeader) Reset()         { *m = PartSetHeader{} }
@@ -148,6 +150,13 @@ func (m *PartSetHeader) GetHash() []byte {
 	return nil
 }
 
+func (m *PartSetHeader) GetDataParts() uint32 {
+	if m != nil {
+		return m.DataParts
+	}
+	return 0
+}
+
 type Part struct {
 	Index uint32       `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
 	Bytes []byte       `protobuf:"bytes,2,opt,name=bytes,proto3" json:"bytes,omitempty"`
@@ -1049,90 +1058,90 @@ func init() {
 func init() { proto.RegisterFile("tendermint/types/types.proto", fileDescriptor_d3a6e55e2345de56) }
 
 var fileDescriptor_d3a6e55e2345de56 = []byte{
-	// 1314 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x57, 0x4f, 0x6f, 0x1b, 0x45,
-	0x14, 0xcf, 0xda, 0x9b, 0xd8, 0x7e, 0xb6, 0x13, 0x67, 0x95, 0xb6, 0xae, 0xdb, 0x38, 0x2b, 0x23,
-	0x20, 0x2d, 0x68, 0x53, 0x52, 0xc4, 0x9f, 0x03, 0x07, 0xdb, 0x49, 0x5b, 0xab, 0x89, 0x63, 0xd6,
-	0x6e, 0x11, 0x5c, 0x56, 0x6b, 0xef, 0xd4, 0x5e, 0xba, 0xde, 0x59, 0xed, 0x8c, 0x43, 0xd2, 0x4f,
-	0x80, 0x72, 0xea, 0x89, 0x5b, 0x4e, 0x70, 0xe0, 0xce, 0x17, 0x40, 0x9c, 0x7a, 0xec, 0x0d, 0x2e,
-	0x14, 0x94, 0x4a, 0x88, 0x8f, 0x81, 0xe6, 0x8f, 0xd7, 0xeb, 0x38, 0x86, 0xaa, 0xaa, 0xb8, 0x58,
-	0x3b, 0xef, 0xfd, 0xde, 0xcc, 0x7b, 0xbf, 0xf7, 0x9b, 0x3f, 0x86, 0xeb, 0x14, 0xf9, 0x0e, 0x0a,
-	0x87, 0xae, 0x4f, 0xb7, 0xe8, 0x71, 0x80, 0x88, 0xf8, 0x35, 0x82, 0x10, 0x53, 0xac, 0x15, 0x26,
-	0x5e, 0x83, 0xdb, 0x4b, 0x6b, 0x7d, 0xdc, 0xc7, 0xdc, 0xb9, 0xc5, 0xbe, 0x04, 0xae, 0xb4, 0xd1,
-	0xc7, 0xb8, 0xef, 0xa1, 0x2d, 0x3e, 0xea, 0x8e, 0x1e, 0x6d, 0x51, 0x77, 0x88, 0x08, 0xb5, 0x87,
-	0x81, 0x04, 0xac, 0xc7, 0x96, 0xe9, 0x85, 0xc7, 0x01, 0xc5, 0x0c, 0x8b, 0x1f, 0x49, 0x77, 0x39,
-	0xe6, 0x3e, 0x44, 0x21, 0x71, 0xb1, 0x1f, 0xcf, 0xa3, 0xa4, 0xcf, 0x64, 0x79, 0x68, 0x7b, 0xae,
-	0x63, 0x53, 0x1c, 0x0a, 0x44, 0xe5, 0x53, 0xc8, 0xb7, 0xec, 0x90, 0xb6, 0x11, 0xbd, 0x87, 0x6c,
-	0x07, 0x85, 0xda, 0x1a, 0x2c, 0x52, 0x4c, 0x6d, 0xaf, 0xa8, 0xe8, 0xca, 0x66, 0xde, 0x14, 0x03,
-	0x4d, 0x03, 0x75, 0x60, 0x93, 0x41, 0x31, 0xa1, 0x2b, 0x9b, 0x39, 0x93, 0x7f, 0x57, 0x06, 0xa0,
-	0xb2, 0x50, 0x16, 0xe1, 0xfa, 0x0e, 0x3a, 0x1a, 0x47, 0xf0, 0x01, 0xb3, 0x76, 0x8f, 0x29, 0x22,
-	0x32, 0x44, 0x0c, 0xb4, 0x0f, 0x61, 0x91, 0xe7, 0x5f, 0x4c, 0xea, 0xca, 0x66, 0x76, 0xbb, 0x68,
-	0xc4, 0x88, 0x12, 0xf5, 0x19, 0x2d, 0xe6, 0xaf, 0xa9, 0xcf, 0x5e, 0x6c, 0x2c, 0x98, 0x02, 0x5c,
-	0xf1, 0x20, 0x55, 0xf3, 0x70, 0xef, 0x71, 0x63, 0x27, 0x4a, 0x44, 0x99, 0x24, 0xa2, 0xed, 0xc3,
-	0x4a, 0x60, 0x87, 0xd4, 0x22, 0x88, 0x5a, 0x03, 0x5e, 0x05, 0x5f, 0x34, 0xbb, 0xbd, 0x61, 0x9c,
-	0xef, 0x83, 0x31, 0x55, 0xac, 0x5c, 0x25, 0x1f, 0xc4, 0x8d, 0x95, 0xbf, 0x54, 0x58, 0x92, 0x64,
-	0x7c, 0x06, 0x29, 0x49, 0x2b, 0x5f, 0x30, 0xbb, 0xbd, 0x1e, 0x9f, 0x51, 0xba, 0x8c, 0x3a, 0xf6,
-	0x09, 0xf2, 0xc9, 0x88, 0xc8, 0xf9, 0xc6, 0x31, 0xda, 0x3b, 0x90, 0xee, 0x0d, 0x6c, 0xd7, 0xb7,
-	0x5c, 0x87, 0x67, 0x94, 0xa9, 0x65, 0xcf, 0x5e, 0x6c, 0xa4, 0xea, 0xcc, 0xd6, 0xd8, 0x31, 0x53,
-	0xdc, 0xd9, 0x70, 0xb4, 0xcb, 0xb0, 0x34, 0x40, 0x6e, 0x7f, 0x40, 0x39, 0x2d, 0x49, 0x53, 0x8e,
-	0xb4, 0x4f, 0x40, 0x65, 0x82, 0x28, 0xaa, 0x7c, 0xed, 0x92, 0x21, 0xd4, 0x62, 0x8c, 0xd5, 0x62,
-	0x74, 0xc6, 0x6a, 0xa9, 0xa5, 0xd9, 0xc2, 0x4f, 0xff, 0xd8, 0x50, 0x4c, 0x1e, 0xa1, 0xd5, 0x21,
-	0xef, 0xd9, 0x84, 0x5a, 0x5d, 0x46, 0x1b, 0x5b, 0x7e, 0x91, 0x4f, 0x71, 0x75, 0x96, 0x10, 0x49,
-	0xac, 0x4c, 0x3d, 0xcb, 0xa2, 0x84, 0xc9, 0xd1, 0x36, 0xa1, 0xc0, 0x27, 0xe9, 0xe1, 0xe1, 0xd0,
-	0xa5, 0x16, 0xe7, 0x7d, 0x89, 0xf3, 0xbe, 0xcc, 0xec, 0x75, 0x6e, 0xbe, 0xc7, 0x3a, 0x70, 0x0d,
-	0x32, 0x8e, 0x4d, 0x6d, 0x01, 0x49, 0x71, 0x48, 0x9a, 0x19, 0xb8, 0xf3, 0x5d, 0x58, 0x89, 0x54,
-	0x47, 0x04, 0x24, 0x2d, 0x66, 0x99, 0x98, 0x39, 0xf0, 0x16, 0xac, 0xf9, 0xe8, 0x88, 0x5a, 0xe7,
-	0xd1, 0x19, 0x8e, 0xd6, 0x98, 0xef, 0xe1, 0x74, 0xc4, 0xdb, 0xb0, 0xdc, 0x1b, 0x93, 0x2f, 0xb0,
-	0xc0, 0xb1, 0xf9, 0xc8, 0xca, 0x61, 0x57, 0x21, 0x6d, 0x07, 0x81, 0x00, 0x64, 0x39, 0x20, 0x65,
-	0x07, 0x01, 0x77, 0xdd, 0x84, 0x55, 0x5e, 0x63, 0x88, 0xc8, 0xc8, 0xa3, 0x72, 0x92, 0x1c, 0xc7,
-	0xac, 0x30, 0x87, 0x29, 0xec, 0x1c, 0xfb, 0x16, 0xe4, 0xd1, 0xa1, 0xeb, 0x20, 0xbf, 0x87, 0x04,
-	0x2e, 0xcf, 0x71, 0xb9, 0xb1, 0x91, 0x83, 0x6e, 0x40, 0x21, 0x08, 0x71, 0x80, 0x09, 0x0a, 0x2d,
-	0xdb, 0x71, 0x42, 0x44, 0x48, 0x71, 0x59, 0xcc, 0x37, 0xb6, 0x57, 0x85, 0xb9, 0x52, 0x04, 0x75,
-	0xc7, 0xa6, 0xb6, 0x56, 0x80, 0x24, 0x3d, 0x22, 0x45, 0x45, 0x4f, 0x6e, 0xe6, 0x4c, 0xf6, 0x59,
-	0xf9, 0x3b, 0x01, 0xea, 0x43, 0x4c, 0x91, 0x76, 0x1b, 0x54, 0xd6, 0x26, 0xae, 0xbe, 0xe5, 0x8b,
-	0xf4, 0xdc, 0x76, 0xfb, 0x3e, 0x72, 0xf6, 0x49, 0xbf, 0x73, 0x1c, 0x20, 0x93, 0x83, 0x63, 0x72,
-	0x4a, 0x4c, 0xc9, 0x69, 0x0d, 0x16, 0x43, 0x3c, 0xf2, 0x1d, 0xae, 0xb2, 0x45, 0x53, 0x0c, 0xb4,
-	0x5d, 0x48, 0x47, 0x2a, 0x51, 0xff, 0x4b, 0x25, 0x2b, 0x4c, 0x25, 0x4c, 0xc3, 0xd2, 0x60, 0xa6,
-	0xba, 0x52, 0x2c, 0x35, 0xc8, 0x44, 0x87, 0x97, 0x54, 0xdb, 0xab, 0x09, 0x76, 0x12, 0xa6, 0xbd,
-	0x07, 0xab, 0x51, 0xef, 0x23, 0xf2, 0x84, 0xe2, 0x0a, 0x91, 0x43, 0xb2, 0x37, 0x25, 0x2b, 0x4b,
-	0x1c, 0x40, 0x29, 0x5e, 0xd7, 0x44, 0x56, 0x0d, 0x7e, 0x12, 0x5d, 0x87, 0x0c, 0x71, 0xfb, 0xbe,
-	0x4d, 0x47, 0x21, 0x92, 0xca, 0x9b, 0x18, 0x2a, 0x3f, 0x2b, 0xb0, 0x24, 0x94, 0x1c, 0xe3, 0x4d,
-	0xb9, 0x98, 0xb7, 0xc4, 0x3c, 0xde, 0x92, 0xaf, 0xcf, 0x5b, 0x15, 0x20, 0x4a, 0x86, 0x14, 0x55,
-	0x3d, 0xb9, 0x99, 0xdd, 0xbe, 0x36, 0x3b, 0x91, 0x48, 0xb1, 0xed, 0xf6, 0xe5, 0x46, 0x8d, 0x05,
-	0x55, 0x7e, 0x57, 0x20, 0x13, 0xf9, 0xb5, 0x2a, 0xe4, 0xc7, 0x79, 0x59, 0x8f, 0x3c, 0xbb, 0x2f,
-	0xb5, 0xb3, 0x3e, 0x37, 0xb9, 0x3b, 0x9e, 0xdd, 0x37, 0xb3, 0x32, 0x1f, 0x36, 0xb8, 0xb8, 0x0f,
-	0x89, 0x39, 0x7d, 0x98, 0x6a, 0x7c, 0xf2, 0xf5, 0x1a, 0x3f, 0xd5, 0x22, 0xf5, 0x7c, 0x8b, 0x7e,
-	0x4a, 0x40, 0xba, 0xc5, 0xf7, 0x8e, 0xed, 0xfd, 0x1f, 0x3b, 0xe2, 0x1a, 0x64, 0x02, 0xec, 0x59,
-	0xc2, 0xa3, 0x72, 0x4f, 0x3a, 0xc0, 0x9e, 0x39, 0xd3, 0xf6, 0xc5, 0x37, 0xb4, 0x5d, 0x96, 0xde,
-	0x00, 0x6b, 0xa9, 0xf3, 0xac, 0x85, 0x90, 0x13, 0x54, 0xc8, 0xbb, 0xec, 0x16, 0xe3, 0x80, 0x5f,
-	0x8e, 0xca, 0xec, 0xdd, 0x2b, 0xd2, 0x16, 0x48, 0x53, 0xe2, 0x58, 0x84, 0x38, 0xfa, 0xe5, 0x75,
-	0x5a, 0x9c, 0x27, 0x4b, 0x53, 0xe2, 0x2a, 0xdf, 0x29, 0x00, 0x7b, 0x8c, 0x59, 0x5e, 0x2f, 0xbb,
-	0x85, 0x08, 0x4f, 0xc1, 0x9a, 0x5a, 0xb9, 0x3c, 0xaf, 0x69, 0x72, 0xfd, 0x1c, 0x89, 0xe7, 0x5d,
-	0x87, 0xfc, 0x44, 0x8c, 0x04, 0x8d, 0x93, 0xb9, 0x60, 0x92, 0xe8, 0x72, 0x68, 0x23, 0x6a, 0xe6,
-	0x0e, 0x63, 0xa3, 0xca, 0x2f, 0x0a, 0x64, 0x78, 0x4e, 0xfb, 0x88, 0xda, 0x53, 0x3d, 0x54, 0x5e,
-	0xbf, 0x87, 0xeb, 0x00, 0x62, 0x1a, 0xe2, 0x3e, 0x41, 0x52, 0x59, 0x19, 0x6e, 0x69, 0xbb, 0x4f,
-	0x90, 0xf6, 0x51, 0x44, 0x78, 0xf2, 0xdf, 0x09, 0x97, 0x5b, 0x7a, 0x4c, 0xfb, 0x15, 0x48, 0xf9,
-	0xa3, 0xa1, 0xc5, 0xae, 0x04, 0x55, 0xa8, 0xd5, 0x1f, 0x0d, 0x3b, 0x47, 0xa4, 0xf2, 0x35, 0xa4,
-	0x3a, 0x47, 0xfc, 0x79, 0xc4, 0x24, 0x1a, 0x62, 0x2c, 0xef, 0x64, 0xf1, 0x16, 0x4a, 0x33, 0x03,
-	0xbf, 0x82, 0x34, 0x50, 0xd9, 0xe5, 0x3b, 0x7e, 0xac, 0xb1, 0x6f, 0xcd, 0x78, 0xc5, 0x87, 0x97,
-	0x7c, 0x72, 0xdd, 0xfc, 0x55, 0x81, 0x6c, 0xec, 0x7c, 0xd0, 0x3e, 0x80, 0x4b, 0xb5, 0xbd, 0x83,
-	0xfa, 0x7d, 0xab, 0xb1, 0x63, 0xdd, 0xd9, 0xab, 0xde, 0xb5, 0x1e, 0x34, 0xef, 0x37, 0x0f, 0xbe,
-	0x68, 0x16, 0x16, 0x4a, 0x97, 0x4f, 0x4e, 0x75, 0x2d, 0x86, 0x7d, 0xe0, 0x3f, 0xf6, 0xf1, 0x37,
-	0xbe, 0xb6, 0x05, 0x6b, 0xd3, 0x21, 0xd5, 0x5a, 0x7b, 0xb7, 0xd9, 0x29, 0x28, 0xa5, 0x4b, 0x27,
-	0xa7, 0xfa, 0x6a, 0x2c, 0xa2, 0xda, 0x25, 0xc8, 0xa7, 0xb3, 0x01, 0xf5, 0x83, 0xfd, 0xfd, 0x46,
-	0xa7, 0x90, 0x98, 0x09, 0x90, 0x07, 0xf6, 0x0d, 0x58, 0x9d, 0x0e, 0x68, 0x36, 0xf6, 0x0a, 0xc9,
-	0x92, 0x76, 0x72, 0xaa, 0x2f, 0xc7, 0xd0, 0x4d, 0xd7, 0x2b, 0xa5, 0xbf, 0xfd, 0xbe, 0xbc, 0xf0,
-	0xe3, 0x0f, 0x65, 0x85, 0x55, 0x96, 0x9f, 0x3a, 0x23, 0xb4, 0xf7, 0xe1, 0x4a, 0xbb, 0x71, 0xb7,
-	0xb9, 0xbb, 0x63, 0xed, 0xb7, 0xef, 0x5a, 0x9d, 0x2f, 0x5b, 0xbb, 0xb1, 0xea, 0x56, 0x4e, 0x4e,
-	0xf5, 0xac, 0x2c, 0x69, 0x1e, 0xba, 0x65, 0xee, 0x3e, 0x3c, 0xe8, 0xec, 0x16, 0x14, 0x81, 0x6e,
-	0x85, 0xe8, 0x10, 0x53, 0xc4, 0xd1, 0xb7, 0xe0, 0xea, 0x05, 0xe8, 0xa8, 0xb0, 0xd5, 0x93, 0x53,
-	0x3d, 0xdf, 0x0a, 0x91, 0xd8, 0x3f, 0x3c, 0xc2, 0x80, 0xe2, 0x6c, 0xc4, 0x41, 0xeb, 0xa0, 0x5d,
-	0xdd, 0x2b, 0xe8, 0xa5, 0xc2, 0xc9, 0xa9, 0x9e, 0x1b, 0x1f, 0x86, 0x0c, 0x3f, 0xa9, 0xac, 0xf6,
-	0xf9, 0xb3, 0xb3, 0xb2, 0xf2, 0xfc, 0xac, 0xac, 0xfc, 0x79, 0x56, 0x56, 0x9e, 0xbe, 0x2c, 0x2f,
-	0x3c, 0x7f, 0x59, 0x5e, 0xf8, 0xed, 0x65, 0x79, 0xe1, 0xab, 0x8f, 0xfb, 0x2e, 0x1d, 0x8c, 0xba,
-	0x46, 0x0f, 0x0f, 0xb7, 0xe2, 0x7f, 0x09, 0x26, 0x9f, 0xe2, 0xaf, 0xc9, 0xf9, 0xbf, 0x0b, 0xdd,
-	0x25, 0x6e, 0xbf, 0xfd, 0x4f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x4c, 0x78, 0x43, 0xdf, 0xef, 0x0c,
-	0x00, 0x00,
+	// 1323 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x57, 0xcd, 0x6f, 0x1b, 0x45,
+	0x14, 0xcf, 0xda, 0x1b, 0x7f, 0x3c, 0xdb, 0x89, 0x33, 0x4a, 0xdb, 0xad, 0xdb, 0x38, 0x96, 0x11,
+	0x90, 0x16, 0xb4, 0x29, 0x29, 0x02, 0x2e, 0x1c, 0x6c, 0x27, 0x6d, 0xad, 0x26, 0x8e, 0x59, 0xbb,
+	0xe5, 0xe3, 0xb2, 0x5a, 0x7b, 0xa7, 0xf6, 0xd2, 0xf5, 0xee, 0x6a, 0x67, 0x1c, 0x92, 0xfe, 0x05,
+	0x28, 0xa7, 0x9e, 0xb8, 0xe5, 0x04, 0x07, 0xee, 0xfc, 0x03, 0x88, 0x53, 0x8f, 0xbd, 0xc1, 0x85,
+	0x82, 0x52, 0x09, 0xf1, 0x67, 0xa0, 0xf9, 0xf0, 0x7a, 0x1d, 0x27, 0x7c, 0x54, 0x15, 0x17, 0x6b,
+	0xe6, 0xbd, 0xdf, 0x7b, 0xf3, 0xe6, 0xf7, 0x7e, 0x33, 0xb3, 0x86, 0xeb, 0x14, 0x7b, 0x36, 0x0e,
+	0x47, 0x8e, 0x47, 0x37, 0xe9, 0x51, 0x80, 0x89, 0xf8, 0xd5, 0x83, 0xd0, 0xa7, 0x3e, 0x2a, 0x4e,
+	0xbd, 0x3a, 0xb7, 0x97, 0x56, 0x07, 0xfe, 0xc0, 0xe7, 0xce, 0x4d, 0x36, 0x12, 0xb8, 0xd2, 0xfa,
+	0xc0, 0xf7, 0x07, 0x2e, 0xde, 0xe4, 0xb3, 0xde, 0xf8, 0xd1, 0x26, 0x75, 0x46, 0x98, 0x50, 0x6b,
+	0x14, 0x48, 0xc0, 0x5a, 0x6c, 0x99, 0x7e, 0x78, 0x14, 0x50, 0x9f, 0x61, 0xfd, 0x47, 0xd2, 0x5d,
+	0x8e, 0xb9, 0x0f, 0x70, 0x48, 0x1c, 0xdf, 0x8b, 0xd7, 0x51, 0xaa, 0xcc, 0x55, 0x79, 0x60, 0xb9,
+	0x8e, 0x6d, 0x51, 0x3f, 0x14, 0x88, 0xea, 0x67, 0x50, 0x68, 0x5b, 0x21, 0xed, 0x60, 0x7a, 0x0f,
+	0x5b, 0x36, 0x0e, 0xd1, 0x2a, 0x2c, 0x52, 0x9f, 0x5a, 0xae, 0xa6, 0x54, 0x94, 0x8d, 0x82, 0x21,
+	0x26, 0x08, 0x81, 0x3a, 0xb4, 0xc8, 0x50, 0x4b, 0x54, 0x94, 0x8d, 0xbc, 0xc1, 0xc7, 0x68, 0x0d,
+	0xc0, 0xb6, 0xa8, 0x65, 0x06, 0x56, 0x48, 0x89, 0x96, 0xe4, 0xf0, 0x2c, 0xb3, 0xb0, 0x84, 0xa4,
+	0x3a, 0x04, 0x95, 0x0d, 0x58, 0x42, 0xc7, 0xb3, 0xf1, 0xe1, 0x24, 0x21, 0x9f, 0x30, 0x6b, 0xef,
+	0x88, 0x62, 0x22, 0x33, 0x8a, 0x09, 0x7a, 0x1f, 0x16, 0xf9, 0xf6, 0x78, 0xb6, 0xdc, 0x96, 0xa6,
+	0xc7, 0x78, 0x14, 0xdb, 0xd7, 0xdb, 0xcc, 0x5f, 0x57, 0x9f, 0xbd, 0x58, 0x5f, 0x30, 0x04, 0xb8,
+	0xea, 0x42, 0xba, 0xee, 0xfa, 0xfd, 0xc7, 0xcd, 0xed, 0xa8, 0x4e, 0x25, 0x56, 0xe7, 0x1e, 0x2c,
+	0xb3, 0x12, 0x4d, 0x82, 0xa9, 0x39, 0xe4, 0x9b, 0xe4, 0x8b, 0xe6, 0xb6, 0xd6, 0xf5, 0xb3, 0x6d,
+	0xd2, 0x67, 0xb8, 0x90, 0xab, 0x14, 0x82, 0xb8, 0xb1, 0xfa, 0x87, 0x0a, 0x29, 0xc9, 0xd5, 0xc7,
+	0x90, 0x96, 0xac, 0xf3, 0x05, 0x73, 0x5b, 0x6b, 0xf1, 0x8c, 0xd2, 0xa5, 0x37, 0x7c, 0x8f, 0x60,
+	0x8f, 0x8c, 0x89, 0xcc, 0x37, 0x89, 0x41, 0x6f, 0x41, 0xa6, 0x3f, 0xb4, 0x1c, 0xcf, 0x74, 0x6c,
+	0x5e, 0x51, 0xb6, 0x9e, 0x3b, 0x7d, 0xb1, 0x9e, 0x6e, 0x30, 0x5b, 0x73, 0xdb, 0x48, 0x73, 0x67,
+	0xd3, 0x46, 0x97, 0x21, 0x35, 0xc4, 0xce, 0x60, 0x48, 0x39, 0x2d, 0x49, 0x43, 0xce, 0xd0, 0x47,
+	0xa0, 0x32, 0xbd, 0x68, 0x2a, 0x5f, 0xbb, 0xa4, 0x0b, 0x31, 0xe9, 0x13, 0x31, 0xe9, 0xdd, 0x89,
+	0x98, 0xea, 0x19, 0xb6, 0xf0, 0xd3, 0xdf, 0xd6, 0x15, 0x83, 0x47, 0xa0, 0x06, 0x14, 0x5c, 0x8b,
+	0x50, 0xb3, 0xc7, 0x68, 0x63, 0xcb, 0x2f, 0xf2, 0x14, 0x57, 0xe7, 0x09, 0x91, 0xc4, 0xca, 0xd2,
+	0x73, 0x2c, 0x4a, 0x98, 0x6c, 0xb4, 0x01, 0x45, 0x9e, 0xa4, 0xef, 0x8f, 0x46, 0x0e, 0x35, 0x39,
+	0xef, 0x29, 0xce, 0xfb, 0x12, 0xb3, 0x37, 0xb8, 0xf9, 0x1e, 0xeb, 0xc0, 0x35, 0xe0, 0xba, 0x10,
+	0x90, 0x34, 0x87, 0x64, 0x98, 0x81, 0x3b, 0xdf, 0x86, 0xe5, 0x48, 0x94, 0x44, 0x40, 0x32, 0x22,
+	0xcb, 0xd4, 0xcc, 0x81, 0xb7, 0x60, 0xd5, 0xc3, 0x87, 0xd4, 0x3c, 0x8b, 0xce, 0x72, 0x34, 0x62,
+	0xbe, 0x87, 0xb3, 0x11, 0x6f, 0xc2, 0x52, 0x7f, 0x42, 0xbe, 0xc0, 0x02, 0xc7, 0x16, 0x22, 0x2b,
+	0x87, 0x5d, 0x85, 0x8c, 0x15, 0x04, 0x02, 0x90, 0xe3, 0x80, 0xb4, 0x15, 0x04, 0xdc, 0x75, 0x13,
+	0x56, 0xf8, 0x1e, 0x43, 0x4c, 0xc6, 0x2e, 0x95, 0x49, 0xf2, 0x1c, 0xb3, 0xcc, 0x1c, 0x86, 0xb0,
+	0x73, 0xec, 0x1b, 0x50, 0xc0, 0x07, 0x8e, 0x8d, 0xbd, 0x3e, 0x16, 0xb8, 0x02, 0xc7, 0xe5, 0x27,
+	0x46, 0x0e, 0xba, 0x01, 0xc5, 0x20, 0xf4, 0x03, 0x9f, 0xe0, 0xd0, 0xb4, 0x6c, 0x3b, 0xc4, 0x84,
+	0x68, 0x4b, 0x22, 0xdf, 0xc4, 0x5e, 0x13, 0xe6, 0xaa, 0x06, 0xea, 0xb6, 0x45, 0x2d, 0x54, 0x84,
+	0x24, 0x3d, 0x24, 0x9a, 0x52, 0x49, 0x6e, 0xe4, 0x0d, 0x36, 0xac, 0xfe, 0x99, 0x00, 0xf5, 0xa1,
+	0x4f, 0x31, 0xba, 0x0d, 0x2a, 0x6b, 0x13, 0x57, 0xdf, 0xd2, 0x79, 0x7a, 0xee, 0x38, 0x03, 0x0f,
+	0xdb, 0x7b, 0x64, 0xd0, 0x3d, 0x0a, 0xb0, 0xc1, 0xc1, 0x31, 0x39, 0x25, 0x66, 0xe4, 0xb4, 0x0a,
+	0x8b, 0xa1, 0x3f, 0xf6, 0x6c, 0xae, 0xb2, 0x45, 0x43, 0x4c, 0xd0, 0x0e, 0x64, 0x22, 0x95, 0xa8,
+	0xff, 0xa4, 0x92, 0x65, 0xa6, 0x12, 0xa6, 0x61, 0x69, 0x30, 0xd2, 0x3d, 0x29, 0x96, 0x3a, 0x64,
+	0xa3, 0xbb, 0x4d, 0x5b, 0xfc, 0x0f, 0x82, 0x9d, 0x86, 0xa1, 0x77, 0x60, 0x25, 0xea, 0x7d, 0x44,
+	0x9e, 0x50, 0x5c, 0x31, 0x72, 0x48, 0xf6, 0x66, 0x64, 0x65, 0x8a, 0x0b, 0x28, 0xcd, 0xf7, 0x35,
+	0x95, 0x55, 0x93, 0x59, 0xd1, 0x75, 0xc8, 0x12, 0x67, 0xe0, 0x59, 0x74, 0x1c, 0x62, 0xa9, 0xbc,
+	0xa9, 0xa1, 0xfa, 0xa3, 0x02, 0x29, 0xa1, 0xe4, 0x18, 0x6f, 0xca, 0xf9, 0xbc, 0x25, 0x2e, 0xe2,
+	0x2d, 0xf9, 0xea, 0xbc, 0xd5, 0x00, 0xa2, 0x62, 0x88, 0xa6, 0x56, 0x92, 0x1b, 0xb9, 0xad, 0x6b,
+	0xf3, 0x89, 0x44, 0x89, 0x1d, 0x67, 0x20, 0x0f, 0x6a, 0x2c, 0xa8, 0xfa, 0xab, 0x02, 0xd9, 0xc8,
+	0x8f, 0x6a, 0x50, 0x98, 0xd4, 0x65, 0x3e, 0x72, 0xad, 0x81, 0xd4, 0xce, 0xda, 0x85, 0xc5, 0xdd,
+	0x71, 0xad, 0x81, 0x91, 0x93, 0xf5, 0xb0, 0xc9, 0xf9, 0x7d, 0x48, 0x5c, 0xd0, 0x87, 0x99, 0xc6,
+	0x27, 0x5f, 0xad, 0xf1, 0x33, 0x2d, 0x52, 0xcf, 0xb6, 0xe8, 0x87, 0x04, 0x64, 0xda, 0xfc, 0xec,
+	0x58, 0xee, 0xff, 0x71, 0x22, 0xae, 0x41, 0x36, 0xf0, 0x5d, 0x53, 0x78, 0x54, 0xee, 0xc9, 0x04,
+	0xbe, 0x6b, 0xcc, 0xb5, 0x7d, 0xf1, 0x35, 0x1d, 0x97, 0xd4, 0x6b, 0x60, 0x2d, 0x7d, 0x96, 0xb5,
+	0x10, 0xf2, 0x82, 0x0a, 0xf9, 0x96, 0xdd, 0x62, 0x1c, 0xb0, 0x91, 0xa6, 0xcc, 0xbf, 0xbd, 0xa2,
+	0x6c, 0x81, 0x34, 0x52, 0xc3, 0x28, 0x42, 0x5c, 0xfd, 0x5a, 0xe2, 0xa2, 0x08, 0x21, 0x3b, 0x43,
+	0xe2, 0xaa, 0xdf, 0x28, 0x00, 0xbb, 0x8c, 0x59, 0xbe, 0x5f, 0xf6, 0x0a, 0x11, 0x5e, 0x82, 0x39,
+	0xb3, 0x72, 0xf9, 0xa2, 0xa6, 0xc9, 0xf5, 0xf3, 0x24, 0x5e, 0x77, 0x03, 0x0a, 0x53, 0x31, 0x12,
+	0x3c, 0x29, 0xe6, 0x9c, 0x24, 0xd1, 0xe3, 0xd0, 0xc1, 0xd4, 0xc8, 0x1f, 0xc4, 0x66, 0xd5, 0x9f,
+	0x14, 0xc8, 0xf2, 0x9a, 0xf6, 0x30, 0xb5, 0x66, 0x7a, 0xa8, 0xbc, 0x7a, 0x0f, 0xd7, 0x00, 0x44,
+	0x1a, 0xe2, 0x3c, 0xc1, 0x52, 0x59, 0x59, 0x6e, 0xe9, 0x38, 0x4f, 0x30, 0xfa, 0x20, 0x22, 0x3c,
+	0xf9, 0xf7, 0x84, 0xcb, 0x23, 0x3d, 0xa1, 0xfd, 0x0a, 0xa4, 0xbd, 0xf1, 0xc8, 0x64, 0x4f, 0x82,
+	0x2a, 0xd4, 0xea, 0x8d, 0x47, 0xdd, 0x43, 0x52, 0xfd, 0x12, 0xd2, 0xdd, 0x43, 0xfe, 0x79, 0xc4,
+	0x24, 0x1a, 0xfa, 0xbe, 0x7c, 0x93, 0xc5, 0xb7, 0x50, 0x86, 0x19, 0xf8, 0x13, 0x84, 0x40, 0x65,
+	0x8f, 0xef, 0xe4, 0x5b, 0x8e, 0x8d, 0x91, 0xfe, 0x2f, 0x3f, 0xbc, 0xe4, 0x27, 0xd7, 0xcd, 0x9f,
+	0x15, 0xc8, 0xc5, 0xee, 0x07, 0xf4, 0x1e, 0x5c, 0xaa, 0xef, 0xee, 0x37, 0xee, 0x9b, 0xcd, 0x6d,
+	0xf3, 0xce, 0x6e, 0xed, 0xae, 0xf9, 0xa0, 0x75, 0xbf, 0xb5, 0xff, 0x69, 0xab, 0xb8, 0x50, 0xba,
+	0x7c, 0x7c, 0x52, 0x41, 0x31, 0xec, 0x03, 0xef, 0xb1, 0xe7, 0x7f, 0xe5, 0xa1, 0x4d, 0x58, 0x9d,
+	0x0d, 0xa9, 0xd5, 0x3b, 0x3b, 0xad, 0x6e, 0x51, 0x29, 0x5d, 0x3a, 0x3e, 0xa9, 0xac, 0xc4, 0x22,
+	0x6a, 0x3d, 0x82, 0x3d, 0x3a, 0x1f, 0xd0, 0xd8, 0xdf, 0xdb, 0x6b, 0x76, 0x8b, 0x89, 0xb9, 0x00,
+	0x79, 0x61, 0xdf, 0x80, 0x95, 0xd9, 0x80, 0x56, 0x73, 0xb7, 0x98, 0x2c, 0xa1, 0xe3, 0x93, 0xca,
+	0x52, 0x0c, 0xdd, 0x72, 0xdc, 0x52, 0xe6, 0xeb, 0x6f, 0xcb, 0x0b, 0xdf, 0x7f, 0x57, 0x56, 0xd8,
+	0xce, 0x0a, 0x33, 0x77, 0x04, 0x7a, 0x17, 0xae, 0x74, 0x9a, 0x77, 0x5b, 0x3b, 0xdb, 0xe6, 0x5e,
+	0xe7, 0xae, 0xd9, 0xfd, 0xbc, 0xbd, 0x13, 0xdb, 0xdd, 0xf2, 0xf1, 0x49, 0x25, 0x27, 0xb7, 0x74,
+	0x11, 0xba, 0x6d, 0xec, 0x3c, 0xdc, 0xef, 0xee, 0x14, 0x15, 0x81, 0x6e, 0x87, 0xf8, 0xc0, 0xa7,
+	0x98, 0xa3, 0x6f, 0xc1, 0xd5, 0x73, 0xd0, 0xd1, 0xc6, 0x56, 0x8e, 0x4f, 0x2a, 0x85, 0x76, 0x88,
+	0xc5, 0xf9, 0xe1, 0x11, 0x3a, 0x68, 0xf3, 0x11, 0xfb, 0xed, 0xfd, 0x4e, 0x6d, 0xb7, 0x58, 0x29,
+	0x15, 0x8f, 0x4f, 0x2a, 0xf9, 0xc9, 0x65, 0xc8, 0xf0, 0xd3, 0x9d, 0xd5, 0x3f, 0x79, 0x76, 0x5a,
+	0x56, 0x9e, 0x9f, 0x96, 0x95, 0xdf, 0x4f, 0xcb, 0xca, 0xd3, 0x97, 0xe5, 0x85, 0xe7, 0x2f, 0xcb,
+	0x0b, 0xbf, 0xbc, 0x2c, 0x2f, 0x7c, 0xf1, 0xe1, 0xc0, 0xa1, 0xc3, 0x71, 0x4f, 0xef, 0xfb, 0xa3,
+	0xcd, 0xf8, 0x3f, 0x86, 0xe9, 0x50, 0xfc, 0x73, 0x39, 0xfb, 0x6f, 0xa2, 0x97, 0xe2, 0xf6, 0xdb,
+	0x7f, 0x0d, 0x00, 0x1c, 0xc8, 0x01, 0xa4, 0x0e, 0x0d, 0x00, 0x00,
 }
 
 func (m *PartSetHeader) Marshal() (dAtA []byte, err error) {
@@ -1155,6 +1164,11 @@ func (m *PartSetHeader) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.DataParts != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.DataParts))
+		i--
+		dAtA[i] = 0x18
+	}
 	if len(m.Hash) > 0 {
 		i -= len(m.Hash)
 		copy(dAtA[i:], m.Hash)
@@ -1883,6 +1897,9 @@ func (m *PartSetHeader) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.DataParts != 0 {
+		n += 1 + sovTypes(uint64(m.DataParts))
+	}
 	return n
 }
 
@@ -2261,6 +2278,25 @@ func (m *PartSetHeader) Unmarshal(dAtA []byte) error {
 				m.Hash = []byte{}
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DataParts", wireType)
+			}
+			m.DataParts = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DataParts |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
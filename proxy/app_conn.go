@@ -30,6 +30,8 @@ type AppConnMempool interface {
 
 	CheckTxAsync(context.Context, types.RequestCheckTx) (*abcicli.ReqRes, error)
 	CheckTxSync(context.Context, types.RequestCheckTx) (*types.ResponseCheckTx, error)
+	CheckTxBatchAsync(context.Context, types.RequestCheckTxBatch) (*abcicli.ReqRes, error)
+	CheckTxBatchSync(context.Context, types.RequestCheckTxBatch) (*types.ResponseCheckTxBatch, error)
 
 	FlushAsync(context.Context) (*abcicli.ReqRes, error)
 	FlushSync(context.Context) error
@@ -139,6 +141,20 @@ func (app *appConnMempool) CheckTxSync(ctx context.Context, req types.RequestChe
 	return app.appConn.CheckTxSync(ctx, req)
 }
 
+func (app *appConnMempool) CheckTxBatchAsync(
+	ctx context.Context,
+	req types.RequestCheckTxBatch,
+) (*abcicli.ReqRes, error) {
+	return app.appConn.CheckTxBatchAsync(ctx, req)
+}
+
+func (app *appConnMempool) CheckTxBatchSync(
+	ctx context.Context,
+	req types.RequestCheckTxBatch,
+) (*types.ResponseCheckTxBatch, error) {
+	return app.appConn.CheckTxBatchSync(ctx, req)
+}
+
 //------------------------------------------------
 // Implements AppConnQuery (subset of abcicli.Client)
 
@@ -40,6 +40,52 @@ func (_m *AppConnMempool) CheckTxAsync(_a0 context.Context, _a1 types.RequestChe
 	return r0, r1
 }
 
+// CheckTxBatchAsync provides a mock function with given fields: _a0, _a1
+func (_m *AppConnMempool) CheckTxBatchAsync(_a0 context.Context, _a1 types.RequestCheckTxBatch) (*abcicli.ReqRes, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *abcicli.ReqRes
+	if rf, ok := ret.Get(0).(func(context.Context, types.RequestCheckTxBatch) *abcicli.ReqRes); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*abcicli.ReqRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.RequestCheckTxBatch) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CheckTxBatchSync provides a mock function with given fields: _a0, _a1
+func (_m *AppConnMempool) CheckTxBatchSync(_a0 context.Context, _a1 types.RequestCheckTxBatch) (*types.ResponseCheckTxBatch, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *types.ResponseCheckTxBatch
+	if rf, ok := ret.Get(0).(func(context.Context, types.RequestCheckTxBatch) *types.ResponseCheckTxBatch); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.ResponseCheckTxBatch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.RequestCheckTxBatch) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CheckTxSync provides a mock function with given fields: _a0, _a1
 func (_m *AppConnMempool) CheckTxSync(_a0 context.Context, _a1 types.RequestCheckTx) (*types.ResponseCheckTx, error) {
 	ret := _m.Called(_a0, _a1)
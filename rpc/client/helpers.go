@@ -15,16 +15,47 @@ type Waiter func(delta int64) (abort error)
 // DefaultWaitStrategy is the standard backoff algorithm,
 // but you can plug in another one
 func DefaultWaitStrategy(delta int64) (abort error) {
+	delay, err := waitDelay(delta)
+	if err != nil {
+		return err
+	}
+	time.Sleep(delay)
+	return nil
+}
+
+// ContextWaitStrategy is the standard backoff algorithm, aborting as soon as
+// the given context is done.
+func ContextWaitStrategy(ctx context.Context) Waiter {
+	return func(delta int64) (abort error) {
+		delay, err := waitDelay(delta)
+		if err != nil {
+			return err
+		}
+
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// waitDelay returns how long to wait for delta blocks, or an error if there
+// are too many to wait for.
+func waitDelay(delta int64) (time.Duration, error) {
 	if delta > 10 {
-		return fmt.Errorf("waiting for %d blocks... aborting", delta)
+		return 0, fmt.Errorf("waiting for %d blocks... aborting", delta)
 	} else if delta > 0 {
 		// estimate of wait time....
 		// wait half a second for the next block (in progress)
 		// plus one second for every full block
-		delay := time.Duration(delta-1)*time.Second + 500*time.Millisecond
-		time.Sleep(delay)
+		return time.Duration(delta-1)*time.Second + 500*time.Millisecond, nil
 	}
-	return nil
+	return 0, nil
 }
 
 // Wait for height will poll status at reasonable intervals until
@@ -36,9 +67,19 @@ func WaitForHeight(c StatusClient, h int64, waiter Waiter) error {
 	if waiter == nil {
 		waiter = DefaultWaitStrategy
 	}
+	return WaitForHeightContext(context.Background(), c, h, waiter)
+}
+
+// WaitForHeightContext is like WaitForHeight, but queries the status with the
+// given context. If waiter is nil, we use ContextWaitStrategy, which stops
+// waiting once the context is done.
+func WaitForHeightContext(ctx context.Context, c StatusClient, h int64, waiter Waiter) error {
+	if waiter == nil {
+		waiter = ContextWaitStrategy(ctx)
+	}
 	delta := int64(1)
 	for delta > 0 {
-		s, err := c.Status(context.Background())
+		s, err := c.Status(ctx)
 		if err != nil {
 			return err
 		}
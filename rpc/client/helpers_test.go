@@ -1,9 +1,11 @@
 package client_test
 
 import (
+	"context"
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -75,3 +77,27 @@ func TestWaitForHeight(t *testing.T) {
 	require.True(ok)
 	assert.Equal(int64(15), postr.SyncInfo.LatestBlockHeight)
 }
+
+func TestWaitForHeightContext(t *testing.T) {
+	m := &mock.StatusMock{
+		Call: mock.Call{
+			Response: &ctypes.ResultStatus{SyncInfo: ctypes.SyncInfo{LatestBlockHeight: 10}},
+		},
+	}
+	r := mock.NewStatusRecorder(m)
+
+	// waiting for the past returns immediately
+	err := client.WaitForHeightContext(context.Background(), r, 5, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(r.Calls))
+
+	// the wait stops once the context is done
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err = client.WaitForHeightContext(ctx, r, 15, nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), time.Second)
+	require.Equal(t, 2, len(r.Calls))
+}
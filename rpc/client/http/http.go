@@ -312,6 +312,51 @@ func (c *baseRPCClient) NumUnconfirmedTxs(ctx context.Context) (*ctypes.ResultUn
 	return result, nil
 }
 
+func (c *baseRPCClient) MempoolTx(ctx context.Context, hash []byte) (*ctypes.ResultMempoolTx, error) {
+	result := new(ctypes.ResultMempoolTx)
+	_, err := c.caller.Call(ctx, "mempool_tx", map[string]interface{}{"hash": hash}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) MempoolTxs(
+	ctx context.Context,
+	sender string,
+	minPriority, maxPriority *int64,
+	page, perPage *int,
+	sortBy, orderBy string,
+) (*ctypes.ResultMempoolTxs, error) {
+
+	result := new(ctypes.ResultMempoolTxs)
+	params := map[string]interface{}{
+		"sender":   sender,
+		"sort_by":  sortBy,
+		"order_by": orderBy,
+	}
+
+	if minPriority != nil {
+		params["min_priority"] = minPriority
+	}
+	if maxPriority != nil {
+		params["max_priority"] = maxPriority
+	}
+	if page != nil {
+		params["page"] = page
+	}
+	if perPage != nil {
+		params["per_page"] = perPage
+	}
+
+	_, err := c.caller.Call(ctx, "mempool_txs", params, result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
 func (c *baseRPCClient) CheckTx(ctx context.Context, tx types.Tx) (*ctypes.ResultCheckTx, error) {
 	result := new(ctypes.ResultCheckTx)
 	_, err := c.caller.Call(ctx, "check_tx", map[string]interface{}{"tx": tx}, result)
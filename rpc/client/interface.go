@@ -136,6 +136,14 @@ type EventsClient interface {
 type MempoolClient interface {
 	UnconfirmedTxs(ctx context.Context, limit *int) (*ctypes.ResultUnconfirmedTxs, error)
 	NumUnconfirmedTxs(context.Context) (*ctypes.ResultUnconfirmedTxs, error)
+	MempoolTx(ctx context.Context, hash []byte) (*ctypes.ResultMempoolTx, error)
+	MempoolTxs(
+		ctx context.Context,
+		sender string,
+		minPriority, maxPriority *int64,
+		page, perPage *int,
+		sortBy, orderBy string,
+	) (*ctypes.ResultMempoolTxs, error)
 	CheckTx(context.Context, types.Tx) (*ctypes.ResultCheckTx, error)
 }
 
@@ -111,6 +111,20 @@ func (c *Local) NumUnconfirmedTxs(ctx context.Context) (*ctypes.ResultUnconfirme
 	return c.env.NumUnconfirmedTxs(c.ctx)
 }
 
+func (c *Local) MempoolTx(ctx context.Context, hash []byte) (*ctypes.ResultMempoolTx, error) {
+	return c.env.MempoolTx(c.ctx, hash)
+}
+
+func (c *Local) MempoolTxs(
+	_ context.Context,
+	sender string,
+	minPriority, maxPriority *int64,
+	page, perPage *int,
+	sortBy, orderBy string,
+) (*ctypes.ResultMempoolTxs, error) {
+	return c.env.MempoolTxs(c.ctx, sender, minPriority, maxPriority, page, perPage, sortBy, orderBy)
+}
+
 func (c *Local) CheckTx(ctx context.Context, tx types.Tx) (*ctypes.ResultCheckTx, error) {
 	return c.env.CheckTx(c.ctx, tx)
 }
@@ -496,6 +496,52 @@ func (_m *Client) IsRunning() bool {
 	return r0
 }
 
+// MempoolTx provides a mock function with given fields: ctx, hash
+func (_m *Client) MempoolTx(ctx context.Context, hash []byte) (*coretypes.ResultMempoolTx, error) {
+	ret := _m.Called(ctx, hash)
+
+	var r0 *coretypes.ResultMempoolTx
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) *coretypes.ResultMempoolTx); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultMempoolTx)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []byte) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MempoolTxs provides a mock function with given fields: ctx, sender, minPriority, maxPriority, page, perPage, sortBy, orderBy
+func (_m *Client) MempoolTxs(ctx context.Context, sender string, minPriority *int64, maxPriority *int64, page *int, perPage *int, sortBy string, orderBy string) (*coretypes.ResultMempoolTxs, error) {
+	ret := _m.Called(ctx, sender, minPriority, maxPriority, page, perPage, sortBy, orderBy)
+
+	var r0 *coretypes.ResultMempoolTxs
+	if rf, ok := ret.Get(0).(func(context.Context, string, *int64, *int64, *int, *int, string, string) *coretypes.ResultMempoolTxs); ok {
+		r0 = rf(ctx, sender, minPriority, maxPriority, page, perPage, sortBy, orderBy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultMempoolTxs)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, *int64, *int64, *int, *int, string, string) error); ok {
+		r1 = rf(ctx, sender, minPriority, maxPriority, page, perPage, sortBy, orderBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NetInfo provides a mock function with given fields: _a0
 func (_m *Client) NetInfo(_a0 context.Context) (*coretypes.ResultNetInfo, error) {
 	ret := _m.Called(_a0)
@@ -539,6 +539,94 @@ func TestNumUnconfirmedTxs(t *testing.T) {
 	mempool.Flush()
 }
 
+func TestMempoolTx(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, _, tx := MakeTxKV()
+
+	n, conf := NodeSuite(t)
+	ch := make(chan *abci.Response, 1)
+	mempool := getMempool(t, n)
+
+	err := mempool.CheckTx(ctx, tx, func(resp *abci.Response) { ch <- resp }, mempl.TxInfo{})
+	require.NoError(t, err)
+
+	// wait for tx to arrive in mempoool.
+	select {
+	case <-ch:
+	case <-time.After(5 * time.Second):
+		t.Error("Timed out waiting for CheckTx callback")
+	}
+
+	for i, c := range GetClients(t, n, conf) {
+		mc, ok := c.(client.MempoolClient)
+		require.True(t, ok, "%d", i)
+		res, err := mc.MempoolTx(ctx, types.Tx(tx).Hash())
+		require.NoError(t, err, "%d", i)
+
+		assert.EqualValues(t, types.Tx(tx).Hash(), res.Hash)
+		assert.EqualValues(t, tx, res.Tx)
+		assert.Equal(t, string(mempl.TxStatusPending), res.Status)
+		assert.False(t, res.Timestamp.IsZero())
+
+		_, err = mc.MempoolTx(ctx, types.Tx("unknown").Hash())
+		assert.Error(t, err, "%d", i)
+	}
+
+	mempool.Flush()
+}
+
+func TestMempoolTxs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	n, conf := NodeSuite(t)
+	mempool := getMempool(t, n)
+
+	txs := make(types.Txs, 3)
+	for i := range txs {
+		_, _, tx := MakeTxKV()
+		txs[i] = tx
+
+		ch := make(chan *abci.Response, 1)
+		err := mempool.CheckTx(ctx, tx, func(resp *abci.Response) { ch <- resp }, mempl.TxInfo{})
+		require.NoError(t, err)
+
+		// wait for tx to arrive in mempoool.
+		select {
+		case <-ch:
+		case <-time.After(5 * time.Second):
+			t.Error("Timed out waiting for CheckTx callback")
+		}
+	}
+
+	page, perPage := 1, 2
+	minPriority := int64(1)
+	for i, c := range GetClients(t, n, conf) {
+		mc, ok := c.(client.MempoolClient)
+		require.True(t, ok, "%d", i)
+
+		res, err := mc.MempoolTxs(ctx, "", nil, nil, &page, &perPage, "timestamp", "asc")
+		require.NoError(t, err, "%d", i)
+		require.Equal(t, 3, res.TotalCount, "%d", i)
+		require.Len(t, res.Txs, 2, "%d", i)
+		assert.EqualValues(t, txs[0], res.Txs[0].Tx)
+		assert.EqualValues(t, txs[0].Hash(), res.Txs[0].Hash)
+		assert.EqualValues(t, txs[1], res.Txs[1].Tx)
+
+		// the kvstore application does not prioritize transactions
+		res, err = mc.MempoolTxs(ctx, "", &minPriority, nil, nil, nil, "", "")
+		require.NoError(t, err, "%d", i)
+		assert.Zero(t, res.TotalCount, "%d", i)
+
+		_, err = mc.MempoolTxs(ctx, "", nil, nil, nil, nil, "unknown", "")
+		assert.Error(t, err, "%d", i)
+	}
+
+	mempool.Flush()
+}
+
 func TestCheckTx(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
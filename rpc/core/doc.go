@@ -37,6 +37,8 @@ Endpoints that require arguments:
 /commit?height=_
 /dial_seeds?seeds=_
 /dial_persistent_peers?persistent_peers=_
+/mempool_tx?hash=_
+/mempool_txs?sender=_&min_priority=_&max_priority=_&page=_&per_page=_&sort_by=_&order_by=_
 /subscribe?event=_
 /tx?hash=_&prove=_
 /unsubscribe?event=_
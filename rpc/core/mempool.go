@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	mempl "github.com/tendermint/tendermint/internal/mempool"
+	tmmath "github.com/tendermint/tendermint/libs/math"
 	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
@@ -168,6 +170,125 @@ func (env *Environment) NumUnconfirmedTxs(ctx *rpctypes.Context) (*ctypes.Result
 		TotalBytes: env.Mempool.SizeBytes()}, nil
 }
 
+// MempoolTx returns the status, priority, sender and age of the transaction
+// with the given hash, if it is in the mempool or recently left it, whether it
+// was committed, evicted, expired or failed to pass CheckTx again.
+// More: https://docs.tendermint.com/master/rpc/#/Info/mempool_tx
+func (env *Environment) MempoolTx(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultMempoolTx, error) {
+	if len(hash) != mempl.TxKeySize {
+		return nil, fmt.Errorf("invalid tx hash length %d, expected %d", len(hash), mempl.TxKeySize)
+	}
+
+	var key [mempl.TxKeySize]byte
+	copy(key[:], hash)
+
+	record, ok := env.Mempool.GetTxRecord(key)
+	if !ok {
+		return nil, fmt.Errorf("tx (%X) not found in mempool", hash)
+	}
+
+	return newResultMempoolTx(record), nil
+}
+
+// MempoolTxs returns the transactions in the mempool, optionally only those of
+// the given sender and with a priority within the given range, sorted by
+// priority, height, timestamp or gas wanted, in ascending or descending order.
+// More: https://docs.tendermint.com/master/rpc/#/Info/mempool_txs
+func (env *Environment) MempoolTxs(
+	ctx *rpctypes.Context,
+	sender string,
+	minPriorityPtr, maxPriorityPtr *int64,
+	pagePtr, perPagePtr *int,
+	sortBy, orderBy string,
+) (*ctypes.ResultMempoolTxs, error) {
+
+	var records []mempl.TxRecord
+	for _, record := range env.Mempool.GetTxRecords() {
+		if sender != "" && record.Sender != sender {
+			continue
+		}
+		if minPriorityPtr != nil && record.Priority < *minPriorityPtr {
+			continue
+		}
+		if maxPriorityPtr != nil && record.Priority > *maxPriorityPtr {
+			continue
+		}
+		records = append(records, record)
+	}
+
+	var key func(record mempl.TxRecord) int64
+	switch sortBy {
+	case "priority", "":
+		key = func(record mempl.TxRecord) int64 { return record.Priority }
+	case "height":
+		key = func(record mempl.TxRecord) int64 { return record.Height }
+	case "timestamp":
+		key = func(record mempl.TxRecord) int64 { return record.Timestamp.UnixNano() }
+	case "gas_wanted":
+		key = func(record mempl.TxRecord) int64 { return record.GasWanted }
+	default:
+		return nil, fmt.Errorf(
+			"expected sort_by to be either `priority`, `height`, `timestamp` or `gas_wanted` or empty: %w",
+			ctypes.ErrInvalidRequest)
+	}
+
+	var desc bool
+	switch orderBy {
+	case "desc", "":
+		desc = true
+	case "asc":
+		desc = false
+	default:
+		return nil, fmt.Errorf("expected order_by to be either `asc` or `desc` or empty: %w", ctypes.ErrInvalidRequest)
+	}
+
+	// sort records (must be done before pagination), the transactions which
+	// entered the mempool first coming first among those with the same key
+	sort.Slice(records, func(i, j int) bool {
+		ki, kj := key(records[i]), key(records[j])
+		if ki == kj {
+			return records[i].Timestamp.Before(records[j].Timestamp)
+		}
+		if desc {
+			return ki > kj
+		}
+		return ki < kj
+	})
+
+	// paginate records
+	totalCount := len(records)
+	perPage := env.validatePerPage(perPagePtr)
+
+	page, err := validatePage(pagePtr, perPage, totalCount)
+	if err != nil {
+		return nil, err
+	}
+
+	skipCount := validateSkipCount(page, perPage)
+	pageSize := tmmath.MinInt(perPage, totalCount-skipCount)
+
+	txs := make([]*ctypes.ResultMempoolTx, 0, pageSize)
+	for _, record := range records[skipCount : skipCount+pageSize] {
+		txs = append(txs, newResultMempoolTx(record))
+	}
+
+	return &ctypes.ResultMempoolTxs{Txs: txs, TotalCount: totalCount}, nil
+}
+
+func newResultMempoolTx(record mempl.TxRecord) *ctypes.ResultMempoolTx {
+	return &ctypes.ResultMempoolTx{
+		Hash:      record.Tx.Hash(),
+		Tx:        record.Tx,
+		Status:    string(record.Status),
+		Priority:  record.Priority,
+		Sender:    record.Sender,
+		GasWanted: record.GasWanted,
+		Height:    record.Height,
+		Timestamp: record.Timestamp,
+		Age:       time.Since(record.Timestamp),
+	}
+}
+
 // CheckTx checks the transaction without executing it. The transaction won't
 // be added to the mempool either.
 // More: https://docs.tendermint.com/master/rpc/#/Tx/check_tx
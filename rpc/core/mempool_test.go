@@ -0,0 +1,101 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	mempl "github.com/tendermint/tendermint/internal/mempool"
+	"github.com/tendermint/tendermint/internal/mempool/mock"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// recordsMempool is a mock mempool holding the given transaction records.
+type recordsMempool struct {
+	mock.Mempool
+	records []mempl.TxRecord
+}
+
+func (m recordsMempool) GetTxRecords() []mempl.TxRecord { return m.records }
+
+func TestMempoolTxs(t *testing.T) {
+	now := time.Now()
+	records := []mempl.TxRecord{
+		{Tx: types.Tx("a"), Sender: "alice", Priority: 10, GasWanted: 3, Height: 2, Timestamp: now},
+		{Tx: types.Tx("b"), Sender: "bob", Priority: 30, GasWanted: 1, Height: 1, Timestamp: now.Add(time.Second)},
+		{Tx: types.Tx("c"), Sender: "alice", Priority: 20, GasWanted: 2, Height: 3, Timestamp: now.Add(2 * time.Second)},
+		{Tx: types.Tx("d"), Sender: "carol", Priority: 20, GasWanted: 4, Height: 1, Timestamp: now.Add(3 * time.Second)},
+	}
+	env := &Environment{Mempool: recordsMempool{records: records}}
+
+	int64Ptr := func(i int64) *int64 { return &i }
+	intPtr := func(i int) *int { return &i }
+
+	cases := []struct {
+		name                     string
+		sender                   string
+		minPriority, maxPriority *int64
+		page, perPage            *int
+		sortBy, orderBy          string
+		expected                 types.Txs
+		totalCount               int
+		wantErr                  bool
+	}{
+		{name: "default", expected: types.Txs{types.Tx("b"), types.Tx("c"), types.Tx("d"), types.Tx("a")}, totalCount: 4},
+		{name: "sender", sender: "alice", expected: types.Txs{types.Tx("c"), types.Tx("a")}, totalCount: 2},
+		{
+			name:        "priority range",
+			minPriority: int64Ptr(15),
+			maxPriority: int64Ptr(25),
+			expected:    types.Txs{types.Tx("c"), types.Tx("d")},
+			totalCount:  2,
+		},
+		{
+			name:       "by height ascending",
+			sortBy:     "height",
+			orderBy:    "asc",
+			expected:   types.Txs{types.Tx("b"), types.Tx("d"), types.Tx("a"), types.Tx("c")},
+			totalCount: 4,
+		},
+		{
+			name:       "by gas wanted",
+			sortBy:     "gas_wanted",
+			expected:   types.Txs{types.Tx("d"), types.Tx("a"), types.Tx("c"), types.Tx("b")},
+			totalCount: 4,
+		},
+		{
+			name:       "by timestamp descending, second page",
+			sortBy:     "timestamp",
+			page:       intPtr(2),
+			perPage:    intPtr(3),
+			expected:   types.Txs{types.Tx("a")},
+			totalCount: 4,
+		},
+		{name: "invalid sort_by", sortBy: "sender", wantErr: true},
+		{name: "invalid order_by", orderBy: "random", wantErr: true},
+		{name: "invalid page", page: intPtr(3), perPage: intPtr(3), wantErr: true},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := env.MempoolTxs(&rpctypes.Context{}, tc.sender, tc.minPriority, tc.maxPriority,
+				tc.page, tc.perPage, tc.sortBy, tc.orderBy)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			txs := make(types.Txs, len(res.Txs))
+			for i, tx := range res.Txs {
+				require.EqualValues(t, tx.Tx.Hash(), tx.Hash)
+				txs[i] = tx.Tx
+			}
+			require.Equal(t, tc.expected, txs)
+			require.Equal(t, tc.totalCount, res.TotalCount)
+		})
+	}
+}
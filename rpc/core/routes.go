@@ -37,6 +37,9 @@ func (env *Environment) GetRoutes() RoutesMap {
 		"consensus_params":     rpc.NewRPCFunc(env.ConsensusParams, "height", true),
 		"unconfirmed_txs":      rpc.NewRPCFunc(env.UnconfirmedTxs, "limit", false),
 		"num_unconfirmed_txs":  rpc.NewRPCFunc(env.NumUnconfirmedTxs, "", false),
+		"mempool_tx":           rpc.NewRPCFunc(env.MempoolTx, "hash", false),
+		"mempool_txs": rpc.NewRPCFunc(env.MempoolTxs,
+			"sender,min_priority,max_priority,page,per_page,sort_by,order_by", false),
 
 		// tx broadcast API
 		"broadcast_tx_commit": rpc.NewRPCFunc(env.BroadcastTxCommit, "tx", false),
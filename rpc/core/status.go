@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"time"
 
+	"github.com/tendermint/tendermint/internal/statesync"
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
@@ -76,10 +77,41 @@ func (env *Environment) Status(ctx *rpctypes.Context) (*ctypes.ResultStatus, err
 		},
 		ValidatorInfo: validatorInfo,
 	}
+	if env.StateSyncReactor != nil {
+		result.SyncInfo.StateSync = stateSyncInfo(env.StateSyncReactor.Progress())
+	}
 
 	return result, nil
 }
 
+// stateSyncInfo returns the info about the state sync snapshot being restored,
+// or nil if there is none.
+func stateSyncInfo(progress *statesync.Progress) *ctypes.StateSyncInfo {
+	if progress == nil {
+		return nil
+	}
+	info := &ctypes.StateSyncInfo{
+		SnapshotHeight: progress.SnapshotHeight,
+		SnapshotFormat: progress.SnapshotFormat,
+		Chunks:         progress.Chunks,
+		ChunksFetched:  progress.ChunksFetched,
+		ChunksApplied:  progress.ChunksApplied,
+		ChunksResumed:  progress.ChunksResumed,
+		Elapsed:        progress.Elapsed,
+		RemainingTime:  progress.RemainingTime,
+		Peers:          make([]ctypes.StateSyncPeerInfo, 0, len(progress.Peers)),
+	}
+	for _, peer := range progress.Peers {
+		info.Peers = append(info.Peers, ctypes.StateSyncPeerInfo{
+			NodeID:     peer.PeerID,
+			Chunks:     peer.Chunks,
+			Bytes:      peer.Bytes,
+			Throughput: peer.Throughput,
+		})
+	}
+	return info
+}
+
 func (env *Environment) validatorAtHeight(h int64) *types.Validator {
 	valsWithH, err := env.StateStore.LoadValidators(h)
 	if err != nil {
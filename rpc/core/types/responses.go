@@ -101,6 +101,31 @@ type SyncInfo struct {
 
 	TotalSyncedTime time.Duration `json:"total_synced_time"`
 	RemainingTime   time.Duration `json:"remaining_time"`
+
+	// set while the node restores a state sync snapshot
+	StateSync *StateSyncInfo `json:"state_sync,omitempty"`
+}
+
+// Info about the state sync snapshot being restored
+type StateSyncInfo struct {
+	SnapshotHeight uint64        `json:"snapshot_height"`
+	SnapshotFormat uint32        `json:"snapshot_format"`
+	Chunks         uint32        `json:"chunks"`
+	ChunksFetched  uint32        `json:"chunks_fetched"`
+	ChunksApplied  uint32        `json:"chunks_applied"`
+	ChunksResumed  uint32        `json:"chunks_resumed"`
+	Elapsed        time.Duration `json:"elapsed"`
+	RemainingTime  time.Duration `json:"remaining_time"`
+
+	Peers []StateSyncPeerInfo `json:"peers"`
+}
+
+// Info about the snapshot chunks received from a peer
+type StateSyncPeerInfo struct {
+	NodeID     types.NodeID `json:"node_id"`
+	Chunks     uint32       `json:"chunks"`
+	Bytes      uint64       `json:"bytes"`
+	Throughput float64      `json:"throughput"` // bytes per second
 }
 
 // Info about the node's validator
@@ -239,6 +264,34 @@ type ResultUnconfirmedTxs struct {
 	Txs        []types.Tx `json:"txs"`
 }
 
+// ResultMempoolTx describes a transaction in the mempool, or which recently left
+// the mempool.
+type ResultMempoolTx struct {
+	Hash   bytes.HexBytes `json:"hash"`
+	Tx     types.Tx       `json:"tx"`
+	Status string         `json:"status"`
+
+	// Priority, Sender and GasWanted are assigned by the application in
+	// CheckTx.
+	Priority  int64  `json:"priority"`
+	Sender    string `json:"sender"`
+	GasWanted int64  `json:"gas_wanted"`
+
+	// Height and Timestamp are the height and time at which the transaction
+	// entered the mempool, Age the time elapsed since then.
+	Height    int64         `json:"height"`
+	Timestamp time.Time     `json:"timestamp"`
+	Age       time.Duration `json:"age"`
+}
+
+// ResultMempoolTxs is a page of the transactions in the mempool matching the
+// filters of a mempool_txs request, TotalCount being the number of matching
+// transactions.
+type ResultMempoolTxs struct {
+	Txs        []*ResultMempoolTx `json:"txs"`
+	TotalCount int                `json:"total_count"`
+}
+
 // Info abci msg
 type ResultABCIInfo struct {
 	Response abci.ResponseInfo `json:"response"`
@@ -1,6 +1,7 @@
 package state
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -596,7 +597,14 @@ func ExecCommitBlock(
 			return nil, err
 		}
 
-		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(types.BlockPartSizeBytes).Header()}
+		// prefer the block ID from the block store, as the part set header
+		// depends on whether the block was erasure coded by its proposer
+		var blockID types.BlockID
+		if meta := be.blockStore.LoadBlockMeta(block.Height); meta != nil && bytes.Equal(meta.BlockID.Hash, block.Hash()) {
+			blockID = meta.BlockID
+		} else {
+			blockID = types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(types.BlockPartSizeBytes).Header()}
+		}
 		fireEvents(be.logger, be.eventBus, block, blockID, abciResponses, validatorUpdates)
 	}
 
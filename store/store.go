@@ -136,7 +136,13 @@ func (bs *BlockStore) LoadBlock(height int64) *types.Block {
 
 	pbb := new(tmproto.Block)
 	buf := []byte{}
-	for i := 0; i < int(blockMeta.BlockID.PartSetHeader.Total); i++ {
+	// only the data parts of an erasure coded block are needed
+	header := blockMeta.BlockID.PartSetHeader
+	total := header.Total
+	if header.IsErasureCoded() {
+		total = header.DataParts
+	}
+	for i := 0; i < int(total); i++ {
 		part := bs.LoadBlockPart(height, i)
 		// If the part is missing (e.g. since it has been deleted after we
 		// loaded the block meta) we consider the whole block to be missing.
@@ -145,6 +151,12 @@ func (bs *BlockStore) LoadBlock(height int64) *types.Block {
 		}
 		buf = append(buf, part.Bytes...)
 	}
+	if header.IsErasureCoded() {
+		var err error
+		if buf, err = types.DecodeErasureCodedData(buf); err != nil {
+			panic(fmt.Sprintf("Error reading block: %v", err))
+		}
+	}
 	err := proto.Unmarshal(buf, pbb)
 	if err != nil {
 		// NOTE: The existence of meta should imply the existence of the
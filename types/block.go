@@ -130,6 +130,40 @@ func (b *Block) MakePartSet(partSize uint32) *PartSet {
 	if b == nil {
 		return nil
 	}
+	return NewPartSetFromData(b.marshal(), partSize)
+}
+
+// MakeErasureCodedPartSet returns a PartSet containing parts of a serialized
+// block along with "parityParts" parity parts, so that the block can be
+// reconstructed from any subset of the parts as large as the number of data
+// parts. See NewErasureCodedPartSetFromData.
+// CONTRACT: partSize is greater than zero.
+func (b *Block) MakeErasureCodedPartSet(partSize, parityParts uint32) *PartSet {
+	if b == nil {
+		return nil
+	}
+	return NewErasureCodedPartSetFromData(b.marshal(), partSize, parityParts)
+}
+
+// MakePartSetWithParams returns a PartSet containing parts of a serialized
+// block, erasure coded as required by the block params. This is the form in
+// which a proposed block is gossipped to peers.
+// CONTRACT: partSize is greater than zero.
+func (b *Block) MakePartSetWithParams(partSize uint32, params BlockParams) *PartSet {
+	if b == nil {
+		return nil
+	}
+	return NewPartSetFromDataWithParams(b.marshal(), partSize, params)
+}
+
+// MakePartSetLike returns a PartSet containing parts of a serialized block,
+// using the same erasure coding as the given part set header.
+// CONTRACT: partSize is greater than zero.
+func (b *Block) MakePartSetLike(partSize uint32, header PartSetHeader) *PartSet {
+	return b.MakeErasureCodedPartSet(partSize, header.ParityParts())
+}
+
+func (b *Block) marshal() []byte {
 	b.mtx.Lock()
 	defer b.mtx.Unlock()
 
@@ -141,7 +175,7 @@ func (b *Block) MakePartSet(partSize uint32) *PartSet {
 	if err != nil {
 		panic(err)
 	}
-	return NewPartSetFromData(bz, partSize)
+	return bz
 }
 
 // HashesTo is a convenience function that checks if a block hashes to the given argument.
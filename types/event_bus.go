@@ -197,6 +197,69 @@ func (b *EventBus) PublishEventTx(data EventDataTx) error {
 	return b.pubsub.PublishWithEvents(ctx, data, events)
 }
 
+func (b *EventBus) PublishEventMempoolTxAdded(data EventDataMempoolTx) error {
+	return b.publishEventMempoolTx(EventMempoolTxAddedValue, data)
+}
+
+func (b *EventBus) PublishEventMempoolTxEvicted(data EventDataMempoolTx) error {
+	return b.publishEventMempoolTx(EventMempoolTxEvictedValue, data)
+}
+
+func (b *EventBus) PublishEventMempoolTxExpired(data EventDataMempoolTx) error {
+	return b.publishEventMempoolTx(EventMempoolTxExpiredValue, data)
+}
+
+func (b *EventBus) PublishEventMempoolTxRecheckFailed(data EventDataMempoolTx) error {
+	return b.publishEventMempoolTx(EventMempoolTxRecheckFailedValue, data)
+}
+
+// publishEventMempoolTx publishes a mempool tx event with the predefined keys
+// (EventTypeKey, TxHashKey and, if the transaction has a sender,
+// MempoolTxSenderKey).
+func (b *EventBus) publishEventMempoolTx(eventValue string, data EventDataMempoolTx) error {
+	// no explicit deadline for publishing events
+	ctx := context.Background()
+
+	tokens := strings.Split(EventTypeKey, ".")
+	events := []types.Event{
+		{
+			Type: tokens[0],
+			Attributes: []types.EventAttribute{
+				{
+					Key:   tokens[1],
+					Value: eventValue,
+				},
+			},
+		},
+	}
+
+	tokens = strings.Split(TxHashKey, ".")
+	events = append(events, types.Event{
+		Type: tokens[0],
+		Attributes: []types.EventAttribute{
+			{
+				Key:   tokens[1],
+				Value: fmt.Sprintf("%X", data.Tx.Hash()),
+			},
+		},
+	})
+
+	if len(data.Sender) > 0 {
+		tokens = strings.Split(MempoolTxSenderKey, ".")
+		events = append(events, types.Event{
+			Type: tokens[0],
+			Attributes: []types.EventAttribute{
+				{
+					Key:   tokens[1],
+					Value: data.Sender,
+				},
+			},
+		})
+	}
+
+	return b.pubsub.PublishWithEvents(ctx, data, events)
+}
+
 func (b *EventBus) PublishEventNewRoundStep(data EventDataRoundState) error {
 	return b.Publish(EventNewRoundStepValue, data)
 }
@@ -277,6 +340,22 @@ func (NopEventBus) PublishEventTx(data EventDataTx) error {
 	return nil
 }
 
+func (NopEventBus) PublishEventMempoolTxAdded(data EventDataMempoolTx) error {
+	return nil
+}
+
+func (NopEventBus) PublishEventMempoolTxEvicted(data EventDataMempoolTx) error {
+	return nil
+}
+
+func (NopEventBus) PublishEventMempoolTxExpired(data EventDataMempoolTx) error {
+	return nil
+}
+
+func (NopEventBus) PublishEventMempoolTxRecheckFailed(data EventDataMempoolTx) error {
+	return nil
+}
+
 func (NopEventBus) PublishEventNewRoundStep(data EventDataRoundState) error {
 	return nil
 }
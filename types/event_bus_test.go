@@ -315,6 +315,43 @@ func TestEventBusPublishEventNewEvidence(t *testing.T) {
 	}
 }
 
+func TestEventBusPublishEventMempoolTx(t *testing.T) {
+	eventBus := NewEventBus()
+	err := eventBus.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	tx := Tx("foo")
+	query := fmt.Sprintf("tm.event='MempoolTxEvicted' AND tx.hash='%X' AND mempool_tx.sender='alice'", tx.Hash())
+	txSub, err := eventBus.Subscribe(context.Background(), "test", tmquery.MustParse(query))
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		msg := <-txSub.Out()
+		edt := msg.Data().(EventDataMempoolTx)
+		assert.Equal(t, tx, edt.Tx)
+		assert.Equal(t, int64(10), edt.Priority)
+		assert.Equal(t, int64(2), edt.Height)
+		close(done)
+	}()
+
+	data := EventDataMempoolTx{Tx: tx, Priority: 10, Sender: "alice", Height: 2}
+	require.NoError(t, eventBus.PublishEventMempoolTxAdded(data))
+	require.NoError(t, eventBus.PublishEventMempoolTxEvicted(EventDataMempoolTx{Tx: Tx("bar"), Sender: "alice"}))
+	require.NoError(t, eventBus.PublishEventMempoolTxEvicted(data))
+
+	select {
+	case <-done:
+	case <-time.After(1 * time.Second):
+		t.Fatal("did not receive a mempool tx event after 1 sec.")
+	}
+}
+
 func TestEventBusPublish(t *testing.T) {
 	eventBus := NewEventBus()
 	err := eventBus.Start()
@@ -41,6 +41,14 @@ const (
 	EventUnlockValue          = "Unlock"
 	EventValidBlockValue      = "ValidBlock"
 	EventVoteValue            = "Vote"
+
+	// Mempool events.
+	// These are triggered when a transaction enters the mempool or leaves it
+	// without being committed.
+	EventMempoolTxAddedValue         = "MempoolTxAdded"
+	EventMempoolTxEvictedValue       = "MempoolTxEvicted"
+	EventMempoolTxExpiredValue       = "MempoolTxExpired"
+	EventMempoolTxRecheckFailedValue = "MempoolTxRecheckFailed"
 )
 
 // Pre-populated ABCI Tendermint-reserved events
@@ -106,6 +114,7 @@ func init() {
 	tmjson.RegisterType(EventDataString(""), "tendermint/event/ProposalString")
 	tmjson.RegisterType(EventDataBlockSyncStatus{}, "tendermint/event/FastSyncStatus")
 	tmjson.RegisterType(EventDataStateSyncStatus{}, "tendermint/event/StateSyncStatus")
+	tmjson.RegisterType(EventDataMempoolTx{}, "tendermint/event/MempoolTx")
 }
 
 // Most event messages are basic types (a block, a transaction)
@@ -190,6 +199,17 @@ type EventDataStateSyncStatus struct {
 	Height   int64 `json:"height"`
 }
 
+// EventDataMempoolTx describes a transaction entering or leaving the mempool,
+// along with the priority and sender assigned by the application in CheckTx.
+type EventDataMempoolTx struct {
+	Tx       Tx     `json:"tx"`
+	Priority int64  `json:"priority"`
+	Sender   string `json:"sender"`
+
+	// Height is the height at which the transaction entered the mempool.
+	Height int64 `json:"height"`
+}
+
 // PUBSUB
 
 const (
@@ -202,6 +222,11 @@ const (
 	// see EventBus#PublishEventTx
 	TxHeightKey = "tx.height"
 
+	// MempoolTxSenderKey is a reserved key, used to specify the sender of a
+	// transaction entering or leaving the mempool.
+	// see EventBus#PublishEventMempoolTxAdded
+	MempoolTxSenderKey = "mempool_tx.sender"
+
 	// BlockHeightKey is a reserved key used for indexing BeginBlock and Endblock
 	// events.
 	BlockHeightKey = "block.height"
@@ -229,6 +254,11 @@ var (
 	EventQueryVote                = QueryForEvent(EventVoteValue)
 	EventQueryBlockSyncStatus     = QueryForEvent(EventBlockSyncStatusValue)
 	EventQueryStateSyncStatus     = QueryForEvent(EventStateSyncStatusValue)
+
+	EventQueryMempoolTxAdded         = QueryForEvent(EventMempoolTxAddedValue)
+	EventQueryMempoolTxEvicted       = QueryForEvent(EventMempoolTxEvictedValue)
+	EventQueryMempoolTxExpired       = QueryForEvent(EventMempoolTxExpiredValue)
+	EventQueryMempoolTxRecheckFailed = QueryForEvent(EventMempoolTxRecheckFailedValue)
 )
 
 func EventQueryTxFor(tx Tx) tmpubsub.Query {
@@ -251,3 +281,12 @@ type BlockEventPublisher interface {
 type TxEventPublisher interface {
 	PublishEventTx(EventDataTx) error
 }
+
+// MempoolTxEventPublisher publishes the events of transactions entering or
+// leaving the mempool.
+type MempoolTxEventPublisher interface {
+	PublishEventMempoolTxAdded(EventDataMempoolTx) error
+	PublishEventMempoolTxEvicted(EventDataMempoolTx) error
+	PublishEventMempoolTxExpired(EventDataMempoolTx) error
+	PublishEventMempoolTxRecheckFailed(EventDataMempoolTx) error
+}
@@ -47,15 +47,18 @@ type ConsensusParams struct {
 // It is amino encoded and hashed into
 // the Header.ConsensusHash.
 type HashedParams struct {
-	BlockMaxBytes int64
-	BlockMaxGas   int64
+	BlockMaxBytes          int64
+	BlockMaxGas            int64
+	BlockPartParityPercent int64
 }
 
 // BlockParams define limits on the block size and gas plus minimum time
-// between blocks.
+// between blocks, and how the parts of proposed blocks are erasure coded.
 type BlockParams struct {
 	MaxBytes int64 `json:"max_bytes"`
 	MaxGas   int64 `json:"max_gas"`
+	// Number of parity parts, as a percentage of the number of data parts.
+	PartParityPercent int64 `json:"part_parity_percent"`
 }
 
 // EvidenceParams determine how we handle evidence of malfeasance.
@@ -116,6 +119,12 @@ func DefaultVersionParams() VersionParams {
 	}
 }
 
+// ParityParts returns the number of parity parts with which the parts of a
+// block split into "dataParts" data parts are erasure coded.
+func (params BlockParams) ParityParts(dataParts uint32) uint32 {
+	return uint32((int64(dataParts)*params.PartParityPercent + 99) / 100)
+}
+
 func (val *ValidatorParams) IsValidPubkeyType(pubkeyType string) bool {
 	for i := 0; i < len(val.PubKeyTypes); i++ {
 		if val.PubKeyTypes[i] == pubkeyType {
@@ -142,6 +151,11 @@ func (params ConsensusParams) ValidateConsensusParams() error {
 			params.Block.MaxGas)
 	}
 
+	if params.Block.PartParityPercent < 0 || params.Block.PartParityPercent > 100 {
+		return fmt.Errorf("block.PartParityPercent must be between 0 and 100. Got %d",
+			params.Block.PartParityPercent)
+	}
+
 	if params.Evidence.MaxAgeNumBlocks <= 0 {
 		return fmt.Errorf("evidence.MaxAgeNumBlocks must be greater than 0. Got %d",
 			params.Evidence.MaxAgeNumBlocks)
@@ -179,15 +193,17 @@ func (params ConsensusParams) ValidateConsensusParams() error {
 }
 
 // Hash returns a hash of a subset of the parameters to store in the block header.
-// Only the Block.MaxBytes and Block.MaxGas are included in the hash.
+// Only the Block.MaxBytes, Block.MaxGas and Block.PartParityPercent are
+// included in the hash.
 // This allows the ConsensusParams to evolve more without breaking the block
 // protocol. No need for a Merkle tree here, just a small struct to hash.
 func (params ConsensusParams) HashConsensusParams() []byte {
 	hasher := tmhash.New()
 
 	hp := tmproto.HashedParams{
-		BlockMaxBytes: params.Block.MaxBytes,
-		BlockMaxGas:   params.Block.MaxGas,
+		BlockMaxBytes:          params.Block.MaxBytes,
+		BlockMaxGas:            params.Block.MaxGas,
+		BlockPartParityPercent: params.Block.PartParityPercent,
 	}
 
 	bz, err := hp.Marshal()
@@ -221,6 +237,7 @@ func (params ConsensusParams) UpdateConsensusParams(params2 *tmproto.ConsensusPa
 	if params2.Block != nil {
 		res.Block.MaxBytes = params2.Block.MaxBytes
 		res.Block.MaxGas = params2.Block.MaxGas
+		res.Block.PartParityPercent = params2.Block.PartParityPercent
 	}
 	if params2.Evidence != nil {
 		res.Evidence.MaxAgeNumBlocks = params2.Evidence.MaxAgeNumBlocks
@@ -241,8 +258,9 @@ func (params ConsensusParams) UpdateConsensusParams(params2 *tmproto.ConsensusPa
 func (params *ConsensusParams) ToProto() tmproto.ConsensusParams {
 	return tmproto.ConsensusParams{
 		Block: &tmproto.BlockParams{
-			MaxBytes: params.Block.MaxBytes,
-			MaxGas:   params.Block.MaxGas,
+			MaxBytes:          params.Block.MaxBytes,
+			MaxGas:            params.Block.MaxGas,
+			PartParityPercent: params.Block.PartParityPercent,
 		},
 		Evidence: &tmproto.EvidenceParams{
 			MaxAgeNumBlocks: params.Evidence.MaxAgeNumBlocks,
@@ -261,8 +279,9 @@ func (params *ConsensusParams) ToProto() tmproto.ConsensusParams {
 func ConsensusParamsFromProto(pbParams tmproto.ConsensusParams) ConsensusParams {
 	return ConsensusParams{
 		Block: BlockParams{
-			MaxBytes: pbParams.Block.MaxBytes,
-			MaxGas:   pbParams.Block.MaxGas,
+			MaxBytes:          pbParams.Block.MaxBytes,
+			MaxGas:            pbParams.Block.MaxGas,
+			PartParityPercent: pbParams.Block.PartParityPercent,
 		},
 		Evidence: EvidenceParams{
 			MaxAgeNumBlocks: pbParams.Evidence.MaxAgeNumBlocks,
@@ -40,6 +40,10 @@ func TestConsensusParamsValidation(t *testing.T) {
 		12: {makeParams(1, 0, 2, 0, []string{}), false},
 		// test invalid pubkey type provided
 		13: {makeParams(1, 0, 2, 0, []string{"potatoes make good pubkeys"}), false},
+		// test block part parity
+		14: {makeBlockPartParityParams(50), true},
+		15: {makeBlockPartParityParams(-1), false},
+		16: {makeBlockPartParityParams(101), false},
 	}
 	for i, tc := range testCases {
 		if tc.valid {
@@ -72,6 +76,12 @@ func makeParams(
 	}
 }
 
+func makeBlockPartParityParams(partParityPercent int64) ConsensusParams {
+	params := makeParams(1, 0, 2, 0, valEd25519)
+	params.Block.PartParityPercent = partParityPercent
+	return params
+}
+
 func TestConsensusParamsHash(t *testing.T) {
 	params := []ConsensusParams{
 		makeParams(4, 2, 3, 1, valEd25519),
@@ -82,6 +92,7 @@ func TestConsensusParamsHash(t *testing.T) {
 		makeParams(9, 5, 4, 1, valEd25519),
 		makeParams(7, 8, 9, 1, valEd25519),
 		makeParams(4, 6, 5, 1, valEd25519),
+		makeBlockPartParityParams(50),
 	}
 
 	hashes := make([][]byte, len(params))
@@ -154,6 +165,18 @@ func TestConsensusParamsUpdate(t *testing.T) {
 	}
 }
 
+func TestBlockParamsParityParts(t *testing.T) {
+	params := DefaultBlockParams()
+	assert.EqualValues(t, 0, params.ParityParts(10))
+
+	params.PartParityPercent = 25
+	assert.EqualValues(t, 3, params.ParityParts(10))
+	assert.EqualValues(t, 1, params.ParityParts(1))
+
+	params.PartParityPercent = 100
+	assert.EqualValues(t, 10, params.ParityParts(10))
+}
+
 func TestConsensusParamsUpdate_AppVersion(t *testing.T) {
 	params := makeParams(1, 2, 3, 0, valEd25519)
 
@@ -175,6 +198,7 @@ func TestProto(t *testing.T) {
 		makeParams(9, 5, 4, 1, valEd25519),
 		makeParams(7, 8, 9, 1, valEd25519),
 		makeParams(4, 6, 5, 1, valEd25519),
+		makeBlockPartParityParams(50),
 	}
 
 	for i := range params {
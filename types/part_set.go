@@ -2,11 +2,13 @@ package types
 
 import (
 	"bytes"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
 
 	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/internal/libs/reedsolomon"
 	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
 	"github.com/tendermint/tendermint/libs/bits"
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
@@ -15,9 +17,15 @@ import (
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 )
 
+// MaxErasureCodedParts is the maximum total number of parts, data and parity,
+// of an erasure coded PartSet.
+const MaxErasureCodedParts = reedsolomon.MaxShards
+
 var (
 	ErrPartSetUnexpectedIndex = errors.New("error part set unexpected index")
 	ErrPartSetInvalidProof    = errors.New("error part set invalid proof")
+	ErrPartSetInvalidSize     = errors.New("error part set invalid part size")
+	ErrPartSetInvalidCoding   = errors.New("error part set invalid erasure coding")
 )
 
 type Part struct {
@@ -94,22 +102,58 @@ func PartFromProto(pb *tmproto.Part) (*Part, error) {
 type PartSetHeader struct {
 	Total uint32           `json:"total"`
 	Hash  tmbytes.HexBytes `json:"hash"`
+	// DataParts is the number of parts, out of Total, needed to reconstruct an
+	// erasure coded part set. It is 0 if the part set is not erasure coded.
+	DataParts uint32 `json:"data_parts,omitempty"`
 }
 
 // String returns a string representation of PartSetHeader.
 //
-// 1. total number of parts
+// 1. total number of parts, preceded by the number of data parts if erasure
+// coded
 // 2. first 6 bytes of the hash
 func (psh PartSetHeader) String() string {
+	if psh.DataParts > 0 {
+		return fmt.Sprintf("%v/%v:%X", psh.DataParts, psh.Total, tmbytes.Fingerprint(psh.Hash))
+	}
 	return fmt.Sprintf("%v:%X", psh.Total, tmbytes.Fingerprint(psh.Hash))
 }
 
 func (psh PartSetHeader) IsZero() bool {
-	return psh.Total == 0 && len(psh.Hash) == 0
+	return psh.Total == 0 && len(psh.Hash) == 0 && psh.DataParts == 0
 }
 
 func (psh PartSetHeader) Equals(other PartSetHeader) bool {
-	return psh.Total == other.Total && bytes.Equal(psh.Hash, other.Hash)
+	return psh.Total == other.Total && bytes.Equal(psh.Hash, other.Hash) && psh.DataParts == other.DataParts
+}
+
+// IsErasureCoded returns true if the part set is erasure coded.
+func (psh PartSetHeader) IsErasureCoded() bool {
+	return psh.DataParts > 0
+}
+
+// ParityParts returns the number of parity parts of an erasure coded part set.
+func (psh PartSetHeader) ParityParts() uint32 {
+	if psh.DataParts == 0 || psh.DataParts > psh.Total {
+		return 0
+	}
+	return psh.Total - psh.DataParts
+}
+
+// ValidateParams returns an error unless the header is the one of "size"
+// bytes of data split into "partSize" parts and erasure coded as required by
+// the block params, as done by NewPartSetFromDataWithParams.
+func (psh PartSetHeader) ValidateParams(params BlockParams, size int64, partSize uint32) error {
+	total := uint32((size + int64(partSize) - 1) / int64(partSize))
+	dataParts := uint32(0)
+	if n := erasureCodedDataParts(int(size), partSize); isErasureCodable(n, params.ParityParts(n)) {
+		total, dataParts = n+params.ParityParts(n), n
+	}
+	if psh.Total != total || psh.DataParts != dataParts {
+		return fmt.Errorf("expected %d parts, of which %d data parts, got %d parts, of which %d data parts",
+			total, dataParts, psh.Total, psh.DataParts)
+	}
+	return nil
 }
 
 // ValidateBasic performs basic validation.
@@ -118,6 +162,14 @@ func (psh PartSetHeader) ValidateBasic() error {
 	if err := ValidateHash(psh.Hash); err != nil {
 		return fmt.Errorf("wrong Hash: %w", err)
 	}
+	if psh.DataParts > 0 {
+		if psh.DataParts >= psh.Total {
+			return fmt.Errorf("data parts (%d) must be less than total (%d)", psh.DataParts, psh.Total)
+		}
+		if psh.Total > MaxErasureCodedParts {
+			return fmt.Errorf("too many erasure coded parts: %d, max: %d", psh.Total, MaxErasureCodedParts)
+		}
+	}
 	return nil
 }
 
@@ -128,8 +180,9 @@ func (psh *PartSetHeader) ToProto() tmproto.PartSetHeader {
 	}
 
 	return tmproto.PartSetHeader{
-		Total: psh.Total,
-		Hash:  psh.Hash,
+		Total:     psh.Total,
+		Hash:      psh.Hash,
+		DataParts: psh.DataParts,
 	}
 }
 
@@ -141,6 +194,7 @@ func PartSetHeaderFromProto(ppsh *tmproto.PartSetHeader) (*PartSetHeader, error)
 	psh := new(PartSetHeader)
 	psh.Total = ppsh.Total
 	psh.Hash = ppsh.Hash
+	psh.DataParts = ppsh.DataParts
 
 	return psh, psh.ValidateBasic()
 }
@@ -150,14 +204,25 @@ func PartSetHeaderFromProto(ppsh *tmproto.PartSetHeader) (*PartSetHeader, error)
 type PartSet struct {
 	total uint32
 	hash  []byte
+	// number of data parts if the part set is erasure coded, 0 otherwise
+	dataParts uint32
 
 	mtx           tmsync.Mutex
 	parts         []*Part
 	partsBitArray *bits.BitArray
 	count         uint32
 	// a count of the total size (in bytes). Used to ensure that the
-	// part set doesn't exceed the maximum block bytes
+	// part set doesn't exceed the maximum block bytes. For erasure coded part
+	// sets, it is the size of the data once the part set is complete and 0
+	// before.
 	byteSize int64
+	// size of the parts of an erasure coded part set, 0 until known
+	partSize int
+	// data of an erasure coded part set, set once it is complete
+	data []byte
+	// set if the parts of an erasure coded part set don't form a valid
+	// encoding, in which case the part set can never be completed
+	invalid bool
 }
 
 // Returns an immutable, full PartSet from the data bytes.
@@ -193,11 +258,107 @@ func NewPartSetFromData(data []byte, partSize uint32) *PartSet {
 	}
 }
 
+// NewErasureCodedPartSetFromData returns an immutable, full PartSet from the
+// data bytes, erasure coded so that any "dataParts" parts out of the
+// "dataParts"+"parityParts" parts of the set are enough to reconstruct it. The
+// data bytes, prefixed with their length, are split into "dataParts" chunks of
+// equal size, at most "partSize", from which "parityParts" parity chunks are
+// computed with a Reed-Solomon code. The merkle tree is computed over all of
+// them.
+//
+// A PartSet which isn't erasure coded is returned if "parityParts" is zero or
+// if the total number of parts would exceed MaxErasureCodedParts.
+// CONTRACT: partSize is greater than zero.
+func NewErasureCodedPartSetFromData(data []byte, partSize, parityParts uint32) *PartSet {
+	payloadSize := 4 + len(data)
+	dataParts := int(erasureCodedDataParts(len(data), partSize))
+	if !isErasureCodable(uint32(dataParts), parityParts) {
+		return NewPartSetFromData(data, partSize)
+	}
+
+	// split the payload into chunks of equal size, padding the last one
+	size := (payloadSize + dataParts - 1) / dataParts
+	payload := make([]byte, dataParts*size)
+	binary.BigEndian.PutUint32(payload, uint32(len(data)))
+	copy(payload[4:], data)
+
+	total := dataParts + int(parityParts)
+	shards := make([][]byte, total)
+	for i := range shards {
+		if i < dataParts {
+			shards[i] = payload[i*size : (i+1)*size]
+		} else {
+			shards[i] = make([]byte, size)
+		}
+	}
+	enc, err := reedsolomon.New(dataParts, int(parityParts))
+	if err != nil {
+		panic(err)
+	}
+	if err := enc.Encode(shards); err != nil {
+		panic(err)
+	}
+
+	root, proofs := merkle.ProofsFromByteSlices(shards)
+	parts := make([]*Part, total)
+	partsBitArray := bits.NewBitArray(total)
+	for i := range shards {
+		parts[i] = &Part{Index: uint32(i), Bytes: shards[i], Proof: *proofs[i]}
+		partsBitArray.SetIndex(i, true)
+	}
+	return &PartSet{
+		total:         uint32(total),
+		hash:          root,
+		dataParts:     uint32(dataParts),
+		parts:         parts,
+		partsBitArray: partsBitArray,
+		count:         uint32(total),
+		byteSize:      int64(len(data)),
+		partSize:      size,
+		data:          data,
+	}
+}
+
+// NewPartSetFromDataWithParams returns an immutable, full PartSet from the data
+// bytes, erasure coded as required by the block params. See
+// NewErasureCodedPartSetFromData.
+// CONTRACT: partSize is greater than zero.
+func NewPartSetFromDataWithParams(data []byte, partSize uint32, params BlockParams) *PartSet {
+	parityParts := params.ParityParts(erasureCodedDataParts(len(data), partSize))
+	return NewErasureCodedPartSetFromData(data, partSize, parityParts)
+}
+
+// erasureCodedDataParts returns the number of data parts of "size" bytes of
+// data once erasure coded.
+func erasureCodedDataParts(size int, partSize uint32) uint32 {
+	return uint32((4 + size + int(partSize) - 1) / int(partSize))
+}
+
+// isErasureCodable returns true if a part set with the given number of data
+// and parity parts can be erasure coded.
+func isErasureCodable(dataParts, parityParts uint32) bool {
+	return parityParts > 0 && int(dataParts)+int(parityParts) <= MaxErasureCodedParts
+}
+
+// DecodeErasureCodedData returns the data held by an erasure coded part set
+// given the concatenated bytes of its data parts.
+func DecodeErasureCodedData(bz []byte) ([]byte, error) {
+	if len(bz) < 4 {
+		return nil, ErrPartSetInvalidCoding
+	}
+	size := binary.BigEndian.Uint32(bz)
+	if uint64(size) > uint64(len(bz)-4) {
+		return nil, ErrPartSetInvalidCoding
+	}
+	return bz[4 : 4+size], nil
+}
+
 // Returns an empty PartSet ready to be populated.
 func NewPartSetFromHeader(header PartSetHeader) *PartSet {
 	return &PartSet{
 		total:         header.Total,
 		hash:          header.Hash,
+		dataParts:     header.DataParts,
 		parts:         make([]*Part, header.Total),
 		partsBitArray: bits.NewBitArray(int(header.Total)),
 		count:         0,
@@ -210,8 +371,9 @@ func (ps *PartSet) Header() PartSetHeader {
 		return PartSetHeader{}
 	}
 	return PartSetHeader{
-		Total: ps.total,
-		Hash:  ps.hash,
+		Total:     ps.total,
+		Hash:      ps.hash,
+		DataParts: ps.dataParts,
 	}
 }
 
@@ -280,6 +442,16 @@ func (ps *PartSet) AddPart(part *Part) (bool, error) {
 		return false, nil
 	}
 
+	// No more parts are needed once the part set is known to be invalid.
+	if ps.invalid {
+		return false, nil
+	}
+
+	// All the parts of an erasure coded part set have the same size
+	if ps.dataParts > 0 && ps.partSize > 0 && len(part.Bytes) != ps.partSize {
+		return false, ErrPartSetInvalidSize
+	}
+
 	// Check hash proof
 	if part.Proof.Verify(ps.Hash(), part.Bytes) != nil {
 		return false, ErrPartSetInvalidProof
@@ -289,10 +461,72 @@ func (ps *PartSet) AddPart(part *Part) (bool, error) {
 	ps.parts[part.Index] = part
 	ps.partsBitArray.SetIndex(int(part.Index), true)
 	ps.count++
-	ps.byteSize += int64(len(part.Bytes))
+	if ps.dataParts == 0 {
+		ps.byteSize += int64(len(part.Bytes))
+		return true, nil
+	}
+
+	ps.partSize = len(part.Bytes)
+	if ps.count == ps.dataParts {
+		// The part set is reconstructed, or found to be invalid, exactly once:
+		// as soon as enough parts have been received.
+		if err := ps.reconstruct(); err != nil {
+			ps.invalid = true
+			return true, err
+		}
+	}
 	return true, nil
 }
 
+// reconstruct recovers the missing parts of an erasure coded part set from the
+// parts received so far, and completes the part set. All the parts are then
+// encoded again from the recovered data, and must hash to the part set hash.
+// As any "dataParts" parts of a valid encoding recover the same data, this
+// check fails, regardless of which parts were received, if and only if the
+// part set was not properly encoded.
+// CONTRACT: ps.mtx is locked and exactly dataParts parts have been added.
+func (ps *PartSet) reconstruct() error {
+	shards := make([][]byte, ps.total)
+	for i, part := range ps.parts {
+		if part != nil {
+			shards[i] = part.Bytes
+		}
+	}
+	enc, err := reedsolomon.New(int(ps.dataParts), int(ps.total-ps.dataParts))
+	if err != nil {
+		return err
+	}
+	if err := enc.Reconstruct(shards); err != nil {
+		return fmt.Errorf("%w: %v", ErrPartSetInvalidCoding, err)
+	}
+	for i := ps.dataParts; i < ps.total; i++ {
+		shards[i] = make([]byte, ps.partSize)
+	}
+	if err := enc.Encode(shards); err != nil {
+		return fmt.Errorf("%w: %v", ErrPartSetInvalidCoding, err)
+	}
+
+	root, proofs := merkle.ProofsFromByteSlices(shards)
+	if !bytes.Equal(root, ps.hash) {
+		return ErrPartSetInvalidCoding
+	}
+	data, err := DecodeErasureCodedData(bytes.Join(shards[:ps.dataParts], nil))
+	if err != nil {
+		return err
+	}
+
+	for i := range shards {
+		if ps.parts[i] == nil {
+			ps.parts[i] = &Part{Index: uint32(i), Bytes: shards[i], Proof: *proofs[i]}
+			ps.partsBitArray.SetIndex(i, true)
+		}
+	}
+	ps.count = ps.total
+	ps.byteSize = int64(len(data))
+	ps.data = data
+	return nil
+}
+
 func (ps *PartSet) GetPart(index int) *Part {
 	ps.mtx.Lock()
 	defer ps.mtx.Unlock()
@@ -300,13 +534,35 @@ func (ps *PartSet) GetPart(index int) *Part {
 }
 
 func (ps *PartSet) IsComplete() bool {
-	return ps.count == ps.total
+	return ps.count == ps.total && !ps.invalid
+}
+
+// IsInvalid returns true if the part set is erasure coded and the parts
+// received were found not to form a valid encoding. An invalid part set can't
+// be completed.
+func (ps *PartSet) IsInvalid() bool {
+	if ps == nil {
+		return false
+	}
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+	return ps.invalid
+}
+
+// IsErasureCoded returns true if the part set is erasure coded.
+func (ps *PartSet) IsErasureCoded() bool {
+	return ps != nil && ps.dataParts > 0
 }
 
 func (ps *PartSet) GetReader() io.Reader {
 	if !ps.IsComplete() {
 		panic("Cannot GetReader() on incomplete PartSet")
 	}
+	if ps.dataParts > 0 {
+		ps.mtx.Lock()
+		defer ps.mtx.Unlock()
+		return bytes.NewReader(ps.data)
+	}
 	return NewPartSetReader(ps.parts)
 }
 
@@ -2,6 +2,7 @@ package types
 
 import (
 	"io/ioutil"
+	"math/rand"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -88,6 +89,139 @@ func TestWrongProof(t *testing.T) {
 	}
 }
 
+func TestErasureCodedPartSet(t *testing.T) {
+	const (
+		nData   = 20
+		nParity = 10
+	)
+	data := tmrand.Bytes(testPartSize*nData - 100)
+	partSet := NewErasureCodedPartSetFromData(data, testPartSize, nParity)
+
+	assert.True(t, partSet.IsErasureCoded())
+	assert.EqualValues(t, nData+nParity, partSet.Total())
+	assert.EqualValues(t, nData, partSet.Header().DataParts)
+	assert.EqualValues(t, nParity, partSet.Header().ParityParts())
+	assert.True(t, partSet.IsComplete())
+	assert.EqualValues(t, len(data), partSet.ByteSize())
+
+	// Any nData parts are enough to reconstruct the part set.
+	partSet2 := NewPartSetFromHeader(partSet.Header())
+	require.True(t, partSet2.IsErasureCoded())
+	for i, index := range rand.Perm(nData + nParity)[:nData] {
+		require.False(t, partSet2.IsComplete())
+		added, err := partSet2.AddPart(partSet.GetPart(index))
+		require.NoError(t, err, "part %d", i)
+		require.True(t, added)
+	}
+	assert.True(t, partSet2.IsComplete())
+	assert.False(t, partSet2.IsInvalid())
+	assert.True(t, partSet2.BitArray().IsFull())
+	assert.EqualValues(t, len(data), partSet2.ByteSize())
+	for i := 0; i < nData+nParity; i++ {
+		assert.Equal(t, partSet.GetPart(i), partSet2.GetPart(i))
+	}
+
+	data2, err := ioutil.ReadAll(partSet2.GetReader())
+	require.NoError(t, err)
+	assert.Equal(t, data, data2)
+
+	// Parts of another size are rejected.
+	partSet3 := NewPartSetFromHeader(partSet.Header())
+	_, err = partSet3.AddPart(partSet.GetPart(0))
+	require.NoError(t, err)
+	part := partSet.GetPart(1)
+	_, err = partSet3.AddPart(&Part{Index: part.Index, Bytes: part.Bytes[1:], Proof: part.Proof})
+	assert.ErrorIs(t, err, ErrPartSetInvalidSize)
+}
+
+func TestErasureCodedPartSetFallback(t *testing.T) {
+	data := tmrand.Bytes(testPartSize * 10)
+
+	partSet := NewErasureCodedPartSetFromData(data, testPartSize, 0)
+	assert.False(t, partSet.IsErasureCoded())
+	assert.Equal(t, NewPartSetFromData(data, testPartSize).Header(), partSet.Header())
+
+	partSet = NewErasureCodedPartSetFromData(data, testPartSize, MaxErasureCodedParts)
+	assert.False(t, partSet.IsErasureCoded())
+}
+
+func TestPartSetWithParams(t *testing.T) {
+	data := tmrand.Bytes(testPartSize * 10)
+	params := DefaultBlockParams()
+
+	// Erasure coding is disabled by default.
+	partSet := NewPartSetFromDataWithParams(data, testPartSize, params)
+	assert.False(t, partSet.IsErasureCoded())
+	assert.NoError(t, partSet.Header().ValidateParams(params, int64(len(data)), testPartSize))
+
+	// 11 data parts, as the data is prefixed with its size, and 6 parity parts.
+	params.PartParityPercent = 50
+	codedPartSet := NewPartSetFromDataWithParams(data, testPartSize, params)
+	assert.EqualValues(t, 11, codedPartSet.Header().DataParts)
+	assert.EqualValues(t, 6, codedPartSet.Header().ParityParts())
+	assert.NoError(t, codedPartSet.Header().ValidateParams(params, int64(len(data)), testPartSize))
+
+	// Part sets not erasure coded as the params require are rejected.
+	assert.Error(t, partSet.Header().ValidateParams(params, int64(len(data)), testPartSize))
+	assert.Error(t, codedPartSet.Header().ValidateParams(DefaultBlockParams(), int64(len(data)), testPartSize))
+	params.PartParityPercent = 100
+	assert.Error(t, codedPartSet.Header().ValidateParams(params, int64(len(data)), testPartSize))
+
+	// Part sets which would have too many parts are never erasure coded.
+	data = tmrand.Bytes(testPartSize * MaxErasureCodedParts)
+	partSet = NewPartSetFromDataWithParams(data, testPartSize, params)
+	assert.False(t, partSet.IsErasureCoded())
+	assert.NoError(t, partSet.Header().ValidateParams(params, int64(len(data)), testPartSize))
+}
+
+func TestErasureCodedPartSetInvalidCoding(t *testing.T) {
+	// Build a part set whose parity parts don't match its data parts.
+	partSet := NewErasureCodedPartSetFromData(tmrand.Bytes(testPartSize*4), testPartSize, 2)
+	shards := make([][]byte, partSet.Total())
+	for i := range shards {
+		shards[i] = append([]byte(nil), partSet.GetPart(i).Bytes...)
+	}
+	shards[len(shards)-1][0]++
+	root, proofs := merkle.ProofsFromByteSlices(shards)
+
+	header := partSet.Header()
+	header.Hash = root
+	last := int(header.Total - 1)
+
+	// The part set is found to be invalid whichever parts are received, even
+	// if they are all data parts or include the bad parity part.
+	testCases := map[string][]int{
+		"data parts":        rand.Perm(int(header.DataParts)),
+		"bad parity part":   append([]int{last}, rand.Perm(last)...),
+		"good parity parts": rand.Perm(last),
+	}
+	for name, indexes := range testCases {
+		indexes := indexes
+		t.Run(name, func(t *testing.T) {
+			partSet2 := NewPartSetFromHeader(header)
+			for i, index := range indexes[:header.DataParts] {
+				added, err := partSet2.AddPart(&Part{Index: uint32(index), Bytes: shards[index], Proof: *proofs[index]})
+				require.True(t, added)
+				if i < int(header.DataParts)-1 {
+					require.NoError(t, err)
+				} else {
+					require.ErrorIs(t, err, ErrPartSetInvalidCoding)
+				}
+			}
+			assert.True(t, partSet2.IsInvalid())
+			assert.False(t, partSet2.IsComplete())
+
+			// The remaining parts are ignored.
+			for index := 0; index <= last; index++ {
+				added, err := partSet2.AddPart(&Part{Index: uint32(index), Bytes: shards[index], Proof: *proofs[index]})
+				require.NoError(t, err)
+				require.False(t, added)
+			}
+			assert.False(t, partSet2.IsComplete())
+		})
+	}
+}
+
 func TestPartSetHeaderValidateBasic(t *testing.T) {
 	testCases := []struct {
 		testName              string
@@ -96,6 +230,12 @@ func TestPartSetHeaderValidateBasic(t *testing.T) {
 	}{
 		{"Good PartSet", func(psHeader *PartSetHeader) {}, false},
 		{"Invalid Hash", func(psHeader *PartSetHeader) { psHeader.Hash = make([]byte, 1) }, true},
+		{"Erasure coded", func(psHeader *PartSetHeader) { psHeader.DataParts = 50 }, false},
+		{"No parity parts", func(psHeader *PartSetHeader) { psHeader.DataParts = 100 }, true},
+		{"Too many erasure coded parts", func(psHeader *PartSetHeader) {
+			psHeader.Total = MaxErasureCodedParts + 1
+			psHeader.DataParts = 100
+		}, true},
 	}
 	for _, tc := range testCases {
 		tc := tc
@@ -147,6 +287,8 @@ func TestParSetHeaderProtoBuf(t *testing.T) {
 		{"success empty", &PartSetHeader{}, true},
 		{"success",
 			&PartSetHeader{Total: 1, Hash: []byte("hash")}, true},
+		{"success erasure coded",
+			&PartSetHeader{Total: 3, Hash: []byte("hash"), DataParts: 2}, true},
 	}
 
 	for _, tc := range testCases {